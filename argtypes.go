@@ -0,0 +1,126 @@
+package tooldocs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// ErrUnknownArgType is matched (via errors.Is) by registration errors for
+// example Args holding values that are not JSON-compatible, when
+// StoreOptions.RejectUnknownArgTypes is set or Args fail to encode under
+// NormalizeJSON. Use errors.As with *ArgTypeError for the offending key and
+// type (RejectUnknownArgTypes only).
+var ErrUnknownArgType = errors.New("unsupported arg type")
+
+// ArgsNormalization selects how example Args are copied into MCP-native
+// shapes at registration.
+type ArgsNormalization string
+
+const (
+	// NormalizeCopy deep-copies Args, converting the common typed maps and
+	// slices to map[string]any and []any and keeping other values by
+	// reference (see StoreOptions.RejectUnknownArgTypes). Numbers keep
+	// their Go type. It is the default.
+	NormalizeCopy ArgsNormalization = "copy"
+
+	// NormalizeJSON round-trips Args through encoding/json, so structs,
+	// custom types with MarshalJSON, and json.Number all become
+	// map[string]any, []any, string, bool, or float64. Values that cannot
+	// be encoded fail registration with ErrUnknownArgType. It costs an
+	// encode and decode per example.
+	NormalizeJSON ArgsNormalization = "json"
+)
+
+// ArgTypeError reports an example Args value of a type the store cannot
+// normalize to an MCP-native shape (a struct, pointer, channel, typed map
+// other than map[string]{string,int,float64,bool}, ...).
+type ArgTypeError struct {
+	// Path locates the value, e.g. "filter.tags[2]".
+	Path string
+
+	// Type is the value's Go type, e.g. "time.Time".
+	Type string
+}
+
+// Error implements error.
+func (e *ArgTypeError) Error() string {
+	return fmt.Sprintf("%v: %s has Go type %s", ErrUnknownArgType, e.Path, e.Type)
+}
+
+// Unwrap returns ErrUnknownArgType.
+func (e *ArgTypeError) Unwrap() error {
+	return ErrUnknownArgType
+}
+
+// checkArgTypes returns an *ArgTypeError for the first value in args (in
+// sorted key order) that deepCopyValue would keep by reference rather than
+// normalize, or nil when every value is JSON-compatible.
+func checkArgTypes(args map[string]any) error {
+	return checkArgMap(args, "")
+}
+
+func checkArgMap(m map[string]any, prefix string) error {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		if err := checkArgValue(m[k], path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkArgValue mirrors the cases deepCopyValue normalizes; keep the two in
+// sync.
+func checkArgValue(v any, path string) error {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		return checkArgMap(val, path)
+	case []any:
+		for i, item := range val {
+			if err := checkArgValue(item, path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]string, map[string]int, map[string]float64, map[string]bool,
+		[]string, []int, []float64, []bool,
+		string, bool, float64, float32,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		json.Number:
+		return nil
+	default:
+		return &ArgTypeError{Path: path, Type: fmt.Sprintf("%T", v)}
+	}
+}
+
+// normalizeArgsJSON returns args after a JSON encode and decode.
+//
+// Returns ErrUnknownArgType if args cannot be encoded.
+func normalizeArgsJSON(args map[string]any) (map[string]any, error) {
+	if args == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownArgType, err)
+	}
+	var normalized map[string]any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownArgType, err)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,111 @@
+package tooldocs
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCheckArgTypes(t *testing.T) {
+	type point struct{ X, Y int }
+	n := 3
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		wantPath string
+		wantType string
+	}{
+		{"json", map[string]any{"q": "x", "n": 1, "tags": []string{"a"}, "nested": map[string]any{"ok": []any{true, nil}}}, "", ""},
+		{"struct", map[string]any{"a": 1, "pt": point{1, 2}}, "pt", "tooldocs.point"},
+		{"time", map[string]any{"filter": map[string]any{"since": time.Time{}}}, "filter.since", "time.Time"},
+		{"pointer in slice", map[string]any{"ids": []any{1, &n}}, "ids[1]", "*int"},
+		{"typed map", map[string]any{"m": map[string]int64{}}, "m", "map[string]int64"},
+		{"first sorted key", map[string]any{"b": point{}, "a": struct{}{}}, "a", "struct {}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkArgTypes(tt.args)
+			if tt.wantPath == "" {
+				if err != nil {
+					t.Errorf("checkArgTypes = %v, want nil", err)
+				}
+				return
+			}
+			var typeErr *ArgTypeError
+			if !errors.As(err, &typeErr) || !errors.Is(err, ErrUnknownArgType) {
+				t.Fatalf("checkArgTypes = %v, want *ArgTypeError", err)
+			}
+			if typeErr.Path != tt.wantPath || typeErr.Type != tt.wantType {
+				t.Errorf("ArgTypeError = %+v, want path %s type %s", typeErr, tt.wantPath, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestRejectUnknownArgTypes(t *testing.T) {
+	entry := DocEntry{
+		Summary:  "Search",
+		Examples: []ToolExample{{Title: "Since", Args: map[string]any{"since": time.Time{}}}},
+	}
+
+	lenient := NewInMemoryStore(StoreOptions{})
+	if err := lenient.RegisterDoc("gh:search", entry); err != nil {
+		t.Errorf("RegisterDoc without RejectUnknownArgTypes failed: %v", err)
+	}
+
+	strict := NewInMemoryStore(StoreOptions{RejectUnknownArgTypes: true})
+	err := strict.RegisterDoc("gh:search", entry)
+	var typeErr *ArgTypeError
+	if !errors.As(err, &typeErr) || typeErr.Path != "since" || typeErr.Type != "time.Time" {
+		t.Errorf("RegisterDoc error = %v, want *ArgTypeError for since", err)
+	}
+	if err := strict.AppendExamples("gh:search", entry.Examples); !errors.Is(err, ErrUnknownArgType) {
+		t.Errorf("AppendExamples error = %v, want ErrUnknownArgType", err)
+	}
+	if err := strict.RegisterDoc("gh:search", DocEntry{
+		Summary:  "Search",
+		Examples: []ToolExample{{Title: "Plain", Args: map[string]any{"since": "2024-01-01"}}},
+	}); err != nil {
+		t.Errorf("RegisterDoc with JSON args failed: %v", err)
+	}
+}
+
+func TestArgsNormalizationJSON(t *testing.T) {
+	type filter struct {
+		Labels []string `json:"labels"`
+		Open   bool     `json:"open"`
+	}
+	store := NewInMemoryStore(StoreOptions{ArgsNormalization: NormalizeJSON, RejectUnknownArgTypes: true})
+	err := store.RegisterDoc("gh:search", DocEntry{
+		Summary: "Search",
+		Examples: []ToolExample{{Title: "Typed", Args: map[string]any{
+			"filter": filter{Labels: []string{"bug"}, Open: true},
+			"limit":  json.Number("10"),
+			"page":   2,
+			"since":  time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		}}},
+	})
+	if err != nil {
+		t.Fatalf("RegisterDoc failed: %v", err)
+	}
+	stored, _ := store.GetDocEntry("gh:search")
+	want := map[string]any{
+		"filter": map[string]any{"labels": []any{"bug"}, "open": true},
+		"limit":  10.0,
+		"page":   2.0,
+		"since":  "2024-01-02T00:00:00Z",
+	}
+	if got := stored.Entry.Examples[0].Args; !reflect.DeepEqual(got, want) {
+		t.Errorf("Args = %#v, want %#v", got, want)
+	}
+
+	err = store.RegisterDoc("gh:search", DocEntry{
+		Examples: []ToolExample{{Title: "Chan", Args: map[string]any{"c": make(chan int)}}},
+	})
+	if !errors.Is(err, ErrUnknownArgType) {
+		t.Errorf("RegisterDoc(chan) error = %v, want ErrUnknownArgType", err)
+	}
+}
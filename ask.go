@@ -0,0 +1,146 @@
+package tooldocs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNoAnswerer is returned by AskAboutTool when StoreOptions.Answerer is
+// not set.
+var ErrNoAnswerer = errors.New("no answerer configured")
+
+// Answerer answers a question about a tool from its documentation,
+// typically by prompting an LLM with q.Sources. See StoreOptions.Answerer.
+type Answerer func(ctx context.Context, q Question) (Answer, error)
+
+// Question is the input to an Answerer: the caller's question and the
+// tool's documentation, split into citable sources.
+type Question struct {
+	// ToolID is the tool asked about.
+	ToolID string `json:"toolId"`
+
+	// Question is the caller's question, as asked.
+	Question string `json:"question"`
+
+	// Doc is the tool's full-level documentation.
+	Doc ToolDoc `json:"doc"`
+
+	// Sources holds the documentation as citable passages, in document
+	// order: the summary, short notes, notes, each section, prerequisite,
+	// and example, and each external reference (with its text when
+	// StoreOptions.ResourceLoader loaded it).
+	Sources []Source `json:"sources"`
+}
+
+// Source is a citable passage of a tool's documentation.
+type Source struct {
+	// ID is the citation key: "summary", "shortNotes", "notes",
+	// "section:<title>", "prerequisite:<n>", "example:<id or n>", or
+	// "resource:<ref>", with n counting from 1.
+	ID string `json:"id"`
+
+	// Title labels the passage for display.
+	Title string `json:"title,omitempty"`
+
+	// Text is the passage. It is empty for resources that were not loaded.
+	Text string `json:"text,omitempty"`
+}
+
+// Answer is an Answerer's reply.
+type Answer struct {
+	// Text is the answer.
+	Text string `json:"text"`
+
+	// Citations lists the IDs of the Question.Sources the answer relies on.
+	Citations []string `json:"citations,omitempty"`
+}
+
+// AskAboutTool answers a targeted question about a tool ("which scope does
+// this need?", "how do I page past 100 results?") without the caller
+// reading the whole doc: it assembles the full-level documentation plus any
+// long-form resources (see StoreOptions.ResourceLoader) and delegates to
+// StoreOptions.Answerer. Citations naming no source are dropped, so every
+// remaining one points at a passage of the doc.
+//
+// Returns ErrNoAnswerer if no Answerer is configured, the errors of
+// DescribeTool at DetailFull (e.g. ErrUnauthorized for Sensitive docs), or
+// the Answerer's error.
+func (s *InMemoryStore) AskAboutTool(ctx context.Context, id, question string) (Answer, error) {
+	if s.answerer == nil {
+		return Answer{}, ErrNoAnswerer
+	}
+	doc, err := s.DescribeToolContext(ctx, id, DetailFull)
+	if err != nil {
+		return Answer{}, err
+	}
+
+	q := Question{ToolID: id, Question: question, Doc: doc, Sources: s.docSources(ctx, doc)}
+	answer, err := callHook(HookAnswerer, func() (Answer, error) {
+		return s.answerer(ctx, q)
+	})
+	if err != nil {
+		return Answer{}, fmt.Errorf("answer %s: %w", id, err)
+	}
+
+	known := make(map[string]bool, len(q.Sources))
+	for _, src := range q.Sources {
+		known[src.ID] = true
+	}
+	cited := answer.Citations[:0:0]
+	for _, c := range answer.Citations {
+		if known[c] {
+			cited = append(cited, c)
+		}
+	}
+	answer.Citations = cited
+	return answer, nil
+}
+
+// docSources splits doc into citable sources, loading external references
+// with the ResourceLoader. Resources that fail to load are cited by
+// reference only.
+func (s *InMemoryStore) docSources(ctx context.Context, doc ToolDoc) []Source {
+	var sources []Source
+	add := func(id, title, text string) {
+		if text != "" {
+			sources = append(sources, Source{ID: id, Title: title, Text: text})
+		}
+	}
+	add("summary", "Summary", doc.Summary)
+	add("shortNotes", "Key constraints", doc.ShortNotes)
+	add("notes", "Notes", doc.Notes)
+	for _, sec := range doc.Sections {
+		add("section:"+sec.Title, sec.Title, sec.Body)
+	}
+	for i, p := range doc.Prerequisites {
+		add("prerequisite:"+strconv.Itoa(i+1), "Prerequisite", p.String())
+	}
+	for i, ex := range doc.Examples {
+		key := ex.ID
+		if key == "" {
+			key = strconv.Itoa(i + 1)
+		}
+		lines := []string{ex.Description}
+		if args, err := json.Marshal(ex.Args); err == nil {
+			lines = append(lines, "Args: "+string(args))
+		}
+		if ex.ResultHint != "" {
+			lines = append(lines, "Result: "+ex.ResultHint)
+		}
+		add("example:"+key, ex.Title, strings.TrimSpace(strings.Join(lines, "\n")))
+	}
+	for _, ref := range doc.ExternalRefs {
+		src := Source{ID: "resource:" + ref, Title: ref}
+		if s.loadResource != nil {
+			src.Text, _ = callHook(HookResourceLoader, func() (string, error) {
+				return s.loadResource(ctx, ref)
+			})
+		}
+		sources = append(sources, src)
+	}
+	return sources
+}
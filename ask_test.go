@@ -0,0 +1,88 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestAskAboutTool(t *testing.T) {
+	tool := makeToolWithSchema("search", "gh", "Search issues", map[string]any{"type": "object"})
+	var got Question
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(string) (*toolmodel.Tool, error) { return &tool, nil },
+		Answerer: func(_ context.Context, q Question) (Answer, error) {
+			got = q
+			return Answer{Text: "Use the page argument.", Citations: []string{"section:Pagination", "made-up"}}, nil
+		},
+		ResourceLoader: func(_ context.Context, ref string) (string, error) {
+			if ref == "https://docs.example.com/broken" {
+				return "", errors.New("404")
+			}
+			return "Long-form guide for " + ref, nil
+		},
+	})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{
+		Summary:      "Search issues",
+		Notes:        "Results are capped at 1000.",
+		Sections:     []DocSection{{Title: "Pagination", Body: "Pass page=2 for the next 100 results."}},
+		Examples:     []ToolExample{{ID: "bugs", Title: "Open bugs", Args: map[string]any{"q": "label:bug"}}},
+		ExternalRefs: []string{"https://docs.example.com/search", "https://docs.example.com/broken"},
+	})
+
+	answer, err := store.AskAboutTool(context.Background(), "gh:search", "How do I get the next page?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer.Text != "Use the page argument." || !reflect.DeepEqual(answer.Citations, []string{"section:Pagination"}) {
+		t.Errorf("answer = %+v, want unknown citations dropped", answer)
+	}
+	if got.ToolID != "gh:search" || got.Question != "How do I get the next page?" || got.Doc.Notes == "" {
+		t.Errorf("question = %+v", got)
+	}
+	var ids []string
+	for _, src := range got.Sources {
+		ids = append(ids, src.ID)
+	}
+	want := []string{"summary", "notes", "section:Pagination", "example:bugs", "resource:https://docs.example.com/search", "resource:https://docs.example.com/broken"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("sources = %v, want %v", ids, want)
+	}
+	if text := got.Sources[4].Text; text != "Long-form guide for https://docs.example.com/search" {
+		t.Errorf("loaded resource text = %q", text)
+	}
+	if text := got.Sources[5].Text; text != "" {
+		t.Errorf("failed resource text = %q, want empty", text)
+	}
+}
+
+func TestAskAboutTool_Errors(t *testing.T) {
+	tool := makeToolWithSchema("rotate", "ops", "Rotate keys", map[string]any{"type": "object"})
+	resolver := func(string) (*toolmodel.Tool, error) { return &tool, nil }
+
+	plain := NewInMemoryStore(StoreOptions{ToolResolver: resolver})
+	if _, err := plain.AskAboutTool(context.Background(), "ops:rotate", "?"); !errors.Is(err, ErrNoAnswerer) {
+		t.Errorf("err = %v, want ErrNoAnswerer", err)
+	}
+
+	calls := 0
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: resolver,
+		Answerer: func(context.Context, Question) (Answer, error) {
+			calls++
+			panic("llm client bug")
+		},
+	})
+	mustRegisterDoc(t, store, "ops:rotate", DocEntry{Summary: "Rotate keys", Notes: "Runbook.", Sensitive: true})
+	if _, err := store.AskAboutTool(context.Background(), "ops:rotate", "?"); !errors.Is(err, ErrUnauthorized) || calls != 0 {
+		t.Errorf("sensitive doc err = %v (answerer calls %d), want ErrUnauthorized before answering", err, calls)
+	}
+	ctx := WithGrants(context.Background(), GrantSensitiveDocs)
+	var hp *HookPanicError
+	if _, err := store.AskAboutTool(ctx, "ops:rotate", "?"); !errors.As(err, &hp) || hp.Hook != HookAnswerer {
+		t.Errorf("panicking answerer err = %v, want HookPanicError", err)
+	}
+}
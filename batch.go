@@ -0,0 +1,100 @@
+package tooldocs
+
+import (
+	"context"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// DescribeResult is the outcome of describing one tool in DescribeTools.
+type DescribeResult struct {
+	// ID is the requested tool ID.
+	ID string `json:"id"`
+
+	// Doc is the tool's documentation when Err is nil.
+	Doc ToolDoc `json:"doc"`
+
+	// Err is the error DescribeTool would have returned for this ID.
+	Err error `json:"-"`
+}
+
+// DescribeTools describes many tools at one detail level, returning one
+// result per ID in input order.
+//
+// Tools the Index does not know are resolved together: with
+// StoreOptions.BatchToolResolver set, all of them in a single call;
+// otherwise one ToolResolver call each. A failed batch call fails every ID
+// that depended on it. Only ErrInvalidDetail is returned as an error; all
+// other failures are reported per result.
+func (s *InMemoryStore) DescribeTools(ids []string, level DetailLevel) ([]DescribeResult, error) {
+	return s.DescribeToolsContext(context.Background(), ids, level)
+}
+
+// DescribeToolsContext is DescribeTools with a caller context used to
+// authorize the full tier of Sensitive tools (see DescribeToolContext).
+func (s *InMemoryStore) DescribeToolsContext(ctx context.Context, ids []string, level DetailLevel) ([]DescribeResult, error) {
+	if err := validateDetail(level); err != nil {
+		return nil, err
+	}
+
+	resolved := s.resolveTools(ids)
+	resolve := func(id string) (*toolmodel.Tool, error) {
+		if r, ok := resolved[id]; ok {
+			return r.tool, r.err
+		}
+		return s.resolveToolContext(ctx, id)
+	}
+
+	results := make([]DescribeResult, len(ids))
+	for i, id := range ids {
+		start := s.readStart()
+		doc, err := s.describe(ctx, id, level, resolve)
+		if err == nil {
+			s.outcomes.noteDescribe(TraceID(ctx), id, level)
+		}
+		s.usage.served(id, doc.Examples, s.now())
+		s.observe(ctx, ReadEvent{Op: OpDescribe, ToolID: id, Level: level, Stale: doc.Stale, Err: err}, start)
+		results[i] = DescribeResult{ID: id, Doc: doc, Err: err}
+	}
+	return results, nil
+}
+
+// resolution is the lookup outcome for one tool.
+type resolution struct {
+	tool *toolmodel.Tool
+	err  error
+}
+
+// resolveTools looks up many tools, consulting the index first and sending
+// every miss to BatchToolResolver in one call. Without a batch resolver it
+// returns nil and callers fall back to resolveTool.
+func (s *InMemoryStore) resolveTools(ids []string) map[string]resolution {
+	if s.batchResolver == nil {
+		return nil
+	}
+
+	resolved := make(map[string]resolution, len(ids))
+	var misses []string
+	for _, id := range ids {
+		if _, done := resolved[id]; done {
+			continue
+		}
+		if s.index != nil {
+			if t, err := s.lookupIndex(id); err == nil {
+				resolved[id] = resolution{tool: t}
+				continue
+			}
+		}
+		resolved[id] = resolution{}
+		misses = append(misses, id)
+	}
+	if len(misses) == 0 {
+		return resolved
+	}
+
+	tools, err := s.callBatchResolver(misses)
+	for _, id := range misses {
+		resolved[id] = resolution{tool: tools[id], err: err}
+	}
+	return resolved
+}
@@ -0,0 +1,113 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolindex"
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func batchTool(name string) *toolmodel.Tool {
+	return &toolmodel.Tool{Namespace: "ns", Tool: mcp.Tool{Name: name, InputSchema: map[string]any{"type": "object"}}}
+}
+
+func TestDescribeTools_BatchResolver(t *testing.T) {
+	idx := toolindex.NewInMemoryIndex()
+	if err := idx.RegisterTool(*batchTool("indexed"), toolmodel.ToolBackend{
+		Kind:  toolmodel.BackendKindLocal,
+		Local: &toolmodel.LocalBackend{Name: "handler"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls [][]string
+	s := NewInMemoryStore(StoreOptions{
+		Index: idx,
+		BatchToolResolver: func(ids []string) (map[string]*toolmodel.Tool, error) {
+			calls = append(calls, ids)
+			return map[string]*toolmodel.Tool{"ns:a": batchTool("a"), "ns:b": batchTool("b")}, nil
+		},
+	})
+	for _, id := range []string{"ns:a", "ns:b", "ns:indexed", "ns:gone"} {
+		mustRegisterDoc(t, s, id, DocEntry{Summary: "Doc for " + id})
+	}
+
+	results, err := s.DescribeTools([]string{"ns:a", "ns:indexed", "ns:b", "ns:gone", "ns:a"}, DetailSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"ns:a", "ns:b", "ns:gone"}}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("batch calls = %v, want %v", calls, want)
+	}
+	if len(results) != 5 {
+		t.Fatalf("got %d results, want 5", len(results))
+	}
+	for _, i := range []int{0, 1, 2, 4} {
+		r := results[i]
+		if r.Err != nil || r.Doc.Tool == nil || r.Doc.Summary != "Doc for "+r.ID {
+			t.Errorf("result %d = %+v", i, r)
+		}
+	}
+	if !errors.Is(results[3].Err, ErrNoTool) {
+		t.Errorf("ns:gone err = %v, want ErrNoTool", results[3].Err)
+	}
+
+	// Single lookups use the batch resolver when ToolResolver is nil.
+	calls = nil
+	if doc, err := s.DescribeTool("ns:b", DetailSchema); err != nil || doc.Tool == nil {
+		t.Errorf("DescribeTool = %+v, %v", doc, err)
+	}
+	if want := [][]string{{"ns:b"}}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("batch calls = %v, want %v", calls, want)
+	}
+}
+
+func TestDescribeTools_BatchError(t *testing.T) {
+	upstream := errors.New("upstream down")
+	s := NewInMemoryStore(StoreOptions{
+		BatchToolResolver: func([]string) (map[string]*toolmodel.Tool, error) {
+			return nil, upstream
+		},
+	})
+	mustRegisterDoc(t, s, "ns:a", DocEntry{Summary: "A"})
+
+	results, err := s.DescribeTools([]string{"ns:a"}, DetailFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(results[0].Err, upstream) {
+		t.Errorf("err = %v, want upstream error", results[0].Err)
+	}
+
+	results, err = s.DescribeTools([]string{"ns:a"}, DetailSummary)
+	if err != nil || results[0].Err != nil || results[0].Doc.Summary != "A" {
+		t.Errorf("summary = %+v, %v", results, err)
+	}
+}
+
+func TestDescribeTools_WithoutBatchResolver(t *testing.T) {
+	var calls int
+	s := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			calls++
+			return batchTool(id[len("ns:"):]), nil
+		},
+	})
+	mustRegisterDoc(t, s, "ns:a", DocEntry{Summary: "A"})
+	mustRegisterDoc(t, s, "ns:b", DocEntry{Summary: "B"})
+
+	results, err := s.DescribeTools([]string{"ns:a", "ns:b"}, DetailSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 || results[0].Err != nil || results[1].Doc.Tool.Name != "b" {
+		t.Errorf("calls = %d, results = %+v", calls, results)
+	}
+
+	if _, err := s.DescribeTools(nil, "bogus"); !errors.Is(err, ErrInvalidDetail) {
+		t.Errorf("err = %v, want ErrInvalidDetail", err)
+	}
+}
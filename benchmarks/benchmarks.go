@@ -0,0 +1,120 @@
+// Package benchmarks holds the tooldocs performance suite and the fixtures it
+// runs against.
+//
+// Run the suite with
+//
+//	go test ./benchmarks -run '^$' -bench . -benchmem
+//
+// and compare two revisions with scripts/bench-compare.sh, which runs the
+// suite repeatedly on each and summarizes the difference with benchstat.
+//
+// Baseline allocations per operation on a 500-tool catalog (Go 1.24,
+// linux/amd64):
+//
+//	BenchmarkDescribeTool/summary       10 allocs/op     ~0.9 KB/op
+//	BenchmarkDescribeTool/schema        66 allocs/op      ~36 KB/op
+//	BenchmarkDescribeTool/full          67 allocs/op      ~36 KB/op
+//	BenchmarkSummary                     0 allocs/op        0 KB/op
+//	BenchmarkImport                   2220 allocs/op     ~264 KB/op (100 entries)
+//	BenchmarkFindToolsByArgsShape     1515 allocs/op      ~69 KB/op (full scan)
+//
+// TestAllocBudgets fails when an operation exceeds its budget in AllocBudgets,
+// so performance-motivated redesigns (copy-on-write records, schema caches)
+// can be validated and then protected against regressions. Raise a budget
+// only together with the change that justifies it.
+package benchmarks
+
+import (
+	"fmt"
+
+	"github.com/jonwraymond/tooldocs"
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CatalogSize is the number of tools in the default benchmark catalog.
+const CatalogSize = 500
+
+// AllocBudgets caps allocations per operation, keyed by benchmark name. The
+// budgets leave headroom over the baselines in the package documentation.
+var AllocBudgets = map[string]float64{
+	"DescribeTool/summary": 12,
+	"DescribeTool/schema":  90,
+	"DescribeTool/full":    90,
+	"Summary":              0,
+}
+
+// ToolID returns the ID of the i-th catalog tool.
+func ToolID(i int) string {
+	return fmt.Sprintf("bench:tool_%04d", i)
+}
+
+// Tool returns the i-th catalog tool: a typical generated API tool with a
+// handful of typed, constrained parameters.
+func Tool(i int) *toolmodel.Tool {
+	return &toolmodel.Tool{
+		Namespace: "bench",
+		Tool: mcp.Tool{
+			Name:        fmt.Sprintf("tool_%04d", i),
+			Description: fmt.Sprintf("Benchmark tool %d", i),
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []any{"id"},
+				"properties": map[string]any{
+					"id":     map[string]any{"type": "string", "pattern": "^[a-z0-9-]+$"},
+					"limit":  map[string]any{"type": "integer", "minimum": 1, "maximum": 100, "default": 20},
+					"cursor": map[string]any{"type": "string"},
+					"state":  map[string]any{"type": "string", "enum": []any{"open", "closed"}},
+					"fields": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+				},
+			},
+		},
+	}
+}
+
+// Entry returns documentation for the i-th catalog tool, with boilerplate
+// notes shared across tools as in generated catalogs.
+func Entry(i int) tooldocs.DocEntry {
+	return tooldocs.DocEntry{
+		Summary: fmt.Sprintf("Fetch benchmark resource %d", i),
+		Notes:   "Generated from the OpenAPI spec. Paginate with cursor; results are capped at 100 per page.",
+		Examples: []tooldocs.ToolExample{
+			{Title: "By ID", Description: "Fetch one resource.", Args: map[string]any{"id": fmt.Sprintf("res-%d", i)}},
+			{
+				Title:       "Filtered page",
+				Description: "List open resources with selected fields.",
+				Args:        map[string]any{"id": "all", "state": "open", "limit": 50, "fields": []any{"id", "name"}},
+				ResultHint:  "Array of resources and a next cursor",
+			},
+		},
+		ExternalRefs: []string{"https://example.com/api"},
+		Source:       "importer:openapi",
+	}
+}
+
+// Entries returns documentation for n catalog tools keyed by ID.
+func Entries(n int) map[string]tooldocs.DocEntry {
+	entries := make(map[string]tooldocs.DocEntry, n)
+	for i := range n {
+		entries[ToolID(i)] = Entry(i)
+	}
+	return entries
+}
+
+// NewCatalog returns a store documenting n catalog tools, resolved through
+// StoreOptions.ToolResolver.
+func NewCatalog(n int) (*tooldocs.InMemoryStore, error) {
+	tools := make(map[string]*toolmodel.Tool, n)
+	for i := range n {
+		tools[ToolID(i)] = Tool(i)
+	}
+	store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			return tools[id], nil
+		},
+	})
+	if _, err := store.Import(Entries(n), tooldocs.ImportOptions{}); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
@@ -0,0 +1,142 @@
+package benchmarks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+var levels = []tooldocs.DetailLevel{tooldocs.DetailSummary, tooldocs.DetailSchema, tooldocs.DetailFull}
+
+func newCatalog(tb testing.TB) *tooldocs.InMemoryStore {
+	tb.Helper()
+	store, err := NewCatalog(CatalogSize)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return store
+}
+
+func BenchmarkDescribeTool(b *testing.B) {
+	store := newCatalog(b)
+	ids := make([]string, CatalogSize)
+	for i := range ids {
+		ids[i] = ToolID(i)
+	}
+	for _, level := range levels {
+		b.Run(string(level), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; b.Loop(); i++ {
+				if _, err := store.DescribeTool(ids[i%CatalogSize], level); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSummary(b *testing.B) {
+	store := newCatalog(b)
+	ids := make([]string, CatalogSize)
+	for i := range ids {
+		ids[i] = ToolID(i)
+	}
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		if _, err := store.Summary(ids[i%CatalogSize]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSummaries(b *testing.B) {
+	store := newCatalog(b)
+	ids := make([]string, 50)
+	for i := range ids {
+		ids[i] = ToolID(i)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		if got := store.Summaries(ids); len(got) != len(ids) {
+			b.Fatalf("got %d summaries, want %d", len(got), len(ids))
+		}
+	}
+}
+
+func BenchmarkImport(b *testing.B) {
+	entries := Entries(100)
+	b.ReportAllocs()
+	for b.Loop() {
+		store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{})
+		if _, err := store.Import(entries, tooldocs.ImportOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFindToolsByArgsShape(b *testing.B) {
+	store := newCatalog(b)
+	args := map[string]any{"id": "res-1", "limit": 10}
+	b.ReportAllocs()
+	for b.Loop() {
+		if ids := store.FindToolsByArgsShape(args); len(ids) != CatalogSize {
+			b.Fatalf("matched %d tools, want %d", len(ids), CatalogSize)
+		}
+	}
+}
+
+func BenchmarkSearchDocs(b *testing.B) {
+	store := newCatalog(b)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		store.SearchDocs(ctx, "fetch resource", tooldocs.SearchOptions{Limit: 10})
+	}
+}
+
+// BenchmarkRegisterDoc measures re-registering one tool in a full catalog,
+// which updates the search index for that tool only.
+func BenchmarkRegisterDoc(b *testing.B) {
+	store := newCatalog(b)
+	entry := Entry(0)
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := store.RegisterDoc(ToolID(0), entry); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestAllocBudgets guards the hot read paths against allocation regressions.
+func TestAllocBudgets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("allocation budgets are checked in full test runs")
+	}
+	if raceEnabled {
+		t.Skip("the race detector adds allocations; budgets are checked without -race")
+	}
+	store := newCatalog(t)
+	id := ToolID(7)
+	for _, level := range levels {
+		name := "DescribeTool/" + string(level)
+		allocs := testing.AllocsPerRun(100, func() {
+			if _, err := store.DescribeTool(id, level); err != nil {
+				t.Fatal(err)
+			}
+		})
+		t.Logf("%s: %.0f allocs/op", name, allocs)
+		if budget := AllocBudgets[name]; allocs > budget {
+			t.Errorf("%s: %.0f allocs/op, budget %.0f", name, allocs, budget)
+		}
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := store.Summary(id); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if budget := AllocBudgets["Summary"]; allocs > budget {
+		t.Errorf("Summary: %.0f allocs/op, budget %.0f", allocs, budget)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package benchmarks
+
+// raceEnabled reports whether the race detector is on; it adds allocations
+// the budgets do not account for.
+const raceEnabled = true
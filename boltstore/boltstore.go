@@ -0,0 +1,449 @@
+// Package boltstore provides a tooldocs.Store persisted in a bbolt database
+// file, so single-binary deployments keep documentation across restarts
+// without an external database.
+//
+// Each tool's DocEntry is stored as JSON in the "tools" bucket under its
+// tool ID; a write touches only the keys it changes. Reads are served by a
+// tooldocs.InMemoryStore loaded from the database at Open, so read
+// behavior matches InMemoryStore exactly. As with tooldocs.FileStore, each
+// record keeps the doc's frozen flag and expiry, and Open restores records
+// as saved (see tooldocs.InMemoryStore.Restore); review queues and other
+// store-managed state live in memory.
+//
+// With StoreOptions.Encryptor set, each record's free-text fields are
+// sealed with it (see tooldocs.SealEntry) before they are written, and
+// opened on load; titles, summaries, and structured fields stay readable.
+//
+// With Options.History set, every persisted write is also kept as a
+// revision in the "history" bucket, keyed by tool ID and write time, so
+// ExportAt and DescribeToolAt can read the documentation as it was at a
+// past time.
+//
+// bbolt holds an exclusive lock on the file, so only one process may have
+// a database open at a time.
+package boltstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jonwraymond/tooldocs"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	toolsBucket   = []byte("tools")
+	historyBucket = []byte("history")
+	metaBucket    = []byte("meta")
+	versionKey    = []byte("version")
+)
+
+// ErrClosed is returned by writes and Compact after Close.
+var ErrClosed = errors.New("boltstore: store is closed")
+
+// record is the stored form of a DocEntry: the entry, with its free-text
+// fields moved into Sealed when the store encrypts them, and the doc's
+// store-managed state.
+type record struct {
+	tooldocs.DocEntry
+	Sealed  []byte    `json:"sealed,omitempty"`
+	Frozen  bool      `json:"frozen,omitempty"`
+	Expires time.Time `json:"expires,omitzero"`
+}
+
+// Options configures Open.
+type Options struct {
+	// StoreOptions configures the in-memory store that serves reads.
+	StoreOptions tooldocs.StoreOptions
+
+	// Timeout bounds how long Open waits for another process to release
+	// the file lock. Zero waits indefinitely.
+	Timeout time.Duration
+
+	// History keeps every persisted revision of each tool's documentation
+	// for time-travel reads (ExportAt, DescribeToolAt). Tools persisted
+	// before history was enabled get their current entry as a first
+	// revision at Open. The history grows with every write.
+	History bool
+}
+
+// Store is a tooldocs.Store backed by a bbolt database.
+type Store struct {
+	path string
+	opts Options
+	mem  *tooldocs.InMemoryStore
+
+	mu sync.Mutex // serializes writes, Compact, and Close
+	db *bolt.DB
+}
+
+var _ tooldocs.Store = (*Store)(nil)
+
+// Open opens or creates the database at path and loads its documentation.
+//
+// Returns tooldocs.ErrEvictionUnsupported if StoreOptions.MaxDocs or
+// MaxMemoryBytes is set, tooldocs.ErrBundleVersion if the database was
+// written by a newer package version, tooldocs.ErrDecrypt if sealed records
+// cannot be opened with StoreOptions.Encryptor, or the error of opening or
+// decoding the database.
+func Open(path string, opts Options) (*Store, error) {
+	if opts.StoreOptions.MaxDocs > 0 || opts.StoreOptions.MaxMemoryBytes > 0 {
+		return nil, fmt.Errorf("open %s: %w", path, tooldocs.ErrEvictionUnsupported)
+	}
+	db, err := openDB(path, opts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{path: path, opts: opts, db: db, mem: tooldocs.NewInMemoryStore(opts.StoreOptions)}
+
+	var docs []tooldocs.StoredDoc
+	if opts.History {
+		err = seedHistory(db, time.Now())
+	}
+	if err == nil {
+		docs, err = readDocs(db, opts.StoreOptions.Encryptor)
+	}
+	if err == nil {
+		err = s.mem.Restore(docs)
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// openDB opens the database and creates its buckets.
+func openDB(path string, timeout time.Duration) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: timeout})
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(toolsBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(historyBucket); err != nil {
+			return err
+		}
+		meta, err := tx.CreateBucketIfNotExists(metaBucket)
+		if err != nil {
+			return err
+		}
+		if v := meta.Get(versionKey); v != nil {
+			version, err := strconv.Atoi(string(v))
+			if err != nil {
+				return fmt.Errorf("%w: %q", tooldocs.ErrBundleVersion, v)
+			}
+			if version > tooldocs.BundleVersion {
+				return fmt.Errorf("%w: %d (this package supports %d)", tooldocs.ErrBundleVersion, version, tooldocs.BundleVersion)
+			}
+		}
+		return meta.Put(versionKey, []byte(strconv.Itoa(tooldocs.BundleVersion)))
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// readDocs decodes every stored record, opening sealed ones with enc.
+func readDocs(db *bolt.DB, enc tooldocs.Encryptor) ([]tooldocs.StoredDoc, error) {
+	var docs []tooldocs.StoredDoc
+	err := db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(toolsBucket).ForEach(func(k, v []byte) error {
+			doc, err := decodeRecord(string(k), v, enc)
+			if err != nil {
+				return err
+			}
+			docs = append(docs, doc)
+			return nil
+		})
+	})
+	return docs, err
+}
+
+// decodeRecord decodes the stored record of id, opening it with enc if it
+// is sealed.
+func decodeRecord(id string, data []byte, enc tooldocs.Encryptor) (tooldocs.StoredDoc, error) {
+	var rec record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return tooldocs.StoredDoc{}, fmt.Errorf("decode %s: %w", id, err)
+	}
+	if rec.Sealed != nil && enc == nil {
+		return tooldocs.StoredDoc{}, fmt.Errorf("decode %s: record is sealed; set StoreOptions.Encryptor", id)
+	}
+	entry, err := tooldocs.OpenEntry(id, rec.DocEntry, rec.Sealed, enc)
+	if err != nil {
+		return tooldocs.StoredDoc{}, err
+	}
+	return tooldocs.StoredDoc{ID: id, Entry: entry, Frozen: rec.Frozen, Expires: rec.Expires}, nil
+}
+
+// write applies a write to memory and persists the current entries of the
+// saved IDs and the deletion of the removed IDs it returns, in one
+// transaction. When persisting fails the write stays applied in memory and
+// the error is returned.
+func (s *Store) write(apply func() (saved, removed []string, err error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return ErrClosed
+	}
+	saved, removed, err := apply()
+	if err != nil {
+		return err
+	}
+	return s.persist(saved, removed)
+}
+
+// persist stores the in-memory entries of saved and deletes the records of
+// removed, with their revisions under Options.History. Saved IDs no longer
+// in memory keep their stored record: only removals delete. The caller
+// holds s.mu.
+func (s *Store) persist(saved, removed []string) error {
+	now := time.Now()
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(toolsBucket)
+		for _, id := range removed {
+			if bucket.Get([]byte(id)) == nil {
+				continue
+			}
+			if s.opts.History {
+				if err := putRevision(tx, id, now, nil); err != nil {
+					return err
+				}
+			}
+			if err := bucket.Delete([]byte(id)); err != nil {
+				return err
+			}
+		}
+		for _, id := range saved {
+			stored, err := s.mem.GetDocEntry(id)
+			if errors.Is(err, tooldocs.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			rec := record{DocEntry: stored.Entry, Frozen: stored.Frozen, Expires: stored.Expires}
+			if enc := s.opts.StoreOptions.Encryptor; enc != nil {
+				if rec.DocEntry, rec.Sealed, err = tooldocs.SealEntry(id, stored.Entry, enc); err != nil {
+					return fmt.Errorf("encode %w", err)
+				}
+			}
+			data, err := json.Marshal(rec)
+			if err != nil {
+				return fmt.Errorf("encode %s: %w", id, err)
+			}
+			if err := bucket.Put([]byte(id), data); err != nil {
+				return err
+			}
+			if s.opts.History {
+				if err := putRevision(tx, id, now, data); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// RegisterDoc is InMemoryStore.RegisterDoc, persisted.
+func (s *Store) RegisterDoc(id string, entry tooldocs.DocEntry) error {
+	return s.write(func() ([]string, []string, error) {
+		return []string{id}, nil, s.mem.RegisterDoc(id, entry)
+	})
+}
+
+// RegisterDocWithOptions is InMemoryStore.RegisterDocWithOptions, persisted.
+func (s *Store) RegisterDocWithOptions(id string, entry tooldocs.DocEntry, opts tooldocs.RegisterOptions) error {
+	return s.write(func() ([]string, []string, error) {
+		return []string{id}, nil, s.mem.RegisterDocWithOptions(id, entry, opts)
+	})
+}
+
+// RegisterExamples is InMemoryStore.RegisterExamples, persisted.
+func (s *Store) RegisterExamples(id string, examples []tooldocs.ToolExample) error {
+	return s.write(func() ([]string, []string, error) {
+		return []string{id}, nil, s.mem.RegisterExamples(id, examples)
+	})
+}
+
+// AppendExamples is InMemoryStore.AppendExamples, persisted.
+func (s *Store) AppendExamples(id string, examples []tooldocs.ToolExample) error {
+	return s.write(func() ([]string, []string, error) {
+		return []string{id}, nil, s.mem.AppendExamples(id, examples)
+	})
+}
+
+// Import is InMemoryStore.Import, persisted unless opts.DryRun is set.
+func (s *Store) Import(entries map[string]tooldocs.DocEntry, opts tooldocs.ImportOptions) (tooldocs.ImportReport, error) {
+	var report tooldocs.ImportReport
+	err := s.write(func() ([]string, []string, error) {
+		var err error
+		report, err = s.mem.Import(entries, opts)
+		if err != nil || opts.DryRun {
+			return nil, nil, err
+		}
+		ids := make([]string, 0, len(report.Changes))
+		for _, c := range report.Changes {
+			switch c.Action {
+			case tooldocs.ImportCreated, tooldocs.ImportUpdated, tooldocs.ImportMerged:
+				ids = append(ids, c.ID)
+			}
+		}
+		return ids, nil, nil
+	})
+	return report, err
+}
+
+// UnregisterWhere is InMemoryStore.UnregisterWhere, persisted.
+func (s *Store) UnregisterWhere(filter tooldocs.UnregisterFilter) ([]string, error) {
+	var removed []string
+	err := s.write(func() ([]string, []string, error) {
+		var err error
+		removed, err = s.mem.UnregisterWhere(filter)
+		return nil, removed, err
+	})
+	return removed, err
+}
+
+// Freeze is InMemoryStore.Freeze, persisted.
+func (s *Store) Freeze(id string) error {
+	return s.write(func() ([]string, []string, error) {
+		return []string{id}, nil, s.mem.Freeze(id)
+	})
+}
+
+// Unfreeze is InMemoryStore.Unfreeze, persisted.
+func (s *Store) Unfreeze(id string) error {
+	return s.write(func() ([]string, []string, error) {
+		return []string{id}, nil, s.mem.Unfreeze(id)
+	})
+}
+
+// IsFrozen is InMemoryStore.IsFrozen.
+func (s *Store) IsFrozen(id string) bool {
+	return s.mem.IsFrozen(id)
+}
+
+// DescribeTool implements tooldocs.Store.
+func (s *Store) DescribeTool(id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error) {
+	return s.mem.DescribeTool(id, level)
+}
+
+// DescribeToolContext is InMemoryStore.DescribeToolContext.
+func (s *Store) DescribeToolContext(ctx context.Context, id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error) {
+	return s.mem.DescribeToolContext(ctx, id, level)
+}
+
+// ListExamples implements tooldocs.Store.
+func (s *Store) ListExamples(id string, maxExamples int) ([]tooldocs.ToolExample, error) {
+	return s.mem.ListExamples(id, maxExamples)
+}
+
+// ListExamplesContext is InMemoryStore.ListExamplesContext.
+func (s *Store) ListExamplesContext(ctx context.Context, id string, maxExamples int) ([]tooldocs.ToolExample, error) {
+	return s.mem.ListExamplesContext(ctx, id, maxExamples)
+}
+
+// GetDocEntry is InMemoryStore.GetDocEntry.
+func (s *Store) GetDocEntry(id string) (tooldocs.StoredDoc, error) {
+	return s.mem.GetDocEntry(id)
+}
+
+// DocIDs returns the IDs of all persisted tools, sorted.
+func (s *Store) DocIDs() []string {
+	return s.mem.DocIDs()
+}
+
+// Export reads every persisted record back from the database into a new
+// InMemoryStore configured with opts, restored as saved (see
+// InMemoryStore.Restore), e.g. to hand a durable catalog to code that
+// expects an InMemoryStore or to take a consistent snapshot. Sealed records
+// are opened with the store's own Encryptor.
+//
+// Returns ErrClosed after Close, or the error of decoding the database.
+func (s *Store) Export(opts tooldocs.StoreOptions) (*tooldocs.InMemoryStore, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return nil, ErrClosed
+	}
+	docs, err := readDocs(s.db, s.opts.StoreOptions.Encryptor)
+	if err != nil {
+		return nil, fmt.Errorf("export %s: %w", s.path, err)
+	}
+	mem := tooldocs.NewInMemoryStore(opts)
+	if err := mem.Restore(docs); err != nil {
+		return nil, fmt.Errorf("export %s: %w", s.path, err)
+	}
+	return mem, nil
+}
+
+// Compact rewrites the database into a fresh file, reclaiming the pages
+// bbolt keeps after deletes and overwrites, and swaps it into place.
+// Writes wait while it runs; reads are unaffected.
+//
+// Returns ErrClosed after Close. If compaction fails the original file is
+// left in place; if reopening after the swap fails the store is closed.
+func (s *Store) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return ErrClosed
+	}
+
+	tmpPath := s.path + ".compact"
+	dst, err := bolt.Open(tmpPath, 0o600, &bolt.Options{Timeout: s.opts.Timeout})
+	if err != nil {
+		return fmt.Errorf("compact %s: %w", s.path, err)
+	}
+	defer os.Remove(tmpPath) // no-op after a successful rename
+	if err := bolt.Compact(dst, s.db, 0); err != nil {
+		dst.Close()
+		return fmt.Errorf("compact %s: %w", s.path, err)
+	}
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("compact %s: %w", s.path, err)
+	}
+
+	if err := s.db.Close(); err != nil {
+		return fmt.Errorf("compact %s: %w", s.path, err)
+	}
+	s.db = nil
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		err = fmt.Errorf("compact %s: %w", s.path, err)
+	}
+	db, openErr := openDB(s.path, s.opts.Timeout)
+	if openErr != nil {
+		return errors.Join(err, openErr)
+	}
+	s.db = db
+	return err
+}
+
+// Close releases the database. Reads keep serving the loaded docs; writes,
+// Export, and Compact return ErrClosed.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return nil
+	}
+	err := s.db.Close()
+	s.db = nil
+	return err
+}
@@ -0,0 +1,389 @@
+package boltstore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/tooldocs"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStore_PersistsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.db")
+
+	store, err := Open(path, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDoc("gh:search", tooldocs.DocEntry{
+		Summary:  "Search issues",
+		Examples: []tooldocs.ToolExample{{Title: "Open", Args: map[string]any{"q": "is:open"}}},
+	}); err != nil {
+		t.Fatalf("RegisterDoc failed: %v", err)
+	}
+	if err := store.AppendExamples("gh:search", []tooldocs.ToolExample{{Title: "Bugs", Args: map[string]any{"q": "label:bug"}}}); err != nil {
+		t.Fatalf("AppendExamples failed: %v", err)
+	}
+	if _, err := store.Import(map[string]tooldocs.DocEntry{
+		"gh:list": {Summary: "List issues"},
+		"ops:run": {Summary: "Run a job"},
+	}, tooldocs.ImportOptions{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if _, err := store.Import(map[string]tooldocs.DocEntry{"ops:dry": {Summary: "Not persisted"}}, tooldocs.ImportOptions{DryRun: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.UnregisterWhere(tooldocs.UnregisterFilter{NamespacePrefix: "ops"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDoc("gh:late", tooldocs.DocEntry{Summary: "After close"}); !errors.Is(err, ErrClosed) {
+		t.Errorf("RegisterDoc after Close error = %v, want ErrClosed", err)
+	}
+
+	reopened, err := Open(path, Options{StoreOptions: tooldocs.StoreOptions{MaxExamples: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if ids := reopened.DocIDs(); len(ids) != 2 || ids[0] != "gh:list" || ids[1] != "gh:search" {
+		t.Errorf("DocIDs after reopen = %v, want [gh:list gh:search]", ids)
+	}
+	doc, err := reopened.DescribeTool("gh:search", tooldocs.DetailSummary)
+	if err != nil || doc.Summary != "Search issues" {
+		t.Fatalf("DescribeTool after reopen = %+v, %v", doc, err)
+	}
+	if examples, err := reopened.ListExamples("gh:search", 5); err != nil || len(examples) != 1 {
+		t.Errorf("ListExamples = %d examples, %v; want 1 (MaxExamples)", len(examples), err)
+	}
+	stored, _ := reopened.GetDocEntry("gh:search")
+	if len(stored.Entry.Examples) != 2 {
+		t.Errorf("stored examples = %d, want 2", len(stored.Entry.Examples))
+	}
+}
+
+func TestStore_CompactAndExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.db")
+	store, err := Open(path, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	entries := make(map[string]tooldocs.DocEntry)
+	for _, id := range []string{"a:one", "a:two", "a:three"} {
+		entries[id] = tooldocs.DocEntry{Summary: "Tool " + id, Notes: strings.Repeat("x", 1500)}
+	}
+	if _, err := store.Import(entries, tooldocs.ImportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.UnregisterWhere(tooldocs.UnregisterFilter{NamespacePrefix: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDoc("b:kept", tooldocs.DocEntry{Summary: "Kept"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Compact(); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if _, err := os.Stat(path + ".compact"); !os.IsNotExist(err) {
+		t.Errorf("temp file left behind: %v", err)
+	}
+	// The store keeps working on the compacted file.
+	if err := store.RegisterDoc("b:after", tooldocs.DocEntry{Summary: "After compaction"}); err != nil {
+		t.Fatalf("RegisterDoc after Compact failed: %v", err)
+	}
+
+	mem, err := store.Export(tooldocs.StoreOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := mem.DocIDs(); len(ids) != 2 || ids[0] != "b:after" || ids[1] != "b:kept" {
+		t.Errorf("exported DocIDs = %v, want [b:after b:kept]", ids)
+	}
+}
+
+func TestOpen_NewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.db")
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		meta, err := tx.CreateBucket(metaBucket)
+		if err != nil {
+			return err
+		}
+		return meta.Put(versionKey, []byte("99"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	if _, err := Open(path, Options{}); !errors.Is(err, tooldocs.ErrBundleVersion) {
+		t.Errorf("Open error = %v, want ErrBundleVersion", err)
+	}
+}
+
+func TestOpen_RejectsEviction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.db")
+	for _, opts := range []tooldocs.StoreOptions{{MaxDocs: 1}, {MaxMemoryBytes: 1 << 20}} {
+		if _, err := Open(path, Options{StoreOptions: opts}); !errors.Is(err, tooldocs.ErrEvictionUnsupported) {
+			t.Errorf("Open(%+v) error = %v, want ErrEvictionUnsupported", opts, err)
+		}
+	}
+}
+
+func TestStore_PersistKeepsRecordsMissingFromMemory(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "docs.db"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if err := store.RegisterDoc("gh:search", tooldocs.DocEntry{Summary: "Search"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A doc gone from memory without a removal keeps its stored record.
+	if _, err := store.mem.UnregisterWhere(tooldocs.UnregisterFilter{NamespacePrefix: "gh"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.persist([]string{"gh:search"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	docs, err := readDocs(store.db, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].ID != "gh:search" {
+		t.Error("persist deleted a record it was asked to save")
+	}
+}
+
+func TestStore_RestoresAsSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.db")
+	store, err := Open(path, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDocWithOptions("gh:search", tooldocs.DocEntry{Summary: "searches issues."}, tooldocs.RegisterOptions{TTL: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Freeze("gh:search"); err != nil {
+		t.Fatal(err)
+	}
+	saved, _ := store.GetDocEntry("gh:search")
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopening neither re-checks the summary profile nor logs the docs.
+	var log strings.Builder
+	reopened, err := Open(path, Options{StoreOptions: tooldocs.StoreOptions{
+		SummaryProfile: &tooldocs.SummaryProfile{RequireImperative: true},
+		ChangeLog:      &log,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	stored, err := reopened.GetDocEntry("gh:search")
+	if err != nil || !stored.Frozen || !stored.Expires.Equal(saved.Expires) {
+		t.Errorf("restored doc = %+v, %v; want frozen, expiring at %v", stored, err, saved.Expires)
+	}
+	if log.Len() != 0 {
+		t.Errorf("change log after Open = %q, want empty", log.String())
+	}
+}
+
+func TestStore_Encryptor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.db")
+	enc, err := tooldocs.NewAESGCMEncryptor(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := Options{StoreOptions: tooldocs.StoreOptions{Encryptor: enc}}
+
+	store, err := Open(path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDoc("ops:deploy", tooldocs.DocEntry{
+		Summary:  "Deploy a service",
+		Notes:    "See https://wiki.corp.internal/deploy",
+		Examples: []tooldocs.ToolExample{{Title: "Prod", Args: map[string]any{"env": "prod"}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	err = store.db.View(func(tx *bolt.Tx) error {
+		if raw := string(tx.Bucket(toolsBucket).Get([]byte("ops:deploy"))); strings.Contains(raw, "corp.internal") || strings.Contains(raw, "Prod") {
+			t.Errorf("stored record holds plaintext notes or examples: %s", raw)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, err := store.Export(tooldocs.StoreOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored, err := exported.GetDocEntry("ops:deploy"); err != nil || stored.Entry.Notes == "" || len(stored.Entry.Examples) != 1 {
+		t.Errorf("Export = %+v, %v; want opened notes and examples", stored, err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored, err := reopened.GetDocEntry("ops:deploy"); err != nil || stored.Entry.Notes != "See https://wiki.corp.internal/deploy" {
+		t.Errorf("GetDocEntry after reopen = %+v, %v", stored, err)
+	}
+	reopened.Close()
+
+	if _, err := Open(path, Options{}); err == nil {
+		t.Error("Open without Encryptor succeeded on sealed records")
+	}
+	wrongKey, _ := tooldocs.NewAESGCMEncryptor(make([]byte, 16))
+	if _, err := Open(path, Options{StoreOptions: tooldocs.StoreOptions{Encryptor: wrongKey}}); !errors.Is(err, tooldocs.ErrDecrypt) {
+		t.Errorf("Open with wrong key err = %v, want ErrDecrypt", err)
+	}
+}
+
+func TestStore_History(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.db")
+
+	// Docs persisted before history is enabled are seeded at Open.
+	store, err := Open(path, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDoc("gh:search", tooldocs.DocEntry{Summary: "Search v1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.DescribeToolAt("gh:search", tooldocs.DetailSummary, time.Now()); !errors.Is(err, ErrNoHistory) {
+		t.Errorf("DescribeToolAt without history error = %v, want ErrNoHistory", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = Open(path, Options{History: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	v1 := time.Now()
+	if err := store.RegisterDoc("gh:search", tooldocs.DocEntry{Summary: "Search v2"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Import(map[string]tooldocs.DocEntry{"gh:list": {Summary: "List issues"}}, tooldocs.ImportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	v2 := time.Now()
+	if _, err := store.UnregisterWhere(tooldocs.UnregisterFilter{Match: func(id string) bool { return id == "gh:list" }}); err != nil {
+		t.Fatal(err)
+	}
+	v3 := time.Now()
+
+	for _, tt := range []struct {
+		ts   time.Time
+		want string
+	}{
+		{v1, "Search v1"},
+		{v2, "Search v2"},
+		{v3, "Search v2"},
+	} {
+		doc, err := store.DescribeToolAt("gh:search", tooldocs.DetailSummary, tt.ts)
+		if err != nil || doc.Summary != tt.want {
+			t.Errorf("DescribeToolAt = %q, %v; want %q", doc.Summary, err, tt.want)
+		}
+	}
+
+	if _, err := store.DescribeToolAt("gh:search", tooldocs.DetailSummary, v1.Add(-time.Hour)); !errors.Is(err, tooldocs.ErrNotFound) {
+		t.Errorf("DescribeToolAt before history error = %v, want ErrNotFound", err)
+	}
+	past, err := store.ExportAt(v2, tooldocs.StoreOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := past.DocIDs(); len(ids) != 2 {
+		t.Errorf("DocIDs at v2 = %v, want [gh:list gh:search]", ids)
+	}
+	if _, err := store.DescribeToolAt("gh:list", tooldocs.DetailSummary, v3); !errors.Is(err, tooldocs.ErrNotFound) {
+		t.Errorf("DescribeToolAt after removal error = %v, want ErrNotFound", err)
+	}
+
+	// Unchanged imports add no revisions.
+	count := func() int {
+		n := 0
+		_ = store.db.View(func(tx *bolt.Tx) error {
+			n = tx.Bucket(historyBucket).Stats().KeyN
+			return nil
+		})
+		return n
+	}
+	before := count()
+	if _, err := store.Import(map[string]tooldocs.DocEntry{"gh:search": {Summary: "Search v2"}}, tooldocs.ImportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if after := count(); after != before {
+		t.Errorf("history grew from %d to %d revisions on an unchanged import", before, after)
+	}
+}
+
+func TestStore_GC(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "docs.db"), Options{History: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	for _, summary := range []string{"Search v1", "Search v2", "Search v3"} {
+		if err := store.RegisterDoc("gh:search", tooldocs.DocEntry{Summary: summary, Examples: []tooldocs.ToolExample{{Title: "Idle"}}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.RegisterDoc("gh:list", tooldocs.DocEntry{Summary: "List issues"}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+
+	opts := GCOptions{GCOptions: tooldocs.GCOptions{ExampleMaxIdle: time.Nanosecond, DryRun: true}, KeepRevisions: 1}
+	report, err := store.GC(opts)
+	if err != nil {
+		t.Fatalf("dry-run GC failed: %v", err)
+	}
+	if len(report.Examples) != 1 || report.Revisions["gh:search"] != 2 || len(report.Revisions) != 1 {
+		t.Fatalf("dry-run report = %+v, want 1 example and 2 gh:search revisions", report)
+	}
+
+	opts.DryRun = false
+	if _, err := store.GC(opts); err != nil {
+		t.Fatal(err)
+	}
+	if report, _ = store.GC(opts); len(report.Examples) != 0 || len(report.Revisions) != 0 {
+		t.Errorf("second GC report = %+v, want nothing collected", report)
+	}
+	stored, err := store.GetDocEntry("gh:search")
+	if err != nil || len(stored.Entry.Examples) != 0 {
+		t.Errorf("gh:search after GC = %+v, %v; want no examples", stored.Entry, err)
+	}
+	// The collected example was persisted as the latest revision.
+	past, err := store.ExportAt(time.Now(), tooldocs.StoreOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry, _ := past.GetDocEntry("gh:search"); entry.Entry.Summary != "Search v3" || len(entry.Entry.Examples) != 0 {
+		t.Errorf("latest revision = %+v, want v3 without examples", entry.Entry)
+	}
+}
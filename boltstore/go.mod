@@ -0,0 +1,21 @@
+module github.com/jonwraymond/tooldocs/boltstore
+
+go 1.24.4
+
+require (
+	github.com/jonwraymond/tooldocs v0.2.0
+	go.etcd.io/bbolt v1.4.3
+)
+
+require (
+	github.com/google/jsonschema-go v0.3.0 // indirect
+	github.com/jonwraymond/toolindex v0.3.0 // indirect
+	github.com/jonwraymond/toolmodel v0.2.0 // indirect
+	github.com/modelcontextprotocol/go-sdk v1.2.0 // indirect
+	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
+	golang.org/x/oauth2 v0.30.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/jonwraymond/tooldocs => ../
@@ -0,0 +1,259 @@
+package boltstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jonwraymond/tooldocs"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrNoHistory is returned by ExportAt and DescribeToolAt on a store
+// opened without Options.History.
+var ErrNoHistory = errors.New("boltstore: history is not enabled")
+
+// History keys are the tool ID, a zero byte, the big-endian write time in
+// Unix nanoseconds, and a big-endian sequence number ordering revisions
+// written in the same nanosecond, so each tool's revisions are contiguous
+// and in write order. A revision's value is the stored record, or empty
+// when the tool was removed.
+const historySuffixLen = 1 + 8 + 8
+
+// historyKey returns the key of id's revision written at t.
+func historyKey(id string, t time.Time, seq uint64) []byte {
+	key := make([]byte, 0, len(id)+historySuffixLen)
+	key = append(key, id...)
+	key = append(key, 0)
+	key = binary.BigEndian.AppendUint64(key, uint64(t.UnixNano()))
+	return binary.BigEndian.AppendUint64(key, seq)
+}
+
+// parseHistoryKey returns the tool ID and write time of a history key.
+func parseHistoryKey(key []byte) (string, time.Time, bool) {
+	if len(key) < historySuffixLen || key[len(key)-historySuffixLen] != 0 {
+		return "", time.Time{}, false
+	}
+	id := string(key[:len(key)-historySuffixLen])
+	nanos := binary.BigEndian.Uint64(key[len(key)-16:])
+	return id, time.Unix(0, int64(nanos)), true
+}
+
+// putRevision records id's record data (nil for a removal) as written at t.
+func putRevision(tx *bolt.Tx, id string, t time.Time, data []byte) error {
+	bucket := tx.Bucket(historyBucket)
+	seq, err := bucket.NextSequence()
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		data = []byte{}
+	}
+	return bucket.Put(historyKey(id, t, seq), data)
+}
+
+// seedHistory records the current record of every tool without revisions
+// as written at t, so tools persisted before history was enabled can be
+// read back from then on.
+func seedHistory(db *bolt.DB, t time.Time) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		history := tx.Bucket(historyBucket).Cursor()
+		return tx.Bucket(toolsBucket).ForEach(func(k, v []byte) error {
+			prefix := append(bytes.Clone(k), 0)
+			if key, _ := history.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix) {
+				return nil
+			}
+			return putRevision(tx, string(k), t, bytes.Clone(v))
+		})
+	})
+}
+
+// ExportAt is Export as of ts: it reads the latest revision of every tool
+// written at or before ts back into a new InMemoryStore configured with
+// opts, leaving out tools removed by then. It reads the whole history, so
+// it suits audits and incident reviews rather than serving.
+//
+// Returns ErrNoHistory without Options.History, ErrClosed after Close, or
+// the error of decoding the database.
+func (s *Store) ExportAt(ts time.Time, opts tooldocs.StoreOptions) (*tooldocs.InMemoryStore, error) {
+	if !s.opts.History {
+		return nil, ErrNoHistory
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return nil, ErrClosed
+	}
+
+	latest := make(map[string][]byte) // nil value: removed
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(historyBucket).ForEach(func(k, v []byte) error {
+			id, written, ok := parseHistoryKey(k)
+			if !ok {
+				return fmt.Errorf("decode history key %q", k)
+			}
+			if written.After(ts) {
+				return nil
+			}
+			if len(v) == 0 {
+				latest[id] = nil
+			} else {
+				latest[id] = bytes.Clone(v)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("export %s: %w", s.path, err)
+	}
+
+	docs := make([]tooldocs.StoredDoc, 0, len(latest))
+	for id, data := range latest {
+		if data == nil {
+			continue
+		}
+		doc, err := decodeRecord(id, data, s.opts.StoreOptions.Encryptor)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", s.path, err)
+		}
+		docs = append(docs, doc)
+	}
+	mem := tooldocs.NewInMemoryStore(opts)
+	if err := mem.Restore(docs); err != nil {
+		return nil, fmt.Errorf("export %s: %w", s.path, err)
+	}
+	return mem, nil
+}
+
+// DescribeToolAt is DescribeToolAtContext with a background context.
+func (s *Store) DescribeToolAt(id string, level tooldocs.DetailLevel, ts time.Time) (tooldocs.ToolDoc, error) {
+	return s.DescribeToolAtContext(context.Background(), id, level, ts)
+}
+
+// DescribeToolAtContext describes id at level as it was documented at ts,
+// e.g. to reproduce what an agent was shown during an incident. The doc is
+// rendered from the store's documentation as of ts (see ExportAt) with the
+// store's options, so tool resolution and access control apply as for
+// current reads; the tool schema is the current one. Change log and
+// Persister options are not used for the past docs.
+//
+// Returns ErrNoHistory without Options.History, ErrClosed after Close, or
+// the errors of DescribeToolContext, such as tooldocs.ErrNotFound if the
+// tool was neither documented at ts nor known to the store's index.
+func (s *Store) DescribeToolAtContext(ctx context.Context, id string, level tooldocs.DetailLevel, ts time.Time) (tooldocs.ToolDoc, error) {
+	opts := s.opts.StoreOptions
+	opts.Persister = nil
+	opts.ChangeLog = nil
+	past, err := s.ExportAt(ts, opts)
+	if err != nil {
+		return tooldocs.ToolDoc{}, err
+	}
+	return past.DescribeToolContext(ctx, id, level)
+}
+
+// GCOptions configures Store.GC.
+type GCOptions struct {
+	tooldocs.GCOptions
+
+	// KeepRevisions keeps the latest KeepRevisions history revisions of
+	// each tool (see Options.History) and drops older ones, so time-travel
+	// reads no longer reach past the oldest kept revision. Zero keeps every
+	// revision.
+	KeepRevisions int
+}
+
+// GCReport lists what Store.GC collected, or would collect in a dry run.
+type GCReport struct {
+	tooldocs.GCReport
+
+	// Revisions counts the dropped history revisions by tool ID.
+	Revisions map[string]int `json:"revisions,omitempty"`
+}
+
+// GC is InMemoryStore.GC, persisted, followed by pruning the history to
+// opts.KeepRevisions revisions per tool.
+//
+// Returns ErrClosed after Close, the errors of InMemoryStore.GC, or the
+// error of updating the database.
+func (s *Store) GC(opts GCOptions) (GCReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return GCReport{}, ErrClosed
+	}
+
+	var report GCReport
+	var err error
+	if report.GCReport, err = s.mem.GC(opts.GCOptions); err != nil {
+		return report, err
+	}
+	if !opts.DryRun {
+		var ids []string
+		for _, ex := range report.Examples {
+			if len(ids) == 0 || ids[len(ids)-1] != ex.ToolID {
+				ids = append(ids, ex.ToolID)
+			}
+		}
+		if err := s.persist(ids, nil); err != nil {
+			return report, err
+		}
+	}
+	if opts.KeepRevisions <= 0 {
+		return report, nil
+	}
+
+	prune := func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(historyBucket)
+		var (
+			stale   [][]byte
+			id      string
+			revs    [][]byte
+			collect = func() {
+				if n := len(revs) - opts.KeepRevisions; n > 0 {
+					stale = append(stale, revs[:n]...)
+					if report.Revisions == nil {
+						report.Revisions = make(map[string]int)
+					}
+					report.Revisions[id] = n
+				}
+			}
+		)
+		err := bucket.ForEach(func(k, _ []byte) error {
+			kid, _, ok := parseHistoryKey(k)
+			if !ok {
+				return fmt.Errorf("decode history key %q", k)
+			}
+			if kid != id {
+				collect()
+				id, revs = kid, nil
+			}
+			revs = append(revs, bytes.Clone(k))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		collect()
+		if opts.DryRun {
+			return nil
+		}
+		for _, k := range stale {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if opts.DryRun {
+		err = s.db.View(prune)
+	} else {
+		err = s.db.Update(prune)
+	}
+	if err != nil {
+		return report, fmt.Errorf("gc %s: %w", s.path, err)
+	}
+	return report, nil
+}
@@ -0,0 +1,200 @@
+package tooldocs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RenderPolicy is a toolset's prompt-assembly policy: how much of each
+// tool's documentation an agent using the toolset should see.
+type RenderPolicy struct {
+	// Level is the default detail level for the toolset: ToolsetView reads
+	// with an empty level use it, and Render starts from it. Empty means
+	// DetailSummary.
+	Level DetailLevel `json:"level,omitempty"`
+
+	// Examples is the number of examples per tool Render includes. At
+	// DetailSchema, examples are attached even though the level normally
+	// omits them ("schema plus one example"). Zero means the level's
+	// default: all capped examples at DetailFull, none below.
+	Examples int `json:"examples,omitempty"`
+
+	// TokenBudget caps the estimated tokens (see EstimateTokens) of Render
+	// and Preamble output. Zero means unlimited.
+	TokenBudget int `json:"tokenBudget,omitempty"`
+}
+
+// validate checks the policy's fields.
+func (p RenderPolicy) validate() error {
+	if p.Level != "" {
+		if err := validateDetail(p.Level); err != nil {
+			return err
+		}
+	}
+	if p.Examples < 0 || p.TokenBudget < 0 {
+		return fmt.Errorf("render policy: examples and token budget must not be negative")
+	}
+	return nil
+}
+
+// level returns the policy level, defaulting to DetailSummary.
+func (p RenderPolicy) level() DetailLevel {
+	if p.Level == "" {
+		return DetailSummary
+	}
+	return p.Level
+}
+
+// EstimateTokens approximates the prompt tokens of a ToolDoc as its JSON
+// length divided by four, rounded up. It is a budgeting heuristic, not a
+// tokenizer.
+func EstimateTokens(doc ToolDoc) int {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return 0
+	}
+	return estimateTextTokens(string(data))
+}
+
+// estimateTextTokens approximates the tokens of text as len/4, rounded up.
+func estimateTextTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// Rendering is the output of ToolsetView.Render.
+type Rendering struct {
+	// Level is the detail level actually used.
+	Level DetailLevel `json:"level"`
+
+	// Examples is the maximum number of examples per tool actually
+	// included.
+	Examples int `json:"examples"`
+
+	// Tokens is the estimated size of Docs.
+	Tokens int `json:"tokens"`
+
+	// Docs holds one result per included tool, in presentation order.
+	// Tools that failed to describe keep their error and count zero tokens.
+	Docs []DescribeResult `json:"docs"`
+
+	// Omitted lists tools left out because even their summaries exceeded
+	// the budget, in presentation order.
+	Omitted []string `json:"omitted,omitempty"`
+
+	// Degraded reports whether the policy had to be relaxed to fit the
+	// token budget.
+	Degraded bool `json:"degraded"`
+}
+
+// Render describes the toolset according to its RenderPolicy. When the
+// result exceeds TokenBudget it degrades uniformly across tools until it
+// fits: first dropping examples one at a time, then lowering the detail
+// level (full, schema, summary), and finally omitting tools from the end.
+//
+// Returns ErrToolsetNotFound if the toolset has been unregistered.
+func (v *ToolsetView) Render(ctx context.Context) (Rendering, error) {
+	ts, err := v.store.Toolset(v.name)
+	if err != nil {
+		return Rendering{}, err
+	}
+	policy := ts.Policy
+	start := policy.level()
+
+	described, err := v.store.DescribeToolsContext(ctx, ts.Tools, start)
+	if err != nil {
+		return Rendering{}, err
+	}
+
+	examples := policy.Examples
+	if start == DetailSchema && examples > 0 {
+		for i, r := range described {
+			if r.Err == nil {
+				// Examples the caller may not see are simply left out.
+				described[i].Doc.Examples, _ = v.store.ListExamplesContext(ctx, r.ID, examples)
+			}
+		}
+	}
+	if examples == 0 && start == DetailFull {
+		for _, r := range described {
+			examples = max(examples, len(r.Doc.Examples))
+		}
+	}
+	if start == DetailSummary {
+		examples = 0
+	}
+
+	budget := policy.TokenBudget
+	var out Rendering
+	for _, level := range levelsFrom(start) {
+		for e := examples; e >= 0; e-- {
+			out = shapeRendering(described, level, e)
+			if budget == 0 || out.Tokens <= budget {
+				out.Degraded = level != start || e != examples
+				return out, nil
+			}
+		}
+		examples = 0
+	}
+
+	// Even summaries exceed the budget: omit tools from the end.
+	for len(out.Docs) > 0 && out.Tokens > budget {
+		last := out.Docs[len(out.Docs)-1]
+		out.Docs = out.Docs[:len(out.Docs)-1]
+		out.Omitted = append([]string{last.ID}, out.Omitted...)
+		if last.Err == nil {
+			out.Tokens -= EstimateTokens(last.Doc)
+		}
+	}
+	out.Degraded = true
+	return out, nil
+}
+
+// levelsFrom returns level followed by the lower detail levels.
+func levelsFrom(level DetailLevel) []DetailLevel {
+	switch level {
+	case DetailFull:
+		return []DetailLevel{DetailFull, DetailSchema, DetailSummary}
+	case DetailSchema:
+		return []DetailLevel{DetailSchema, DetailSummary}
+	default:
+		return []DetailLevel{DetailSummary}
+	}
+}
+
+// shapeRendering reduces each described doc to level with at most examples
+// examples and totals the estimated tokens.
+func shapeRendering(described []DescribeResult, level DetailLevel, examples int) Rendering {
+	out := Rendering{Level: level, Examples: examples, Docs: make([]DescribeResult, len(described))}
+	for i, r := range described {
+		if r.Err == nil {
+			omitted, total := splitOmittedExamples(r.Doc.Omitted, len(r.Doc.Examples))
+			omitted = append(omittedContent(r.Doc, level), omitted...)
+			r.Doc = reduceDoc(r.Doc, level)
+			r.Doc.Examples = capExamples(r.Doc.Examples, examples)
+			if examples == 0 {
+				r.Doc.Examples = nil
+			}
+			r.Doc.Omitted = omittedExamples(omitted, total, len(r.Doc.Examples))
+			out.Tokens += EstimateTokens(r.Doc)
+		}
+		out.Docs[i] = r
+	}
+	return out
+}
+
+// reduceDoc strips the fields a lower detail level does not carry. Examples
+// and Omitted are left for the caller to set.
+func reduceDoc(doc ToolDoc, level DetailLevel) ToolDoc {
+	switch level {
+	case DetailSummary:
+		return ToolDoc{Title: doc.Title, Summary: doc.Summary, Sections: sectionsAt(doc.Sections, level), Omitted: doc.Omitted, Stale: doc.Stale}
+	case DetailSchema:
+		doc.Sections = sectionsAt(doc.Sections, level)
+		doc.Notes = ""
+		doc.Prerequisites = nil
+		doc.Edges = nil
+		doc.ExternalRefs = nil
+	}
+	return doc
+}
@@ -0,0 +1,141 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func newBudgetStore(t *testing.T, policy RenderPolicy) (*InMemoryStore, *ToolsetView) {
+	t.Helper()
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			tool := makeToolWithSchema(strings.TrimPrefix(id, "ci:"), "ci", id, map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"ref": map[string]any{"type": "string"}},
+			})
+			return &tool, nil
+		},
+	})
+	for _, id := range []string{"ci:build", "ci:test"} {
+		mustRegisterDoc(t, store, id, DocEntry{
+			Summary: "Run " + id,
+			Notes:   strings.Repeat("Long usage notes. ", 20),
+			Examples: []ToolExample{
+				{Title: "Main", Args: map[string]any{"ref": "main"}},
+				{Title: "Tag", Args: map[string]any{"ref": "v1.0.0"}},
+			},
+		})
+	}
+	if err := store.RegisterToolset(Toolset{Name: "ci", Tools: []string{"ci:build", "ci:test"}, Policy: policy}); err != nil {
+		t.Fatalf("RegisterToolset failed: %v", err)
+	}
+	view, err := store.View("ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store, view
+}
+
+func TestRegisterToolset_InvalidPolicy(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	err := store.RegisterToolset(Toolset{Name: "x", Policy: RenderPolicy{Level: "verbose"}})
+	if !errors.Is(err, ErrInvalidDetail) {
+		t.Errorf("invalid level error = %v, want ErrInvalidDetail", err)
+	}
+	if err := store.RegisterToolset(Toolset{Name: "x", Policy: RenderPolicy{TokenBudget: -1}}); err == nil {
+		t.Error("negative budget accepted")
+	}
+}
+
+func TestToolsetView_DefaultLevel(t *testing.T) {
+	_, view := newBudgetStore(t, RenderPolicy{Level: DetailSchema})
+	doc, err := view.DescribeTool("ci:build", "")
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if doc.Tool == nil || doc.Notes != "" {
+		t.Errorf("empty level did not use schema policy: %+v", doc)
+	}
+
+	_, summaryView := newBudgetStore(t, RenderPolicy{})
+	doc, err = summaryView.DescribeTool("ci:build", "")
+	if err != nil || doc.Tool != nil {
+		t.Errorf("default policy level = %+v, %v; want summary", doc, err)
+	}
+}
+
+func TestToolsetView_RenderSchemaPlusExample(t *testing.T) {
+	_, view := newBudgetStore(t, RenderPolicy{Level: DetailSchema, Examples: 1})
+	out, err := view.Render(context.Background())
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if out.Level != DetailSchema || out.Examples != 1 || out.Degraded {
+		t.Errorf("Render = level %s, examples %d, degraded %v", out.Level, out.Examples, out.Degraded)
+	}
+	for _, r := range out.Docs {
+		if r.Doc.Tool == nil || len(r.Doc.Examples) != 1 || r.Doc.Notes != "" {
+			t.Errorf("%s: doc = %+v, want schema with one example", r.ID, r.Doc)
+		}
+	}
+}
+
+func TestToolsetView_RenderDegrades(t *testing.T) {
+	_, view := newBudgetStore(t, RenderPolicy{Level: DetailFull})
+	full, err := view.Render(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Level != DetailFull || full.Examples != 2 || full.Degraded {
+		t.Fatalf("unbudgeted Render = level %s, examples %d, degraded %v", full.Level, full.Examples, full.Degraded)
+	}
+
+	// Summary-level docs still list what they omit.
+	summaryTokens := shapeRendering(full.Docs, DetailSummary, 0).Tokens
+
+	tests := []struct {
+		name        string
+		budget      int
+		wantLevel   DetailLevel
+		wantOmitted []string
+	}{
+		{"fits", full.Tokens, DetailFull, nil},
+		{"summary only", summaryTokens, DetailSummary, nil},
+		{"omit tools", summaryTokens - 1, DetailSummary, []string{"ci:test"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, view := newBudgetStore(t, RenderPolicy{Level: DetailFull, TokenBudget: tt.budget})
+			out, err := view.Render(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.Level != tt.wantLevel || !reflect.DeepEqual(out.Omitted, tt.wantOmitted) {
+				t.Errorf("Render = level %s, omitted %v; want %s, %v", out.Level, out.Omitted, tt.wantLevel, tt.wantOmitted)
+			}
+			if out.Tokens > tt.budget {
+				t.Errorf("Tokens = %d, over budget %d", out.Tokens, tt.budget)
+			}
+			if out.Degraded != (tt.wantLevel != DetailFull) {
+				t.Errorf("Degraded = %v", out.Degraded)
+			}
+		})
+	}
+}
+
+func TestToolsetView_PreambleBudget(t *testing.T) {
+	_, view := newBudgetStore(t, RenderPolicy{TokenBudget: 8})
+	got, err := view.Preamble(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "- ci:build: Run ci:build\n- (1 more omitted)\n"
+	if got != want {
+		t.Errorf("Preamble = %q, want %q", got, want)
+	}
+}
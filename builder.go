@@ -0,0 +1,243 @@
+package tooldocs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ErrFieldTooLong is reported by DocBuilder when a field exceeds its cap.
+// RegisterDoc truncates such fields silently; the builder rejects them so
+// generators notice.
+var ErrFieldTooLong = errors.New("field exceeds cap")
+
+// DocBuilder assembles a DocEntry programmatically, checking caps as each
+// piece is added instead of truncating at RegisterDoc time. Methods return
+// the builder for chaining; problems accumulate and are reported by Err and
+// Build.
+//
+//	entry, err := tooldocs.NewDocBuilder().
+//		WithSummary("Create a ticket").
+//		WithNote("Requires project admin rights.").
+//		AddExample(tooldocs.ToolExample{Title: "Bug", Args: args}).
+//		AddRef("https://example.com/api/tickets").
+//		Build()
+type DocBuilder struct {
+	entry DocEntry
+	notes []string
+	errs  []error
+}
+
+// NewDocBuilder returns an empty DocBuilder.
+func NewDocBuilder() *DocBuilder {
+	return &DocBuilder{}
+}
+
+// WithTitle sets DocEntry.Title.
+func (b *DocBuilder) WithTitle(title string) *DocBuilder {
+	b.entry.Title = title
+	return b
+}
+
+// WithAnnotations sets DocEntry.Annotations.
+func (b *DocBuilder) WithAnnotations(annotations *mcp.ToolAnnotations) *DocBuilder {
+	b.entry.Annotations = annotations
+	return b
+}
+
+// WithSummary sets DocEntry.Summary, reporting ErrFieldTooLong beyond
+// MaxSummaryLen.
+func (b *DocBuilder) WithSummary(summary string) *DocBuilder {
+	if len(summary) > MaxSummaryLen {
+		b.fail(fmt.Errorf("%w: summary is %d chars (max %d)", ErrFieldTooLong, len(summary), MaxSummaryLen))
+	}
+	b.entry.Summary = summary
+	return b
+}
+
+// WithShortNotes sets DocEntry.ShortNotes, reporting ErrFieldTooLong beyond
+// MaxShortNotesLen.
+func (b *DocBuilder) WithShortNotes(notes string) *DocBuilder {
+	if len(notes) > MaxShortNotesLen {
+		b.fail(fmt.Errorf("%w: short notes are %d chars (max %d)", ErrFieldTooLong, len(notes), MaxShortNotesLen))
+	}
+	b.entry.ShortNotes = notes
+	return b
+}
+
+// WithNote appends a paragraph to DocEntry.Notes, reporting ErrFieldTooLong
+// once the combined notes exceed MaxNotesLen.
+func (b *DocBuilder) WithNote(note string) *DocBuilder {
+	before := len(b.joinedNotes())
+	b.notes = append(b.notes, note)
+	if n := len(b.joinedNotes()); n > MaxNotesLen && before <= MaxNotesLen {
+		b.fail(fmt.Errorf("%w: notes are %d chars (max %d)", ErrFieldTooLong, n, MaxNotesLen))
+	}
+	return b
+}
+
+// AddSection appends a custom section, reporting ErrFieldTooLong for an
+// over-long Title or Body and beyond MaxSections.
+func (b *DocBuilder) AddSection(sec DocSection) *DocBuilder {
+	n := len(b.entry.Sections)
+	if n == MaxSections {
+		b.fail(fmt.Errorf("%w: more than %d sections", ErrFieldTooLong, MaxSections))
+	}
+	if len(sec.Title) > MaxSectionTitleLen {
+		b.fail(fmt.Errorf("%w: section %d title is %d chars (max %d)", ErrFieldTooLong, n, len(sec.Title), MaxSectionTitleLen))
+	}
+	if len(sec.Body) > MaxSectionBodyLen {
+		b.fail(fmt.Errorf("%w: section %d (%s) body is %d chars (max %d)", ErrFieldTooLong, n, sec.Title, len(sec.Body), MaxSectionBodyLen))
+	}
+	b.entry.Sections = append(b.entry.Sections, sec)
+	return b
+}
+
+// AddExample appends a copy of ex, reporting ErrFieldTooLong for an over-long
+// Description or ResultHint and ErrArgsTooLarge for Args beyond
+// MaxArgsDepth or MaxArgsKeys.
+func (b *DocBuilder) AddExample(ex ToolExample) *DocBuilder {
+	label := fmt.Sprintf("example %d (%s)", len(b.entry.Examples), ex.Title)
+	if len(ex.Description) > MaxDescriptionLen {
+		b.fail(fmt.Errorf("%w: %s description is %d chars (max %d)", ErrFieldTooLong, label, len(ex.Description), MaxDescriptionLen))
+	}
+	if len(ex.ResultHint) > MaxResultHintLen {
+		b.fail(fmt.Errorf("%w: %s result hint is %d chars (max %d)", ErrFieldTooLong, label, len(ex.ResultHint), MaxResultHintLen))
+	}
+	ex = copyExamples([]ToolExample{ex})[0]
+	if stats, ok := ValidateArgs(ex.Args); !ok {
+		b.fail(fmt.Errorf("%w: %s has depth=%d (max %d), keys=%d (max %d)",
+			ErrArgsTooLarge, label, stats.Depth, MaxArgsDepth, stats.Keys, MaxArgsKeys))
+	}
+	b.entry.Examples = append(b.entry.Examples, ex)
+	return b
+}
+
+// AddPrerequisite appends a prerequisite, reporting ErrFieldTooLong for an
+// over-long Description.
+func (b *DocBuilder) AddPrerequisite(p Prerequisite) *DocBuilder {
+	if len(p.Description) > MaxDescriptionLen {
+		b.fail(fmt.Errorf("%w: prerequisite %d description is %d chars (max %d)",
+			ErrFieldTooLong, len(b.entry.Prerequisites), len(p.Description), MaxDescriptionLen))
+	}
+	b.entry.Prerequisites = append(b.entry.Prerequisites, p)
+	return b
+}
+
+// AddEffect appends a declared effect, reporting ErrFieldTooLong for an
+// over-long Description.
+func (b *DocBuilder) AddEffect(e Effect) *DocBuilder {
+	if len(e.Description) > MaxDescriptionLen {
+		b.fail(fmt.Errorf("%w: effect %d description is %d chars (max %d)",
+			ErrFieldTooLong, len(b.entry.Effects), len(e.Description), MaxDescriptionLen))
+	}
+	b.entry.Effects = append(b.entry.Effects, e)
+	return b
+}
+
+// AddDependency appends a dependency on another tool, reporting
+// ErrFieldTooLong for an over-long Description.
+func (b *DocBuilder) AddDependency(d Dependency) *DocBuilder {
+	if len(d.Description) > MaxDescriptionLen {
+		b.fail(fmt.Errorf("%w: dependency %d description is %d chars (max %d)",
+			ErrFieldTooLong, len(b.entry.Dependencies), len(d.Description), MaxDescriptionLen))
+	}
+	b.entry.Dependencies = append(b.entry.Dependencies, d)
+	return b
+}
+
+// WithIdempotent sets DocEntry.Idempotent.
+func (b *DocBuilder) WithIdempotent(idempotent bool) *DocBuilder {
+	b.entry.Idempotent = idempotent
+	return b
+}
+
+// WithRetryPolicy sets a copy of policy as DocEntry.RetryPolicy, reporting
+// ErrFieldTooLong for a Backoff beyond MaxResultHintLen.
+func (b *DocBuilder) WithRetryPolicy(policy RetryPolicy) *DocBuilder {
+	if len(policy.Backoff) > MaxResultHintLen {
+		b.fail(fmt.Errorf("%w: retry backoff is %d chars (max %d)", ErrFieldTooLong, len(policy.Backoff), MaxResultHintLen))
+	}
+	b.entry.RetryPolicy = &policy
+	return b
+}
+
+// WithConcurrency sets a copy of c as DocEntry.Concurrency.
+func (b *DocBuilder) WithConcurrency(c Concurrency) *DocBuilder {
+	b.entry.Concurrency = &c
+	return b
+}
+
+// WithClientRequirements sets a copy of r as DocEntry.ClientRequirements.
+func (b *DocBuilder) WithClientRequirements(r ClientRequirements) *DocBuilder {
+	b.entry.ClientRequirements = &r
+	return b
+}
+
+// AddRef appends an external reference, ignoring exact duplicates.
+func (b *DocBuilder) AddRef(ref string) *DocBuilder {
+	for _, existing := range b.entry.ExternalRefs {
+		if existing == ref {
+			return b
+		}
+	}
+	b.entry.ExternalRefs = append(b.entry.ExternalRefs, ref)
+	return b
+}
+
+// WithSource sets DocEntry.Source.
+func (b *DocBuilder) WithSource(source string) *DocBuilder {
+	b.entry.Source = source
+	return b
+}
+
+// WithSensitive sets DocEntry.Sensitive.
+func (b *DocBuilder) WithSensitive(sensitive bool) *DocBuilder {
+	b.entry.Sensitive = sensitive
+	return b
+}
+
+// WithRender sets a copy of hints as DocEntry.Render.
+func (b *DocBuilder) WithRender(hints RenderHints) *DocBuilder {
+	b.entry.Render = copyRenderHints(&hints)
+	return b
+}
+
+// Err returns the problems found so far, joined, or nil.
+func (b *DocBuilder) Err() error {
+	return errors.Join(b.errs...)
+}
+
+// Build returns the assembled DocEntry, or every problem found while
+// building it joined into one error (match with errors.Is).
+func (b *DocBuilder) Build() (DocEntry, error) {
+	if err := b.Err(); err != nil {
+		return DocEntry{}, err
+	}
+	entry := b.entry
+	entry.Notes = b.joinedNotes()
+	entry.Sections = slices.Clone(entry.Sections)
+	entry.Examples = copyExamples(entry.Examples)
+	entry.Prerequisites = slices.Clone(entry.Prerequisites)
+	entry.Effects = slices.Clone(entry.Effects)
+	entry.Dependencies = slices.Clone(entry.Dependencies)
+	entry.RetryPolicy = copyRetryPolicy(entry.RetryPolicy)
+	entry.Concurrency = copyConcurrency(entry.Concurrency)
+	entry.ClientRequirements = copyClientRequirements(entry.ClientRequirements)
+	entry.Render = copyRenderHints(entry.Render)
+	entry.ExternalRefs = append([]string(nil), entry.ExternalRefs...)
+	return entry, nil
+}
+
+// fail records a problem.
+func (b *DocBuilder) fail(err error) {
+	b.errs = append(b.errs, err)
+}
+
+// joinedNotes returns the notes paragraphs separated by blank lines.
+func (b *DocBuilder) joinedNotes() string {
+	return strings.Join(b.notes, "\n\n")
+}
@@ -0,0 +1,76 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDocBuilder(t *testing.T) {
+	args := map[string]any{"project": "OPS"}
+	entry, err := NewDocBuilder().
+		WithTitle("Create Ticket").
+		WithSummary("Create a ticket").
+		WithNote("Requires project admin rights.").
+		WithNote("Rate limited to 10 per minute.").
+		AddExample(ToolExample{Title: "Bug", Args: args}).
+		AddRef("https://example.com/api").
+		AddRef("https://example.com/api").
+		WithSource("importer:codegen").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	args["project"] = "mutated"
+
+	want := DocEntry{
+		Title:        "Create Ticket",
+		Summary:      "Create a ticket",
+		Notes:        "Requires project admin rights.\n\nRate limited to 10 per minute.",
+		Examples:     []ToolExample{{Title: "Bug", Args: map[string]any{"project": "OPS"}}},
+		ExternalRefs: []string{"https://example.com/api"},
+		Source:       "importer:codegen",
+	}
+	if !reflect.DeepEqual(entry, want) {
+		t.Errorf("Build = %+v, want %+v", entry, want)
+	}
+
+	s := NewInMemoryStore(StoreOptions{})
+	if err := s.RegisterDoc("jira:create_ticket", entry); err != nil {
+		t.Errorf("RegisterDoc rejected built entry: %v", err)
+	}
+}
+
+func TestDocBuilder_CapViolations(t *testing.T) {
+	deep := map[string]any{"a": map[string]any{"b": map[string]any{"c": map[string]any{"d": map[string]any{"e": map[string]any{"f": 1}}}}}}
+
+	b := NewDocBuilder().WithSummary(strings.Repeat("s", MaxSummaryLen+1))
+	if !errors.Is(b.Err(), ErrFieldTooLong) {
+		t.Fatalf("Err after long summary = %v, want ErrFieldTooLong", b.Err())
+	}
+
+	b.WithNote(strings.Repeat("n", MaxNotesLen)).
+		WithNote("overflow").
+		WithNote("still over").
+		AddExample(ToolExample{Title: "Deep", Args: deep}).
+		AddExample(ToolExample{Title: "Wordy", Description: strings.Repeat("d", MaxDescriptionLen+1)})
+
+	entry, err := b.Build()
+	if err == nil {
+		t.Fatal("Build succeeded despite violations")
+	}
+	if !reflect.DeepEqual(entry, DocEntry{}) {
+		t.Errorf("Build returned partial entry %+v", entry)
+	}
+	if !errors.Is(err, ErrArgsTooLarge) || !errors.Is(err, ErrFieldTooLong) {
+		t.Errorf("err = %v", err)
+	}
+	// Summary, notes (reported once), deep args, and long description.
+	if n := len(strings.Split(err.Error(), "\n")); n != 4 {
+		t.Errorf("got %d problems, want 4:\n%v", n, err)
+	}
+	if !strings.Contains(err.Error(), "example 1 (Wordy)") {
+		t.Errorf("err does not identify the example: %v", err)
+	}
+}
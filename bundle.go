@@ -0,0 +1,179 @@
+package tooldocs
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// BundleVersion is the bundle file format version written by this package.
+// Bundles without a version field predate versioning and are version 0.
+const BundleVersion = 1
+
+// ErrBundleVersion is returned when a bundle was written by a newer package
+// version than this one can read.
+var ErrBundleVersion = errors.New("unsupported bundle version")
+
+// bundleMigrations[v] upgrades a decoded bundle object from version v to
+// v+1. Append a step whenever BundleVersion is bumped.
+var bundleMigrations = []func(bundle map[string]json.RawMessage) error{
+	// 0 -> 1: the version field was introduced; the layout is unchanged.
+	func(map[string]json.RawMessage) error { return nil },
+}
+
+// Bundle is the portable, serialized form of a store's documentation:
+// a map of tool ID to registered DocEntry.
+type Bundle struct {
+	// Version is the file format version. Bundle() and WriteBundle set it
+	// to BundleVersion; ReadBundle migrates older bundles to it.
+	Version int `json:"version"`
+
+	// Tools maps tool IDs to their documentation.
+	Tools map[string]DocEntry `json:"tools"`
+
+	// Sealed holds encrypted free-text fields by tool ID for bundles
+	// produced by SealBundle. Use OpenBundle to restore them before import.
+	Sealed map[string][]byte `json:"sealed,omitempty"`
+
+	// State holds store-managed state by tool ID for bundles written by
+	// FileStore, which restores it on load. Bundle() leaves it empty, and
+	// Import ignores it.
+	State map[string]DocState `json:"state,omitempty"`
+}
+
+// DocState is the store-managed state of a tool's documentation kept next
+// to its entry by persistent backends.
+type DocState struct {
+	// Frozen reports whether the documentation is protected from writes.
+	Frozen bool `json:"frozen,omitempty"`
+
+	// Expires is when the documentation's TTL passes; zero means never.
+	Expires time.Time `json:"expires,omitzero"`
+}
+
+// Bundle returns a snapshot of all registered documentation as a Bundle.
+// The returned entries are caller-owned copies.
+func (s *InMemoryStore) Bundle() Bundle {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b := Bundle{Version: BundleVersion, Tools: make(map[string]DocEntry, len(s.docs))}
+	for id, record := range s.docs {
+		b.Tools[id] = record.entry()
+	}
+	return b
+}
+
+// stateBundle is Bundle with each doc's frozen flag and expiry in State.
+func (s *InMemoryStore) stateBundle() Bundle {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b := Bundle{Version: BundleVersion, Tools: make(map[string]DocEntry, len(s.docs))}
+	for id, record := range s.docs {
+		b.Tools[id] = record.entry()
+		if record.frozen || !record.expires.IsZero() {
+			if b.State == nil {
+				b.State = make(map[string]DocState)
+			}
+			b.State[id] = DocState{Frozen: record.frozen, Expires: record.expires}
+		}
+	}
+	return b
+}
+
+// storedDocs returns the docs of b with their State, for Restore.
+func (b Bundle) storedDocs() []StoredDoc {
+	docs := make([]StoredDoc, 0, len(b.Tools))
+	for id, entry := range b.Tools {
+		state := b.State[id]
+		docs = append(docs, StoredDoc{ID: id, Entry: entry, Frozen: state.Frozen, Expires: state.Expires})
+	}
+	return docs
+}
+
+// WriteBundle writes b to w as indented JSON. Map keys (tool IDs and Args
+// keys) are emitted in sorted order, so output is stable across runs. A
+// zero Version is written as BundleVersion.
+func WriteBundle(w io.Writer, b Bundle) error {
+	if b.Version == 0 {
+		b.Version = BundleVersion
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(b); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	return nil
+}
+
+// ReadBundle decodes a JSON or YAML bundle from r, migrating bundles
+// written by older package versions (see MigrateBundle). Input opening
+// with "{" is read as JSON, anything else as YAML.
+//
+// Returns ErrBundleVersion if the bundle is newer than BundleVersion.
+func ReadBundle(r io.Reader) (Bundle, error) {
+	br := bufio.NewReader(r)
+	var raw json.RawMessage
+	if sniffBundleFormat(br) == BundleYAML {
+		data, err := io.ReadAll(br)
+		if err == nil {
+			raw, err = yamlToJSON(data)
+		}
+		if err != nil {
+			return Bundle{}, fmt.Errorf("read bundle: %w", err)
+		}
+	} else if err := json.NewDecoder(br).Decode(&raw); err != nil {
+		return Bundle{}, fmt.Errorf("read bundle: %w", err)
+	}
+	data, err := MigrateBundle(raw)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("read bundle: %w", err)
+	}
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return Bundle{}, fmt.Errorf("read bundle: %w", err)
+	}
+	if b.Tools == nil {
+		b.Tools = map[string]DocEntry{}
+	}
+	return b, nil
+}
+
+// MigrateBundle upgrades a JSON bundle written by any earlier package
+// version to BundleVersion, returning the migrated JSON. Current bundles
+// are returned unchanged. ReadBundle calls it automatically; use it
+// directly to rewrite bundle files in place.
+//
+// Returns ErrBundleVersion if the bundle is newer than BundleVersion.
+func MigrateBundle(old []byte) ([]byte, error) {
+	var bundle map[string]json.RawMessage
+	if err := json.Unmarshal(old, &bundle); err != nil {
+		return nil, fmt.Errorf("migrate bundle: %w", err)
+	}
+	if bundle == nil {
+		return nil, errors.New("migrate bundle: bundle is null")
+	}
+	version := 0
+	if v, ok := bundle["version"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil {
+			return nil, fmt.Errorf("migrate bundle: version: %w", err)
+		}
+	}
+	switch {
+	case version == BundleVersion:
+		return old, nil
+	case version < 0 || version > BundleVersion:
+		return nil, fmt.Errorf("%w: %d (max %d)", ErrBundleVersion, version, BundleVersion)
+	}
+	for ; version < BundleVersion; version++ {
+		if err := bundleMigrations[version](bundle); err != nil {
+			return nil, fmt.Errorf("migrate bundle: version %d: %w", version, err)
+		}
+	}
+	bundle["version"] = json.RawMessage(fmt.Sprint(BundleVersion))
+	return json.Marshal(bundle)
+}
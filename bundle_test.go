@@ -0,0 +1,209 @@
+package tooldocs
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBundle_RoundTrip(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{
+		Summary:      "a",
+		Notes:        "notes",
+		Examples:     []ToolExample{{ID: "ex", Title: "Example", Args: map[string]any{"q": "x"}}},
+		ExternalRefs: []string{"https://example.com"},
+		Source:       "manual",
+	})
+	mustRegisterDoc(t, store, "ns:b", DocEntry{Summary: "b"})
+
+	var buf bytes.Buffer
+	if err := WriteBundle(&buf, store.Bundle()); err != nil {
+		t.Fatalf("WriteBundle failed: %v", err)
+	}
+	got, err := ReadBundle(&buf)
+	if err != nil {
+		t.Fatalf("ReadBundle failed: %v", err)
+	}
+
+	if !DiffBundles(store.Bundle(), got).Empty() {
+		t.Errorf("round trip changed bundle: %+v", DiffBundles(store.Bundle(), got))
+	}
+	if got.Tools["ns:a"].Source != "manual" {
+		t.Errorf("Source = %q, want manual", got.Tools["ns:a"].Source)
+	}
+}
+
+func TestReadBundle_Invalid(t *testing.T) {
+	if _, err := ReadBundle(bytes.NewBufferString("{")); err == nil {
+		t.Error("expected error for malformed bundle")
+	}
+	b, err := ReadBundle(bytes.NewBufferString("{}"))
+	if err != nil || b.Tools == nil {
+		t.Errorf("ReadBundle({}) = %+v, %v", b, err)
+	}
+}
+
+func TestDiffBundles(t *testing.T) {
+	oldBundle := Bundle{Tools: map[string]DocEntry{
+		"ns:same":    {Summary: "same"},
+		"ns:removed": {Summary: "gone"},
+		"ns:changed": {
+			Summary: "old",
+			Examples: []ToolExample{
+				{ID: "keep", Title: "Keep"},
+				{ID: "edit", Title: "Edit", Args: map[string]any{"a": 1.0}},
+				{Title: "Drop"},
+			},
+		},
+	}}
+	newBundle := Bundle{Tools: map[string]DocEntry{
+		"ns:same":  {Summary: "same"},
+		"ns:added": {Summary: "new"},
+		"ns:changed": {
+			Summary: "new",
+			Notes:   "added notes",
+			Examples: []ToolExample{
+				{ID: "keep", Title: "Keep"},
+				{ID: "edit", Title: "Edit", Args: map[string]any{"a": 2.0}},
+				{Title: "Fresh"},
+			},
+		},
+	}}
+
+	got := DiffBundles(oldBundle, newBundle)
+	want := BundleDiff{
+		Added:   []string{"ns:added"},
+		Removed: []string{"ns:removed"},
+		Changed: []ToolDiff{{
+			ID:              "ns:changed",
+			Fields:          []string{"summary", "notes", "examples"},
+			OldSummary:      "old",
+			NewSummary:      "new",
+			ExamplesAdded:   []string{"Fresh"},
+			ExamplesRemoved: []string{"Drop"},
+			ExamplesChanged: []string{"edit"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffBundles =\n%+v\nwant\n%+v", got, want)
+	}
+	if got.Empty() {
+		t.Error("Empty() = true, want false")
+	}
+	if !DiffBundles(oldBundle, oldBundle).Empty() {
+		t.Error("diff of identical bundles should be empty")
+	}
+}
+
+func TestDiffExamples(t *testing.T) {
+	before := []ToolExample{
+		{ID: "list", Description: "List open issues", Args: map[string]any{
+			"repo":   "a/b",
+			"filter": map[string]any{"state": "open", "label": "bug"},
+			"sort":   []any{"created", "desc"},
+		}},
+		{ID: "same", Args: map[string]any{"q": 1}},
+		{Title: "no id", Args: map[string]any{"q": 1}},
+	}
+	after := []ToolExample{
+		{ID: "same", Args: map[string]any{"q": 1}},
+		{ID: "list", Description: "List closed issues", Args: map[string]any{
+			"repo":   "a/b",
+			"filter": map[string]any{"state": "closed"},
+			"sort":   []any{"created"},
+			"limit":  10,
+		}},
+		{Title: "no id", Args: map[string]any{"q": 2}},
+		{ID: "new", Args: map[string]any{"q": 1}},
+	}
+
+	want := []ExampleDiff{{
+		ID: "list",
+		Args: []ArgChange{
+			{Path: "filter.label", Old: "bug"},
+			{Path: "filter.state", Old: "open", New: "closed"},
+			{Path: "limit", New: 10},
+			{Path: "sort[1]", Old: "desc"},
+		},
+		OldDescription: "List open issues",
+		NewDescription: "List closed issues",
+	}}
+	if got := DiffExamples(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffExamples =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestMigrateBundle(t *testing.T) {
+	legacy := []byte(`{"tools": {"ns:a": {"summary": "a"}}}`)
+	migrated, err := MigrateBundle(legacy)
+	if err != nil {
+		t.Fatalf("MigrateBundle failed: %v", err)
+	}
+	b, err := ReadBundle(bytes.NewReader(migrated))
+	if err != nil {
+		t.Fatalf("ReadBundle failed: %v", err)
+	}
+	if b.Version != BundleVersion || b.Tools["ns:a"].Summary != "a" {
+		t.Errorf("migrated bundle = %+v", b)
+	}
+
+	current := []byte(`{"version": 1, "tools": {}}`)
+	if got, err := MigrateBundle(current); err != nil || !bytes.Equal(got, current) {
+		t.Errorf("MigrateBundle(current) = %s, %v; want unchanged", got, err)
+	}
+
+	for _, data := range []string{`{"version": 99, "tools": {}}`, `{"version": -1}`} {
+		if _, err := MigrateBundle([]byte(data)); !errors.Is(err, ErrBundleVersion) {
+			t.Errorf("MigrateBundle(%s) error = %v, want ErrBundleVersion", data, err)
+		}
+	}
+	for _, data := range []string{`[]`, `null`, `{"version": "1"}`} {
+		if _, err := MigrateBundle([]byte(data)); err == nil {
+			t.Errorf("MigrateBundle(%s) succeeded, want error", data)
+		}
+	}
+}
+
+func TestReadBundle_Legacy(t *testing.T) {
+	b, err := ReadBundle(bytes.NewBufferString(`{"tools": {"ns:a": {"summary": "a"}}}`))
+	if err != nil {
+		t.Fatalf("ReadBundle failed: %v", err)
+	}
+	if b.Version != BundleVersion || b.Tools["ns:a"].Summary != "a" {
+		t.Errorf("ReadBundle(legacy) = %+v", b)
+	}
+	if _, err := ReadBundle(bytes.NewBufferString(`{"version": 99}`)); !errors.Is(err, ErrBundleVersion) {
+		t.Errorf("ReadBundle(future) error = %v, want ErrBundleVersion", err)
+	}
+}
+
+func TestReadBundle_GoFieldNames(t *testing.T) {
+	// Entries encoded before DocEntry had JSON tags used the Go field names.
+	b, err := ReadBundle(bytes.NewBufferString(`{"tools": {"ns:a": {
+		"Title": "A", "Summary": "a", "Notes": "n",
+		"Examples": [{"Title": "t", "Args": {"q": "x"}}],
+		"ExternalRefs": ["https://example.com"], "Source": "manual"}}}`))
+	if err != nil {
+		t.Fatalf("ReadBundle failed: %v", err)
+	}
+	want := DocEntry{
+		Title: "A", Summary: "a", Notes: "n",
+		Examples:     []ToolExample{{Title: "t", Args: map[string]any{"q": "x"}}},
+		ExternalRefs: []string{"https://example.com"}, Source: "manual",
+	}
+	if got := b.Tools["ns:a"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("entry = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteBundle_StampsVersion(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBundle(&buf, Bundle{Tools: map[string]DocEntry{}}); err != nil {
+		t.Fatalf("WriteBundle failed: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"version": 1`)) {
+		t.Errorf("WriteBundle output missing version:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,134 @@
+package tooldocs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BundleFormat is a bundle file encoding.
+type BundleFormat string
+
+// Bundle formats. Both encode the same document: YAML bundles use the JSON
+// field names (version, tools, summary, externalRefs, ...), so a bundle
+// converts between the formats without loss.
+const (
+	BundleJSON BundleFormat = "json"
+	BundleYAML BundleFormat = "yaml"
+)
+
+// WriteBundleYAML writes b to w as YAML, with the fields in the order
+// WriteBundle uses and map keys sorted. A zero Version is written as
+// BundleVersion.
+func WriteBundleYAML(w io.Writer, b Bundle) error {
+	if b.Version == 0 {
+		b.Version = BundleVersion
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	if data, err = jsonToYAML(data); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	return nil
+}
+
+// ExportBundle writes a snapshot of all registered documentation (see
+// Bundle) to w in format.
+//
+// Returns an error for an unknown format, or the write error.
+func (s *InMemoryStore) ExportBundle(w io.Writer, format BundleFormat) error {
+	switch format {
+	case BundleJSON:
+		return WriteBundle(w, s.Bundle())
+	case BundleYAML:
+		return WriteBundleYAML(w, s.Bundle())
+	default:
+		return fmt.Errorf("unknown bundle format: %s", format)
+	}
+}
+
+// ImportBundle reads a JSON or YAML bundle from r (see ReadBundle) and
+// imports its tools with opts, so docs authored in files can be loaded
+// without RegisterDoc calls.
+//
+// Returns ReadBundle's errors, an error for sealed bundles (open them
+// with OpenBundle and Import the result), or Import's errors.
+func (s *InMemoryStore) ImportBundle(r io.Reader, opts ImportOptions) (ImportReport, error) {
+	b, err := ReadBundle(r)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	if len(b.Sealed) > 0 {
+		return ImportReport{}, fmt.Errorf("import bundle: bundle is sealed; open it with OpenBundle")
+	}
+	return s.Import(b.Tools, opts)
+}
+
+// sniffBundleFormat reports the format of a bundle starting with the
+// buffered data: JSON if it opens with an object, YAML otherwise.
+func sniffBundleFormat(r *bufio.Reader) BundleFormat {
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return BundleJSON
+		}
+		if c == ' ' || c == '\t' || c == '\r' || c == '\n' {
+			continue
+		}
+		_ = r.UnreadByte()
+		if c == '{' {
+			return BundleJSON
+		}
+		return BundleYAML
+	}
+}
+
+// yamlToJSON converts a YAML document to JSON, so it can be decoded with
+// the json field names of the package's types.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// jsonToYAML converts a JSON document to block-style YAML, keeping the
+// order of object fields.
+func jsonToYAML(data []byte) ([]byte, error) {
+	// JSON is YAML, so the parsed tree keeps the field order; clearing the
+	// flow and quoting styles makes the encoder write block YAML.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	plainStyle(&doc)
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// plainStyle resets the style of n and its descendants to the encoder's
+// default.
+func plainStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		plainStyle(c)
+	}
+}
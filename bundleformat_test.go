@@ -0,0 +1,108 @@
+package tooldocs
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExportBundle_YAMLRoundTrip(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{
+		Summary:      "Search issues",
+		Notes:        "Line one.\nLine two.",
+		Examples:     []ToolExample{{ID: "ex", Title: "Example", Args: map[string]any{"q": "true", "limit": 5, "nested": map[string]any{"n": "123"}}}},
+		ExternalRefs: []string{"https://example.com"},
+		Idempotent:   true,
+	})
+	mustRegisterDoc(t, store, "ns:b", DocEntry{Summary: "null"})
+
+	var buf bytes.Buffer
+	if err := store.ExportBundle(&buf, BundleYAML); err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"version: 1\n", "tools:\n", "externalRefs:\n", "summary: Search issues\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("YAML bundle missing %q:\n%s", want, out)
+		}
+	}
+
+	got, err := ReadBundle(&buf)
+	if err != nil {
+		t.Fatalf("ReadBundle failed: %v", err)
+	}
+
+	// Both formats decode to the same bundle (numbers in Args come back as
+	// float64 either way).
+	buf.Reset()
+	if err := store.ExportBundle(&buf, BundleJSON); err != nil || !strings.HasPrefix(buf.String(), "{") {
+		t.Fatalf("JSON ExportBundle = %q, %v", buf.String(), err)
+	}
+	want, err := ReadBundle(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := DiffBundles(want, got); !diff.Empty() {
+		t.Errorf("YAML round trip differs from JSON: %+v", diff)
+	}
+	if got.Tools["ns:a"].Examples[0].Args["q"] != "true" || got.Tools["ns:b"].Summary != "null" {
+		t.Errorf("YAML round trip lost string typing: %+v", got.Tools)
+	}
+	if err := store.ExportBundle(&buf, "toml"); err == nil {
+		t.Error("ExportBundle with unknown format succeeded")
+	}
+}
+
+func TestImportBundle(t *testing.T) {
+	const authored = `
+# Docs maintained by the platform team.
+version: 1
+tools:
+  github:search:
+    summary: Search issues and pull requests
+    examples:
+      - title: Open bugs
+        args:
+          q: is:open label:bug
+    externalRefs:
+      - https://docs.github.com/en/search-github
+  github:list:
+    summary: List repository issues
+`
+	store := NewInMemoryStore(StoreOptions{})
+	report, err := store.ImportBundle(strings.NewReader(authored), ImportOptions{Source: "bundle:yaml"})
+	if err != nil {
+		t.Fatalf("ImportBundle failed: %v", err)
+	}
+	if report.Totals.Created != 2 {
+		t.Errorf("Totals = %+v, want 2 created", report.Totals)
+	}
+	stored, err := store.GetDocEntry("github:search")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := stored.Entry; len(e.Examples) != 1 || e.Examples[0].Args["q"] != "is:open label:bug" || len(e.ExternalRefs) != 1 || e.Source != "bundle:yaml" {
+		t.Errorf("imported entry = %+v", e)
+	}
+
+	if _, err := store.ImportBundle(strings.NewReader("version: 99\ntools: {}\n"), ImportOptions{}); !errors.Is(err, ErrBundleVersion) {
+		t.Errorf("newer YAML bundle error = %v, want ErrBundleVersion", err)
+	}
+	if _, err := store.ImportBundle(strings.NewReader("tools: [unclosed"), ImportOptions{}); err == nil {
+		t.Error("ImportBundle of malformed YAML succeeded")
+	}
+
+	sealed, err := SealBundle(store.Bundle(), testEncryptor(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteBundle(&buf, sealed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.ImportBundle(&buf, ImportOptions{}); err == nil || !strings.Contains(err.Error(), "sealed") {
+		t.Errorf("ImportBundle of sealed bundle error = %v", err)
+	}
+}
@@ -0,0 +1,247 @@
+package tooldocs
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CacheOptions configures NewCachingStore.
+type CacheOptions struct {
+	// TTL is how long a cached result is served. Zero means results stay
+	// until invalidated or evicted.
+	TTL time.Duration
+
+	// MaxEntries caps the number of cached results; the least recently used
+	// is evicted first. Zero means no limit.
+	MaxEntries int
+}
+
+// CachingStore is a read-through cache in front of another Store, for
+// inner stores whose reads are slow (e.g. backed by a remote service).
+// Successful DescribeTool and ListExamples results are memoized per
+// arguments; errors are not cached. Writes made through the CachingStore
+// are forwarded to the inner store and invalidate the tool's cached
+// results; call Invalidate or Purge after changes made elsewhere.
+//
+// Cached results are shared by every caller, so CachingStore deliberately
+// has no context-aware reads: do not put it in front of stores whose
+// results depend on the caller (Sensitive docs behind AuthorizeSensitive
+// or grants, experiments).
+type CachingStore struct {
+	inner Store
+	opts  CacheOptions
+	now   func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	lru     *list.List // of *cacheEntry, most recently used first
+	gen     uint64     // bumped by Invalidate and Purge
+}
+
+var _ Store = (*CachingStore)(nil)
+
+// cacheKey identifies a memoized read. max is -1 for DescribeTool.
+type cacheKey struct {
+	id    string
+	level DetailLevel
+	max   int
+}
+
+type cacheEntry struct {
+	key      cacheKey
+	doc      ToolDoc
+	examples []ToolExample
+	expires  time.Time // zero when opts.TTL is zero
+}
+
+// NewCachingStore returns a CachingStore reading through to inner.
+func NewCachingStore(inner Store, opts CacheOptions) *CachingStore {
+	return &CachingStore{
+		inner:   inner,
+		opts:    opts,
+		now:     time.Now,
+		entries: make(map[cacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// DescribeTool returns the cached result for id and level, or the inner
+// store's result, caching it on success.
+func (c *CachingStore) DescribeTool(id string, level DetailLevel) (ToolDoc, error) {
+	key := cacheKey{id: id, level: level, max: -1}
+	e, gen := c.get(key)
+	if e != nil {
+		return cloneToolDoc(e.doc), nil
+	}
+	doc, err := c.inner.DescribeTool(id, level)
+	if err != nil {
+		return ToolDoc{}, err
+	}
+	c.put(&cacheEntry{key: key, doc: cloneToolDoc(doc)}, gen)
+	return doc, nil
+}
+
+// ListExamples returns the cached result for id and maxExamples, or the
+// inner store's result, caching it on success.
+func (c *CachingStore) ListExamples(id string, maxExamples int) ([]ToolExample, error) {
+	if maxExamples < 0 {
+		maxExamples = 0
+	}
+	key := cacheKey{id: id, max: maxExamples}
+	e, gen := c.get(key)
+	if e != nil {
+		return copyExamples(e.examples), nil
+	}
+	examples, err := c.inner.ListExamples(id, maxExamples)
+	if err != nil {
+		return nil, err
+	}
+	c.put(&cacheEntry{key: key, examples: copyExamples(examples)}, gen)
+	return examples, nil
+}
+
+// get returns the live entry for key, marking it recently used and
+// dropping it if expired, or nil. It also returns the cache generation to
+// pass to put after a miss.
+func (c *CachingStore) get(key cacheKey) (*cacheEntry, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, c.gen
+	}
+	e := el.Value.(*cacheEntry)
+	if !e.expires.IsZero() && !c.now().Before(e.expires) {
+		c.remove(el)
+		return nil, c.gen
+	}
+	c.lru.MoveToFront(el)
+	return e, c.gen
+}
+
+// put caches e, evicting the least recently used entries over MaxEntries.
+// The result is dropped if the cache was invalidated since gen was read,
+// since it may predate the write that caused the invalidation.
+func (c *CachingStore) put(e *cacheEntry, gen uint64) {
+	if c.opts.TTL > 0 {
+		e.expires = c.now().Add(c.opts.TTL)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gen != gen {
+		return
+	}
+
+	if el, ok := c.entries[e.key]; ok {
+		c.remove(el)
+	}
+	c.entries[e.key] = c.lru.PushFront(e)
+	for c.opts.MaxEntries > 0 && c.lru.Len() > c.opts.MaxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove drops el. The caller holds c.mu.
+func (c *CachingStore) remove(el *list.Element) {
+	delete(c.entries, el.Value.(*cacheEntry).key)
+	c.lru.Remove(el)
+}
+
+// Invalidate drops every cached result for the given tools.
+func (c *CachingStore) Invalidate(ids ...string) {
+	drop := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		drop[id] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	for key, el := range c.entries {
+		if drop[key.id] {
+			c.remove(el)
+		}
+	}
+}
+
+// Purge drops every cached result.
+func (c *CachingStore) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.entries = make(map[cacheKey]*list.Element)
+	c.lru.Init()
+}
+
+// Len returns the number of cached results, including expired ones not yet
+// dropped.
+func (c *CachingStore) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// RegisterDoc registers entry in the inner store and invalidates id.
+//
+// Returns ErrReadOnly if the inner store has no RegisterDoc method.
+func (c *CachingStore) RegisterDoc(id string, entry DocEntry) error {
+	w, ok := c.inner.(interface {
+		RegisterDoc(string, DocEntry) error
+	})
+	if !ok {
+		return fmt.Errorf("%w: RegisterDoc", ErrReadOnly)
+	}
+	defer c.Invalidate(id)
+	return w.RegisterDoc(id, entry)
+}
+
+// RegisterExamples registers examples in the inner store and invalidates
+// id.
+//
+// Returns ErrReadOnly if the inner store has no RegisterExamples method.
+func (c *CachingStore) RegisterExamples(id string, examples []ToolExample) error {
+	w, ok := c.inner.(interface {
+		RegisterExamples(string, []ToolExample) error
+	})
+	if !ok {
+		return fmt.Errorf("%w: RegisterExamples", ErrReadOnly)
+	}
+	defer c.Invalidate(id)
+	return w.RegisterExamples(id, examples)
+}
+
+// AppendExamples appends examples in the inner store and invalidates id.
+//
+// Returns ErrReadOnly if the inner store has no AppendExamples method.
+func (c *CachingStore) AppendExamples(id string, examples []ToolExample) error {
+	w, ok := c.inner.(interface {
+		AppendExamples(string, []ToolExample) error
+	})
+	if !ok {
+		return fmt.Errorf("%w: AppendExamples", ErrReadOnly)
+	}
+	defer c.Invalidate(id)
+	return w.AppendExamples(id, examples)
+}
+
+// Import imports entries into the inner store and invalidates their IDs.
+//
+// Returns ErrReadOnly if the inner store has no Import method.
+func (c *CachingStore) Import(entries map[string]DocEntry, opts ImportOptions) (ImportReport, error) {
+	w, ok := c.inner.(interface {
+		Import(map[string]DocEntry, ImportOptions) (ImportReport, error)
+	})
+	if !ok {
+		return ImportReport{}, fmt.Errorf("%w: Import", ErrReadOnly)
+	}
+	ids := make([]string, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	defer c.Invalidate(ids...)
+	return w.Import(entries, opts)
+}
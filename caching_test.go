@@ -0,0 +1,114 @@
+package tooldocs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingStore counts the reads that reach an InMemoryStore.
+type countingStore struct {
+	*InMemoryStore
+	describes, lists int
+}
+
+func (c *countingStore) DescribeTool(id string, level DetailLevel) (ToolDoc, error) {
+	c.describes++
+	return c.InMemoryStore.DescribeTool(id, level)
+}
+
+func (c *countingStore) ListExamples(id string, maxExamples int) ([]ToolExample, error) {
+	c.lists++
+	return c.InMemoryStore.ListExamples(id, maxExamples)
+}
+
+func TestCachingStore(t *testing.T) {
+	inner := &countingStore{InMemoryStore: NewInMemoryStore(StoreOptions{})}
+	mustRegisterDoc(t, inner.InMemoryStore, "gh:search", DocEntry{
+		Summary:  "Search issues",
+		Examples: []ToolExample{{Title: "Open", Args: map[string]any{"q": "is:open"}}},
+	})
+	store := NewCachingStore(inner, CacheOptions{TTL: time.Minute})
+	now := time.Unix(0, 0)
+	store.now = func() time.Time { return now }
+
+	for range 3 {
+		doc, err := store.DescribeTool("gh:search", DetailSummary)
+		if err != nil || doc.Summary != "Search issues" {
+			t.Fatalf("DescribeTool = %+v, %v", doc, err)
+		}
+		doc.Summary = "mutated"
+	}
+	examples, _ := store.ListExamples("gh:search", 5)
+	examples[0].Args["q"] = "mutated"
+	if examples, _ := store.ListExamples("gh:search", 5); examples[0].Args["q"] != "is:open" {
+		t.Errorf("cached examples were mutated through a returned copy: %v", examples[0].Args)
+	}
+	if inner.describes != 1 || inner.lists != 1 {
+		t.Errorf("inner reads = %d describes, %d lists; want 1 each", inner.describes, inner.lists)
+	}
+
+	// Errors are not cached.
+	for range 2 {
+		if _, err := store.DescribeTool("gh:missing", DetailSummary); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("DescribeTool(missing) error = %v, want ErrNotFound", err)
+		}
+	}
+	if inner.describes != 3 {
+		t.Errorf("inner describes = %d, want 3", inner.describes)
+	}
+
+	// Writes invalidate the tool.
+	if err := store.RegisterDoc("gh:search", DocEntry{Summary: "Search all issues"}); err != nil {
+		t.Fatal(err)
+	}
+	if doc, _ := store.DescribeTool("gh:search", DetailSummary); doc.Summary != "Search all issues" {
+		t.Errorf("Summary after RegisterDoc = %q, want the new summary", doc.Summary)
+	}
+	if err := store.RegisterExamples("gh:search", []ToolExample{{Title: "Closed", Args: map[string]any{"q": "is:closed"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if examples, _ := store.ListExamples("gh:search", 5); len(examples) != 1 || examples[0].Title != "Closed" {
+		t.Errorf("examples after RegisterExamples = %+v", examples)
+	}
+
+	// Entries expire after the TTL.
+	before := inner.describes
+	now = now.Add(time.Minute)
+	store.DescribeTool("gh:search", DetailSummary)
+	if inner.describes != before+1 {
+		t.Errorf("inner describes after TTL = %d, want %d", inner.describes, before+1)
+	}
+
+	store.Purge()
+	if store.Len() != 0 {
+		t.Errorf("Len after Purge = %d, want 0", store.Len())
+	}
+
+	if err := NewCachingStore(readOnlyStore{inner}, CacheOptions{}).RegisterDoc("gh:x", DocEntry{}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("RegisterDoc on read-only inner error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestCachingStore_MaxEntries(t *testing.T) {
+	inner := &countingStore{InMemoryStore: NewInMemoryStore(StoreOptions{})}
+	for _, id := range []string{"ns:a", "ns:b", "ns:c"} {
+		mustRegisterDoc(t, inner.InMemoryStore, id, DocEntry{Summary: id})
+	}
+	store := NewCachingStore(inner, CacheOptions{MaxEntries: 2})
+
+	store.DescribeTool("ns:a", DetailSummary)
+	store.DescribeTool("ns:b", DetailSummary)
+	store.DescribeTool("ns:a", DetailSummary) // a is now most recently used
+	store.DescribeTool("ns:c", DetailSummary) // evicts b
+	if store.Len() != 2 {
+		t.Errorf("Len = %d, want 2", store.Len())
+	}
+
+	inner.describes = 0
+	store.DescribeTool("ns:a", DetailSummary)
+	store.DescribeTool("ns:b", DetailSummary)
+	if inner.describes != 1 {
+		t.Errorf("inner describes = %d, want 1 (only the evicted b)", inner.describes)
+	}
+}
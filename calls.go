@@ -0,0 +1,41 @@
+package tooldocs
+
+import "github.com/modelcontextprotocol/go-sdk/mcp"
+
+// ToolCallRequest is a JSON-RPC 2.0 MCP tools/call request. Encoded as
+// newline-delimited JSON it is the format CaptureExamples reads.
+type ToolCallRequest struct {
+	JSONRPC string              `json:"jsonrpc"`
+	ID      int                 `json:"id"`
+	Method  string              `json:"method"`
+	Params  *mcp.CallToolParams `json:"params"`
+}
+
+// ExampleCallParams converts an example into MCP tools/call params for the
+// tool with the given wire name. Arguments are a deep copy of ex.Args and
+// are never nil, so the encoded call always carries an arguments object.
+//
+// name is the tool name the receiving server exposes, which for a namespaced
+// tool ID is usually the part after ":" (see toolmodel.ParseToolID).
+func ExampleCallParams(name string, ex ToolExample) *mcp.CallToolParams {
+	args := deepCopyArgs(ex.Args)
+	if args == nil {
+		args = map[string]any{}
+	}
+	return &mcp.CallToolParams{Name: name, Arguments: args}
+}
+
+// ExampleCallRequests converts examples into JSON-RPC tools/call requests
+// numbered from 1, ready to embed in prompts or feed to a replay harness.
+func ExampleCallRequests(name string, examples []ToolExample) []ToolCallRequest {
+	reqs := make([]ToolCallRequest, len(examples))
+	for i, ex := range examples {
+		reqs[i] = ToolCallRequest{
+			JSONRPC: "2.0",
+			ID:      i + 1,
+			Method:  "tools/call",
+			Params:  ExampleCallParams(name, ex),
+		}
+	}
+	return reqs
+}
@@ -0,0 +1,65 @@
+package tooldocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestExampleCallParams(t *testing.T) {
+	ex := ToolExample{Title: "Search", Args: map[string]any{"query": "go", "filters": map[string]any{"lang": "en"}}}
+	params := ExampleCallParams("search", ex)
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"search","arguments":{"filters":{"lang":"en"},"query":"go"}}`; string(data) != want {
+		t.Errorf("params = %s, want %s", data, want)
+	}
+
+	// Arguments are a deep copy.
+	params.Arguments.(map[string]any)["filters"].(map[string]any)["lang"] = "fr"
+	if ex.Args["filters"].(map[string]any)["lang"] != "en" {
+		t.Error("ExampleCallParams must not alias example Args")
+	}
+
+	data, _ = json.Marshal(ExampleCallParams("ping", ToolExample{Title: "No args"}))
+	if want := `{"name":"ping","arguments":{}}`; string(data) != want {
+		t.Errorf("params = %s, want %s", data, want)
+	}
+}
+
+func TestExampleCallRequests_RoundTripsThroughCapture(t *testing.T) {
+	examples := []ToolExample{
+		{Title: "One", Args: map[string]any{"repo": "a/b", "limit": 5}},
+		{Title: "Two", Args: map[string]any{"repo": "c/d"}},
+	}
+	reqs := ExampleCallRequests("get_repo", examples)
+	if len(reqs) != 2 || reqs[0].ID != 1 || reqs[1].ID != 2 || reqs[0].Method != "tools/call" || reqs[0].JSONRPC != "2.0" {
+		t.Fatalf("requests = %+v", reqs)
+	}
+
+	var log bytes.Buffer
+	enc := json.NewEncoder(&log)
+	for _, req := range reqs {
+		if err := enc.Encode(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	result, err := CaptureExamples(&log, CaptureOptions{Namespace: "github"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	captured := result.Examples["github:get_repo"]
+	if len(captured) != 2 {
+		t.Fatalf("captured = %+v", captured)
+	}
+	for i := range examples {
+		got, _ := json.Marshal(captured[i].Args)
+		want, _ := json.Marshal(examples[i].Args)
+		if !bytes.Equal(got, want) {
+			t.Errorf("example %d args = %v, want %v", i, captured[i].Args, examples[i].Args)
+		}
+	}
+}
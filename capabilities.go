@@ -0,0 +1,85 @@
+package tooldocs
+
+import (
+	"strings"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// Capabilities holds boolean flags derived from a tool's schema and
+// annotations so planners can filter tools with simple predicates.
+// Flags are best-effort; false means "not detected", not "guaranteed absent".
+type Capabilities struct {
+	// SupportsPagination is set when the input schema declares a cursor/page
+	// style parameter or the output schema declares a next-cursor field.
+	SupportsPagination bool `json:"supportsPagination"`
+
+	// RequiresAuth is set when the input schema declares a credential
+	// parameter (token, api key, authorization).
+	RequiresAuth bool `json:"requiresAuth"`
+
+	// IsDestructive is set when the annotations explicitly mark the tool as
+	// destructive and not read-only, or when the docs declare an
+	// irreversible Effect.
+	IsDestructive bool `json:"isDestructive"`
+
+	// HasOutputSchema is set when the tool declares an OutputSchema.
+	HasOutputSchema bool `json:"hasOutputSchema"`
+}
+
+// paginationParams are normalized input parameter names that indicate pagination.
+var paginationParams = map[string]bool{
+	"cursor": true, "pagetoken": true, "page": true, "offset": true,
+	"nexttoken": true, "after": true, "before": true, "startingafter": true,
+}
+
+// paginationOutputs are normalized output property names that indicate pagination.
+var paginationOutputs = map[string]bool{
+	"nextcursor": true, "nextpagetoken": true, "nexttoken": true, "hasmore": true,
+}
+
+// authParams are normalized input parameter names that indicate credentials.
+var authParams = map[string]bool{
+	"token": true, "accesstoken": true, "apikey": true, "authorization": true,
+	"auth": true, "credentials": true, "bearertoken": true,
+}
+
+// deriveCapabilities computes Capabilities for a tool.
+func deriveCapabilities(tool *toolmodel.Tool) *Capabilities {
+	caps := &Capabilities{
+		HasOutputSchema: tool.OutputSchema != nil,
+	}
+
+	for name := range schemaProperties(tool.InputSchema) {
+		key := normalizeParamName(name)
+		if paginationParams[key] {
+			caps.SupportsPagination = true
+		}
+		if authParams[key] {
+			caps.RequiresAuth = true
+		}
+	}
+	for name := range schemaProperties(tool.OutputSchema) {
+		if paginationOutputs[normalizeParamName(name)] {
+			caps.SupportsPagination = true
+		}
+	}
+
+	if a := tool.Annotations; a != nil && !a.ReadOnlyHint && a.DestructiveHint != nil {
+		caps.IsDestructive = *a.DestructiveHint
+	}
+
+	return caps
+}
+
+// schemaProperties returns the top-level properties of a JSON Schema.
+func schemaProperties(schema any) map[string]any {
+	props, _ := schemaAsMap(schema)["properties"].(map[string]any)
+	return props
+}
+
+// normalizeParamName lowercases a parameter name and strips separators so
+// that page_token, pageToken, and page-token compare equal.
+func normalizeParamName(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
@@ -0,0 +1,114 @@
+package tooldocs
+
+import (
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestDeriveCapabilities(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name string
+		tool func() toolmodel.Tool
+		want Capabilities
+	}{
+		{
+			name: "plain",
+			tool: func() toolmodel.Tool {
+				return makeToolWithSchema("get", "ns", "", map[string]any{"type": "object"})
+			},
+			want: Capabilities{},
+		},
+		{
+			name: "pagination and auth params",
+			tool: func() toolmodel.Tool {
+				return makeToolWithSchema("list", "ns", "", map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"page_token": map[string]any{"type": "string"},
+						"apiKey":     map[string]any{"type": "string"},
+					},
+				})
+			},
+			want: Capabilities{SupportsPagination: true, RequiresAuth: true},
+		},
+		{
+			name: "output schema with next cursor",
+			tool: func() toolmodel.Tool {
+				tool := makeToolWithSchema("list", "ns", "", map[string]any{"type": "object"})
+				tool.OutputSchema = map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"nextCursor": map[string]any{"type": "string"}},
+				}
+				return tool
+			},
+			want: Capabilities{SupportsPagination: true, HasOutputSchema: true},
+		},
+		{
+			name: "explicitly destructive",
+			tool: func() toolmodel.Tool {
+				tool := makeToolWithSchema("delete", "ns", "", map[string]any{"type": "object"})
+				tool.Annotations = &mcp.ToolAnnotations{DestructiveHint: &yes}
+				return tool
+			},
+			want: Capabilities{IsDestructive: true},
+		},
+		{
+			name: "read-only overrides destructive hint",
+			tool: func() toolmodel.Tool {
+				tool := makeToolWithSchema("get", "ns", "", map[string]any{"type": "object"})
+				tool.Annotations = &mcp.ToolAnnotations{ReadOnlyHint: true, DestructiveHint: &yes}
+				return tool
+			},
+			want: Capabilities{},
+		},
+		{
+			name: "explicitly non-destructive",
+			tool: func() toolmodel.Tool {
+				tool := makeToolWithSchema("update", "ns", "", map[string]any{"type": "object"})
+				tool.Annotations = &mcp.ToolAnnotations{DestructiveHint: &no}
+				return tool
+			},
+			want: Capabilities{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := tt.tool()
+			got := deriveCapabilities(&tool)
+			if *got != tt.want {
+				t.Errorf("deriveCapabilities = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeTool_Capabilities(t *testing.T) {
+	tool := makeToolWithSchema("list", "ns", "List", map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"cursor": map[string]any{"type": "string"}},
+	})
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(_ string) (*toolmodel.Tool, error) { return &tool, nil },
+	})
+
+	doc, err := store.DescribeTool("ns:list", DetailSummary)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if doc.Capabilities != nil {
+		t.Error("Capabilities should be nil at summary level")
+	}
+
+	doc, err = store.DescribeTool("ns:list", DetailSchema)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if doc.Capabilities == nil || !doc.Capabilities.SupportsPagination {
+		t.Errorf("Capabilities = %+v, want SupportsPagination", doc.Capabilities)
+	}
+}
@@ -0,0 +1,264 @@
+package tooldocs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RedactedValue replaces secret values in captured Args.
+const RedactedValue = "<REDACTED>"
+
+// DefaultRedactKeys are normalized key fragments whose values are redacted
+// by RedactArgs. Keys are normalized by lowercasing and removing "_" and "-".
+// Keys naming a quantity (see quantityPrefixes and quantitySuffixes), such
+// as max_tokens or token_count, are not redacted.
+var DefaultRedactKeys = []string{
+	"password", "passwd", "secret", "token", "apikey", "authorization",
+	"credential", "privatekey", "cookie", "session",
+}
+
+// quantityPrefixes and quantitySuffixes mark normalized keys that name a
+// count or bound rather than a secret (max_tokens, session_limit).
+var (
+	quantityPrefixes = []string{"max", "min", "num"}
+	quantitySuffixes = []string{"count", "limit", "limits", "size", "length", "ttl", "timeout"}
+)
+
+// secretValuePattern matches values that look like credentials regardless of
+// their key: bearer tokens and common provider key prefixes.
+var secretValuePattern = regexp.MustCompile(`^(?i:bearer\s+\S+)$|^(sk-|ghp_|gho_|github_pat_|xox[abpr]-|AKIA)[A-Za-z0-9_\-]{8,}`)
+
+// CaptureOptions configures CaptureExamples.
+type CaptureOptions struct {
+	// Namespace, when set, is prefixed to captured tool names to form tool
+	// IDs ("namespace:name"). Names that already contain ":" are kept as is.
+	Namespace string
+
+	// RedactKeys overrides DefaultRedactKeys when non-nil.
+	RedactKeys []string
+
+	// MaxPerTool caps the candidates kept per tool. Zero means no limit.
+	MaxPerTool int
+}
+
+// CaptureResult holds candidate examples extracted from MCP traffic.
+type CaptureResult struct {
+	// Examples maps tool IDs to distinct candidate examples, in log order.
+	Examples map[string][]ToolExample
+
+	// Calls is the number of tools/call requests seen.
+	Calls int
+
+	// Skipped counts records that were malformed, had arguments exceeding
+	// the Args caps, or duplicated an earlier candidate.
+	Skipped int
+}
+
+// Bundle returns the candidates as a Bundle of example-only entries with
+// the given source, ready for human review and import.
+func (r CaptureResult) Bundle(source string) Bundle {
+	b := Bundle{Tools: make(map[string]DocEntry, len(r.Examples))}
+	for id, examples := range r.Examples {
+		b.Tools[id] = DocEntry{Examples: copyExamples(examples), Source: source}
+	}
+	return b
+}
+
+// jsonRPCMessage is the subset of a JSON-RPC request CaptureExamples reads.
+type jsonRPCMessage struct {
+	Method string `json:"method"`
+	Params struct {
+		Name      string         `json:"name"`
+		Arguments map[string]any `json:"arguments"`
+	} `json:"params"`
+}
+
+// CaptureExamples reads newline-delimited JSON-RPC messages (single messages
+// or batches) from r and extracts tools/call requests as candidate examples.
+// Argument values under secret-looking keys, or that look like credentials,
+// are replaced with RedactedValue. Non-tools/call messages are ignored.
+func CaptureExamples(r io.Reader, opts CaptureOptions) (CaptureResult, error) {
+	result := CaptureResult{Examples: make(map[string][]ToolExample)}
+	seen := make(map[string]struct{})
+
+	redactKeys := opts.RedactKeys
+	if redactKeys == nil {
+		redactKeys = DefaultRedactKeys
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var msgs []jsonRPCMessage
+		if line[0] == '[' {
+			if err := json.Unmarshal(line, &msgs); err != nil {
+				result.Skipped++
+				continue
+			}
+		} else {
+			var msg jsonRPCMessage
+			if err := json.Unmarshal(line, &msg); err != nil {
+				result.Skipped++
+				continue
+			}
+			msgs = []jsonRPCMessage{msg}
+		}
+
+		for _, msg := range msgs {
+			if msg.Method != "tools/call" {
+				continue
+			}
+			result.Calls++
+			if msg.Params.Name == "" {
+				result.Skipped++
+				continue
+			}
+
+			id := msg.Params.Name
+			if opts.Namespace != "" && !strings.Contains(id, ":") {
+				id = opts.Namespace + ":" + id
+			}
+
+			args := RedactArgs(msg.Params.Arguments, redactKeys)
+			if _, ok := ValidateArgs(args); !ok {
+				result.Skipped++
+				continue
+			}
+
+			ex := ToolExample{
+				Title:       captureTitle(args),
+				Description: "Captured from MCP traffic.",
+				Args:        args,
+			}
+			key := id + "\x00" + exampleKey(ex)
+			if _, dup := seen[key]; dup {
+				result.Skipped++
+				continue
+			}
+			if opts.MaxPerTool > 0 && len(result.Examples[id]) >= opts.MaxPerTool {
+				continue
+			}
+			seen[key] = struct{}{}
+			result.Examples[id] = append(result.Examples[id], ex)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("capture: %w", err)
+	}
+	return result, nil
+}
+
+// captureTitle builds a short title from the argument names.
+func captureTitle(args map[string]any) string {
+	if len(args) == 0 {
+		return "Call without arguments"
+	}
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return "Call with " + strings.Join(keys, ", ")
+}
+
+// RedactArgs returns a normalized deep copy of args with secret values
+// replaced by RedactedValue. A value is secret when its key, normalized by
+// lowercasing and removing "_" and "-", contains any of keys and does not
+// name a quantity (max_tokens, token_count), or when it is a string that
+// looks like a credential.
+func RedactArgs(args map[string]any, keys []string) map[string]any {
+	copied, _ := redactArgs(args, keys)
+	return copied
+}
+
+// redactArgs is RedactArgs, also returning the sorted paths of the values it
+// replaced ("auth.token", "headers[0]"). Values that already equal
+// RedactedValue are not reported.
+func redactArgs(args map[string]any, keys []string) (map[string]any, []string) {
+	normalized := make([]string, len(keys))
+	for i, k := range keys {
+		normalized[i] = normalizeParamName(k)
+	}
+	copied := deepCopyArgs(args)
+	var paths []string
+	redactMap(copied, normalized, "", &paths)
+	sort.Strings(paths)
+	return copied, paths
+}
+
+func redactMap(m map[string]any, keys []string, path string, paths *[]string) {
+	for k, v := range m {
+		p := k
+		if path != "" {
+			p = path + "." + k
+		}
+		if isSecretKey(k, keys) {
+			if v != RedactedValue {
+				*paths = append(*paths, p)
+			}
+			m[k] = RedactedValue
+			continue
+		}
+		m[k] = redactValue(v, keys, p, paths)
+	}
+}
+
+func redactValue(v any, keys []string, path string, paths *[]string) any {
+	switch val := v.(type) {
+	case map[string]any:
+		redactMap(val, keys, path, paths)
+		return val
+	case []any:
+		for i := range val {
+			val[i] = redactValue(val[i], keys, fmt.Sprintf("%s[%d]", path, i), paths)
+		}
+		return val
+	case string:
+		if secretValuePattern.MatchString(val) {
+			*paths = append(*paths, path)
+			return RedactedValue
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+func isSecretKey(key string, keys []string) bool {
+	k := normalizeParamName(key)
+	if isQuantityKey(k) {
+		return false
+	}
+	for _, fragment := range keys {
+		if fragment != "" && strings.Contains(k, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// isQuantityKey reports whether the normalized key k names a quantity.
+func isQuantityKey(k string) bool {
+	for _, p := range quantityPrefixes {
+		if strings.HasPrefix(k, p) {
+			return true
+		}
+	}
+	for _, suffix := range quantitySuffixes {
+		if strings.HasSuffix(k, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package tooldocs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCaptureExamples(t *testing.T) {
+	log := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"search","arguments":{"query":"bug","api_key":"abc123"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"search","arguments":{"query":"bug","api_key":"other"}}}`,
+		`not json`,
+		`[{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"github:get_repo","arguments":{"repo":"a/b","headers":{"Authorization":"Bearer xyz"}}}}]`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"ping"}}`,
+		``,
+	}, "\n")
+
+	result, err := CaptureExamples(strings.NewReader(log), CaptureOptions{Namespace: "local"})
+	if err != nil {
+		t.Fatalf("CaptureExamples failed: %v", err)
+	}
+
+	if result.Calls != 4 {
+		t.Errorf("Calls = %d, want 4", result.Calls)
+	}
+	// malformed line + duplicate after redaction
+	if result.Skipped != 2 {
+		t.Errorf("Skipped = %d, want 2", result.Skipped)
+	}
+
+	search := result.Examples["local:search"]
+	if len(search) != 1 {
+		t.Fatalf("local:search examples = %+v, want 1", search)
+	}
+	if want := map[string]any{"query": "bug", "api_key": RedactedValue}; !reflect.DeepEqual(search[0].Args, want) {
+		t.Errorf("Args = %v, want %v", search[0].Args, want)
+	}
+	if search[0].Title != "Call with api_key, query" {
+		t.Errorf("Title = %q", search[0].Title)
+	}
+
+	repo := result.Examples["github:get_repo"]
+	if len(repo) != 1 {
+		t.Fatalf("github:get_repo examples = %+v", repo)
+	}
+	headers := repo[0].Args["headers"].(map[string]any)
+	if headers["Authorization"] != RedactedValue {
+		t.Errorf("Authorization = %v, want redacted", headers["Authorization"])
+	}
+
+	if ping := result.Examples["local:ping"]; len(ping) != 1 || ping[0].Title != "Call without arguments" {
+		t.Errorf("local:ping examples = %+v", ping)
+	}
+
+	b := result.Bundle("capture:mcp.log")
+	if got := b.Tools["local:search"]; got.Source != "capture:mcp.log" || len(got.Examples) != 1 {
+		t.Errorf("Bundle entry = %+v", got)
+	}
+}
+
+func TestCaptureExamples_MaxPerTool(t *testing.T) {
+	log := `{"method":"tools/call","params":{"name":"a","arguments":{"n":1}}}
+{"method":"tools/call","params":{"name":"a","arguments":{"n":2}}}
+{"method":"tools/call","params":{"name":"a","arguments":{"n":3}}}`
+
+	result, err := CaptureExamples(strings.NewReader(log), CaptureOptions{MaxPerTool: 2})
+	if err != nil {
+		t.Fatalf("CaptureExamples failed: %v", err)
+	}
+	if len(result.Examples["a"]) != 2 {
+		t.Errorf("examples = %d, want 2", len(result.Examples["a"]))
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	args := map[string]any{
+		"Password":    "hunter2",
+		"nested":      map[string]any{"client-secret": "s", "ok": "fine"},
+		"list":        []string{"ghp_abcdefghijklmnop", "plain"},
+		"note":        "Bearer is a word",
+		"max_tokens":  256,
+		"tokenCount":  12,
+		"session_ttl": 60,
+		"api_token":   "t",
+	}
+	got := RedactArgs(args, DefaultRedactKeys)
+	want := map[string]any{
+		"Password":    RedactedValue,
+		"nested":      map[string]any{"client-secret": RedactedValue, "ok": "fine"},
+		"list":        []any{RedactedValue, "plain"},
+		"note":        "Bearer is a word",
+		"max_tokens":  256,
+		"tokenCount":  12,
+		"session_ttl": 60,
+		"api_token":   RedactedValue,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RedactArgs = %v, want %v", got, want)
+	}
+	if args["Password"] != "hunter2" {
+		t.Error("RedactArgs modified its input")
+	}
+}
@@ -0,0 +1,94 @@
+package tooldocs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ToolLister is implemented by toolindex.Index implementations that can
+// enumerate their tool IDs directly.
+type ToolLister interface {
+	ListTools() ([]string, error)
+}
+
+// indexPageSize is the SearchPage size used to enumerate indexes that do
+// not implement ToolLister.
+const indexPageSize = 500
+
+// CatalogIDs returns the sorted IDs of every tool the store knows about:
+// each tool with registered documentation and, when StoreOptions.Index is
+// set, each tool in the index, documented or not. Indexes implementing
+// ToolLister are asked directly; others are paged through with an empty
+// SearchPage query, which toolindex's default searcher answers with every
+// tool.
+//
+// Returns the index error, with no IDs, if the index cannot be enumerated.
+func (s *InMemoryStore) CatalogIDs() ([]string, error) {
+	ids := s.DocIDs()
+	if s.index == nil {
+		return ids, nil
+	}
+	indexed, err := s.indexedToolIDs()
+	if err != nil {
+		return nil, fmt.Errorf("enumerate index: %w", err)
+	}
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	for _, id := range indexed {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// indexedToolIDs lists the IDs in the configured index.
+func (s *InMemoryStore) indexedToolIDs() ([]string, error) {
+	if lister, ok := s.index.(ToolLister); ok {
+		return lister.ListTools()
+	}
+	var (
+		ids    []string
+		cursor string
+	)
+	seen := make(map[string]bool)
+	for {
+		page, next, err := s.index.SearchPage("", indexPageSize, cursor)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range page {
+			ids = append(ids, summary.ID)
+		}
+		// Stop on a repeated cursor rather than loop on a broken index.
+		if next == "" || seen[next] {
+			return ids, nil
+		}
+		seen[next] = true
+		cursor = next
+	}
+}
+
+// ListToolDocs describes every catalog tool (see CatalogIDs) at level,
+// sorted by ID, so catalog pages need not enumerate the index themselves.
+// Per-tool failures, such as ErrNotFound for indexed tools whose summary
+// cannot be derived, are reported per result as in DescribeTools.
+//
+// Returns ErrInvalidDetail for unknown levels, or the index error if the
+// index cannot be enumerated.
+func (s *InMemoryStore) ListToolDocs(ctx context.Context, level DetailLevel) ([]DescribeResult, error) {
+	if err := validateDetail(level); err != nil {
+		return nil, err
+	}
+	ids, err := s.CatalogIDs()
+	if err != nil {
+		return nil, err
+	}
+	return s.DescribeToolsContext(ctx, ids, level)
+}
@@ -0,0 +1,126 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolindex"
+	"github.com/jonwraymond/toolmodel"
+)
+
+// listingIndex is an index that enumerates itself via ToolLister.
+type listingIndex struct {
+	toolindex.Index
+	ids []string
+	err error
+}
+
+func (l listingIndex) ListTools() ([]string, error) { return l.ids, l.err }
+
+func newCatalogIndex(t *testing.T, names ...string) *toolindex.InMemoryIndex {
+	t.Helper()
+	idx := toolindex.NewInMemoryIndex()
+	backend := toolmodel.ToolBackend{
+		Kind:  toolmodel.BackendKindLocal,
+		Local: &toolmodel.LocalBackend{Name: "handler"},
+	}
+	for _, name := range names {
+		tool := makeToolWithSchema(name, "ns", "Tool "+name, map[string]any{"type": "object"})
+		if err := idx.RegisterTool(tool, backend); err != nil {
+			t.Fatalf("RegisterTool failed: %v", err)
+		}
+	}
+	return idx
+}
+
+func TestCatalogIDs(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{Index: newCatalogIndex(t, "a", "b", "c")})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "A"})
+	mustRegisterDoc(t, store, "ext:orphan", DocEntry{Summary: "Orphan"})
+
+	ids, err := store.CatalogIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"ext:orphan", "ns:a", "ns:b", "ns:c"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("CatalogIDs = %v, want %v", ids, want)
+	}
+
+	report := store.CoverageReport(nil)
+	if len(report.Tools) != 4 || report.Documented != 2 || report.Tools[2].Documented {
+		t.Errorf("CoverageReport(nil) = %+v, want undocumented indexed tools included", report)
+	}
+
+	// Without an index, the catalog is the documented tools.
+	plain := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, plain, "ns:a", DocEntry{Summary: "A"})
+	if ids, err := plain.CatalogIDs(); err != nil || !reflect.DeepEqual(ids, []string{"ns:a"}) {
+		t.Errorf("CatalogIDs without index = %v, %v", ids, err)
+	}
+}
+
+func TestCatalogIDs_Paging(t *testing.T) {
+	names := make([]string, indexPageSize+20)
+	for i := range names {
+		names[i] = fmt.Sprintf("t%04d", i)
+	}
+	store := NewInMemoryStore(StoreOptions{Index: newCatalogIndex(t, names...)})
+	ids, err := store.CatalogIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != len(names) || ids[0] != "ns:t0000" || ids[len(ids)-1] != fmt.Sprintf("ns:t%04d", len(names)-1) {
+		t.Errorf("CatalogIDs returned %d IDs (%s..%s), want %d", len(ids), ids[0], ids[len(ids)-1], len(names))
+	}
+}
+
+func TestCatalogIDs_ToolLister(t *testing.T) {
+	idx := listingIndex{Index: newCatalogIndex(t, "a"), ids: []string{"ns:z", "ns:a"}}
+	store := NewInMemoryStore(StoreOptions{Index: idx})
+	ids, err := store.CatalogIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"ns:a", "ns:z"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("CatalogIDs = %v, want %v", ids, want)
+	}
+
+	boom := errors.New("boom")
+	store = NewInMemoryStore(StoreOptions{Index: listingIndex{Index: idx.Index, err: boom}})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "A"})
+	if _, err := store.CatalogIDs(); !errors.Is(err, boom) {
+		t.Errorf("CatalogIDs err = %v, want %v", err, boom)
+	}
+	if _, err := store.ListToolDocs(context.Background(), DetailSummary); !errors.Is(err, boom) {
+		t.Errorf("ListToolDocs err = %v, want %v", err, boom)
+	}
+	if report := store.CoverageReport(nil); len(report.Tools) != 1 {
+		t.Errorf("CoverageReport(nil) after index failure = %+v, want documented tools", report.Tools)
+	}
+}
+
+func TestListToolDocs(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{Index: newCatalogIndex(t, "a", "b")})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "Documented A"})
+
+	results, err := store.ListToolDocs(context.Background(), DetailSummary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].ID != "ns:a" || results[0].Doc.Summary != "Documented A" {
+		t.Errorf("results[0] = %+v", results[0])
+	}
+	if results[1].ID != "ns:b" || results[1].Err != nil || results[1].Doc.Summary != "Tool b" {
+		t.Errorf("results[1] = %+v, want summary derived from the tool", results[1])
+	}
+
+	if _, err := store.ListToolDocs(context.Background(), "verbose"); !errors.Is(err, ErrInvalidDetail) {
+		t.Errorf("err = %v, want ErrInvalidDetail", err)
+	}
+}
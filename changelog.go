@@ -0,0 +1,192 @@
+package tooldocs
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ChangeOp names the write recorded by a Change.
+type ChangeOp string
+
+// Change log operations.
+const (
+	ChangeRegisterDoc      ChangeOp = "registerDoc"
+	ChangeRegisterExamples ChangeOp = "registerExamples"
+	ChangeAppendExamples   ChangeOp = "appendExamples"
+	ChangeSchemaExamples   ChangeOp = "schemaExamples"
+	ChangeImport           ChangeOp = "import"
+	ChangeApprove          ChangeOp = "approve"
+	ChangeFreeze           ChangeOp = "freeze"
+	ChangeUnfreeze         ChangeOp = "unfreeze"
+	ChangeDelete           ChangeOp = "delete"
+	ChangeEvict            ChangeOp = "evict"
+	ChangeRefresh          ChangeOp = "refresh"
+	ChangeGC               ChangeOp = "gc"
+)
+
+// Change is one entry of the change log written to StoreOptions.ChangeLog:
+// a write to a tool's documentation and the documentation it left. Entries
+// are written as JSON lines, in write order.
+type Change struct {
+	// Time is when the write was applied, in UTC.
+	Time time.Time `json:"time"`
+
+	// Op is the write.
+	Op ChangeOp `json:"op"`
+
+	// ID is the tool ID.
+	ID string `json:"id"`
+
+	// Author is StoreOptions.Author, or the reviewer for ChangeApprove.
+	Author string `json:"author,omitempty"`
+
+	// Entry is the tool's whole documentation after the write, as stored
+	// (validated and truncated). It is nil for ChangeDelete and ChangeEvict. With
+	// StoreOptions.Encryptor, its free-text fields are in Sealed instead
+	// (see SealEntry).
+	Entry *DocEntry `json:"entry,omitempty"`
+
+	// Sealed holds Entry's encrypted fields, if any.
+	Sealed []byte `json:"sealed,omitempty"`
+
+	// Frozen reports whether the documentation is frozen after the write.
+	Frozen bool `json:"frozen,omitempty"`
+
+	// ExampleChanges lists the examples the write re-registered under an
+	// existing ID with different Args or Description (see DiffExamples).
+	ExampleChanges []ExampleDiff `json:"exampleChanges,omitempty"`
+}
+
+// logChange appends a change to the change log. doc is nil for removals;
+// changes are the write's example diffs. Callers hold s.mu, which keeps
+// appends in write order.
+func (s *InMemoryStore) logChange(op ChangeOp, author, id string, doc *StoredDoc, changes []ExampleDiff) error {
+	if s.changeLog == nil {
+		return nil
+	}
+	if author == "" {
+		author = s.author
+	}
+	change := Change{Time: s.now().UTC(), Op: op, ID: id, Author: author}
+	if doc != nil {
+		entry := doc.Entry
+		if enc := s.opts.Encryptor; enc != nil {
+			var err error
+			if entry, change.Sealed, err = SealEntry(id, entry, enc); err != nil {
+				return fmt.Errorf("%w: change log: %w", ErrPersist, err)
+			}
+		}
+		change.Entry = &entry
+		change.Frozen = doc.Frozen
+		change.ExampleChanges = changes
+	}
+	data, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("%w: %s: change log: %w", ErrPersist, id, err)
+	}
+	if _, err := s.changeLog.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("%w: %s: change log: %w", ErrPersist, id, err)
+	}
+	return nil
+}
+
+// ReplayLog rebuilds documentation from a change log written through
+// StoreOptions.ChangeLog, applying each change in order: the logged entry
+// (and frozen state) replaces the tool's documentation, and ChangeDelete
+// and ChangeEvict remove it. It returns the number of changes applied.
+//
+// Replay restores logged state as is: frozen docs, the rate limit, and the
+// summary profile do not apply, and replayed changes are neither logged
+// again nor passed to the Persister. Entries are still validated, so a
+// store with tighter example caps than the one that wrote the log may
+// reject them.
+//
+// Sealed changes are opened with StoreOptions.Encryptor.
+//
+// Returns an error naming the line of the first malformed, invalid, or
+// undecryptable change (wrapping ErrDecrypt for a wrong key); changes
+// before it stay applied.
+func (s *InMemoryStore) ReplayLog(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	applied := 0
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var change Change
+		if err := json.Unmarshal(scanner.Bytes(), &change); err != nil {
+			return applied, fmt.Errorf("change log line %d: %w", line, err)
+		}
+		if err := s.replayChange(change); err != nil {
+			return applied, fmt.Errorf("change log line %d: %w", line, err)
+		}
+		applied++
+	}
+	if err := scanner.Err(); err != nil {
+		return applied, fmt.Errorf("change log: %w", err)
+	}
+	return applied, nil
+}
+
+// replayChange applies one change log entry.
+func (s *InMemoryStore) replayChange(change Change) error {
+	if change.ID == "" {
+		return fmt.Errorf("change has no tool ID")
+	}
+	if change.Op == ChangeDelete || change.Op == ChangeEvict {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.writable(); err != nil {
+			return err
+		}
+		s.dropped(change.ID)
+		return nil
+	}
+	if change.Entry == nil {
+		return fmt.Errorf("%s change for %s has no entry", change.Op, change.ID)
+	}
+	entry := *change.Entry
+	if change.Sealed != nil {
+		if s.opts.Encryptor == nil {
+			return fmt.Errorf("change for %s is sealed; set StoreOptions.Encryptor", change.ID)
+		}
+		var err error
+		if entry, err = OpenEntry(change.ID, entry, change.Sealed, s.opts.Encryptor); err != nil {
+			return err
+		}
+	}
+	prepared, err := s.prepareRecord(entry)
+	if err != nil {
+		return fmt.Errorf("%s: %w", change.ID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return err
+	}
+	record := s.docs[change.ID]
+	if record == nil {
+		record = &docRecord{}
+		s.docs[change.ID] = record
+	}
+	record.setContent(prepared)
+	record.frozen = change.Frozen
+	s.stored(change.ID, record)
+	s.evicted = nil // replayed changes are not logged again
+	return nil
+}
+
+// exampleChanges diffs a write's examples against the examples it replaces,
+// for the change log. It returns nil without a change log, so writes skip
+// the diff when nothing records it. Callers hold s.mu.
+func (s *InMemoryStore) exampleChanges(before, after []ToolExample) []ExampleDiff {
+	if s.changeLog == nil || len(before) == 0 || len(after) == 0 {
+		return nil
+	}
+	return DiffExamples(before, after)
+}
@@ -0,0 +1,201 @@
+package tooldocs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChangeLog(t *testing.T) {
+	var log bytes.Buffer
+	store := NewInMemoryStore(StoreOptions{ChangeLog: &log, Author: "sync-bot"})
+
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "A"})
+	if err := store.RegisterExamples("ns:a", []ToolExample{{Title: "one", Args: map[string]any{"q": "x"}}}); err != nil {
+		t.Fatal(err)
+	}
+	mustRegisterDoc(t, store, "ns:b", DocEntry{Summary: "B"})
+	if err := store.SubmitForReview("ns:b", DocEntry{Summary: "B, reviewed"}, "gen"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Approve("ns:b", "alice", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Freeze("ns:a"); err != nil {
+		t.Fatal(err)
+	}
+	mustRegisterDoc(t, store, "ns:c", DocEntry{Summary: "C"})
+	if _, err := store.UnregisterWhere(UnregisterFilter{Match: func(id string) bool { return id == "ns:c" }}); err != nil {
+		t.Fatal(err)
+	}
+
+	var changes []Change
+	scanner := bufio.NewScanner(bytes.NewReader(log.Bytes()))
+	for scanner.Scan() {
+		var c Change
+		if err := json.Unmarshal(scanner.Bytes(), &c); err != nil {
+			t.Fatalf("log line %q: %v", scanner.Text(), err)
+		}
+		changes = append(changes, c)
+	}
+	var ops []string
+	for _, c := range changes {
+		ops = append(ops, string(c.Op)+" "+c.ID+" "+c.Author)
+		if c.Time.IsZero() {
+			t.Errorf("%s %s has no time", c.Op, c.ID)
+		}
+	}
+	want := []string{
+		"registerDoc ns:a sync-bot",
+		"registerExamples ns:a sync-bot",
+		"registerDoc ns:b sync-bot",
+		"approve ns:b alice",
+		"freeze ns:a sync-bot",
+		"registerDoc ns:c sync-bot",
+		"delete ns:c sync-bot",
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("ops =\n%s\nwant\n%s", strings.Join(ops, "\n"), strings.Join(want, "\n"))
+	}
+	if e := changes[1].Entry; e == nil || e.Summary != "A" || len(e.Examples) != 1 {
+		t.Errorf("registerExamples entry = %+v, want the whole doc", e)
+	}
+	if changes[6].Entry != nil {
+		t.Errorf("delete entry = %+v, want nil", changes[6].Entry)
+	}
+
+	replayed := NewInMemoryStore(StoreOptions{})
+	n, err := replayed.ReplayLog(bytes.NewReader(log.Bytes()))
+	if err != nil || n != len(want) {
+		t.Fatalf("ReplayLog = %d, %v", n, err)
+	}
+	if got, want := replayed.Bundle(), store.Bundle(); !reflect.DeepEqual(got.Tools, want.Tools) {
+		t.Errorf("replayed tools = %+v, want %+v", got.Tools, want.Tools)
+	}
+	if !replayed.IsFrozen("ns:a") {
+		t.Error("frozen state not replayed")
+	}
+	if ids := searchIDs(replayed, "reviewed"); len(ids) != 1 {
+		t.Errorf("search index not rebuilt: %v", ids)
+	}
+}
+
+func TestChangeLog_StoreClock(t *testing.T) {
+	var log bytes.Buffer
+	store, clock := newTTLStore(StoreOptions{ChangeLog: &log})
+	clock.advance(time.Hour)
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "A"})
+
+	var c Change
+	if err := json.Unmarshal(log.Bytes(), &c); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Time.Equal(clock.now()) {
+		t.Errorf("change time = %v, want the store clock's %v", c.Time, clock.now())
+	}
+}
+
+func TestChangeLog_ExampleChanges(t *testing.T) {
+	var log bytes.Buffer
+	store := NewInMemoryStore(StoreOptions{ChangeLog: &log})
+
+	basic := func(repo string) ToolExample {
+		return ToolExample{ID: "basic", Title: "Basic", Args: map[string]any{"repo": repo}}
+	}
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "A", Examples: []ToolExample{basic("a/b")}})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "A", Examples: []ToolExample{basic("a/c")}})
+	if err := store.RegisterExamples("ns:a", []ToolExample{basic("a/d")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AppendExamples("ns:a", []ToolExample{basic("a/e")}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got [][]ExampleDiff
+	scanner := bufio.NewScanner(bytes.NewReader(log.Bytes()))
+	for scanner.Scan() {
+		var c Change
+		if err := json.Unmarshal(scanner.Bytes(), &c); err != nil {
+			t.Fatalf("log line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, c.ExampleChanges)
+	}
+	diff := func(old, new string) []ExampleDiff {
+		return []ExampleDiff{{ID: "basic", Args: []ArgChange{{Path: "repo", Old: old, New: new}}}}
+	}
+	want := [][]ExampleDiff{nil, diff("a/b", "a/c"), diff("a/c", "a/d"), diff("a/d", "a/e")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("logged ExampleChanges = %+v, want %+v", got, want)
+	}
+}
+
+func TestReplayLog_Errors(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	log := `{"op":"registerDoc","id":"ns:a","entry":{"summary":"A"}}
+
+{"op":"registerDoc","id":"ns:b"}
+`
+	n, err := store.ReplayLog(strings.NewReader(log))
+	if n != 1 || err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("ReplayLog = %d, %v; want 1 change and an error at line 3", n, err)
+	}
+	if _, err := store.GetDocEntry("ns:a"); err != nil {
+		t.Errorf("changes before the error must stay applied: %v", err)
+	}
+
+	if _, err := store.ReplayLog(strings.NewReader("not json\n")); err == nil {
+		t.Error("expected error for malformed line")
+	}
+}
+
+func TestChangeLog_Encryptor(t *testing.T) {
+	var log bytes.Buffer
+	enc := testEncryptor(t)
+	store := NewInMemoryStore(StoreOptions{ChangeLog: &log, Encryptor: enc})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{
+		Summary:  "Public summary",
+		Notes:    "Internal runbook at https://wiki.internal/x",
+		Examples: []ToolExample{{Title: "Example", Args: map[string]any{"host": "db.internal"}}},
+	})
+
+	for _, leak := range []string{"wiki.internal", "db.internal"} {
+		if strings.Contains(log.String(), leak) {
+			t.Errorf("change log leaks %q", leak)
+		}
+	}
+
+	replayed := NewInMemoryStore(StoreOptions{Encryptor: enc})
+	if _, err := replayed.ReplayLog(bytes.NewReader(log.Bytes())); err != nil {
+		t.Fatalf("ReplayLog failed: %v", err)
+	}
+	if got, want := replayed.Bundle(), store.Bundle(); !reflect.DeepEqual(got.Tools, want.Tools) {
+		t.Errorf("replayed tools = %+v, want %+v", got.Tools, want.Tools)
+	}
+
+	if _, err := NewInMemoryStore(StoreOptions{}).ReplayLog(bytes.NewReader(log.Bytes())); err == nil {
+		t.Error("replaying a sealed log without an Encryptor succeeded")
+	}
+	other, _ := NewAESGCMEncryptor(bytes.Repeat([]byte{9}, 32))
+	if _, err := NewInMemoryStore(StoreOptions{Encryptor: other}).ReplayLog(bytes.NewReader(log.Bytes())); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("wrong key error = %v, want ErrDecrypt", err)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestChangeLog_WriteError(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{ChangeLog: failingWriter{}})
+	if err := store.RegisterDoc("ns:a", DocEntry{Summary: "A"}); !errors.Is(err, ErrPersist) {
+		t.Fatalf("err = %v, want ErrPersist", err)
+	}
+	if _, err := store.GetDocEntry("ns:a"); err != nil {
+		t.Errorf("write was not kept: %v", err)
+	}
+}
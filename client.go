@@ -0,0 +1,107 @@
+package tooldocs
+
+import (
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ClientRequirements declares the client-side MCP features a tool needs to
+// work, so gateways can hide tools the connected client cannot drive. It is
+// returned at DetailSchema and DetailFull.
+type ClientRequirements struct {
+	// Sampling means the tool asks the client's LLM for completions
+	// (sampling/createMessage) while it runs.
+	Sampling bool `json:"sampling,omitempty"`
+
+	// Elicitation means the tool asks the user for input mid-call
+	// (elicitation/create).
+	Elicitation bool `json:"elicitation,omitempty"`
+
+	// Roots means the tool reads the client's filesystem roots.
+	Roots bool `json:"roots,omitempty"`
+
+	// Resources means the tool returns resource links the client must
+	// read or subscribe to for the result to be useful.
+	Resources bool `json:"resources,omitempty"`
+}
+
+// String renders the requirements on one line, e.g. "sampling, elicitation".
+func (r ClientRequirements) String() string {
+	if names := r.names(); len(names) > 0 {
+		return strings.Join(names, ", ")
+	}
+	return "none"
+}
+
+func (r ClientRequirements) names() []string {
+	var names []string
+	if r.Sampling {
+		names = append(names, "sampling")
+	}
+	if r.Elicitation {
+		names = append(names, "elicitation")
+	}
+	if r.Roots {
+		names = append(names, "roots")
+	}
+	if r.Resources {
+		names = append(names, "resources")
+	}
+	return names
+}
+
+// Unmet returns the names of the requirements that have lacks, in the
+// order sampling, elicitation, roots, resources, or nil when all are met.
+func (r ClientRequirements) Unmet(have ClientRequirements) []string {
+	return ClientRequirements{
+		Sampling:    r.Sampling && !have.Sampling,
+		Elicitation: r.Elicitation && !have.Elicitation,
+		Roots:       r.Roots && !have.Roots,
+		Resources:   r.Resources && !have.Resources,
+	}.names()
+}
+
+// ClientSupport returns what a client advertising caps can do. Roots is
+// detected from RootsV2 or an advertised roots.listChanged, since a
+// decoded capabilities object cannot otherwise tell an empty roots
+// capability from none. MCP has no client capability for reading
+// resources, so Resources is always true; gateways that know better can
+// clear it. A nil caps supports only resources.
+func ClientSupport(caps *mcp.ClientCapabilities) ClientRequirements {
+	have := ClientRequirements{Resources: true}
+	if caps == nil {
+		return have
+	}
+	have.Sampling = caps.Sampling != nil
+	have.Elicitation = caps.Elicitation != nil
+	have.Roots = caps.RootsV2 != nil || caps.Roots.ListChanged
+	return have
+}
+
+// ToolsForClient returns the IDs in ids whose documented
+// ClientRequirements are met by have, in order. Tools without documented
+// requirements are kept.
+func (s *InMemoryStore) ToolsForClient(ids []string, have ClientRequirements) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []string
+	for _, id := range ids {
+		if record := s.docs[id]; record != nil && record.clientReqs != nil &&
+			len(record.clientReqs.Unmet(have)) > 0 {
+			continue
+		}
+		out = append(out, id)
+	}
+	return out
+}
+
+// copyClientRequirements returns a copy of r.
+func copyClientRequirements(r *ClientRequirements) *ClientRequirements {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	return &c
+}
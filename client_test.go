@@ -0,0 +1,59 @@
+package tooldocs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestClientRequirements_Unmet(t *testing.T) {
+	need := ClientRequirements{Sampling: true, Elicitation: true, Resources: true}
+	have := ClientSupport(&mcp.ClientCapabilities{Sampling: &mcp.SamplingCapabilities{}})
+	if got, want := need.Unmet(have), []string{"elicitation"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmet = %v, want %v", got, want)
+	}
+	if got := need.Unmet(ClientRequirements{Sampling: true, Elicitation: true, Resources: true}); got != nil {
+		t.Errorf("Unmet(all) = %v, want nil", got)
+	}
+	if got := ClientSupport(nil); got != (ClientRequirements{Resources: true}) {
+		t.Errorf("ClientSupport(nil) = %+v, want resources only", got)
+	}
+	if got := need.String(); got != "sampling, elicitation, resources" {
+		t.Errorf("String = %q", got)
+	}
+}
+
+func TestClientRequirements_Store(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			tool := makeToolWithSchema("draft", "mail", "Draft", map[string]any{"type": "object"})
+			return &tool, nil
+		},
+	})
+	mustRegisterDoc(t, store, "mail:draft", DocEntry{
+		Summary:            "Draft an email",
+		ClientRequirements: &ClientRequirements{Sampling: true},
+	})
+	mustRegisterDoc(t, store, "mail:send", DocEntry{Summary: "Send an email"})
+
+	doc, err := store.DescribeTool("mail:draft", DetailSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.ClientRequirements == nil || !doc.ClientRequirements.Sampling {
+		t.Errorf("ClientRequirements = %+v, want sampling", doc.ClientRequirements)
+	}
+	if summary, _ := store.DescribeTool("mail:draft", DetailSummary); summary.ClientRequirements != nil {
+		t.Error("ClientRequirements returned at summary level")
+	}
+
+	ids := []string{"mail:draft", "mail:send", "mail:unknown"}
+	if got, want := store.ToolsForClient(ids, ClientSupport(nil)), []string{"mail:send", "mail:unknown"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToolsForClient = %v, want %v", got, want)
+	}
+	if got := store.ToolsForClient(ids, ClientRequirements{Sampling: true}); !reflect.DeepEqual(got, ids) {
+		t.Errorf("ToolsForClient(sampling) = %v, want all", got)
+	}
+}
@@ -0,0 +1,78 @@
+package tooldocs
+
+import (
+	"fmt"
+	"maps"
+)
+
+// MergeStrategy selects how Merge treats tools documented in both stores.
+type MergeStrategy string
+
+const (
+	// MergeOverwrite replaces the receiver's documentation with the other
+	// store's.
+	MergeOverwrite MergeStrategy = "overwrite"
+
+	// MergeKeepExisting keeps the receiver's documentation and only adds
+	// tools it does not document.
+	MergeKeepExisting MergeStrategy = "keep-existing"
+
+	// MergeExamples keeps the receiver's documentation and adds the
+	// other store's examples to it, merged by ID as by MergeDocEntries.
+	MergeExamples MergeStrategy = "merge-examples"
+)
+
+// mergeConflicts maps merge strategies to the Import conflict policies
+// implementing them.
+var mergeConflicts = map[MergeStrategy]ConflictPolicy{
+	MergeOverwrite:    ConflictOverwrite,
+	MergeKeepExisting: ConflictSkipExisting,
+	MergeExamples:     ConflictMergeExamples,
+}
+
+// Clone returns a new store with s's options and a copy of its
+// documentation (including frozen state and expiry), toolsets, and
+// placeholders. The clone is independent: writes to either store do not
+// affect the other. It is never sealed, and it does not write through to
+// StoreOptions.Persister or ChangeLog, which stay with s. Stats, change
+// history, experiments, review submissions, and maintenance results are
+// not copied.
+func (s *InMemoryStore) Clone() *InMemoryStore {
+	opts := s.opts
+	opts.Persister = nil
+	opts.ChangeLog = nil
+	c := NewInMemoryStore(opts)
+	c.now = s.now
+
+	s.rlock()
+	defer s.runlock()
+	// Records are never modified in place, so copies share their content.
+	for id, record := range s.docs {
+		copied := *record
+		c.docs[id] = &copied
+		c.stored(id, &copied)
+	}
+	c.toolsets = maps.Clone(s.toolsets)
+	c.placeholders = maps.Clone(s.placeholders)
+	return c
+}
+
+// Merge imports the documentation of other into s, resolving tools
+// documented in both by strategy (empty means MergeOverwrite), e.g. to
+// combine docs assembled by several plugins into one store at boot. It
+// applies a snapshot of other with Import, so the merge is atomic, frozen
+// tools in s are skipped, the report lists the per-tool changes, and
+// writes are persisted and logged as imports. Merging does not copy
+// other's frozen state, toolsets, or placeholders.
+//
+// Returns an error for an unknown strategy, or Import's errors.
+func (s *InMemoryStore) Merge(other *InMemoryStore, strategy MergeStrategy) (ImportReport, error) {
+	if strategy == "" {
+		strategy = MergeOverwrite
+	}
+	conflict, ok := mergeConflicts[strategy]
+	if !ok {
+		return ImportReport{}, fmt.Errorf("unknown merge strategy: %s", strategy)
+	}
+	return s.Import(other.Bundle().Tools, ImportOptions{Conflict: conflict})
+}
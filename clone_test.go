@@ -0,0 +1,143 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestClone_Independent(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{MaxExamples: 2})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "Original a", Examples: []ToolExample{{Title: "one"}}})
+	mustRegisterDoc(t, store, "ns:frozen", DocEntry{Summary: "Curated"})
+	if err := store.Freeze("ns:frozen"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterToolset(Toolset{Name: "core", Tools: []string{"ns:a"}}); err != nil {
+		t.Fatal(err)
+	}
+	store.Seal()
+
+	clone := store.Clone()
+	if clone.Sealed() {
+		t.Error("clone is sealed")
+	}
+	if !clone.IsFrozen("ns:frozen") {
+		t.Error("clone lost frozen state")
+	}
+	if _, err := clone.Toolset("core"); err != nil {
+		t.Errorf("clone Toolset failed: %v", err)
+	}
+	if hits := clone.SearchDocs(context.Background(), "original", SearchOptions{}).Hits; len(hits) != 1 || hits[0].ID != "ns:a" {
+		t.Errorf("clone search hits = %+v, want ns:a", hits)
+	}
+
+	mustRegisterDoc(t, clone, "ns:a", DocEntry{Summary: "Changed a"})
+	mustRegisterDoc(t, clone, "ns:b", DocEntry{Summary: "Added b"})
+	if got := store.DocIDs(); !reflect.DeepEqual(got, []string{"ns:a", "ns:frozen"}) {
+		t.Errorf("original DocIDs = %v", got)
+	}
+	doc, err := store.DescribeTool("ns:a", DetailSummary)
+	if err != nil || doc.Summary != "Original a" {
+		t.Errorf("original Summary = %q, %v; want %q", doc.Summary, err, "Original a")
+	}
+
+	// The clone keeps the store's options.
+	err = clone.RegisterExamples("ns:a", []ToolExample{{Title: "1"}, {Title: "2"}, {Title: "3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if examples, _ := clone.ListExamples("ns:a", 10); len(examples) != 2 {
+		t.Errorf("clone kept %d examples, want MaxExamples 2", len(examples))
+	}
+}
+
+func TestMerge_Strategies(t *testing.T) {
+	plugin := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, plugin, "ns:shared", DocEntry{
+		Summary:  "Plugin summary",
+		Examples: []ToolExample{{ID: "basic", Title: "Plugin basic"}, {ID: "extra", Title: "Plugin extra"}},
+	})
+	mustRegisterDoc(t, plugin, "ns:new", DocEntry{Summary: "Plugin only"})
+
+	newBase := func() *InMemoryStore {
+		base := NewInMemoryStore(StoreOptions{})
+		mustRegisterDoc(t, base, "ns:shared", DocEntry{
+			Summary:  "Base summary",
+			Examples: []ToolExample{{ID: "basic", Title: "Base basic"}},
+		})
+		return base
+	}
+
+	tests := []struct {
+		strategy     MergeStrategy
+		wantSummary  string
+		wantExamples []string
+		wantAction   ImportAction
+	}{
+		{"", "Plugin summary", []string{"Plugin basic", "Plugin extra"}, ImportUpdated},
+		{MergeOverwrite, "Plugin summary", []string{"Plugin basic", "Plugin extra"}, ImportUpdated},
+		{MergeKeepExisting, "Base summary", []string{"Base basic"}, ImportSkipped},
+		{MergeExamples, "Base summary", []string{"Plugin basic", "Plugin extra"}, ImportMerged},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			base := newBase()
+			report, err := base.Merge(plugin, tt.strategy)
+			if err != nil {
+				t.Fatalf("Merge failed: %v", err)
+			}
+			if report.Totals.Created != 1 {
+				t.Errorf("Totals = %+v, want ns:new created", report.Totals)
+			}
+			for _, c := range report.Changes {
+				if c.ID == "ns:shared" && c.Action != tt.wantAction {
+					t.Errorf("ns:shared action = %s, want %s", c.Action, tt.wantAction)
+				}
+			}
+
+			entry, err := base.GetDocEntry("ns:shared")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if entry.Entry.Summary != tt.wantSummary {
+				t.Errorf("Summary = %q, want %q", entry.Entry.Summary, tt.wantSummary)
+			}
+			var titles []string
+			for _, ex := range entry.Entry.Examples {
+				titles = append(titles, ex.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.wantExamples) {
+				t.Errorf("examples = %v, want %v", titles, tt.wantExamples)
+			}
+		})
+	}
+}
+
+func TestMerge_Errors(t *testing.T) {
+	base := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, base, "ns:curated", DocEntry{Summary: "Curated"})
+	if err := base.Freeze("ns:curated"); err != nil {
+		t.Fatal(err)
+	}
+	other := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, other, "ns:curated", DocEntry{Summary: "Generated"})
+
+	if _, err := base.Merge(other, "union"); err == nil {
+		t.Error("Merge with unknown strategy succeeded")
+	}
+
+	report, err := base.Merge(other, MergeOverwrite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Totals.Skipped != 1 {
+		t.Errorf("Totals = %+v, want the frozen tool skipped", report.Totals)
+	}
+
+	base.Seal()
+	if _, err := base.Merge(other, MergeOverwrite); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Merge into sealed store error = %v, want ErrReadOnly", err)
+	}
+}
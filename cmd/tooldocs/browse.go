@@ -0,0 +1,402 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/tooldocs"
+	"github.com/jonwraymond/tooldocs/httpstore"
+	"github.com/jonwraymond/toolmodel"
+)
+
+// runBrowse implements `tooldocs browse [-tools TOOLS.json] BUNDLE.json`
+// and `tooldocs browse -url URL [-tools TOOLS.json]`, an interactive
+// line-oriented browser for operators.
+func runBrowse(args []string, stdout, stderr io.Writer) int {
+	return browse(args, os.Stdin, stdout, stderr)
+}
+
+func browse(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("browse", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	toolsPath := fs.String("tools", "", "JSON array of MCP tool definitions (lists undocumented tools; enables schema/full tiers of a bundle)")
+	baseURL := fs.String("url", "", "base URL of a docs service (httpapi) to browse instead of a bundle")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: tooldocs browse [-tools TOOLS.json] BUNDLE.json")
+		fmt.Fprintln(stderr, "       tooldocs browse -url URL [-tools TOOLS.json]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if (*baseURL == "") != (fs.NArg() == 1) || fs.NArg() > 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	var tools map[string]*toolmodel.Tool
+	if *toolsPath != "" {
+		var err error
+		if tools, err = loadTools(*toolsPath); err != nil {
+			fmt.Fprintf(stderr, "tooldocs browse: %v\n", err)
+			return exitError
+		}
+	}
+	src, err := openSource(*baseURL, fs.Arg(0), tools)
+	if err != nil {
+		fmt.Fprintf(stderr, "tooldocs browse: %v\n", err)
+		return exitError
+	}
+	report, err := src.Coverage()
+	if err != nil {
+		fmt.Fprintf(stderr, "tooldocs browse: %v\n", err)
+		return exitError
+	}
+
+	br := &browser{src: src, out: stdout}
+	br.printf("%d tools loaded (%d documented). Type 'help' for commands.\n", len(report.Tools), report.Documented)
+	scanner := bufio.NewScanner(stdin)
+	for {
+		br.printf("tooldocs> ")
+		if !scanner.Scan() {
+			br.printf("\n")
+			return exitOK
+		}
+		if !br.exec(strings.Fields(scanner.Text())) {
+			return exitOK
+		}
+	}
+}
+
+// source is what the browser reads: a bundle loaded into memory or a docs
+// service.
+type source interface {
+	DescribeTool(id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error)
+
+	// Coverage reports on every tool: documented ones and those in the
+	// -tools file, so undocumented tools show up as gaps.
+	Coverage() (tooldocs.CoverageReport, error)
+}
+
+// openSource returns the service at baseURL when set, else the bundle at
+// bundlePath. tools, from -tools, may be nil.
+func openSource(baseURL, bundlePath string, tools map[string]*toolmodel.Tool) (source, error) {
+	catalog := slices.Sorted(maps.Keys(tools))
+	if baseURL != "" {
+		client, err := httpstore.New(baseURL, httpstore.Options{})
+		if err != nil {
+			return nil, err
+		}
+		return serviceSource{client: client, catalog: catalog}, nil
+	}
+
+	b, err := loadBundle(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	opts := tooldocs.StoreOptions{}
+	if tools != nil {
+		opts.ToolResolver = func(id string) (*toolmodel.Tool, error) {
+			return tools[id], nil
+		}
+	}
+	store := tooldocs.NewInMemoryStore(opts)
+	if _, err := store.Import(b.Tools, tooldocs.ImportOptions{Source: "bundle:" + bundlePath}); err != nil {
+		return nil, err
+	}
+	return bundleSource{store: store, catalog: catalog}, nil
+}
+
+// bundleSource browses a bundle loaded into an InMemoryStore.
+type bundleSource struct {
+	store   *tooldocs.InMemoryStore
+	catalog []string // tool IDs from -tools
+}
+
+func (s bundleSource) DescribeTool(id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error) {
+	return s.store.DescribeTool(id, level)
+}
+
+func (s bundleSource) Coverage() (tooldocs.CoverageReport, error) {
+	return s.store.CoverageReport(mergeIDs(s.store.DocIDs(), s.catalog)), nil
+}
+
+// serviceSource browses a docs service through httpstore.
+type serviceSource struct {
+	client  *httpstore.Client
+	catalog []string // tool IDs from -tools
+}
+
+func (s serviceSource) DescribeTool(id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error) {
+	return s.client.DescribeTool(id, level)
+}
+
+// Coverage builds the report from the service's reads: the documented IDs,
+// each tool's richest doc the service will serve, and its examples. Notes
+// only come with the full tier, so tools the service cannot describe in
+// full report none.
+func (s serviceSource) Coverage() (tooldocs.CoverageReport, error) {
+	ctx := context.Background()
+	documented, err := s.client.ListTools(ctx)
+	if err != nil {
+		return tooldocs.CoverageReport{}, err
+	}
+	var report tooldocs.CoverageReport
+	for _, id := range mergeIDs(documented, s.catalog) {
+		cov := tooldocs.ToolCoverage{ID: id, Documented: slices.Contains(documented, id)}
+		if cov.Documented {
+			doc, err := s.client.DescribeToolContext(ctx, id, tooldocs.DetailFull)
+			if errors.Is(err, tooldocs.ErrNoTool) || errors.Is(err, tooldocs.ErrUnauthorized) {
+				doc, err = s.client.DescribeToolContext(ctx, id, tooldocs.DetailSummary)
+			}
+			if err != nil {
+				return tooldocs.CoverageReport{}, err
+			}
+			examples, err := s.client.ListExamplesContext(ctx, id, maxListExamples)
+			if err != nil && !errors.Is(err, tooldocs.ErrUnauthorized) {
+				return tooldocs.CoverageReport{}, err
+			}
+			cov.HasSummary = doc.Summary != ""
+			cov.HasNotes = doc.Notes != ""
+			cov.Examples = len(examples)
+			cov.ExternalRefs = len(doc.ExternalRefs)
+
+			report.Documented++
+			if cov.HasNotes {
+				report.WithNotes++
+			}
+			if cov.Examples > 0 {
+				report.WithExamples++
+			}
+		}
+		report.Tools = append(report.Tools, cov)
+	}
+	return report, nil
+}
+
+// maxListExamples asks ListExamples for every example of a tool.
+const maxListExamples = 1 << 20
+
+// mergeIDs returns the sorted union of a and b.
+func mergeIDs(a, b []string) []string {
+	ids := slices.Concat(a, b)
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// browser executes interactive commands against a source.
+type browser struct {
+	src source
+	out io.Writer
+}
+
+func (b *browser) printf(format string, args ...any) {
+	fmt.Fprintf(b.out, format, args...)
+}
+
+// exec runs one command and reports whether the session should continue.
+func (b *browser) exec(fields []string) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	switch fields[0] {
+	case "help", "?":
+		b.printf("commands:\n" +
+			"  ns                         list namespaces with tool counts\n" +
+			"  ls [NAMESPACE]             list tools (! = no summary, ? = no examples)\n" +
+			"  show ID [summary|schema|full]  describe a tool (default: full)\n" +
+			"  missing                    list undocumented tools and those lacking summary, notes, or examples\n" +
+			"  quit                       exit\n")
+	case "ns":
+		report, ok := b.coverage()
+		if !ok {
+			return true
+		}
+		counts := map[string]int{}
+		for _, cov := range report.Tools {
+			counts[namespaceOf(cov.ID)]++
+		}
+		names := make([]string, 0, len(counts))
+		for name := range counts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			label := name
+			if label == "" {
+				label = "(none)"
+			}
+			b.printf("  %-30s %d\n", label, counts[name])
+		}
+	case "ls":
+		report, ok := b.coverage()
+		if !ok {
+			return true
+		}
+		for _, cov := range report.Tools {
+			if len(fields) > 1 && namespaceOf(cov.ID) != fields[1] {
+				continue
+			}
+			b.printf("  %s%s %s\n", flag1(!cov.HasSummary, "!"), flag1(cov.Examples == 0, "?"), cov.ID)
+		}
+	case "show":
+		if len(fields) < 2 {
+			b.printf("usage: show ID [summary|schema|full]\n")
+			return true
+		}
+		level := tooldocs.DetailFull
+		if len(fields) > 2 {
+			level = tooldocs.DetailLevel(fields[2])
+		}
+		b.show(fields[1], level)
+	case "missing":
+		report, ok := b.coverage()
+		if !ok {
+			return true
+		}
+		for _, cov := range report.Tools {
+			if !cov.Documented {
+				b.printf("  %-40s undocumented\n", cov.ID)
+				continue
+			}
+			var gaps []string
+			if !cov.HasSummary {
+				gaps = append(gaps, "summary")
+			}
+			if !cov.HasNotes {
+				gaps = append(gaps, "notes")
+			}
+			if cov.Examples == 0 {
+				gaps = append(gaps, "examples")
+			}
+			if len(gaps) > 0 {
+				b.printf("  %-40s missing %s\n", cov.ID, strings.Join(gaps, ", "))
+			}
+		}
+	case "quit", "exit", "q":
+		return false
+	default:
+		b.printf("unknown command %q (try 'help')\n", fields[0])
+	}
+	return true
+}
+
+// coverage returns the source's coverage, printing the error if it fails.
+func (b *browser) coverage() (tooldocs.CoverageReport, bool) {
+	report, err := b.src.Coverage()
+	if err != nil {
+		b.printf("error: %v\n", err)
+		return tooldocs.CoverageReport{}, false
+	}
+	return report, true
+}
+
+// show prints a tool's documentation at the given level.
+func (b *browser) show(id string, level tooldocs.DetailLevel) {
+	doc, err := b.src.DescribeTool(id, level)
+	if errors.Is(err, tooldocs.ErrNoTool) {
+		b.printf("%s: no tool definition loaded (use -tools); showing summary\n", id)
+		doc, err = b.src.DescribeTool(id, tooldocs.DetailSummary)
+	}
+	if err != nil {
+		b.printf("error: %v\n", err)
+		return
+	}
+
+	b.printf("%s\n", id)
+	if doc.Title != "" {
+		b.printf("  title:   %s\n", doc.Title)
+	}
+	b.printf("  summary: %s\n", doc.Summary)
+	if info := doc.SchemaInfo; info != nil && len(info.Required) > 0 {
+		b.printf("  required: %s\n", strings.Join(info.Required, ", "))
+	}
+	for _, e := range doc.Effects {
+		b.printf("  effect:  %s\n", e)
+	}
+	if p := doc.RetryPolicy; p != nil {
+		b.printf("  retry:   %s\n", p)
+	}
+	if c := doc.Concurrency; c != nil {
+		b.printf("  limits:  %s\n", c)
+	}
+	if r := doc.ClientRequirements; r != nil {
+		b.printf("  client:  %s\n", r)
+	}
+	if doc.ShortNotes != "" {
+		b.printf("  key:     %s\n", doc.ShortNotes)
+	}
+	if doc.Notes != "" {
+		b.printf("  notes:\n")
+		for _, line := range strings.Split(doc.Notes, "\n") {
+			b.printf("    %s\n", line)
+		}
+	}
+	for _, sec := range doc.Sections {
+		b.printf("  %s:\n", strings.ToLower(sec.Title))
+		for _, line := range strings.Split(sec.Body, "\n") {
+			b.printf("    %s\n", line)
+		}
+	}
+	for _, p := range doc.Prerequisites {
+		b.printf("  prereq:  %s\n", p)
+	}
+	for _, e := range doc.Edges {
+		if e.From == id {
+			b.printf("  needs:   %s (%s)\n", e.To, e.Kind)
+		} else {
+			b.printf("  used by: %s (%s)\n", e.From, e.Kind)
+		}
+	}
+	for _, ex := range doc.Examples {
+		args, _ := json.Marshal(ex.Args)
+		b.printf("  example: %s %s\n", ex.Title, args)
+	}
+	for _, ref := range doc.ExternalRefs {
+		b.printf("  ref:     %s\n", ref)
+	}
+}
+
+// namespaceOf returns the namespace part of a tool ID ("" when absent).
+func namespaceOf(id string) string {
+	ns, _, found := strings.Cut(id, ":")
+	if !found {
+		return ""
+	}
+	return ns
+}
+
+// flag1 returns mark when cond holds, otherwise a space.
+func flag1(cond bool, mark string) string {
+	if cond {
+		return mark
+	}
+	return " "
+}
+
+// loadTools reads a JSON array of MCP tool definitions keyed by tool ID.
+func loadTools(path string) (map[string]*toolmodel.Tool, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is an explicit CLI argument
+	if err != nil {
+		return nil, err
+	}
+	var tools []*toolmodel.Tool
+	if err := json.Unmarshal(data, &tools); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	byID := make(map[string]*toolmodel.Tool, len(tools))
+	for _, t := range tools {
+		byID[t.ToolID()] = t
+	}
+	return byID, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// runCapture implements `tooldocs capture [-namespace NS] [-max N] [LOG...]`.
+// It writes a review bundle of candidate examples to stdout.
+func runCapture(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("capture", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	namespace := fs.String("namespace", "", "namespace prefixed to captured tool names")
+	maxPerTool := fs.Int("max", 3, "maximum candidates per tool (0 for no limit)")
+	redact := fs.String("redact", "", "comma-separated extra key fragments to redact")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: tooldocs capture [-namespace NS] [-max N] [-redact k1,k2] [LOG...]")
+		fmt.Fprintln(stderr, "Reads JSON-RPC logs (stdin when no files are given) and prints a bundle of candidate examples.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+
+	opts := tooldocs.CaptureOptions{Namespace: *namespace, MaxPerTool: *maxPerTool}
+	if *redact != "" {
+		opts.RedactKeys = append(append([]string{}, tooldocs.DefaultRedactKeys...), strings.Split(*redact, ",")...)
+	}
+
+	var readers []io.Reader
+	source := "capture:stdin"
+	if fs.NArg() == 0 {
+		readers = append(readers, os.Stdin)
+	} else {
+		source = "capture:" + strings.Join(fs.Args(), ",")
+		for _, path := range fs.Args() {
+			f, err := os.Open(path) // #nosec G304 -- path is an explicit CLI argument
+			if err != nil {
+				fmt.Fprintf(stderr, "tooldocs capture: %v\n", err)
+				return exitError
+			}
+			defer f.Close()
+			readers = append(readers, f, strings.NewReader("\n"))
+		}
+	}
+
+	result, err := tooldocs.CaptureExamples(io.MultiReader(readers...), opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "tooldocs capture: %v\n", err)
+		return exitError
+	}
+	if err := tooldocs.WriteBundle(stdout, result.Bundle(source)); err != nil {
+		fmt.Fprintf(stderr, "tooldocs capture: %v\n", err)
+		return exitError
+	}
+	fmt.Fprintf(stderr, "captured %d tools from %d calls (%d skipped)\n", len(result.Examples), result.Calls, result.Skipped)
+	return exitOK
+}
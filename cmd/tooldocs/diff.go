@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// runDiff implements `tooldocs diff [-json] [-exit-code] OLD NEW`.
+func runDiff(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print the diff as JSON")
+	exitCode := fs.Bool("exit-code", false, "exit with status 1 if the bundles differ")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: tooldocs diff [-json] [-exit-code] OLD.json NEW.json")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitError
+	}
+
+	oldBundle, err := loadBundle(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "tooldocs diff: %v\n", err)
+		return exitError
+	}
+	newBundle, err := loadBundle(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(stderr, "tooldocs diff: %v\n", err)
+		return exitError
+	}
+
+	d := tooldocs.DiffBundles(oldBundle, newBundle)
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(d); err != nil {
+			fmt.Fprintf(stderr, "tooldocs diff: %v\n", err)
+			return exitError
+		}
+	} else {
+		printDiff(stdout, d)
+	}
+
+	if *exitCode && !d.Empty() {
+		return exitDiff
+	}
+	return exitOK
+}
+
+// printDiff renders a BundleDiff in a compact, line-oriented format.
+func printDiff(w io.Writer, d tooldocs.BundleDiff) {
+	for _, id := range d.Added {
+		fmt.Fprintf(w, "+ %s\n", id)
+	}
+	for _, id := range d.Removed {
+		fmt.Fprintf(w, "- %s\n", id)
+	}
+	for _, td := range d.Changed {
+		fmt.Fprintf(w, "~ %s: %s\n", td.ID, strings.Join(td.Fields, ", "))
+		if td.OldSummary != td.NewSummary {
+			fmt.Fprintf(w, "    summary: %q -> %q\n", td.OldSummary, td.NewSummary)
+		}
+		for _, key := range td.ExamplesAdded {
+			fmt.Fprintf(w, "    + example %q\n", key)
+		}
+		for _, key := range td.ExamplesRemoved {
+			fmt.Fprintf(w, "    - example %q\n", key)
+		}
+		for _, key := range td.ExamplesChanged {
+			fmt.Fprintf(w, "    ~ example %q\n", key)
+		}
+	}
+}
+
+// loadBundle reads a bundle file from disk.
+func loadBundle(path string) (tooldocs.Bundle, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is an explicit CLI argument
+	if err != nil {
+		return tooldocs.Bundle{}, err
+	}
+	defer f.Close()
+
+	b, err := tooldocs.ReadBundle(f)
+	if err != nil {
+		return tooldocs.Bundle{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,73 @@
+// Command tooldocs provides maintenance utilities for tooldocs bundles.
+//
+// Usage:
+//
+//	tooldocs <command> [flags] [args]
+//
+// Commands:
+//
+//	browse  interactively browse a bundle or docs service
+//	capture extract candidate examples from MCP traffic logs
+//	diff    compare two exported bundles
+//	verify  check every example against its tool schema
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Exit codes shared by all subcommands.
+const (
+	exitOK    = 0 // success (or no differences)
+	exitDiff  = 1 // differences or findings reported
+	exitError = 2 // usage or I/O error
+)
+
+// command is a tooldocs subcommand.
+type command struct {
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
+
+var commands = map[string]command{
+	"browse":  {summary: "interactively browse a bundle or docs service", run: runBrowse},
+	"capture": {summary: "extract candidate examples from MCP traffic logs", run: runCapture},
+	"diff":    {summary: "compare two exported bundles", run: runDiff},
+	"verify":  {summary: "check every example against its tool schema", run: runVerify},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run dispatches to a subcommand and returns the process exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage(stderr)
+		return exitError
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "tooldocs: unknown command %q\n", args[0])
+		usage(stderr)
+		return exitError
+	}
+	return cmd.run(args[1:], stdout, stderr)
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: tooldocs <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].summary)
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/tooldocs"
+	"github.com/jonwraymond/tooldocs/httpapi"
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// writeFile writes content to name inside a temp dir and returns the path.
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestRun_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, &stdout, &stderr); code != exitError {
+		t.Errorf("exit code = %d, want %d", code, exitError)
+	}
+	if !strings.Contains(stderr.String(), "diff") {
+		t.Errorf("usage should list commands, got %q", stderr.String())
+	}
+	if code := run([]string{"bogus"}, &stdout, &stderr); code != exitError {
+		t.Errorf("exit code = %d, want %d", code, exitError)
+	}
+}
+
+func TestRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := writeFile(t, dir, "old.json", `{"tools": {
+		"ns:a": {"summary": "old", "examples": [{"title": "List", "args": {}}]},
+		"ns:gone": {"summary": "gone"}
+	}}`)
+	newPath := writeFile(t, dir, "new.json", `{"tools": {
+		"ns:a": {"summary": "new", "examples": [{"title": "Get", "args": {}}]},
+		"ns:added": {"summary": "added"}
+	}}`)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"diff", "-exit-code", oldPath, newPath}, &stdout, &stderr)
+	if code != exitDiff {
+		t.Fatalf("exit code = %d, want %d (stderr: %s)", code, exitDiff, stderr.String())
+	}
+	want := `+ ns:added
+- ns:gone
+~ ns:a: summary, examples
+    summary: "old" -> "new"
+    + example "Get"
+    - example "List"
+`
+	if stdout.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", stdout.String(), want)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"diff", "-json", oldPath, oldPath}, &stdout, &stderr); code != exitOK {
+		t.Errorf("exit code = %d, want %d", code, exitOK)
+	}
+	if strings.TrimSpace(stdout.String()) != "{}" {
+		t.Errorf("JSON output for identical bundles = %q, want {}", stdout.String())
+	}
+}
+
+func TestRunDiff_Errors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"diff", "only-one.json"}, &stdout, &stderr); code != exitError {
+		t.Errorf("exit code = %d, want %d", code, exitError)
+	}
+	if code := run([]string{"diff", "missing-a.json", "missing-b.json"}, &stdout, &stderr); code != exitError {
+		t.Errorf("exit code = %d, want %d", code, exitError)
+	}
+}
+
+func TestRunCapture(t *testing.T) {
+	dir := t.TempDir()
+	logPath := writeFile(t, dir, "mcp.log",
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"search","arguments":{"query":"x","token":"t"}}}`)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"capture", "-namespace", "ns", logPath}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("exit code = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+
+	b, err := tooldocs.ReadBundle(&stdout)
+	if err != nil {
+		t.Fatalf("output is not a bundle: %v", err)
+	}
+	entry, ok := b.Tools["ns:search"]
+	if !ok || len(entry.Examples) != 1 {
+		t.Fatalf("bundle = %+v, want ns:search candidate", b)
+	}
+	if entry.Examples[0].Args["token"] != tooldocs.RedactedValue {
+		t.Errorf("token = %v, want redacted", entry.Examples[0].Args["token"])
+	}
+	if entry.Source != "capture:"+logPath {
+		t.Errorf("Source = %q", entry.Source)
+	}
+}
+
+func TestBrowse(t *testing.T) {
+	dir := t.TempDir()
+	bundlePath := writeFile(t, dir, "bundle.json", `{"tools": {
+		"github:get_repo": {"summary": "Get a repository", "notes": "Needs auth.", "examples": [{"title": "Basic", "args": {"repo": "a/b"}}]},
+		"github:list_issues": {"notes": "Paginates."},
+		"local:echo": {"summary": "Echo input"}
+	}}`)
+	toolsPath := writeFile(t, dir, "tools.json", `[
+		{"namespace": "github", "name": "get_repo", "description": "Get repo", "inputSchema": {"type": "object", "required": ["repo"]}},
+		{"namespace": "github", "name": "delete_repo", "description": "Delete repo", "inputSchema": {"type": "object"}}
+	]`)
+
+	input := strings.NewReader("ns\nls github\nshow github:get_repo\nshow local:echo schema\nmissing\nbogus\nquit\n")
+	var stdout, stderr bytes.Buffer
+	code := browse([]string{"-tools", toolsPath, bundlePath}, input, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("exit code = %d (stderr: %s)", code, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"4 tools loaded (3 documented)",
+		"github                         3",
+		"github:delete_repo                       undocumented",
+		"!? github:list_issues",
+		"required: repo",
+		`example: Basic {"repo":"a/b"}`,
+		"local:echo: no tool definition loaded",
+		"local:echo                               missing notes, examples",
+		`unknown command "bogus"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestBrowse_URL(t *testing.T) {
+	store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			ns, name, _ := strings.Cut(id, ":")
+			return &toolmodel.Tool{Namespace: ns, Tool: mcp.Tool{Name: name, InputSchema: map[string]any{"type": "object"}}}, nil
+		},
+	})
+	for id, entry := range map[string]tooldocs.DocEntry{
+		"github:get_repo": {Summary: "Get a repository", Notes: "Needs auth.", Examples: []tooldocs.ToolExample{{Title: "Basic", Args: map[string]any{"repo": "a/b"}}}},
+		"local:echo":      {Summary: "Echo input"},
+	} {
+		if err := store.RegisterDoc(id, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	srv := httptest.NewServer(httpapi.NewHandler(store))
+	defer srv.Close()
+	toolsPath := writeFile(t, t.TempDir(), "tools.json", `[
+		{"namespace": "github", "name": "delete_repo", "description": "Delete repo", "inputSchema": {"type": "object"}}
+	]`)
+
+	input := strings.NewReader("ls\nshow local:echo summary\nmissing\nquit\n")
+	var stdout, stderr bytes.Buffer
+	code := browse([]string{"-url", srv.URL, "-tools", toolsPath}, input, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("exit code = %d (stderr: %s)", code, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"3 tools loaded (2 documented)",
+		"   github:get_repo",
+		"!? github:delete_repo",
+		"summary: Echo input",
+		"github:delete_repo                       undocumented",
+		"local:echo                               missing notes, examples",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "github:get_repo                          missing") {
+		t.Errorf("fully documented tool reported as missing docs:\n%s", out)
+	}
+
+	if code := browse([]string{"-url", srv.URL, "bundle.json"}, strings.NewReader(""), &stdout, &stderr); code != exitError {
+		t.Errorf("-url with a bundle: exit code = %d, want %d", code, exitError)
+	}
+}
+
+func TestRunVerify(t *testing.T) {
+	dir := t.TempDir()
+	bundlePath := writeFile(t, dir, "bundle.json", `{"tools": {
+		"github:get_repo": {"summary": "Get a repository", "examples": [
+			{"title": "Basic", "args": {"repo": "a/b"}},
+			{"title": "No repo", "args": {}}
+		]},
+		"local:echo": {"summary": "Echo input", "examples": [{"title": "Hi", "args": {"text": "hi"}}]}
+	}}`)
+	toolsPath := writeFile(t, dir, "tools.json", `[
+		{"namespace": "github", "name": "get_repo", "description": "Get repo", "inputSchema": {"type": "object", "required": ["repo"]}}
+	]`)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"verify", "-tools", toolsPath, bundlePath}, &stdout, &stderr)
+	if code != exitDiff {
+		t.Fatalf("exit code = %d, want %d (stderr: %s)", code, exitDiff, stderr.String())
+	}
+	for _, want := range []string{
+		`error   schema github:get_repo "No repo":`,
+		"warning tool   local:echo: examples not checked",
+		"2 tools, 3 examples: 1 errors, 1 warnings",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	// A JSON report used as the baseline suppresses known findings.
+	stdout.Reset()
+	run([]string{"verify", "-json", "-tools", toolsPath, bundlePath}, &stdout, &stderr)
+	baselinePath := writeFile(t, dir, "baseline.json", stdout.String())
+	stdout.Reset()
+	code = run([]string{"verify", "-fail-on", "warning", "-baseline", baselinePath, "-tools", toolsPath, bundlePath}, &stdout, &stderr)
+	if code != exitOK {
+		t.Errorf("exit code with baseline = %d, want %d", code, exitOK)
+	}
+
+	if code := run([]string{"verify", "-fail-on", "info", bundlePath}, &stdout, &stderr); code != exitError {
+		t.Errorf("exit code for bad -fail-on = %d, want %d", code, exitError)
+	}
+	if code := run([]string{"verify", "missing.json"}, &stdout, &stderr); code != exitError {
+		t.Errorf("exit code for missing bundle = %d, want %d", code, exitError)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jonwraymond/tooldocs"
+	"github.com/jonwraymond/toolmodel"
+)
+
+// runVerify implements
+// `tooldocs verify [-tools TOOLS.json] [-json] [-fail-on SEVERITY] [-baseline REPORT.json] BUNDLE.json`.
+func runVerify(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	toolsPath := fs.String("tools", "", "JSON array of MCP tool definitions to validate example args against")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	failOn := fs.String("fail-on", string(tooldocs.SeverityError), "lowest severity that fails the run: error or warning")
+	baselinePath := fs.String("baseline", "", "JSON report from an earlier run; only new findings fail the run")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: tooldocs verify [-tools TOOLS.json] [-json] [-fail-on SEVERITY] [-baseline REPORT.json] BUNDLE.json")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitError
+	}
+	threshold := tooldocs.Severity(*failOn)
+	if threshold != tooldocs.SeverityError && threshold != tooldocs.SeverityWarning {
+		fmt.Fprintf(stderr, "tooldocs verify: invalid -fail-on %q (want error or warning)\n", *failOn)
+		return exitError
+	}
+
+	b, err := loadBundle(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "tooldocs verify: %v\n", err)
+		return exitError
+	}
+	var baseline tooldocs.VerifyReport
+	if *baselinePath != "" {
+		if baseline, err = loadVerifyReport(*baselinePath); err != nil {
+			fmt.Fprintf(stderr, "tooldocs verify: %v\n", err)
+			return exitError
+		}
+	}
+
+	opts := tooldocs.StoreOptions{}
+	if *toolsPath != "" {
+		tools, err := loadTools(*toolsPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "tooldocs verify: %v\n", err)
+			return exitError
+		}
+		opts.ToolResolver = func(id string) (*toolmodel.Tool, error) {
+			return tools[id], nil
+		}
+	}
+	store := tooldocs.NewInMemoryStore(opts)
+	if _, err := store.Import(b.Tools, tooldocs.ImportOptions{Source: "bundle:" + fs.Arg(0)}); err != nil {
+		fmt.Fprintf(stderr, "tooldocs verify: %v\n", err)
+		return exitError
+	}
+
+	report, err := store.VerifyExamples(context.Background(), tooldocs.VerifyOptions{})
+	if err != nil {
+		fmt.Fprintf(stderr, "tooldocs verify: %v\n", err)
+		return exitError
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(stderr, "tooldocs verify: %v\n", err)
+			return exitError
+		}
+	} else {
+		printVerify(stdout, report)
+	}
+
+	failing := report
+	if *baselinePath != "" {
+		failing = tooldocs.VerifyReport{Findings: report.Regressions(baseline)}
+	}
+	if failing.Failed(threshold) {
+		return exitDiff
+	}
+	return exitOK
+}
+
+// printVerify renders a VerifyReport one finding per line, followed by a
+// summary line.
+func printVerify(w io.Writer, r tooldocs.VerifyReport) {
+	for _, f := range r.Findings {
+		target := f.ID
+		if f.Example != "" {
+			target += fmt.Sprintf(" %q", f.Example)
+		}
+		fmt.Fprintf(w, "%-7s %-6s %s: %s\n", f.Severity, f.Check, target, f.Message)
+	}
+	fmt.Fprintf(w, "%d tools, %d examples: %d errors, %d warnings\n", r.Tools, r.Examples, r.Errors, r.Warnings)
+}
+
+// loadVerifyReport reads a report written by `tooldocs verify -json`.
+func loadVerifyReport(path string) (tooldocs.VerifyReport, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is an explicit CLI argument
+	if err != nil {
+		return tooldocs.VerifyReport{}, err
+	}
+	var r tooldocs.VerifyReport
+	if err := json.Unmarshal(data, &r); err != nil {
+		return tooldocs.VerifyReport{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
+}
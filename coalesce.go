@@ -0,0 +1,224 @@
+package tooldocs
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// flightKey identifies a coalescable DescribeTool call.
+type flightKey struct {
+	id     string
+	level  DetailLevel
+	locale string // catalog locale; rendered boilerplate differs per locale
+}
+
+// flight is an in-progress describe whose result is shared with every
+// caller that asked for the same key while it ran.
+type flight struct {
+	done    chan struct{} // closed once doc and err are set; see wait
+	doc     ToolDoc
+	err     error
+	dups    int                // callers that joined after the first; guarded by flightGroup.mu
+	waiting int                // callers still waiting on the result; guarded by flightGroup.mu
+	cancel  context.CancelFunc // cancels the call's context; nil if it cannot be
+}
+
+// flightGroup coalesces identical concurrent describes (singleflight), so an
+// agent swarm describing one tool runs the lookup, schema derivation, and
+// rendering once.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[flightKey]*flight
+}
+
+// do runs fn for key unless a call for key is already running, in which
+// case it waits for that call and returns its result with shared set.
+// Callers of a shared result each own their doc.
+//
+// The call does not belong to the caller that starts it: when that
+// caller's ctx can be cancelled, fn runs on a context detached from it,
+// which is cancelled only once every caller waiting on the result has
+// given up. A caller whose ctx ends returns ctx.Err() without failing the
+// others; the one running fn does so when fn returns.
+func (g *flightGroup) do(ctx context.Context, key flightKey, fn func(context.Context) (ToolDoc, error)) (ToolDoc, bool, error) {
+	g.mu.Lock()
+	if f, ok := g.flights[key]; ok {
+		f.dups++
+		f.waiting++
+		done := f.wait()
+		g.mu.Unlock()
+		select {
+		case <-done:
+			return cloneToolDoc(f.doc), true, f.err
+		case <-ctx.Done():
+			g.leave(key, f)
+			return ToolDoc{}, true, ctx.Err()
+		}
+	}
+	if g.flights == nil {
+		g.flights = make(map[flightKey]*flight)
+	}
+	f := &flight{waiting: 1}
+	g.flights[key] = f
+	callCtx := ctx
+	if ctx.Done() != nil {
+		callCtx, f.cancel = context.WithCancel(context.WithoutCancel(ctx))
+		stop := context.AfterFunc(ctx, func() { g.leave(key, f) })
+		defer stop()
+	}
+	g.mu.Unlock()
+
+	doc, err := fn(callCtx)
+	g.finish(key, f, doc, err)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ToolDoc{}, false, ctxErr
+	}
+	g.mu.Lock()
+	dups := f.dups
+	g.mu.Unlock()
+	if dups > 0 {
+		// Waiters copy f.doc once released, so this caller gets its own
+		// copy too rather than one it could write while they read.
+		doc = cloneToolDoc(doc)
+	}
+	return doc, false, err
+}
+
+// wait returns a channel closed once f's result is set. The channel is
+// made on first use, so a call nobody waits on allocates none; callers
+// join only before the result is set. Callers hold flightGroup.mu.
+func (f *flight) wait() chan struct{} {
+	if f.done == nil {
+		f.done = make(chan struct{})
+	}
+	return f.done
+}
+
+// finish publishes the result of f's call and releases its waiters.
+func (g *flightGroup) finish(key flightKey, f *flight, doc ToolDoc, err error) {
+	g.mu.Lock()
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+	f.doc, f.err = doc, err
+	if f.done != nil {
+		close(f.done)
+	}
+	g.mu.Unlock()
+	if f.cancel != nil {
+		f.cancel()
+	}
+}
+
+// leave drops a caller that gave up on f. When none are left, the call is
+// cancelled and later callers start a new one instead of joining it.
+func (g *flightGroup) leave(key flightKey, f *flight) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	f.waiting--
+	if f.waiting > 0 {
+		return
+	}
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+	if f.cancel != nil {
+		f.cancel()
+	}
+}
+
+// describeCoalesced is describe with identical concurrent calls coalesced.
+// Reads whose result depends on the caller's context are not shared: tools
+// in a running experiment (assignment and exposures are per caller),
+// Sensitive tools (authorization gates the full tier and its NextDetail
+// hint), at DetailFull, stores with an ExampleSelector (the selection may
+// use the context), and traced calls (see TraceID) to stores with
+// context-aware hooks (ContextToolResolver, DocResolver, Summarizer), so
+// each trace reaches them. A shared call runs on the context of the caller
+// that started it, detached from its cancellation.
+func (s *InMemoryStore) describeCoalesced(ctx context.Context, id string, level DetailLevel) (ToolDoc, bool, error) {
+	if !s.coalescable(ctx, id, level) {
+		doc, err := s.describe(ctx, id, level, s.contextResolve(ctx))
+		return doc, false, err
+	}
+	locale, _ := s.localeCatalog(ctx)
+	return s.flights.do(ctx, flightKey{id: id, level: level, locale: locale}, func(ctx context.Context) (ToolDoc, error) {
+		return s.describe(ctx, id, level, s.contextResolve(ctx))
+	})
+}
+
+// contextResolve returns a tool lookup made with ctx.
+func (s *InMemoryStore) contextResolve(ctx context.Context) func(id string) (*toolmodel.Tool, error) {
+	return func(id string) (*toolmodel.Tool, error) {
+		return s.resolveToolContext(ctx, id)
+	}
+}
+
+// coalescable reports whether a describe of id at level is independent of
+// the caller's context.
+func (s *InMemoryStore) coalescable(ctx context.Context, id string, level DetailLevel) bool {
+	if TraceID(ctx) != "" && (s.ctxResolver != nil || s.docResolver != nil || s.summarizer != nil) {
+		return false
+	}
+	s.rlock()
+	defer s.runlock()
+	if s.experiments[id] != nil {
+		return false
+	}
+	// Sensitive docs depend on authorization at every level: the full tier
+	// is gated, and lower levels only point authorized callers at it.
+	if rec := s.docs[id]; rec != nil && rec.sensitive {
+		return false
+	}
+	return level != DetailFull || s.selector == nil
+}
+
+// cloneToolDoc returns a copy of doc that shares no mutable state with it,
+// apart from the tool's schemas, which reads already share with the index.
+func cloneToolDoc(doc ToolDoc) ToolDoc {
+	if doc.Tool != nil {
+		tool := *doc.Tool
+		tool.Annotations = copyAnnotations(tool.Annotations)
+		doc.Tool = &tool
+	}
+	if doc.SchemaInfo != nil {
+		info := SchemaInfo{
+			Required:    slices.Clone(doc.SchemaInfo.Required),
+			Defaults:    deepCopyArgs(doc.SchemaInfo.Defaults),
+			Types:       cloneStringSlices(doc.SchemaInfo.Types),
+			Constraints: cloneStringSlices(doc.SchemaInfo.Constraints),
+		}
+		doc.SchemaInfo = &info
+	}
+	if doc.Capabilities != nil {
+		caps := *doc.Capabilities
+		doc.Capabilities = &caps
+	}
+	doc.Effects = slices.Clone(doc.Effects)
+	doc.RetryPolicy = copyRetryPolicy(doc.RetryPolicy)
+	doc.Concurrency = copyConcurrency(doc.Concurrency)
+	doc.ClientRequirements = copyClientRequirements(doc.ClientRequirements)
+	doc.Sections = slices.Clone(doc.Sections)
+	doc.Examples = copyExamples(doc.Examples)
+	doc.Prerequisites = slices.Clone(doc.Prerequisites)
+	doc.Edges = slices.Clone(doc.Edges)
+	doc.ExternalRefs = slices.Clone(doc.ExternalRefs)
+	doc.Omitted = slices.Clone(doc.Omitted)
+	return doc
+}
+
+// cloneStringSlices copies a map of string slices, including the slices.
+func cloneStringSlices(m map[string][]string) map[string][]string {
+	if m == nil {
+		return nil
+	}
+	out := maps.Clone(m)
+	for k, v := range out {
+		out[k] = slices.Clone(v)
+	}
+	return out
+}
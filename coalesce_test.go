@@ -0,0 +1,285 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// waitForDups blocks until n callers are waiting on the flight for key.
+func waitForDups(t *testing.T, s *InMemoryStore, key flightKey, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		s.flights.mu.Lock()
+		f := s.flights.flights[key]
+		dups := 0
+		if f != nil {
+			dups = f.dups
+		}
+		s.flights.mu.Unlock()
+		if dups == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d coalesced callers", n)
+}
+
+func blockingStore(t *testing.T, opts StoreOptions) (*InMemoryStore, *atomic.Int32, chan struct{}) {
+	t.Helper()
+	var lookups atomic.Int32
+	release := make(chan struct{})
+	opts.ToolResolver = func(id string) (*toolmodel.Tool, error) {
+		lookups.Add(1)
+		<-release
+		tool := makeToolWithSchema("search", "gh", "Search", map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"q": map[string]any{"type": "string"}},
+			"required":   []any{"q"},
+		})
+		return &tool, nil
+	}
+	store := NewInMemoryStore(opts)
+	mustRegisterDoc(t, store, "gh:search", DocEntry{
+		Summary:  "Search issues",
+		Examples: []ToolExample{{Title: "Bugs", Args: map[string]any{"q": "label:bug"}}},
+	})
+	return store, &lookups, release
+}
+
+func TestDescribeTool_CoalescesConcurrentCalls(t *testing.T) {
+	store, lookups, release := blockingStore(t, StoreOptions{})
+	const callers = 10
+
+	docs := make([]ToolDoc, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			doc, err := store.DescribeTool("gh:search", DetailFull)
+			if err != nil {
+				t.Errorf("DescribeTool failed: %v", err)
+			}
+			docs[i] = doc
+		}()
+	}
+	waitForDups(t, store, flightKey{id: "gh:search", level: DetailFull}, callers-1)
+	close(release)
+	wg.Wait()
+
+	if n := lookups.Load(); n != 1 {
+		t.Errorf("tool lookups = %d, want 1", n)
+	}
+	for i, doc := range docs {
+		if doc.Summary != "Search issues" || len(doc.Examples) != 1 || doc.SchemaInfo == nil {
+			t.Fatalf("docs[%d] = %+v", i, doc)
+		}
+	}
+
+	// Every caller owns its copy.
+	docs[0].Examples[0].Args["q"] = "changed"
+	docs[0].SchemaInfo.Required[0] = "changed"
+	docs[0].Tool.Name = "changed"
+	for i, doc := range docs[1:] {
+		if doc.Examples[0].Args["q"] != "label:bug" || doc.SchemaInfo.Required[0] != "q" || doc.Tool.Name != "search" {
+			t.Errorf("docs[%d] shares state with docs[0]", i+1)
+		}
+	}
+}
+
+func TestDescribeTool_CoalescedCallerOwnsDoc(t *testing.T) {
+	store, _, release := blockingStore(t, StoreOptions{})
+	const callers = 4
+
+	// Run with -race: the caller that did the lookup writes its doc while
+	// the waiters copy the shared result.
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			doc, err := store.DescribeTool("gh:search", DetailFull)
+			if err != nil {
+				t.Errorf("DescribeTool failed: %v", err)
+				return
+			}
+			doc.Examples[0].Args["q"] = "changed"
+			doc.SchemaInfo.Required[0] = "changed"
+		}()
+	}
+	waitForDups(t, store, flightKey{id: "gh:search", level: DetailFull}, callers-1)
+	close(release)
+	wg.Wait()
+
+	doc, err := store.DescribeTool("gh:search", DetailFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Examples[0].Args["q"] != "label:bug" {
+		t.Errorf("store example args = %v, want unchanged", doc.Examples[0].Args)
+	}
+}
+
+func TestDescribeTool_DistinctLevelsNotCoalesced(t *testing.T) {
+	store, lookups, release := blockingStore(t, StoreOptions{})
+	close(release)
+
+	for _, level := range []DetailLevel{DetailSummary, DetailSchema, DetailFull} {
+		if _, err := store.DescribeTool("gh:search", level); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := lookups.Load(); n != 3 {
+		t.Errorf("tool lookups = %d, want 3 (sequential calls are not cached)", n)
+	}
+}
+
+func TestCoalescable(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "vault:read", DocEntry{Summary: "Read a secret", Sensitive: true})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Summary: "Search"})
+	if err := store.RegisterExperiment(Experiment{
+		Name:     "exp",
+		ToolID:   "gh:search",
+		Variants: map[string]DocEntry{"b": {Summary: "Find"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id    string
+		level DetailLevel
+		want  bool
+	}{
+		{"vault:read", DetailSummary, false},
+		{"vault:read", DetailSchema, false},
+		{"vault:read", DetailFull, false},
+		{"gh:search", DetailSummary, false},
+		{"gh:other", DetailFull, true},
+	}
+	for _, tt := range tests {
+		if got := store.coalescable(context.Background(), tt.id, tt.level); got != tt.want {
+			t.Errorf("coalescable(%s, %s) = %v, want %v", tt.id, tt.level, got, tt.want)
+		}
+	}
+
+	withSelector := NewInMemoryStore(StoreOptions{ExampleSelector: FirstNSelector{}})
+	if withSelector.coalescable(context.Background(), "gh:other", DetailFull) {
+		t.Error("coalescable with ExampleSelector at full = true, want false")
+	}
+}
+
+func TestCoalescable_TracedContextHooks(t *testing.T) {
+	traced := WithTraceID(context.Background(), "turn-1")
+	if !NewInMemoryStore(StoreOptions{}).coalescable(traced, "gh:search", DetailSummary) {
+		t.Error("traced call without context-aware hooks should be coalescable")
+	}
+	store := NewInMemoryStore(StoreOptions{
+		ContextToolResolver: func(context.Context, string) (*toolmodel.Tool, error) { return nil, nil },
+	})
+	if store.coalescable(traced, "gh:search", DetailSummary) {
+		t.Error("traced call with a ContextToolResolver should not be coalescable")
+	}
+	if !store.coalescable(context.Background(), "gh:search", DetailSummary) {
+		t.Error("untraced call should be coalescable")
+	}
+}
+
+func TestDescribeToolContext_CancelledLeaderDoesNotFailWaiters(t *testing.T) {
+	store, lookups, release := blockingStore(t, StoreOptions{})
+	key := flightKey{id: "gh:search", level: DetailFull}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := store.DescribeToolContext(ctx, "gh:search", DetailFull)
+		leaderErr <- err
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for lookups.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	type result struct {
+		doc ToolDoc
+		err error
+	}
+	waiter := make(chan result, 1)
+	go func() {
+		doc, err := store.DescribeToolContext(context.Background(), "gh:search", DetailFull)
+		waiter <- result{doc, err}
+	}()
+	waitForDups(t, store, key, 1)
+
+	cancel()
+	close(release)
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller err = %v, want context.Canceled", err)
+	}
+	if r := <-waiter; r.err != nil || r.doc.Summary != "Search issues" {
+		t.Errorf("waiter = %+v, %v; want the shared doc", r.doc, r.err)
+	}
+	if n := lookups.Load(); n != 1 {
+		t.Errorf("tool lookups = %d, want 1", n)
+	}
+}
+
+func TestDescribeToolContext_SensitiveNotShared(t *testing.T) {
+	store, _, release := blockingStore(t, StoreOptions{})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Summary: "Search issues", Sensitive: true})
+	close(release)
+
+	if _, err := store.DescribeToolContext(context.Background(), "gh:search", DetailFull); err == nil {
+		t.Error("unauthorized DescribeToolContext succeeded")
+	}
+	ctx := WithGrants(context.Background(), GrantSensitiveDocs)
+	if _, err := store.DescribeToolContext(ctx, "gh:search", DetailFull); err != nil {
+		t.Errorf("authorized DescribeToolContext failed: %v", err)
+	}
+}
+
+func TestDescribeToolContext_SensitiveSummaryNotShared(t *testing.T) {
+	store, lookups, release := blockingStore(t, StoreOptions{})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{
+		Summary:   "Search issues",
+		Notes:     "Results are capped at 1000.",
+		Sensitive: true,
+	})
+
+	// The NextDetail hint points only authorized callers at the full tier,
+	// so concurrent summaries each do their own lookup.
+	authorized := WithGrants(context.Background(), GrantSensitiveDocs)
+	hints := make([]DetailHint, 2)
+	var wg sync.WaitGroup
+	for i, ctx := range []context.Context{authorized, context.Background()} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			doc, err := store.DescribeToolContext(ctx, "gh:search", DetailSummary)
+			if err != nil {
+				t.Errorf("DescribeToolContext failed: %v", err)
+			}
+			hints[i] = doc.NextDetail
+		}()
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for lookups.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if n := lookups.Load(); n != 2 {
+		t.Errorf("tool lookups = %d, want 2", n)
+	}
+	if hints[0].Level != DetailFull || hints[1].Level != DetailSchema {
+		t.Errorf("hints = %+v, want full for the authorized caller and schema otherwise", hints)
+	}
+}
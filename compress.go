@@ -0,0 +1,199 @@
+package tooldocs
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ErrDictionary is returned when a dictionary-compressed payload cannot be
+// decompressed, e.g. because the peers' dictionaries differ.
+var ErrDictionary = errors.New("dictionary mismatch")
+
+// DefaultDictionarySize is the dictionary size TrainDictionary uses for a
+// size <= 0: the largest deflate can refer back to.
+const DefaultDictionarySize = 32 << 10
+
+// Dictionary is a shared compression dictionary for ToolDoc payloads.
+// Catalog docs repeat the same JSON keys, schema boilerplate ("type":
+// "object", "properties"), and phrasing across tools, so deflate primed
+// with them compresses even a single small doc well. Both ends of a
+// transport must hold the same dictionary; see httpapi and grpcapi.
+type Dictionary struct {
+	// ID identifies the dictionary's content, so peers can tell whether
+	// they hold the same one.
+	ID string `json:"id"`
+
+	// Data is the dictionary content, at most DefaultDictionarySize bytes
+	// of which are used.
+	Data []byte `json:"data"`
+}
+
+// NewDictionary returns a Dictionary for data, computing its ID.
+func NewDictionary(data []byte) Dictionary {
+	if len(data) > DefaultDictionarySize {
+		data = data[len(data)-DefaultDictionarySize:]
+	}
+	sum := sha256.Sum256(data)
+	return Dictionary{ID: hex.EncodeToString(sum[:8]), Data: data}
+}
+
+// NewWriter returns a writer compressing to w with the dictionary. Close
+// it to flush the payload.
+func (d Dictionary) NewWriter(w io.Writer) io.WriteCloser {
+	// NewWriterDict only fails for invalid levels.
+	zw, _ := flate.NewWriterDict(w, flate.DefaultCompression, d.Data)
+	return zw
+}
+
+// NewReader returns a reader decompressing r with the dictionary.
+func (d Dictionary) NewReader(r io.Reader) io.ReadCloser {
+	return flate.NewReaderDict(r, d.Data)
+}
+
+// Compress compresses data with the dictionary.
+func (d Dictionary) Compress(data []byte) []byte {
+	var buf bytes.Buffer
+	zw := d.NewWriter(&buf)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+// Decompress reverses Compress.
+//
+// Returns ErrDictionary if data is not a valid payload. Payloads from
+// another dictionary may decode to garbage instead, so transports compare
+// dictionary IDs first.
+func (d Dictionary) Decompress(data []byte) ([]byte, error) {
+	out, err := io.ReadAll(d.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrDictionary, d.ID, err)
+	}
+	return out, nil
+}
+
+// TrainDictionary builds a dictionary of at most size bytes (size <= 0:
+// DefaultDictionarySize) from sample payloads, such as the JSON of a
+// catalog's docs. Samples are split into JSON fragments (keys, values, and
+// punctuation-delimited runs); fragments seen in more than one sample are
+// kept, favoring those saving the most bytes overall, and the most
+// valuable are placed last, where deflate reaches them most cheaply.
+// Training is deterministic for the same samples.
+func TrainDictionary(samples [][]byte, size int) Dictionary {
+	if size <= 0 || size > DefaultDictionarySize {
+		size = DefaultDictionarySize
+	}
+
+	type fragment struct {
+		text    string
+		count   int // occurrences across samples
+		samples int // samples containing it
+	}
+	frags := make(map[string]*fragment)
+	for _, sample := range samples {
+		seen := make(map[string]bool)
+		for _, text := range jsonFragments(sample) {
+			f := frags[text]
+			if f == nil {
+				f = &fragment{text: text}
+				frags[text] = f
+			}
+			f.count++
+			if !seen[text] {
+				seen[text] = true
+				f.samples++
+			}
+		}
+	}
+
+	minSamples := 2
+	if len(samples) < 2 {
+		minSamples = 1
+	}
+	ranked := make([]*fragment, 0, len(frags))
+	for _, f := range frags {
+		if f.samples >= minSamples && f.count > 1 {
+			ranked = append(ranked, f)
+		}
+	}
+	score := func(f *fragment) int { return (f.count - 1) * len(f.text) }
+	sort.Slice(ranked, func(i, j int) bool {
+		if a, b := score(ranked[i]), score(ranked[j]); a != b {
+			return a > b
+		}
+		return ranked[i].text < ranked[j].text
+	})
+
+	var picked []string
+	total := 0
+	for _, f := range ranked {
+		if total+len(f.text) > size {
+			continue
+		}
+		picked = append(picked, f.text)
+		total += len(f.text)
+	}
+	data := make([]byte, 0, total)
+	for i := len(picked) - 1; i >= 0; i-- {
+		data = append(data, picked[i]...)
+	}
+	return NewDictionary(data)
+}
+
+// minFragment is the shortest fragment worth a dictionary entry; deflate
+// matches are at least 3 bytes and short ones save little.
+const minFragment = 4
+
+// jsonFragments splits JSON text after each structural character, so keys
+// ("summary":), values, and boilerplate runs become separate fragments.
+func jsonFragments(data []byte) []string {
+	var out []string
+	start := 0
+	for i, c := range data {
+		switch c {
+		case ',', ':', '{', '}', '[', ']':
+			if i+1-start >= minFragment {
+				out = append(out, string(data[start:i+1]))
+			}
+			start = i + 1
+		}
+	}
+	if len(data)-start >= minFragment {
+		out = append(out, string(data[start:]))
+	}
+	return out
+}
+
+// TrainDictionary trains a dictionary (see the TrainDictionary function)
+// on the JSON of every documented tool at each detail level. The full tier
+// of Sensitive docs is never included, since dictionaries are shared with
+// clients. Training reads are not counted in stats or traced.
+func (s *InMemoryStore) TrainDictionary(size int) Dictionary {
+	ctx := context.Background()
+	var samples [][]byte
+	for _, id := range s.DocIDs() {
+		s.mu.RLock()
+		sensitive := s.docs[id] != nil && s.docs[id].sensitive
+		s.mu.RUnlock()
+		for _, level := range []DetailLevel{DetailSummary, DetailSchema, DetailFull} {
+			if level == DetailFull && sensitive {
+				continue
+			}
+			doc, err := s.describe(ctx, id, level, s.resolveTool)
+			if err != nil {
+				continue
+			}
+			if data, err := MarshalToolDoc(doc, MarshalOptions{}); err == nil {
+				samples = append(samples, data)
+			}
+		}
+	}
+	return TrainDictionary(samples, size)
+}
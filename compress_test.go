@@ -0,0 +1,68 @@
+package tooldocs
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTrainDictionary(t *testing.T) {
+	names := []string{"search", "create", "update", "delete", "list", "get"}
+	s := NewInMemoryStore(StoreOptions{Index: newCatalogIndex(t, names...)})
+	for _, name := range names {
+		mustRegisterDoc(t, s, "ns:"+name, DocEntry{
+			Summary:    fmt.Sprintf("%s issues in the tracker", strings.ToUpper(name[:1])+name[1:]),
+			ShortNotes: "Requires a token with the issues scope.",
+			Notes:      "Rate limited to 30 requests per minute per token.",
+		})
+	}
+	mustRegisterDoc(t, s, "ns:secret", DocEntry{Summary: "Rotate keys", Notes: "Runbook: call ops-pager first.", Sensitive: true})
+
+	dict := s.TrainDictionary(0)
+	if len(dict.Data) == 0 || len(dict.Data) > DefaultDictionarySize || dict.ID == "" {
+		t.Fatalf("dictionary = %d bytes, ID %q", len(dict.Data), dict.ID)
+	}
+	if again := s.TrainDictionary(0); again.ID != dict.ID {
+		t.Errorf("training not deterministic: %s, then %s", dict.ID, again.ID)
+	}
+	if bytes.Contains(dict.Data, []byte("ops-pager")) {
+		t.Error("dictionary includes the full tier of a sensitive doc")
+	}
+	if small := s.TrainDictionary(64); len(small.Data) > 64 {
+		t.Errorf("size 64 dictionary has %d bytes", len(small.Data))
+	}
+
+	doc, err := s.DescribeTool("ns:search", DetailFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := MarshalToolDoc(doc, MarshalOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed := dict.Compress(payload)
+	var plain bytes.Buffer
+	zw, _ := flate.NewWriter(&plain, flate.DefaultCompression)
+	_, _ = zw.Write(payload)
+	_ = zw.Close()
+	if len(compressed) >= plain.Len() {
+		t.Errorf("with dictionary %d bytes, without %d; want smaller", len(compressed), plain.Len())
+	}
+	out, err := dict.Decompress(compressed)
+	if err != nil || !bytes.Equal(out, payload) {
+		t.Errorf("Decompress = %q, %v; want the payload", out, err)
+	}
+}
+
+func TestDictionary_DecompressInvalid(t *testing.T) {
+	dict := NewDictionary([]byte(`"type":"object",`))
+	if _, err := dict.Decompress([]byte("not deflate")); !errors.Is(err, ErrDictionary) {
+		t.Errorf("err = %v, want ErrDictionary", err)
+	}
+	if NewDictionary(dict.Data).ID != dict.ID {
+		t.Error("dictionary ID should depend only on the content")
+	}
+}
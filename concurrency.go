@@ -0,0 +1,80 @@
+package tooldocs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Concurrency declares backend limits on parallel calls of a tool, so
+// multi-agent schedulers can respect them. It is returned at DetailSchema
+// and DetailFull.
+type Concurrency struct {
+	// MaxParallel is the maximum number of calls that may be in flight at
+	// once (across the Group, when set). Zero means no documented limit; 1
+	// means calls must be serialized.
+	MaxParallel int `json:"maxParallel,omitempty"`
+
+	// SerializationKey names an input parameter; calls with equal values
+	// for it must not overlap (e.g. "repo" for a tool that takes a
+	// per-repository lock).
+	SerializationKey string `json:"serializationKey,omitempty"`
+
+	// Group names a limit shared by several tools (e.g. "github-api" for
+	// tools behind one rate-limited client). Empty means the limit applies
+	// to this tool alone.
+	Group string `json:"group,omitempty"`
+}
+
+// String renders the constraints on one line, e.g.
+// "max 2 parallel (group github-api); serialize by repo".
+func (c Concurrency) String() string {
+	return c.describe(nil)
+}
+
+// describe implements String, translating with cat.
+func (c Concurrency) describe(cat Catalog) string {
+	var parts []string
+	if c.MaxParallel > 0 {
+		limit := cat.Sprintf("max %d parallel", c.MaxParallel)
+		if c.Group != "" {
+			limit += " (" + cat.Sprintf("group %s", c.Group) + ")"
+		}
+		parts = append(parts, limit)
+	} else if c.Group != "" {
+		parts = append(parts, cat.Sprintf("group %s", c.Group))
+	}
+	if c.SerializationKey != "" {
+		parts = append(parts, cat.Sprintf("serialize by %s", c.SerializationKey))
+	}
+	if len(parts) == 0 {
+		return cat.Text("no limit")
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Key returns the scheduling key for a call of tool id with args: calls
+// with the same key share the MaxParallel budget. The key is the Group (or
+// id), followed by the SerializationKey argument value when one is
+// declared and present in args.
+func (c Concurrency) Key(id string, args map[string]any) string {
+	key := c.Group
+	if key == "" {
+		key = id
+	}
+	if c.SerializationKey == "" {
+		return key
+	}
+	if v, ok := args[c.SerializationKey]; ok {
+		return fmt.Sprintf("%s/%s=%v", key, c.SerializationKey, v)
+	}
+	return key
+}
+
+// copyConcurrency returns a copy of c, or nil.
+func copyConcurrency(c *Concurrency) *Concurrency {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	return &cp
+}
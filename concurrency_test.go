@@ -0,0 +1,84 @@
+package tooldocs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestConcurrency_SchemaLevel(t *testing.T) {
+	tool := makeToolWithSchema("push", "git", "Push a branch", map[string]any{"type": "object"})
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) { return &tool, nil },
+	})
+	limits := &Concurrency{MaxParallel: 1, SerializationKey: "repo", Group: "git-remote"}
+	mustRegisterDoc(t, store, "git:push", DocEntry{Summary: "Push", Concurrency: limits})
+
+	doc, err := store.DescribeTool("git:push", DetailSchema)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if !reflect.DeepEqual(doc.Concurrency, limits) {
+		t.Errorf("Concurrency = %+v, want %+v", doc.Concurrency, limits)
+	}
+
+	limits.MaxParallel = 5
+	doc.Concurrency.Group = "mutated"
+	again, _ := store.DescribeTool("git:push", DetailSchema)
+	if again.Concurrency.MaxParallel != 1 || again.Concurrency.Group != "git-remote" {
+		t.Errorf("stored limits mutated: %+v", again.Concurrency)
+	}
+
+	summary, _ := store.DescribeTool("git:push", DetailSummary)
+	if summary.Concurrency != nil {
+		t.Errorf("summary Concurrency = %+v, want nil", summary.Concurrency)
+	}
+}
+
+func TestConcurrency_Key(t *testing.T) {
+	args := map[string]any{"repo": "acme/web", "branch": "main"}
+	tests := []struct {
+		name string
+		c    Concurrency
+		want string
+	}{
+		{"tool scope", Concurrency{MaxParallel: 2}, "git:push"},
+		{"group scope", Concurrency{Group: "git-remote"}, "git-remote"},
+		{"serialized", Concurrency{SerializationKey: "repo"}, "git:push/repo=acme/web"},
+		{"group serialized", Concurrency{Group: "git-remote", SerializationKey: "repo"}, "git-remote/repo=acme/web"},
+		{"missing arg", Concurrency{SerializationKey: "org"}, "git:push"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Key("git:push", args); got != tt.want {
+			t.Errorf("%s: Key() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConcurrency_String(t *testing.T) {
+	tests := []struct {
+		c    Concurrency
+		want string
+	}{
+		{Concurrency{MaxParallel: 2, Group: "github-api", SerializationKey: "repo"}, "max 2 parallel (group github-api); serialize by repo"},
+		{Concurrency{Group: "github-api"}, "group github-api"},
+		{Concurrency{}, "no limit"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestConcurrency_MergeImport(t *testing.T) {
+	base := DocEntry{Concurrency: &Concurrency{MaxParallel: 4}}
+	merged := MergeDocEntries(base, DocEntry{Concurrency: &Concurrency{MaxParallel: 1}}, MergePolicy{})
+	if merged.Concurrency.MaxParallel != 1 {
+		t.Errorf("merged MaxParallel = %d, want 1", merged.Concurrency.MaxParallel)
+	}
+	if fields := entryFieldChanges(DocEntry{}, base); !reflect.DeepEqual(fields, []string{"concurrency"}) {
+		t.Errorf("entryFieldChanges = %v, want [concurrency]", fields)
+	}
+}
@@ -0,0 +1,98 @@
+package tooldocs
+
+// ToolCoverage describes how completely a single tool is documented.
+type ToolCoverage struct {
+	// ID is the tool ID.
+	ID string `json:"id"`
+
+	// Documented reports whether any documentation is registered.
+	Documented bool `json:"documented"`
+
+	// HasSummary reports whether a doc summary is registered.
+	HasSummary bool `json:"hasSummary"`
+
+	// HasNotes reports whether notes are registered.
+	HasNotes bool `json:"hasNotes"`
+
+	// Examples is the number of registered examples.
+	Examples int `json:"examples"`
+
+	// ExternalRefs is the number of registered external references.
+	ExternalRefs int `json:"externalRefs"`
+
+	// Source is the registered DocEntry.Source.
+	Source string `json:"source,omitempty"`
+
+	// Frozen reports whether the documentation is frozen.
+	Frozen bool `json:"frozen,omitempty"`
+}
+
+// CoverageReport summarizes documentation coverage across tools.
+type CoverageReport struct {
+	// Tools holds one entry per requested tool, in request order.
+	Tools []ToolCoverage `json:"tools"`
+
+	// Documented is the number of tools with any documentation.
+	Documented int `json:"documented"`
+
+	// WithNotes is the number of tools with notes.
+	WithNotes int `json:"withNotes"`
+
+	// WithExamples is the number of tools with at least one example.
+	WithExamples int `json:"withExamples"`
+
+	// BySource counts documented tools per DocEntry.Source
+	// ("" for unattributed records).
+	BySource map[string]int `json:"bySource,omitempty"`
+}
+
+// CoverageReport reports documentation coverage for the given tool IDs.
+// If ids is nil, it reports on every catalog tool, sorted by ID (see
+// CatalogIDs): with StoreOptions.Index set, undocumented indexed tools are
+// included, so gaps show up without the caller enumerating the index. If
+// the index cannot be enumerated, only documented tools are reported.
+// Coverage reflects registered docs only; tools are not resolved.
+func (s *InMemoryStore) CoverageReport(ids []string) CoverageReport {
+	if ids == nil {
+		var err error
+		if ids, err = s.CatalogIDs(); err != nil {
+			ids = s.DocIDs()
+		}
+	}
+
+	report := CoverageReport{
+		Tools:    make([]ToolCoverage, 0, len(ids)),
+		BySource: make(map[string]int),
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, id := range ids {
+		cov := ToolCoverage{ID: id}
+		if record := s.docs[id]; record != nil {
+			cov.Documented = true
+			cov.HasSummary = record.summary != ""
+			cov.HasNotes = record.notes != ""
+			cov.Examples = len(record.examples)
+			cov.ExternalRefs = len(record.externalRefs)
+			cov.Source = record.source
+			cov.Frozen = record.frozen
+
+			report.Documented++
+			report.BySource[record.source]++
+			if cov.HasNotes {
+				report.WithNotes++
+			}
+			if cov.Examples > 0 {
+				report.WithExamples++
+			}
+		}
+		report.Tools = append(report.Tools, cov)
+	}
+
+	if len(report.BySource) == 0 {
+		report.BySource = nil
+	}
+	return report
+}
@@ -0,0 +1,82 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSourceAttribution(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:manual", DocEntry{Summary: "m", Source: "manual"})
+
+	_, err := store.Import(map[string]DocEntry{
+		"ns:imported": {Summary: "i"},
+		"ns:bundled":  {Summary: "b", Source: "bundle:docs/ns.json"},
+	}, ImportOptions{Source: "importer:openapi"})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	for id, want := range map[string]string{
+		"ns:manual":   "manual",
+		"ns:imported": "importer:openapi",
+		"ns:bundled":  "bundle:docs/ns.json",
+	} {
+		got, err := store.GetDocEntry(id)
+		if err != nil {
+			t.Fatalf("GetDocEntry(%s) failed: %v", id, err)
+		}
+		if got.Entry.Source != want {
+			t.Errorf("%s Source = %q, want %q", id, got.Entry.Source, want)
+		}
+	}
+
+	removed, err := store.UnregisterWhere(UnregisterFilter{Source: "importer:openapi"})
+	if err != nil {
+		t.Fatalf("UnregisterWhere failed: %v", err)
+	}
+	if want := []string{"ns:imported"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+	if _, err := store.GetDocEntry("ns:imported"); !errors.Is(err, ErrNotFound) {
+		t.Error("ns:imported should be removed")
+	}
+}
+
+func TestCoverageReport(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{
+		Summary:  "a",
+		Notes:    "notes",
+		Examples: []ToolExample{{Title: "ex"}},
+		Source:   "manual",
+	})
+	mustRegisterDoc(t, store, "ns:b", DocEntry{Summary: "b", Source: "importer:openapi"})
+	if err := store.Freeze("ns:b"); err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+
+	report := store.CoverageReport(nil)
+	want := CoverageReport{
+		Tools: []ToolCoverage{
+			{ID: "ns:a", Documented: true, HasSummary: true, HasNotes: true, Examples: 1, Source: "manual"},
+			{ID: "ns:b", Documented: true, HasSummary: true, Source: "importer:openapi", Frozen: true},
+		},
+		Documented:   2,
+		WithNotes:    1,
+		WithExamples: 1,
+		BySource:     map[string]int{"manual": 1, "importer:openapi": 1},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("CoverageReport = %+v, want %+v", report, want)
+	}
+
+	report = store.CoverageReport([]string{"ns:missing", "ns:a"})
+	if len(report.Tools) != 2 || report.Tools[0].Documented || !report.Tools[1].Documented {
+		t.Errorf("CoverageReport(ids) = %+v", report.Tools)
+	}
+	if report.Documented != 1 {
+		t.Errorf("Documented = %d, want 1", report.Documented)
+	}
+}
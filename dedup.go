@@ -0,0 +1,120 @@
+package tooldocs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateExample is returned when DuplicatesReject is in effect and an
+// example repeats the Title and normalized Args of another example for the
+// same tool.
+var ErrDuplicateExample = errors.New("duplicate example")
+
+// DuplicatePolicy controls how duplicate examples are handled at registration.
+// Two examples are duplicates when they share a Title and their Args are
+// equal after normalization (so 1 and 1.0 compare equal).
+type DuplicatePolicy string
+
+const (
+	// DuplicatesAllow stores duplicate examples as given.
+	DuplicatesAllow DuplicatePolicy = "allow"
+
+	// DuplicatesCollapse keeps the first occurrence and silently drops later
+	// duplicates.
+	DuplicatesCollapse DuplicatePolicy = "collapse"
+
+	// DuplicatesReject fails the registration with ErrDuplicateExample.
+	DuplicatesReject DuplicatePolicy = "reject"
+)
+
+// AppendExamples adds examples to a tool's existing examples.
+// Examples are validated and truncated like RegisterExamples, and the
+// store's DuplicateExamples policy is applied across both the existing and
+// the appended examples, so repeated sync runs do not accumulate copies.
+//
+// Returns ErrArgsTooLarge if any example's Args exceeds MaxArgsDepth or MaxArgsKeys,
+// ErrDuplicateExample under DuplicatesReject, ErrExampleLimit if the combined
+// examples exceed the per-tool caps, ErrFrozen if the tool's documentation
+// has been frozen, ErrThrottled if WriteRateLimit is exceeded, or ErrPersist
+// if StoreOptions.Persister fails (the write is kept).
+func (s *InMemoryStore) AppendExamples(id string, examples []ToolExample) error {
+	if err := s.limiter.allow(""); err != nil {
+		return err
+	}
+
+	prepared, err := s.prepareExamples(examples)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return err
+	}
+
+	record, exists := s.docs[id]
+	if exists && record.frozen {
+		return fmt.Errorf("%w: %s", ErrFrozen, id)
+	}
+	if !exists {
+		record = &docRecord{}
+		s.docs[id] = record
+	}
+
+	added, err := s.dedupExamples(record.examples, prepared)
+	if err != nil {
+		return err
+	}
+	combined := make([]ToolExample, 0, len(record.examples)+len(added))
+	combined = append(combined, record.examples...)
+	combined = append(combined, added...)
+	if err := s.checkExampleLimits(combined); err != nil {
+		return err
+	}
+	changes := s.exampleChanges(record.examples, prepared)
+	record.examples = combined
+	s.stored(id, record)
+
+	return s.saved(ChangeAppendExamples, "", id, record, changes)
+}
+
+// dedupExamples applies the store's duplicate policy to incoming examples,
+// treating existing examples as already registered. It returns the incoming
+// examples that should be stored.
+func (s *InMemoryStore) dedupExamples(existing, incoming []ToolExample) ([]ToolExample, error) {
+	if s.duplicates == "" || s.duplicates == DuplicatesAllow {
+		return incoming, nil
+	}
+
+	seen := make(map[string]struct{}, len(existing)+len(incoming))
+	for _, ex := range existing {
+		seen[exampleKey(ex)] = struct{}{}
+	}
+
+	result := make([]ToolExample, 0, len(incoming))
+	for i, ex := range incoming {
+		key := exampleKey(ex)
+		if _, dup := seen[key]; dup {
+			if s.duplicates == DuplicatesReject {
+				return nil, fmt.Errorf("%w: example %d (%s)", ErrDuplicateExample, i, ex.Title)
+			}
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, ex)
+	}
+	return result, nil
+}
+
+// exampleKey returns the identity of an example for duplicate detection:
+// its Title plus its Args in canonical JSON form. encoding/json sorts map
+// keys and renders integral numbers identically regardless of Go type.
+func exampleKey(ex ToolExample) string {
+	data, err := json.Marshal(ex.Args)
+	if err != nil {
+		return ex.Title + "\x00" + fmt.Sprintf("%v", ex.Args)
+	}
+	return ex.Title + "\x00" + string(data)
+}
@@ -0,0 +1,85 @@
+package tooldocs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDuplicateExamples_Allow(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	ex := ToolExample{Title: "List", Args: map[string]any{"limit": 1}}
+
+	mustRegisterExamples(t, store, "ns:tool", []ToolExample{ex, ex})
+	got, err := store.ListExamples("ns:tool", 0)
+	if err != nil {
+		t.Fatalf("ListExamples failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("len(examples) = %d, want 2", len(got))
+	}
+}
+
+func TestDuplicateExamples_Collapse(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{DuplicateExamples: DuplicatesCollapse})
+
+	mustRegisterDoc(t, store, "ns:tool", DocEntry{Examples: []ToolExample{
+		{Title: "List", Args: map[string]any{"limit": 1}},
+		{Title: "List", Args: map[string]any{"limit": 1.0}, Description: "normalized duplicate"},
+		{Title: "List", Args: map[string]any{"limit": 2}},
+	}})
+
+	got, _ := store.ListExamples("ns:tool", 0)
+	if len(got) != 2 {
+		t.Fatalf("len(examples) = %d, want 2", len(got))
+	}
+
+	// Repeated sync runs append the same examples again
+	for i := 0; i < 3; i++ {
+		if err := store.AppendExamples("ns:tool", []ToolExample{
+			{Title: "List", Args: map[string]any{"limit": 2}},
+			{Title: "Get", Args: map[string]any{"id": "x"}},
+		}); err != nil {
+			t.Fatalf("AppendExamples failed: %v", err)
+		}
+	}
+
+	got, _ = store.ListExamples("ns:tool", 0)
+	if len(got) != 3 {
+		t.Errorf("len(examples) = %d, want 3", len(got))
+	}
+}
+
+func TestDuplicateExamples_Reject(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{DuplicateExamples: DuplicatesReject})
+	ex := ToolExample{Title: "List", Args: map[string]any{"tags": []string{"a"}}}
+
+	err := store.RegisterExamples("ns:tool", []ToolExample{ex, {Title: "List", Args: map[string]any{"tags": []any{"a"}}}})
+	if !errors.Is(err, ErrDuplicateExample) {
+		t.Fatalf("RegisterExamples error = %v, want ErrDuplicateExample", err)
+	}
+
+	mustRegisterExamples(t, store, "ns:tool", []ToolExample{ex})
+	if err := store.AppendExamples("ns:tool", []ToolExample{ex}); !errors.Is(err, ErrDuplicateExample) {
+		t.Fatalf("AppendExamples error = %v, want ErrDuplicateExample", err)
+	}
+}
+
+func TestAppendExamples(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterExamples(t, store, "ns:tool", []ToolExample{{Title: "First"}})
+
+	if err := store.AppendExamples("ns:tool", []ToolExample{{Title: "Second"}}); err != nil {
+		t.Fatalf("AppendExamples failed: %v", err)
+	}
+	got, _ := store.ListExamples("ns:tool", 0)
+	if len(got) != 2 || got[0].Title != "First" || got[1].Title != "Second" {
+		t.Errorf("examples = %+v", got)
+	}
+
+	if err := store.Freeze("ns:tool"); err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+	if err := store.AppendExamples("ns:tool", []ToolExample{{Title: "Third"}}); !errors.Is(err, ErrFrozen) {
+		t.Errorf("AppendExamples error = %v, want ErrFrozen", err)
+	}
+}
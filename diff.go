@@ -0,0 +1,249 @@
+package tooldocs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// BundleDiff describes the differences between two bundles.
+type BundleDiff struct {
+	// Added lists tool IDs present only in the new bundle, sorted.
+	Added []string `json:"added,omitempty"`
+
+	// Removed lists tool IDs present only in the old bundle, sorted.
+	Removed []string `json:"removed,omitempty"`
+
+	// Changed lists tools present in both bundles whose entries differ,
+	// sorted by ID.
+	Changed []ToolDiff `json:"changed,omitempty"`
+}
+
+// Empty reports whether the bundles were identical.
+func (d BundleDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// ToolDiff describes how a single tool's DocEntry changed.
+type ToolDiff struct {
+	// ID is the tool ID.
+	ID string `json:"id"`
+
+	// Fields lists the changed DocEntry fields by JSON name.
+	Fields []string `json:"fields"`
+
+	// OldSummary and NewSummary are set when the summary changed.
+	OldSummary string `json:"oldSummary,omitempty"`
+	NewSummary string `json:"newSummary,omitempty"`
+
+	// ExamplesAdded, ExamplesRemoved, and ExamplesChanged list example keys
+	// (ID when set, otherwise Title) by kind of change.
+	ExamplesAdded   []string `json:"examplesAdded,omitempty"`
+	ExamplesRemoved []string `json:"examplesRemoved,omitempty"`
+	ExamplesChanged []string `json:"examplesChanged,omitempty"`
+}
+
+// DiffBundles compares two bundles at tool, field, and example granularity.
+func DiffBundles(oldBundle, newBundle Bundle) BundleDiff {
+	var d BundleDiff
+
+	for id := range newBundle.Tools {
+		if _, ok := oldBundle.Tools[id]; !ok {
+			d.Added = append(d.Added, id)
+		}
+	}
+	for id, before := range oldBundle.Tools {
+		after, ok := newBundle.Tools[id]
+		if !ok {
+			d.Removed = append(d.Removed, id)
+			continue
+		}
+		if td, changed := diffEntry(id, before, after); changed {
+			d.Changed = append(d.Changed, td)
+		}
+	}
+
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+	sort.Slice(d.Changed, func(i, j int) bool { return d.Changed[i].ID < d.Changed[j].ID })
+	return d
+}
+
+// diffEntry compares two entries for the same tool.
+func diffEntry(id string, before, after DocEntry) (ToolDiff, bool) {
+	fields := entryFieldChanges(before, after)
+	if len(fields) == 0 {
+		return ToolDiff{}, false
+	}
+
+	td := ToolDiff{ID: id, Fields: fields}
+	if before.Summary != after.Summary {
+		td.OldSummary = before.Summary
+		td.NewSummary = after.Summary
+	}
+
+	oldExamples := examplesByKey(before.Examples)
+	newExamples := examplesByKey(after.Examples)
+	for _, ex := range after.Examples {
+		key := exampleDiffKey(ex)
+		prev, ok := oldExamples[key]
+		switch {
+		case !ok:
+			td.ExamplesAdded = append(td.ExamplesAdded, key)
+		case !examplesEqual(prev, ex):
+			td.ExamplesChanged = append(td.ExamplesChanged, key)
+		}
+	}
+	for _, ex := range before.Examples {
+		key := exampleDiffKey(ex)
+		if _, ok := newExamples[key]; !ok {
+			td.ExamplesRemoved = append(td.ExamplesRemoved, key)
+		}
+	}
+
+	return td, true
+}
+
+// exampleDiffKey identifies an example across bundles: its ID, or its Title
+// when no ID is set.
+func exampleDiffKey(ex ToolExample) string {
+	if ex.ID != "" {
+		return ex.ID
+	}
+	return ex.Title
+}
+
+// examplesByKey indexes examples by exampleDiffKey. Later duplicates win.
+func examplesByKey(examples []ToolExample) map[string]ToolExample {
+	m := make(map[string]ToolExample, len(examples))
+	for _, ex := range examples {
+		m[exampleDiffKey(ex)] = ex
+	}
+	return m
+}
+
+// ExampleDiff describes how an example re-registered under the same ID
+// changed, so reviewers see the edit rather than the whole entry. Values
+// are copies, detached from the examples compared.
+type ExampleDiff struct {
+	// ID is the example ID.
+	ID string `json:"id"`
+
+	// Args lists the changed argument values, sorted by path.
+	Args []ArgChange `json:"args,omitempty"`
+
+	// OldDescription and NewDescription are set when the description
+	// changed.
+	OldDescription string `json:"oldDescription,omitempty"`
+	NewDescription string `json:"newDescription,omitempty"`
+}
+
+// ArgChange is one changed value in example Args. Path addresses it as
+// "filter.state" or "labels[1]". Old is nil for added values and New is
+// nil for removed ones.
+type ArgChange struct {
+	Path string `json:"path"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// DiffExamples compares the Args and Description of examples present in
+// both lists under the same ID. Examples without an ID, and examples added
+// or removed, are not reported. Results follow the order of after.
+func DiffExamples(before, after []ToolExample) []ExampleDiff {
+	previous := make(map[string]ToolExample, len(before))
+	for _, ex := range before {
+		if ex.ID != "" {
+			previous[ex.ID] = ex
+		}
+	}
+
+	var diffs []ExampleDiff
+	for _, ex := range after {
+		prev, ok := previous[ex.ID]
+		if ex.ID == "" || !ok {
+			continue
+		}
+		d := ExampleDiff{ID: ex.ID}
+		diffArgValue(&d.Args, "", prev.Args, ex.Args)
+		sort.Slice(d.Args, func(i, j int) bool { return d.Args[i].Path < d.Args[j].Path })
+		if prev.Description != ex.Description {
+			d.OldDescription = prev.Description
+			d.NewDescription = ex.Description
+		}
+		if len(d.Args) > 0 || prev.Description != ex.Description {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+// diffArgValue appends the changes between two Args values at path,
+// descending into objects and arrays.
+func diffArgValue(changes *[]ArgChange, path string, before, after any) {
+	switch b := before.(type) {
+	case map[string]any:
+		a, ok := after.(map[string]any)
+		if !ok {
+			break
+		}
+		for k, bv := range b {
+			av, ok := a[k]
+			if !ok {
+				*changes = append(*changes, ArgChange{Path: joinArgPath(path, k), Old: deepCopyValue(bv)})
+				continue
+			}
+			diffArgValue(changes, joinArgPath(path, k), bv, av)
+		}
+		for k, av := range a {
+			if _, ok := b[k]; !ok {
+				*changes = append(*changes, ArgChange{Path: joinArgPath(path, k), New: deepCopyValue(av)})
+			}
+		}
+		return
+	case []any:
+		a, ok := after.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < max(len(a), len(b)); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(a):
+				*changes = append(*changes, ArgChange{Path: p, Old: deepCopyValue(b[i])})
+			case i >= len(b):
+				*changes = append(*changes, ArgChange{Path: p, New: deepCopyValue(a[i])})
+			default:
+				diffArgValue(changes, p, b[i], a[i])
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, ArgChange{Path: path, Old: deepCopyValue(before), New: deepCopyValue(after)})
+	}
+}
+
+// joinArgPath appends an object key to an Args path.
+func joinArgPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// copyExampleDiffs deep-copies diffs, including the Args values.
+func copyExampleDiffs(diffs []ExampleDiff) []ExampleDiff {
+	if diffs == nil {
+		return nil
+	}
+	out := make([]ExampleDiff, len(diffs))
+	for i, d := range diffs {
+		out[i] = d
+		out[i].Args = make([]ArgChange, len(d.Args))
+		for j, c := range d.Args {
+			out[i].Args[j] = ArgChange{Path: c.Path, Old: deepCopyValue(c.Old), New: deepCopyValue(c.New)}
+		}
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package tooldocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// ReadDocsDir reads tool docs maintained as Markdown files in fsys, laid
+// out as <namespace>/<tool>.md (tools without a namespace sit at the top
+// level as <tool>.md) and returns them by tool ID ("<namespace>:<tool>").
+// Files not ending in .md are ignored, as are directories and files whose
+// names start with "." or "_" (e.g. drafts and templates).
+//
+// A file may open with YAML front matter between "---" lines, holding
+// DocEntry fields under their JSON names (summary, examples,
+// externalRefs, ...). The Markdown body after it becomes the entry's
+// Notes:
+//
+//	---
+//	summary: Search issues and pull requests
+//	examples:
+//	  - title: Open bugs
+//	    args:
+//	      q: is:open label:bug
+//	externalRefs:
+//	  - https://docs.github.com/en/search-github
+//	---
+//	Results are capped at 1000; narrow the query instead of paging.
+//
+// Returns an error naming the file for unreadable files, directories
+// nested deeper than a namespace, malformed or unknown front matter
+// fields, and notes set in both the front matter and the body.
+func ReadDocsDir(fsys fs.FS) (map[string]DocEntry, error) {
+	entries := make(map[string]DocEntry)
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+		base := d.Name()
+		if strings.HasPrefix(base, ".") || strings.HasPrefix(base, "_") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		depth := strings.Count(name, "/")
+		if d.IsDir() {
+			if depth > 0 {
+				return fmt.Errorf("%s: docs directories are nested one level deep (<namespace>/<tool>.md)", name)
+			}
+			return nil
+		}
+		tool, ok := strings.CutSuffix(base, ".md")
+		if !ok || tool == "" {
+			return nil
+		}
+		id := tool
+		if depth == 1 {
+			id = path.Dir(name) + ":" + tool
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		entry, err := parseDocFile(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		entries[id] = entry
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read docs dir: %w", err)
+	}
+	return entries, nil
+}
+
+// LoadDir reads the docs directory at dir (see ReadDocsDir) and imports
+// its tools with opts, so writers can maintain docs as Markdown files.
+//
+// Returns ReadDocsDir's errors or Import's errors.
+func (s *InMemoryStore) LoadDir(dir string, opts ImportOptions) (ImportReport, error) {
+	entries, err := ReadDocsDir(os.DirFS(dir))
+	if err != nil {
+		return ImportReport{}, err
+	}
+	return s.Import(entries, opts)
+}
+
+// parseDocFile splits a doc file into its front matter, decoded into a
+// DocEntry, and its body, used as Notes.
+func parseDocFile(data []byte) (DocEntry, error) {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	front, body, err := splitFrontMatter(string(data))
+	if err != nil {
+		return DocEntry{}, err
+	}
+
+	var entry DocEntry
+	if strings.TrimSpace(front) != "" {
+		raw, err := yamlToJSON([]byte(front))
+		if err != nil {
+			return DocEntry{}, fmt.Errorf("front matter: %w", err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&entry); err != nil {
+			return DocEntry{}, fmt.Errorf("front matter: %w", err)
+		}
+	}
+	if body = strings.TrimSpace(body); body != "" {
+		if entry.Notes != "" {
+			return DocEntry{}, errors.New("notes are set in both the front matter and the body")
+		}
+		entry.Notes = body
+	}
+	return entry, nil
+}
+
+// splitFrontMatter returns the front matter of a doc file, without its
+// "---" delimiters, and the body after it. Files not opening with "---"
+// are all body.
+func splitFrontMatter(text string) (front, body string, err error) {
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if !ok {
+		return "", text, nil
+	}
+	if strings.HasPrefix(rest, "---\n") || rest == "---" {
+		return "", strings.TrimPrefix(rest, "---"), nil
+	}
+	end := strings.Index(rest, "\n---\n")
+	if end < 0 {
+		if !strings.HasSuffix(rest, "\n---") {
+			return "", "", errors.New("front matter is not closed with ---")
+		}
+		return strings.TrimSuffix(rest, "\n---"), "", nil
+	}
+	return rest[:end], rest[end+len("\n---\n"):], nil
+}
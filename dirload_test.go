@@ -0,0 +1,107 @@
+package tooldocs
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestReadDocsDir(t *testing.T) {
+	fsys := fstest.MapFS{
+		"github/search.md": {Data: []byte(`---
+summary: Search issues and pull requests
+examples:
+  - title: Open bugs
+    args:
+      q: is:open label:bug
+externalRefs:
+  - https://docs.github.com/en/search-github
+---
+
+Results are capped at 1000; narrow the query instead of paging.
+`)},
+		"github/list.md":    {Data: []byte("---\r\nsummary: List issues\r\n---\r\n")},
+		"ping.md":           {Data: []byte("Checks connectivity.\n")},
+		"github/README.txt": {Data: []byte("not a doc")},
+		"github/_draft.md":  {Data: []byte("---\nsumary: typo\n---\n")},
+		".git/config.md":    {Data: []byte("ignored")},
+	}
+	entries, err := ReadDocsDir(fsys)
+	if err != nil {
+		t.Fatalf("ReadDocsDir failed: %v", err)
+	}
+	if got := strings.Join(slices.Sorted(maps.Keys(entries)), ","); got != "github:list,github:search,ping" {
+		t.Fatalf("IDs = %s", got)
+	}
+	search := entries["github:search"]
+	if search.Summary != "Search issues and pull requests" {
+		t.Errorf("Summary = %q", search.Summary)
+	}
+	if len(search.Examples) != 1 || search.Examples[0].Args["q"] != "is:open label:bug" {
+		t.Errorf("Examples = %+v", search.Examples)
+	}
+	if len(search.ExternalRefs) != 1 {
+		t.Errorf("ExternalRefs = %v", search.ExternalRefs)
+	}
+	if search.Notes != "Results are capped at 1000; narrow the query instead of paging." {
+		t.Errorf("Notes = %q", search.Notes)
+	}
+	if entries["github:list"].Summary != "List issues" {
+		t.Errorf("CRLF file Summary = %q", entries["github:list"].Summary)
+	}
+	if entries["ping"].Notes != "Checks connectivity." {
+		t.Errorf("body-only file Notes = %q", entries["ping"].Notes)
+	}
+}
+
+func TestReadDocsDir_Errors(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"unknown field": {"ns/a.md": {Data: []byte("---\nsumary: typo\n---\n")}},
+		"unclosed":      {"ns/a.md": {Data: []byte("---\nsummary: a\n")}},
+		"bad yaml":      {"ns/a.md": {Data: []byte("---\nsummary: [\n---\n")}},
+		"two notes":     {"ns/a.md": {Data: []byte("---\nnotes: front\n---\nbody\n")}},
+		"nested":        {"ns/sub/a.md": {Data: []byte("body")}},
+	}
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ReadDocsDir(fsys); err == nil {
+				t.Error("ReadDocsDir succeeded")
+			}
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "billing"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	doc := "---\nsummary: Create an invoice\n---\nAmounts are in cents.\n"
+	if err := os.WriteFile(filepath.Join(dir, "billing", "create_invoice.md"), []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewInMemoryStore(StoreOptions{})
+	report, err := store.LoadDir(dir, ImportOptions{Source: "docs-dir"})
+	if err != nil {
+		t.Fatalf("LoadDir failed: %v", err)
+	}
+	if report.Totals.Created != 1 {
+		t.Errorf("Totals = %+v, want 1 created", report.Totals)
+	}
+	stored, err := store.GetDocEntry("billing:create_invoice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Entry.Notes != "Amounts are in cents." || stored.Entry.Source != "docs-dir" {
+		t.Errorf("entry = %+v", stored.Entry)
+	}
+
+	if _, err := store.LoadDir(filepath.Join(dir, "missing"), ImportOptions{}); err == nil {
+		t.Error("LoadDir of a missing directory succeeded")
+	}
+}
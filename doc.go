@@ -22,11 +22,15 @@
 //
 // # Error Handling
 //
-// The package defines four error values:
+// The package defines the following error values:
 //   - ErrNotFound: Tool ID not found in index or docs
 //   - ErrNoTool: Schema/full requested but tool not in index (docs may exist)
 //   - ErrInvalidDetail: Invalid DetailLevel value
 //   - ErrArgsTooLarge: Example Args exceeds depth (MaxArgsDepth) or size (MaxArgsKeys) caps
+//   - ErrFrozen: Write rejected because the tool's docs were frozen via Freeze
+//   - ErrUnauthorized: Full tier of a Sensitive tool requested without authorization
+//   - ErrLookupTimeout: Index or ToolResolver did not answer within StoreOptions.LookupTimeout
+//   - ErrHookPanic: A user-supplied hook (e.g. ToolResolver) panicked; see HookPanicError
 //
 // Use errors.Is() to check error types.
 //
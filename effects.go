@@ -0,0 +1,91 @@
+package tooldocs
+
+// EffectKind classifies an Effect.
+type EffectKind string
+
+const (
+	// EffectCreates means the tool creates a resource.
+	EffectCreates EffectKind = "creates"
+
+	// EffectUpdates means the tool modifies an existing resource.
+	EffectUpdates EffectKind = "updates"
+
+	// EffectDeletes means the tool removes a resource.
+	EffectDeletes EffectKind = "deletes"
+
+	// EffectSends means the tool communicates outside the system (email,
+	// chat message, webhook, notification).
+	EffectSends EffectKind = "sends"
+
+	// EffectCharges means the tool incurs cost (payment, paid API usage).
+	EffectCharges EffectKind = "charges"
+)
+
+// Effect declares a state change a tool causes, so planners can verify a
+// plan before executing it. Effects are returned at DetailSchema and
+// DetailFull alongside Capabilities.
+type Effect struct {
+	// Kind classifies the state change.
+	Kind EffectKind `json:"kind"`
+
+	// Resource names what is affected (e.g. "github:issue", "email").
+	Resource string `json:"resource,omitempty"`
+
+	// Description states the effect in one sentence.
+	// Maximum length: MaxDescriptionLen (300 chars).
+	Description string `json:"description,omitempty"`
+
+	// Irreversible marks effects that cannot be undone. Any irreversible
+	// effect sets Capabilities.IsDestructive.
+	Irreversible bool `json:"irreversible,omitempty"`
+}
+
+// String renders the effect on one line, e.g.
+// "deletes github:repo (irreversible): removes all issues".
+func (e Effect) String() string {
+	label := string(e.Kind)
+	if e.Resource != "" {
+		label += " " + e.Resource
+	}
+	if e.Irreversible {
+		label += " (irreversible)"
+	}
+	if e.Description == "" {
+		return label
+	}
+	return label + ": " + e.Description
+}
+
+// hasIrreversibleEffect reports whether any effect is irreversible.
+func hasIrreversibleEffect(effects []Effect) bool {
+	for _, e := range effects {
+		if e.Irreversible {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateEffects returns a copy of effects with descriptions cut to
+// MaxDescriptionLen.
+func truncateEffects(effects []Effect) []Effect {
+	if effects == nil {
+		return nil
+	}
+	result := make([]Effect, len(effects))
+	for i, e := range effects {
+		e.Description = truncateString(e.Description, MaxDescriptionLen)
+		result[i] = e
+	}
+	return result
+}
+
+// internEffects interns the text of effects in place and returns it.
+func internEffects(effects []Effect) []Effect {
+	for i := range effects {
+		effects[i].Kind = EffectKind(intern(string(effects[i].Kind)))
+		effects[i].Resource = intern(effects[i].Resource)
+		effects[i].Description = intern(effects[i].Description)
+	}
+	return effects
+}
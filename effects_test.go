@@ -0,0 +1,91 @@
+package tooldocs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestEffects_SchemaLevel(t *testing.T) {
+	tool := makeToolWithSchema("delete_repo", "gh", "Delete a repository", map[string]any{"type": "object"})
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) { return &tool, nil },
+	})
+	effects := []Effect{
+		{Kind: EffectDeletes, Resource: "github:repo", Irreversible: true},
+		{Kind: EffectSends, Resource: "email", Description: "Notifies collaborators."},
+	}
+	mustRegisterDoc(t, store, "gh:delete_repo", DocEntry{Summary: "Delete a repository", Effects: effects})
+
+	for _, level := range []DetailLevel{DetailSchema, DetailFull} {
+		doc, err := store.DescribeTool("gh:delete_repo", level)
+		if err != nil {
+			t.Fatalf("DescribeTool(%s) failed: %v", level, err)
+		}
+		if !reflect.DeepEqual(doc.Effects, effects) {
+			t.Errorf("%s: Effects = %+v, want %+v", level, doc.Effects, effects)
+		}
+		if !doc.Capabilities.IsDestructive {
+			t.Errorf("%s: IsDestructive = false, want true for irreversible effect", level)
+		}
+	}
+
+	summary, err := store.DescribeTool("gh:delete_repo", DetailSummary)
+	if err != nil {
+		t.Fatalf("DescribeTool(summary) failed: %v", err)
+	}
+	if summary.Effects != nil {
+		t.Errorf("summary Effects = %+v, want nil", summary.Effects)
+	}
+}
+
+func TestEffects_ReversibleNotDestructive(t *testing.T) {
+	tool := makeToolWithSchema("create_issue", "gh", "Create an issue", map[string]any{"type": "object"})
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) { return &tool, nil },
+	})
+	mustRegisterDoc(t, store, "gh:create_issue", DocEntry{Effects: []Effect{{Kind: EffectCreates, Resource: "github:issue"}}})
+
+	doc, err := store.DescribeTool("gh:create_issue", DetailSchema)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if doc.Capabilities.IsDestructive {
+		t.Error("IsDestructive = true, want false")
+	}
+}
+
+func TestEffects_TruncateMergeImport(t *testing.T) {
+	long := Effect{Kind: EffectCharges, Description: strings.Repeat("x", MaxDescriptionLen+5)}
+	if got := len(DocEntry{Effects: []Effect{long}}.ValidateAndTruncate().Effects[0].Description); got != MaxDescriptionLen {
+		t.Errorf("description length = %d, want %d", got, MaxDescriptionLen)
+	}
+
+	creates := Effect{Kind: EffectCreates, Resource: "invoice"}
+	sends := Effect{Kind: EffectSends, Resource: "email"}
+	merged := MergeDocEntries(DocEntry{Effects: []Effect{creates}}, DocEntry{Effects: []Effect{sends, creates}}, MergePolicy{})
+	if want := []Effect{creates, sends}; !reflect.DeepEqual(merged.Effects, want) {
+		t.Errorf("merged Effects = %+v, want %+v", merged.Effects, want)
+	}
+	if fields := entryFieldChanges(DocEntry{}, DocEntry{Effects: []Effect{sends}}); !reflect.DeepEqual(fields, []string{"effects"}) {
+		t.Errorf("entryFieldChanges = %v, want [effects]", fields)
+	}
+}
+
+func TestEffect_String(t *testing.T) {
+	tests := []struct {
+		e    Effect
+		want string
+	}{
+		{Effect{Kind: EffectDeletes, Resource: "github:repo", Irreversible: true, Description: "removes all issues"}, "deletes github:repo (irreversible): removes all issues"},
+		{Effect{Kind: EffectSends, Resource: "email"}, "sends email"},
+		{Effect{Kind: EffectCharges}, "charges"},
+	}
+	for _, tt := range tests {
+		if got := tt.e.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package tooldocs
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrDecrypt is returned when sealed content cannot be decrypted or does not
+// belong to the entry it is attached to.
+var ErrDecrypt = errors.New("decrypt failed")
+
+// Encryptor encrypts documentation content at rest. Persistent backends
+// apply it to an entry's free-text fields before writing (see SealBundle
+// and SealEntry) so files and databases in shared storage do not expose
+// operational details in plaintext. FileStore, boltstore, and the change
+// log use the one in StoreOptions.Encryptor.
+//
+// Implementations must be safe for concurrent use and must authenticate
+// ciphertext, returning an error from Decrypt if it was tampered with.
+type Encryptor interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// aesGCM is an Encryptor using AES-GCM with a random nonce prepended to each
+// ciphertext.
+type aesGCM struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMEncryptor returns an Encryptor using AES-GCM with the given key,
+// which must be 16, 24, or 32 bytes (AES-128, AES-192, or AES-256).
+func NewAESGCMEncryptor(key []byte) (Encryptor, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("aes-gcm encryptor: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("aes-gcm encryptor: %w", err)
+	}
+	return &aesGCM{aead: aead}, nil
+}
+
+// Encrypt seals plaintext under a fresh random nonce.
+func (e *aesGCM) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, e.aead.NonceSize(), e.aead.NonceSize()+len(plaintext)+e.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return e.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens ciphertext produced by Encrypt.
+func (e *aesGCM) Decrypt(ciphertext []byte) ([]byte, error) {
+	n := e.aead.NonceSize()
+	if len(ciphertext) < n {
+		return nil, ErrDecrypt
+	}
+	plaintext, err := e.aead.Open(nil, ciphertext[:n], ciphertext[n:], nil)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// sealedContent is the plaintext of a sealed entry: the entry's free-text
+// fields. ID binds the ciphertext to its tool so sealed blobs cannot be
+// swapped between entries.
+type sealedContent struct {
+	ID            string         `json:"id"`
+	ShortNotes    string         `json:"shortNotes,omitempty"`
+	Notes         string         `json:"notes,omitempty"`
+	Sections      []DocSection   `json:"sections,omitempty"`
+	Examples      []ToolExample  `json:"examples,omitempty"`
+	Prerequisites []Prerequisite `json:"prerequisites,omitempty"`
+	Effects       []Effect       `json:"effects,omitempty"`
+	Dependencies  []Dependency   `json:"dependencies,omitempty"`
+	ExternalRefs  []string       `json:"externalRefs,omitempty"`
+}
+
+// SealBundle returns a copy of b with each entry's free-text fields (short
+// notes, notes, sections, examples, prerequisites, effects, dependencies,
+// and external refs) encrypted into b.Sealed and cleared from the entry.
+// Titles, summaries, and the remaining structured fields stay readable so
+// sealed bundles can still be listed and diffed. Entries without sealed
+// fields are not sealed.
+//
+// Returns an error if b is already sealed.
+func SealBundle(b Bundle, enc Encryptor) (Bundle, error) {
+	if len(b.Sealed) > 0 {
+		return Bundle{}, errors.New("seal bundle: bundle is already sealed")
+	}
+	out := Bundle{Version: b.Version, Tools: make(map[string]DocEntry, len(b.Tools)), State: b.State}
+	for id, entry := range b.Tools {
+		entry, ciphertext, err := SealEntry(id, entry, enc)
+		if err != nil {
+			return Bundle{}, fmt.Errorf("seal bundle: %w", err)
+		}
+		if ciphertext != nil {
+			if out.Sealed == nil {
+				out.Sealed = make(map[string][]byte)
+			}
+			out.Sealed[id] = ciphertext
+		}
+		out.Tools[id] = entry
+	}
+	return out, nil
+}
+
+// SealEntry encrypts entry's free-text fields (see SealBundle), bound to
+// id, and returns the entry without them along with the ciphertext, for
+// backends that seal one record at a time. Entries without sealed fields
+// are returned as is, with nil ciphertext.
+func SealEntry(id string, entry DocEntry, enc Encryptor) (DocEntry, []byte, error) {
+	content := sealedContent{
+		ID:            id,
+		ShortNotes:    entry.ShortNotes,
+		Notes:         entry.Notes,
+		Sections:      entry.Sections,
+		Examples:      entry.Examples,
+		Prerequisites: entry.Prerequisites,
+		Effects:       entry.Effects,
+		Dependencies:  entry.Dependencies,
+		ExternalRefs:  entry.ExternalRefs,
+	}
+	if content.isEmpty() {
+		return entry, nil, nil
+	}
+	plaintext, err := json.Marshal(content)
+	if err != nil {
+		return DocEntry{}, nil, fmt.Errorf("%s: %w", id, err)
+	}
+	ciphertext, err := enc.Encrypt(plaintext)
+	if err != nil {
+		return DocEntry{}, nil, fmt.Errorf("%s: %w", id, err)
+	}
+	entry.ShortNotes = ""
+	entry.Notes = ""
+	entry.Sections = nil
+	entry.Examples = nil
+	entry.Prerequisites = nil
+	entry.Effects = nil
+	entry.Dependencies = nil
+	entry.ExternalRefs = nil
+	return entry, ciphertext, nil
+}
+
+// isEmpty reports whether c holds no sealed fields.
+func (c sealedContent) isEmpty() bool {
+	return c.ShortNotes == "" && c.Notes == "" && len(c.Sections) == 0 &&
+		len(c.Examples) == 0 && len(c.Prerequisites) == 0 && len(c.Effects) == 0 &&
+		len(c.Dependencies) == 0 && len(c.ExternalRefs) == 0
+}
+
+// OpenEntry reverses SealEntry, decrypting sealed fields back into entry. A
+// nil ciphertext returns entry as is. Fields absent from the ciphertext
+// keep entry's value, so content sealed before a field was covered still
+// opens whole.
+//
+// Returns ErrDecrypt if the ciphertext fails to decrypt or was sealed for a
+// different tool ID.
+func OpenEntry(id string, entry DocEntry, ciphertext []byte, enc Encryptor) (DocEntry, error) {
+	if ciphertext == nil {
+		return entry, nil
+	}
+	plaintext, err := enc.Decrypt(ciphertext)
+	if err != nil {
+		return DocEntry{}, fmt.Errorf("%w: %s: %v", ErrDecrypt, id, err)
+	}
+	var content sealedContent
+	if err := json.Unmarshal(plaintext, &content); err != nil {
+		return DocEntry{}, fmt.Errorf("%w: %s: %v", ErrDecrypt, id, err)
+	}
+	if content.ID != id {
+		return DocEntry{}, fmt.Errorf("%w: %s: sealed for %q", ErrDecrypt, id, content.ID)
+	}
+	if content.ShortNotes != "" {
+		entry.ShortNotes = content.ShortNotes
+	}
+	if content.Notes != "" {
+		entry.Notes = content.Notes
+	}
+	if content.Sections != nil {
+		entry.Sections = content.Sections
+	}
+	if content.Examples != nil {
+		entry.Examples = content.Examples
+	}
+	if content.Prerequisites != nil {
+		entry.Prerequisites = content.Prerequisites
+	}
+	if content.Effects != nil {
+		entry.Effects = content.Effects
+	}
+	if content.Dependencies != nil {
+		entry.Dependencies = content.Dependencies
+	}
+	if content.ExternalRefs != nil {
+		entry.ExternalRefs = content.ExternalRefs
+	}
+	return entry, nil
+}
+
+// OpenBundle reverses SealBundle, decrypting sealed fields back into their
+// entries. Bundles without sealed content are returned as is.
+//
+// Returns ErrDecrypt if any sealed entry fails to decrypt or was sealed for
+// a different tool ID.
+func OpenBundle(b Bundle, enc Encryptor) (Bundle, error) {
+	if len(b.Sealed) == 0 {
+		return b, nil
+	}
+	out := Bundle{Version: b.Version, Tools: make(map[string]DocEntry, len(b.Tools)), State: b.State}
+	for id, entry := range b.Tools {
+		out.Tools[id] = entry
+	}
+	for id, ciphertext := range b.Sealed {
+		entry, err := OpenEntry(id, out.Tools[id], ciphertext, enc)
+		if err != nil {
+			return Bundle{}, err
+		}
+		out.Tools[id] = entry
+	}
+	return out, nil
+}
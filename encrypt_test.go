@@ -0,0 +1,118 @@
+package tooldocs
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testEncryptor(t *testing.T) Encryptor {
+	t.Helper()
+	enc, err := NewAESGCMEncryptor(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatalf("NewAESGCMEncryptor failed: %v", err)
+	}
+	return enc
+}
+
+func TestAESGCMEncryptor(t *testing.T) {
+	enc := testEncryptor(t)
+
+	c1, err := enc.Encrypt([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, _ := enc.Encrypt([]byte("secret"))
+	if bytes.Equal(c1, c2) {
+		t.Error("ciphertexts should use distinct nonces")
+	}
+	plain, err := enc.Decrypt(c1)
+	if err != nil || string(plain) != "secret" {
+		t.Errorf("Decrypt = %q, %v", plain, err)
+	}
+
+	c1[len(c1)-1] ^= 1
+	if _, err := enc.Decrypt(c1); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("tampered Decrypt err = %v, want ErrDecrypt", err)
+	}
+	if _, err := enc.Decrypt([]byte{1}); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("short Decrypt err = %v, want ErrDecrypt", err)
+	}
+	if _, err := NewAESGCMEncryptor([]byte("short")); err == nil {
+		t.Error("expected error for invalid key size")
+	}
+}
+
+func TestSealBundle_RoundTrip(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{
+		Summary:       "Public summary",
+		ShortNotes:    "Paged by ops.internal",
+		Notes:         "Internal runbook at https://wiki.internal/x",
+		Sections:      []DocSection{{Title: "Escalation", Body: "Call oncall.internal"}},
+		Examples:      []ToolExample{{ID: "ex", Title: "Example", Args: map[string]any{"host": "db.internal"}}},
+		Prerequisites: []Prerequisite{{Kind: PrerequisiteSetup, Description: "Token from vault.internal"}},
+		ExternalRefs:  []string{"https://runbooks.internal/a"},
+	})
+	mustRegisterDoc(t, store, "ns:b", DocEntry{Summary: "Summary only"})
+	enc := testEncryptor(t)
+
+	sealed, err := SealBundle(store.Bundle(), enc)
+	if err != nil {
+		t.Fatalf("SealBundle failed: %v", err)
+	}
+	if len(sealed.Sealed) != 1 {
+		t.Errorf("Sealed = %d entries, want 1 (entries without notes/examples stay plain)", len(sealed.Sealed))
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBundle(&buf, sealed); err != nil {
+		t.Fatal(err)
+	}
+	for _, leak := range []string{"ops.internal", "wiki.internal", "oncall.internal", "db.internal", "vault.internal", "runbooks.internal"} {
+		if strings.Contains(buf.String(), leak) {
+			t.Errorf("sealed bundle leaks %q", leak)
+		}
+	}
+	if !strings.Contains(buf.String(), "Public summary") {
+		t.Error("summaries should stay readable")
+	}
+
+	read, err := ReadBundle(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opened, err := OpenBundle(read, enc)
+	if err != nil {
+		t.Fatalf("OpenBundle failed: %v", err)
+	}
+	if d := DiffBundles(store.Bundle(), opened); !d.Empty() {
+		t.Errorf("round trip changed bundle: %+v", d)
+	}
+
+	if _, err := SealBundle(sealed, enc); err == nil {
+		t.Error("expected error sealing an already sealed bundle")
+	}
+}
+
+func TestOpenBundle_Errors(t *testing.T) {
+	enc := testEncryptor(t)
+	sealed, err := SealBundle(Bundle{Tools: map[string]DocEntry{
+		"ns:a": {Notes: "a"},
+		"ns:b": {Notes: "b"},
+	}}, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, _ := NewAESGCMEncryptor(bytes.Repeat([]byte{9}, 32))
+	if _, err := OpenBundle(sealed, other); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("wrong key err = %v, want ErrDecrypt", err)
+	}
+
+	sealed.Sealed["ns:a"], sealed.Sealed["ns:b"] = sealed.Sealed["ns:b"], sealed.Sealed["ns:a"]
+	if _, err := OpenBundle(sealed, enc); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("swapped blobs err = %v, want ErrDecrypt", err)
+	}
+}
@@ -0,0 +1,310 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync/atomic"
+)
+
+// ErrExperimentNotFound is returned when a named experiment, or a variant
+// of it, is not registered.
+var ErrExperimentNotFound = errors.New("experiment not found")
+
+// ControlVariant names the arm of every experiment that is served the
+// tool's registered documentation.
+const ControlVariant = "control"
+
+// Experiment A/B tests alternative documentation for one tool, e.g. whether
+// an extra example or rewritten notes improves downstream call success.
+//
+// While an experiment runs, DescribeTool and ListExamples serve each
+// enrolled caller either the registered doc (ControlVariant) or one of the
+// variant docs, chosen by StoreOptions.AssignVariant. A tool can be in at
+// most one experiment at a time.
+type Experiment struct {
+	// Name identifies the experiment.
+	Name string `json:"name"`
+
+	// ToolID is the tool whose documentation is varied.
+	ToolID string `json:"toolId"`
+
+	// Variants maps variant names to the complete DocEntry served to
+	// callers assigned that variant; it replaces the registered doc rather
+	// than overlaying it (use MergeDocEntries to derive one). Names must be
+	// non-empty and must not be ControlVariant. Entries are validated as
+	// for RegisterDoc. A variant cannot clear the registered doc's
+	// Sensitive flag.
+	Variants map[string]DocEntry `json:"variants"`
+}
+
+// Assignment identifies the experiment arm a ToolDoc was rendered from.
+// Report call outcomes for it with InMemoryStore.RecordOutcome.
+type Assignment struct {
+	Experiment string `json:"experiment"`
+	Variant    string `json:"variant"`
+}
+
+// Exposure records one read served from an experiment arm. It is passed
+// to StoreOptions.OnExposure.
+type Exposure struct {
+	Assignment
+	ToolID string      `json:"toolId"`
+	Level  DetailLevel `json:"level"`
+
+	// Subject is the ExperimentSubject of the read's context, if any.
+	Subject string `json:"subject,omitempty"`
+
+	// TraceID is the TraceID of the read's context, if any.
+	TraceID string `json:"traceId,omitempty"`
+}
+
+// VariantResult aggregates the exposures and reported outcomes of one
+// experiment arm.
+type VariantResult struct {
+	Variant   string `json:"variant"`
+	Exposures int64  `json:"exposures"`
+	Successes int64  `json:"successes"`
+	Failures  int64  `json:"failures"`
+}
+
+// SuccessRate returns Successes / (Successes + Failures), or 0 when no
+// outcomes were reported.
+func (r VariantResult) SuccessRate() float64 {
+	total := r.Successes + r.Failures
+	if total == 0 {
+		return 0
+	}
+	return float64(r.Successes) / float64(total)
+}
+
+type experimentSubjectKey struct{}
+
+// WithExperimentSubject returns a context identifying the caller (an agent,
+// session, or user ID) for experiment assignment. The default assignment
+// hashes the subject, so a subject always sees the same variant of an
+// experiment; callers without a subject are served the control doc and are
+// not counted.
+func WithExperimentSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, experimentSubjectKey{}, subject)
+}
+
+// ExperimentSubject returns the subject carried by ctx, or "".
+func ExperimentSubject(ctx context.Context) string {
+	subject, _ := ctx.Value(experimentSubjectKey{}).(string)
+	return subject
+}
+
+// experiment is a running experiment. Everything but the counters is
+// immutable after registration, so reads use it without holding s.mu.
+type experiment struct {
+	name     string
+	toolID   string
+	variants []string // ControlVariant first, then sorted names
+	records  map[string]*docRecord
+	counts   map[string]*variantCounts
+}
+
+type variantCounts struct {
+	exposures atomic.Int64
+	successes atomic.Int64
+	failures  atomic.Int64
+}
+
+// RegisterExperiment starts an experiment.
+//
+// Returns an error if the name or tool ID is empty, no variant is given, a
+// variant name is empty or ControlVariant, the name is already running, or
+// the tool is already in another experiment. Variant entries fail as for
+// RegisterDoc (e.g. ErrArgsTooLarge, ErrExampleLimit).
+func (s *InMemoryStore) RegisterExperiment(exp Experiment) error {
+	if exp.Name == "" {
+		return errors.New("register experiment: name is required")
+	}
+	if exp.ToolID == "" {
+		return fmt.Errorf("register experiment %s: tool ID is required", exp.Name)
+	}
+	if len(exp.Variants) == 0 {
+		return fmt.Errorf("register experiment %s: at least one variant is required", exp.Name)
+	}
+
+	running := &experiment{
+		name:     exp.Name,
+		toolID:   exp.ToolID,
+		variants: []string{ControlVariant},
+		records:  make(map[string]*docRecord, len(exp.Variants)),
+		counts:   map[string]*variantCounts{ControlVariant: {}},
+	}
+	for name, entry := range exp.Variants {
+		if name == "" || name == ControlVariant {
+			return fmt.Errorf("register experiment %s: invalid variant name %q", exp.Name, name)
+		}
+		rec, err := s.prepareRecord(entry)
+		if err != nil {
+			return fmt.Errorf("register experiment %s: variant %s: %w", exp.Name, name, err)
+		}
+		running.variants = append(running.variants, name)
+		running.records[name] = rec
+		running.counts[name] = &variantCounts{}
+	}
+	sort.Strings(running.variants[1:])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return err
+	}
+	if other := s.experiments[exp.ToolID]; other != nil {
+		return fmt.Errorf("register experiment %s: tool %s is already in experiment %s", exp.Name, exp.ToolID, other.name)
+	}
+	if s.experimentNamed(exp.Name) != nil {
+		return fmt.Errorf("register experiment %s: already running", exp.Name)
+	}
+	s.experiments[exp.ToolID] = running
+	return nil
+}
+
+// StopExperiment ends an experiment, restoring the registered doc for all
+// callers, and returns its final results.
+//
+// Returns ErrExperimentNotFound if no experiment has that name.
+func (s *InMemoryStore) StopExperiment(name string) ([]VariantResult, error) {
+	s.mu.Lock()
+	if err := s.writable(); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	exp := s.experimentNamed(name)
+	if exp != nil {
+		delete(s.experiments, exp.toolID)
+	}
+	s.mu.Unlock()
+
+	if exp == nil {
+		return nil, fmt.Errorf("%w: %s", ErrExperimentNotFound, name)
+	}
+	return exp.results(), nil
+}
+
+// ExperimentResults returns the exposures and outcomes of each arm of a
+// running experiment, control first.
+//
+// Returns ErrExperimentNotFound if no experiment has that name.
+func (s *InMemoryStore) ExperimentResults(name string) ([]VariantResult, error) {
+	s.mu.RLock()
+	exp := s.experimentNamed(name)
+	s.mu.RUnlock()
+
+	if exp == nil {
+		return nil, fmt.Errorf("%w: %s", ErrExperimentNotFound, name)
+	}
+	return exp.results(), nil
+}
+
+// RecordOutcome reports whether a tool call made after reading the given
+// variant's documentation succeeded. Use ToolDoc.Experiment to learn the
+// arm a caller was served.
+//
+// Returns ErrExperimentNotFound if the experiment is not running or has no
+// such variant.
+func (s *InMemoryStore) RecordOutcome(experimentName, variant string, success bool) error {
+	s.mu.RLock()
+	exp := s.experimentNamed(experimentName)
+	s.mu.RUnlock()
+
+	if exp == nil {
+		return fmt.Errorf("%w: %s", ErrExperimentNotFound, experimentName)
+	}
+	counts := exp.counts[variant]
+	if counts == nil {
+		return fmt.Errorf("%w: %s has no variant %s", ErrExperimentNotFound, experimentName, variant)
+	}
+	if success {
+		counts.successes.Add(1)
+	} else {
+		counts.failures.Add(1)
+	}
+	return nil
+}
+
+// experimentNamed returns the running experiment called name, or nil.
+// Callers must hold s.mu.
+func (s *InMemoryStore) experimentNamed(name string) *experiment {
+	for _, exp := range s.experiments {
+		if exp.name == name {
+			return exp
+		}
+	}
+	return nil
+}
+
+// assign picks the arm of exp served to the caller. It returns "" when the
+// caller is not enrolled, in which case the control doc is served and no
+// exposure is counted.
+func (s *InMemoryStore) assign(ctx context.Context, exp *experiment) string {
+	var variant string
+	if s.assignVariant != nil {
+		variant, _ = callHook(HookAssignVariant, func() (string, error) {
+			return s.assignVariant(ctx, exp.name, append([]string(nil), exp.variants...)), nil
+		})
+	} else if subject := ExperimentSubject(ctx); subject != "" {
+		h := fnv.New32a()
+		h.Write([]byte(exp.name))
+		h.Write([]byte{0})
+		h.Write([]byte(subject))
+		variant = exp.variants[h.Sum32()%uint32(len(exp.variants))]
+	}
+	if exp.counts[variant] == nil {
+		return ""
+	}
+	return variant
+}
+
+// applyVariant returns the entry served for the assigned variant: the
+// variant's doc, keeping the registered doc's Sensitive flag, or base for
+// the control arm.
+func (exp *experiment) applyVariant(variant string, base DocEntry) DocEntry {
+	rec := exp.records[variant]
+	if rec == nil {
+		return base
+	}
+	entry := rec.entry()
+	entry.Sensitive = entry.Sensitive || base.Sensitive
+	return entry
+}
+
+// expose counts a read served from an arm and calls StoreOptions.OnExposure.
+func (s *InMemoryStore) expose(ctx context.Context, exp *experiment, variant string, level DetailLevel) *Assignment {
+	exp.counts[variant].exposures.Add(1)
+	arm := Assignment{Experiment: exp.name, Variant: variant}
+	if s.onExposure != nil {
+		_, _ = callHook(HookOnExposure, func() (struct{}, error) {
+			s.onExposure(ctx, Exposure{
+				Assignment: arm,
+				ToolID:     exp.toolID,
+				Level:      level,
+				Subject:    ExperimentSubject(ctx),
+				TraceID:    TraceID(ctx),
+			})
+			return struct{}{}, nil
+		})
+	}
+	return &arm
+}
+
+// results snapshots the per-variant counters, control first.
+func (exp *experiment) results() []VariantResult {
+	results := make([]VariantResult, 0, len(exp.variants))
+	for _, name := range exp.variants {
+		c := exp.counts[name]
+		results = append(results, VariantResult{
+			Variant:   name,
+			Exposures: c.exposures.Load(),
+			Successes: c.successes.Load(),
+			Failures:  c.failures.Load(),
+		})
+	}
+	return results
+}
@@ -0,0 +1,235 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func newExperimentStore(t *testing.T, opts StoreOptions) *InMemoryStore {
+	t.Helper()
+	opts.ToolResolver = func(id string) (*toolmodel.Tool, error) {
+		if id == "gh:search" {
+			tool := makeToolWithSchema("search", "gh", "Search", map[string]any{"type": "object"})
+			return &tool, nil
+		}
+		return nil, nil
+	}
+	store := NewInMemoryStore(opts)
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Summary: "Search issues", Notes: "Original notes."})
+	if err := store.RegisterExperiment(Experiment{
+		Name:   "search-notes",
+		ToolID: "gh:search",
+		Variants: map[string]DocEntry{
+			"rewrite": {
+				Summary:  "Search issues",
+				Notes:    "Rewritten notes.",
+				Examples: []ToolExample{{Title: "Open bugs", Args: map[string]any{"q": "is:open label:bug"}}},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("RegisterExperiment failed: %v", err)
+	}
+	return store
+}
+
+func assignTo(variant string) func(context.Context, string, []string) string {
+	return func(context.Context, string, []string) string { return variant }
+}
+
+func TestExperiment_ServesAssignedVariant(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		exposures []Exposure
+	)
+	store := newExperimentStore(t, StoreOptions{
+		AssignVariant: assignTo("rewrite"),
+		OnExposure: func(_ context.Context, e Exposure) {
+			mu.Lock()
+			exposures = append(exposures, e)
+			mu.Unlock()
+		},
+	})
+	ctx := WithExperimentSubject(context.Background(), "agent-7")
+
+	doc, err := store.DescribeToolContext(ctx, "gh:search", DetailFull)
+	if err != nil {
+		t.Fatalf("DescribeToolContext failed: %v", err)
+	}
+	if doc.Notes != "Rewritten notes." || len(doc.Examples) != 1 {
+		t.Errorf("doc = %+v, want rewrite variant", doc)
+	}
+	if want := (&Assignment{Experiment: "search-notes", Variant: "rewrite"}); !reflect.DeepEqual(doc.Experiment, want) {
+		t.Errorf("Experiment = %+v, want %+v", doc.Experiment, want)
+	}
+	want := []Exposure{{Assignment: *doc.Experiment, ToolID: "gh:search", Level: DetailFull, Subject: "agent-7"}}
+	if !reflect.DeepEqual(exposures, want) {
+		t.Errorf("exposures = %+v, want %+v", exposures, want)
+	}
+
+	examples, err := store.ListExamplesContext(ctx, "gh:search", 0)
+	if err != nil || len(examples) != 1 {
+		t.Errorf("ListExamplesContext = %v, %v; want the variant example", examples, err)
+	}
+
+	// Stored docs are unaffected.
+	if stored, _ := store.GetDocEntry("gh:search"); stored.Entry.Notes != "Original notes." {
+		t.Errorf("registered Notes = %q, want original", stored.Entry.Notes)
+	}
+}
+
+func TestExperiment_DefaultAssignment(t *testing.T) {
+	store := newExperimentStore(t, StoreOptions{})
+
+	// No subject: control doc, not enrolled.
+	doc, err := store.DescribeTool("gh:search", DetailFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Notes != "Original notes." || doc.Experiment != nil {
+		t.Errorf("unenrolled doc = %+v, want control without assignment", doc)
+	}
+
+	// A subject always lands in the same arm.
+	seen := map[string]bool{}
+	for _, subject := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		ctx := WithExperimentSubject(context.Background(), subject)
+		first, err := store.DescribeToolContext(ctx, "gh:search", DetailSummary)
+		if err != nil || first.Experiment == nil {
+			t.Fatalf("DescribeToolContext(%s) = %+v, %v", subject, first, err)
+		}
+		again, _ := store.DescribeToolContext(ctx, "gh:search", DetailFull)
+		if again.Experiment.Variant != first.Experiment.Variant {
+			t.Errorf("subject %s assigned %s then %s", subject, first.Experiment.Variant, again.Experiment.Variant)
+		}
+		seen[first.Experiment.Variant] = true
+	}
+	if !seen[ControlVariant] || !seen["rewrite"] {
+		t.Errorf("arms assigned = %v, want both", seen)
+	}
+}
+
+func TestExperiment_Results(t *testing.T) {
+	store := newExperimentStore(t, StoreOptions{AssignVariant: assignTo("rewrite")})
+	for range 3 {
+		if _, err := store.DescribeTool("gh:search", DetailSchema); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.RecordOutcome("search-notes", "rewrite", true); err != nil {
+		t.Fatalf("RecordOutcome failed: %v", err)
+	}
+	_ = store.RecordOutcome("search-notes", "rewrite", false)
+	_ = store.RecordOutcome("search-notes", ControlVariant, false)
+
+	if err := store.RecordOutcome("search-notes", "nope", true); !errors.Is(err, ErrExperimentNotFound) {
+		t.Errorf("RecordOutcome(unknown variant) error = %v, want ErrExperimentNotFound", err)
+	}
+	if err := store.RecordOutcome("nope", "rewrite", true); !errors.Is(err, ErrExperimentNotFound) {
+		t.Errorf("RecordOutcome(unknown experiment) error = %v, want ErrExperimentNotFound", err)
+	}
+
+	want := []VariantResult{
+		{Variant: ControlVariant, Failures: 1},
+		{Variant: "rewrite", Exposures: 3, Successes: 1, Failures: 1},
+	}
+	got, err := store.ExperimentResults("search-notes")
+	if err != nil {
+		t.Fatalf("ExperimentResults failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExperimentResults = %+v, want %+v", got, want)
+	}
+	if rate := got[1].SuccessRate(); rate != 0.5 {
+		t.Errorf("SuccessRate = %v, want 0.5", rate)
+	}
+
+	final, err := store.StopExperiment("search-notes")
+	if err != nil || !reflect.DeepEqual(final, want) {
+		t.Errorf("StopExperiment = %+v, %v; want %+v", final, err, want)
+	}
+	doc, _ := store.DescribeTool("gh:search", DetailFull)
+	if doc.Notes != "Original notes." || doc.Experiment != nil {
+		t.Errorf("doc after stop = %+v, want control", doc)
+	}
+	if _, err := store.ExperimentResults("search-notes"); !errors.Is(err, ErrExperimentNotFound) {
+		t.Errorf("ExperimentResults(stopped) error = %v, want ErrExperimentNotFound", err)
+	}
+}
+
+func TestExperiment_UnenrolledAndPanickingHooks(t *testing.T) {
+	for name, assign := range map[string]func(context.Context, string, []string) string{
+		"empty":   assignTo(""),
+		"unknown": assignTo("missing"),
+		"panic":   func(context.Context, string, []string) string { panic("boom") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := newExperimentStore(t, StoreOptions{AssignVariant: assign})
+			doc, err := store.DescribeTool("gh:search", DetailFull)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if doc.Notes != "Original notes." || doc.Experiment != nil {
+				t.Errorf("doc = %+v, want unenrolled control", doc)
+			}
+		})
+	}
+
+	store := newExperimentStore(t, StoreOptions{
+		AssignVariant: assignTo(ControlVariant),
+		OnExposure:    func(context.Context, Exposure) { panic("boom") },
+	})
+	doc, err := store.DescribeTool("gh:search", DetailFull)
+	if err != nil || doc.Experiment == nil || doc.Experiment.Variant != ControlVariant {
+		t.Errorf("DescribeTool with panicking OnExposure = %+v, %v", doc, err)
+	}
+}
+
+func TestExperiment_KeepsSensitive(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{AssignVariant: assignTo("v")})
+	mustRegisterDoc(t, store, "vault:read", DocEntry{Summary: "Read a secret", Sensitive: true})
+	if err := store.RegisterExperiment(Experiment{
+		Name:     "vault",
+		ToolID:   "vault:read",
+		Variants: map[string]DocEntry{"v": {Summary: "Read a secret", Examples: []ToolExample{{Title: "x", Args: map[string]any{}}}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.ListExamples("vault:read", 0); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("ListExamples error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestRegisterExperiment_Validation(t *testing.T) {
+	store := newExperimentStore(t, StoreOptions{})
+	variants := map[string]DocEntry{"b": {Summary: "B"}}
+
+	tests := map[string]Experiment{
+		"no name":        {ToolID: "x:y", Variants: variants},
+		"no tool":        {Name: "n", Variants: variants},
+		"no variants":    {Name: "n", ToolID: "x:y"},
+		"control name":   {Name: "n", ToolID: "x:y", Variants: map[string]DocEntry{ControlVariant: {}}},
+		"empty name":     {Name: "n", ToolID: "x:y", Variants: map[string]DocEntry{"": {}}},
+		"duplicate name": {Name: "search-notes", ToolID: "x:y", Variants: variants},
+		"tool in use":    {Name: "n", ToolID: "gh:search", Variants: variants},
+	}
+	for name, exp := range tests {
+		if err := store.RegisterExperiment(exp); err == nil {
+			t.Errorf("%s: RegisterExperiment succeeded", name)
+		}
+	}
+
+	deep := map[string]any{"a": map[string]any{"b": map[string]any{"c": map[string]any{"d": map[string]any{"e": map[string]any{"f": 1}}}}}}
+	err := store.RegisterExperiment(Experiment{
+		Name:     "n",
+		ToolID:   "x:y",
+		Variants: map[string]DocEntry{"b": {Examples: []ToolExample{{Title: "deep", Args: deep}}}},
+	})
+	if !errors.Is(err, ErrArgsTooLarge) {
+		t.Errorf("RegisterExperiment(deep args) error = %v, want ErrArgsTooLarge", err)
+	}
+}
@@ -0,0 +1,24 @@
+// Package exporter renders tooldocs documentation into formats consumed
+// outside the MCP path, such as human API reference pipelines and
+// llms.txt sites for web-crawling agents.
+//
+// Exporters read a tooldocs.Bundle, so they work equally on a live store
+// (store.Bundle()) and on a bundle file (tooldocs.ReadBundle). Output is
+// deterministic: tools are emitted in ID order.
+package exporter
+
+import (
+	"sort"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// sortedIDs returns the bundle's tool IDs in order.
+func sortedIDs(b tooldocs.Bundle) []string {
+	ids := make([]string, 0, len(b.Tools))
+	for id := range b.Tools {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
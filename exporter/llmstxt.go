@@ -0,0 +1,308 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// SiteOptions configures LLMSTxt and NewAgentCard.
+type SiteOptions struct {
+	// Title names the catalog (the llms.txt H1 and the agent card name).
+	// Empty means "Tools".
+	Title string
+
+	// Description is a one-paragraph catalog description (the llms.txt
+	// blockquote and the agent card description). Optional.
+	Description string
+
+	// BaseURL is the absolute URL the site is served from (e.g.
+	// "https://docs.example.com/agents/"). Links are relative when empty.
+	BaseURL string
+
+	// SchemaInfo optionally supplies parameter information for a tool,
+	// rendered as a parameter table on its page. A typical implementation
+	// returns the SchemaInfo of store.DescribeTool(id, tooldocs.DetailSchema).
+	SchemaInfo func(id string) *tooldocs.SchemaInfo
+
+	// IncludeSensitive publishes notes and examples of Sensitive tools. By
+	// default their pages carry only the summary.
+	IncludeSensitive bool
+}
+
+// ToolPagePath returns the stable site path of a tool's Markdown page:
+// "tools/<namespace>/<name>.md", or "tools/<name>.md" for IDs without a
+// namespace. Segments are URL path-escaped.
+func ToolPagePath(id string) string {
+	ns, name, ok := strings.Cut(id, ":")
+	if !ok {
+		return "tools/" + url.PathEscape(id) + ".md"
+	}
+	return "tools/" + url.PathEscape(ns) + "/" + url.PathEscape(name) + ".md"
+}
+
+// LLMSTxt renders the bundle as an llms.txt site: an "llms.txt" index
+// linking every tool, grouped by namespace, plus one Markdown page per tool
+// at ToolPagePath. It returns file contents keyed by site path; write them
+// with WriteFiles.
+func LLMSTxt(b tooldocs.Bundle, opts SiteOptions) map[string][]byte {
+	files := make(map[string][]byte, len(b.Tools)+1)
+
+	var index strings.Builder
+	fmt.Fprintf(&index, "# %s\n", siteTitle(opts))
+	if opts.Description != "" {
+		fmt.Fprintf(&index, "\n> %s\n", oneLine(opts.Description))
+	}
+
+	section := "\x00"
+	for _, id := range sortedIDs(b) {
+		entry := b.Tools[id]
+		ns, _, ok := strings.Cut(id, ":")
+		if !ok {
+			ns = ""
+		}
+		if ns != section {
+			section = ns
+			heading := ns
+			if heading == "" {
+				heading = "Tools"
+			}
+			fmt.Fprintf(&index, "\n## %s\n\n", heading)
+		}
+		path := ToolPagePath(id)
+		fmt.Fprintf(&index, "- [%s](%s)", id, siteURL(opts.BaseURL, path))
+		if entry.Summary != "" {
+			fmt.Fprintf(&index, ": %s", oneLine(entry.Summary))
+		}
+		index.WriteByte('\n')
+
+		files[path] = []byte(toolPage(id, entry, opts))
+	}
+	files["llms.txt"] = []byte(index.String())
+	return files
+}
+
+// toolPage renders one tool's Markdown page, honoring the entry's
+// RenderHints.
+func toolPage(id string, entry tooldocs.DocEntry, opts SiteOptions) string {
+	var b strings.Builder
+	title := entry.Title
+	if title == "" {
+		title = id
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	if entry.Summary != "" {
+		fmt.Fprintf(&b, "%s\n\n", entry.Summary)
+	}
+	fmt.Fprintf(&b, "Tool ID: `%s`\n", id)
+
+	restricted := entry.Sensitive && !opts.IncludeSensitive
+	for _, section := range entry.Render.Sections() {
+		switch section {
+		case tooldocs.SectionParameters:
+			if opts.SchemaInfo != nil && (entry.Render == nil || !entry.Render.OmitSchema) {
+				if table := tooldocs.RenderParams(opts.SchemaInfo(id), tooldocs.FormatMarkdown); table != "" {
+					fmt.Fprintf(&b, "\n## Parameters\n\n%s", table)
+				}
+			}
+		case tooldocs.SectionEffects:
+			if len(entry.Effects) > 0 {
+				b.WriteString("\n## Effects\n\n")
+				for _, e := range entry.Effects {
+					fmt.Fprintf(&b, "- %s\n", e)
+				}
+			}
+		case tooldocs.SectionRetries:
+			if entry.Idempotent || entry.RetryPolicy != nil {
+				b.WriteString("\n## Retries\n\n")
+				if entry.Idempotent {
+					b.WriteString("Idempotent.\n")
+				}
+				if entry.RetryPolicy != nil {
+					fmt.Fprintf(&b, "%s\n", entry.RetryPolicy)
+				}
+			}
+		case tooldocs.SectionConcurrency:
+			if entry.Concurrency != nil {
+				fmt.Fprintf(&b, "\n## Concurrency\n\n%s\n", entry.Concurrency)
+			}
+		case tooldocs.SectionNotes:
+			if entry.ShortNotes != "" || (!restricted && entry.Notes != "") {
+				b.WriteString("\n## Notes\n\n")
+				if entry.ShortNotes != "" {
+					fmt.Fprintf(&b, "**Key constraints:** %s\n", entry.ShortNotes)
+				}
+				if !restricted && entry.Notes != "" {
+					if entry.ShortNotes != "" {
+						b.WriteByte('\n')
+					}
+					fmt.Fprintf(&b, "%s\n", entry.Notes)
+				}
+			}
+		case tooldocs.SectionCustom:
+			for _, sec := range entry.Sections {
+				if !restricted || sec.Tier == tooldocs.DetailSummary || sec.Tier == tooldocs.DetailSchema {
+					fmt.Fprintf(&b, "\n## %s\n\n%s\n", sec.Title, sec.Body)
+				}
+			}
+		case tooldocs.SectionPrerequisites:
+			if !restricted && len(entry.Prerequisites) > 0 {
+				b.WriteString("\n## Prerequisites\n\n")
+				for _, p := range entry.Prerequisites {
+					fmt.Fprintf(&b, "- %s\n", p)
+				}
+			}
+		case tooldocs.SectionDependencies:
+			if !restricted && len(entry.Dependencies) > 0 {
+				b.WriteString("\n## Depends on\n\n")
+				for _, d := range entry.Dependencies {
+					fmt.Fprintf(&b, "- [%s](%s) (%s)", d.Tool, siteURL(opts.BaseURL, ToolPagePath(d.Tool)), d.Kind)
+					if d.Description != "" {
+						fmt.Fprintf(&b, ": %s", d.Description)
+					}
+					b.WriteByte('\n')
+				}
+			}
+		case tooldocs.SectionExamples:
+			if !restricted && len(entry.Examples) > 0 {
+				writeExamples(&b, id, entry.Examples, entry.Render.Format())
+			}
+		case tooldocs.SectionReferences:
+			if len(entry.ExternalRefs) > 0 {
+				b.WriteString("\n## References\n\n")
+				for _, ref := range entry.ExternalRefs {
+					fmt.Fprintf(&b, "- %s\n", ref)
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// writeExamples renders the Examples section in the given format.
+func writeExamples(b *strings.Builder, id string, examples []tooldocs.ToolExample, format tooldocs.ExampleFormat) {
+	b.WriteString("\n## Examples\n")
+	_, name, ok := strings.Cut(id, ":")
+	if !ok {
+		name = id
+	}
+	for _, ex := range examples {
+		fmt.Fprintf(b, "\n### %s\n\n", ex.Title)
+		if ex.Description != "" {
+			fmt.Fprintf(b, "%s\n\n", ex.Description)
+		}
+		var body []byte
+		switch format {
+		case tooldocs.ExampleFormatCall:
+			body, _ = json.MarshalIndent(tooldocs.ExampleCallRequests(name, []tooldocs.ToolExample{ex})[0], "", "  ")
+		case tooldocs.ExampleFormatInline:
+			body, _ = json.Marshal(ex.Args)
+		default:
+			body, _ = json.MarshalIndent(ex.Args, "", "  ")
+		}
+		fmt.Fprintf(b, "```json\n%s\n```\n", body)
+		if ex.ResultHint != "" {
+			fmt.Fprintf(b, "\nResult: %s\n", ex.ResultHint)
+		}
+	}
+}
+
+// AgentCard is a minimal agent card advertising the catalog's tools as
+// skills, in the shape of an A2A agent card.
+type AgentCard struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Skills      []AgentSkill `json:"skills"`
+}
+
+// AgentSkill describes one tool in an AgentCard.
+type AgentSkill struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Examples    []string `json:"examples,omitempty"`
+
+	// DocumentationURL links the tool's llms.txt page.
+	DocumentationURL string `json:"documentationUrl,omitempty"`
+}
+
+// NewAgentCard builds an AgentCard for the bundle: one skill per tool with
+// its summary, namespace tag, example titles, and a link to its llms.txt
+// page. Example titles of Sensitive tools are omitted unless
+// opts.IncludeSensitive is set.
+func NewAgentCard(b tooldocs.Bundle, opts SiteOptions) AgentCard {
+	card := AgentCard{
+		Name:        siteTitle(opts),
+		Description: opts.Description,
+		URL:         opts.BaseURL,
+		Skills:      []AgentSkill{},
+	}
+	for _, id := range sortedIDs(b) {
+		entry := b.Tools[id]
+		skill := AgentSkill{
+			ID:               id,
+			Name:             entry.Title,
+			Description:      entry.Summary,
+			DocumentationURL: siteURL(opts.BaseURL, ToolPagePath(id)),
+		}
+		if skill.Name == "" {
+			skill.Name = id
+		}
+		if ns, _, ok := strings.Cut(id, ":"); ok {
+			skill.Tags = []string{ns}
+		}
+		if !entry.Sensitive || opts.IncludeSensitive {
+			for _, ex := range entry.Examples {
+				if ex.Title != "" {
+					skill.Examples = append(skill.Examples, ex.Title)
+				}
+			}
+		}
+		card.Skills = append(card.Skills, skill)
+	}
+	return card
+}
+
+// WriteFiles writes files keyed by slash-separated relative path under dir,
+// creating directories as needed.
+func WriteFiles(dir string, files map[string][]byte) error {
+	for path, data := range files {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// siteTitle returns opts.Title or the default.
+func siteTitle(opts SiteOptions) string {
+	if opts.Title == "" {
+		return "Tools"
+	}
+	return opts.Title
+}
+
+// siteURL joins a site path onto base; it returns path unchanged when base
+// is empty.
+func siteURL(base, path string) string {
+	if base == "" {
+		return path
+	}
+	return strings.TrimSuffix(base, "/") + "/" + path
+}
+
+// oneLine collapses whitespace so text fits on one Markdown line.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
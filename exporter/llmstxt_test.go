@@ -0,0 +1,143 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+func TestLLMSTxt(t *testing.T) {
+	files := LLMSTxt(testBundle(), SiteOptions{
+		Title:       "Billing",
+		Description: "Tools for the billing API.",
+		BaseURL:     "https://docs.example.com/agents/",
+		SchemaInfo: func(id string) *tooldocs.SchemaInfo {
+			if id != "billing:create_invoice" {
+				return nil
+			}
+			return &tooldocs.SchemaInfo{Required: []string{"customer"}, Types: map[string][]string{"customer": {"string"}}}
+		},
+	})
+
+	wantIndex := `# Billing
+
+> Tools for the billing API.
+
+## billing
+
+- [billing:create_invoice](https://docs.example.com/agents/tools/billing/create_invoice.md): Create a draft invoice
+- [billing:get_invoice](https://docs.example.com/agents/tools/billing/get_invoice.md): Fetch an invoice
+- [billing:refund](https://docs.example.com/agents/tools/billing/refund.md): Refund a payment
+
+## local
+
+- [local:echo](https://docs.example.com/agents/tools/local/echo.md): Echo input
+`
+	if got := string(files["llms.txt"]); got != wantIndex {
+		t.Errorf("llms.txt =\n%s\nwant\n%s", got, wantIndex)
+	}
+	if len(files) != 5 {
+		t.Errorf("got %d files, want 5", len(files))
+	}
+
+	wantPage := "# billing:create_invoice\n\nCreate a draft invoice\n\nTool ID: `billing:create_invoice`\n\n" +
+		"## Parameters\n\n| name | type | required | default | constraints |\n|---|---|---|---|---|\n| customer | string | yes |  |  |\n" +
+		"\n## Notes\n\nAmounts are in cents.\n" +
+		"\n## Examples\n\n### Minimal\n\n```json\n{\n  \"amount\": 500,\n  \"customer\": \"cus_1\"\n}\n```\n" +
+		"\n## References\n\n- https://example.com/billing\n"
+	if got := string(files["tools/billing/create_invoice.md"]); got != wantPage {
+		t.Errorf("page =\n%s\nwant\n%s", got, wantPage)
+	}
+
+	if refund := string(files["tools/billing/refund.md"]); strings.Contains(refund, "runbook") {
+		t.Errorf("sensitive notes published:\n%s", refund)
+	}
+}
+
+func TestLLMSTxt_RenderHints(t *testing.T) {
+	b := tooldocs.Bundle{Tools: map[string]tooldocs.DocEntry{
+		"billing:create_invoice": {
+			Summary:      "Create a draft invoice",
+			Notes:        "Amounts are in cents.",
+			Examples:     []tooldocs.ToolExample{{Title: "Minimal", Args: map[string]any{"customer": "cus_1"}}},
+			ExternalRefs: []string{"https://example.com/billing"},
+			Render: &tooldocs.RenderHints{
+				ExampleFormat: tooldocs.ExampleFormatInline,
+				OmitSchema:    true,
+				SectionOrder:  []string{tooldocs.SectionExamples, "bogus"},
+			},
+		},
+	}}
+	files := LLMSTxt(b, SiteOptions{SchemaInfo: func(string) *tooldocs.SchemaInfo {
+		return &tooldocs.SchemaInfo{Required: []string{"customer"}}
+	}})
+
+	want := "# billing:create_invoice\n\nCreate a draft invoice\n\nTool ID: `billing:create_invoice`\n" +
+		"\n## Examples\n\n### Minimal\n\n```json\n{\"customer\":\"cus_1\"}\n```\n" +
+		"\n## Notes\n\nAmounts are in cents.\n" +
+		"\n## References\n\n- https://example.com/billing\n"
+	if got := string(files["tools/billing/create_invoice.md"]); got != want {
+		t.Errorf("page =\n%s\nwant\n%s", got, want)
+	}
+
+	b.Tools["billing:create_invoice"] = tooldocs.DocEntry{
+		Examples: []tooldocs.ToolExample{{Title: "Minimal", Args: map[string]any{"customer": "cus_1"}}},
+		Render:   &tooldocs.RenderHints{ExampleFormat: tooldocs.ExampleFormatCall},
+	}
+	page := string(LLMSTxt(b, SiteOptions{})["tools/billing/create_invoice.md"])
+	if !strings.Contains(page, `"method": "tools/call"`) || !strings.Contains(page, `"name": "create_invoice"`) {
+		t.Errorf("call-format page =\n%s", page)
+	}
+}
+
+func TestToolPagePath(t *testing.T) {
+	tests := map[string]string{
+		"github:get_repo": "tools/github/get_repo.md",
+		"echo":            "tools/echo.md",
+		"a b:c/d":         "tools/a%20b/c%2Fd.md",
+	}
+	for id, want := range tests {
+		if got := ToolPagePath(id); got != want {
+			t.Errorf("ToolPagePath(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestNewAgentCard(t *testing.T) {
+	card := NewAgentCard(testBundle(), SiteOptions{Title: "Billing", BaseURL: "https://docs.example.com"})
+	if card.Name != "Billing" || len(card.Skills) != 4 {
+		t.Fatalf("card = %+v", card)
+	}
+	want := AgentSkill{
+		ID:               "billing:create_invoice",
+		Name:             "billing:create_invoice",
+		Description:      "Create a draft invoice",
+		Tags:             []string{"billing"},
+		Examples:         []string{"Minimal"},
+		DocumentationURL: "https://docs.example.com/tools/billing/create_invoice.md",
+	}
+	if !reflect.DeepEqual(card.Skills[0], want) {
+		t.Errorf("skill = %+v, want %+v", card.Skills[0], want)
+	}
+}
+
+func TestWriteFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteFiles(dir, LLMSTxt(testBundle(), SiteOptions{})); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "tools", "local", "echo.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "# local:echo\n") {
+		t.Errorf("echo.md = %s", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "llms.txt")); err != nil {
+		t.Error(err)
+	}
+}
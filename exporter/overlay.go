@@ -0,0 +1,157 @@
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// OverlayVersion is the OpenAPI Overlay specification version produced by
+// OpenAPIOverlay.
+const OverlayVersion = "1.0.0"
+
+// DefaultOverlayExtension is the specification extension that carries agent
+// docs when OverlayOptions.Extension is empty.
+const DefaultOverlayExtension = "x-agent-docs"
+
+// Operation identifies the OpenAPI operation an HTTP-backed tool calls,
+// either by path and method or by operationId.
+type Operation struct {
+	// Method is the HTTP method (e.g. "POST"); used with Path.
+	Method string
+
+	// Path is the OpenAPI path template (e.g. "/invoices/{id}").
+	Path string
+
+	// OperationID targets the operation by its operationId instead of
+	// Method and Path.
+	OperationID string
+}
+
+// target returns the JSONPath expression selecting the operation.
+func (op Operation) target() string {
+	if op.OperationID != "" {
+		return fmt.Sprintf("$.paths.*.*[?(@.operationId == '%s')]", quoteJSONPath(op.OperationID))
+	}
+	return fmt.Sprintf("$.paths['%s'].%s", quoteJSONPath(op.Path), strings.ToLower(op.Method))
+}
+
+// OverlayOptions configures OpenAPIOverlay.
+type OverlayOptions struct {
+	// Title and Version fill the overlay's info object. Empty values default
+	// to "tooldocs agent documentation" and "1.0.0".
+	Title   string
+	Version string
+
+	// Operation maps a tool ID to the operation it calls. Tools for which it
+	// returns ok=false (non-HTTP tools) are skipped. Required.
+	Operation func(id string) (op Operation, ok bool)
+
+	// Extension names the specification extension holding the agent docs.
+	// Empty means DefaultOverlayExtension.
+	Extension string
+
+	// UpdateDescriptions also overwrites the operation's summary (and its
+	// description, when notes exist) with the curated docs. By default
+	// only the extension is written, leaving human-authored text intact.
+	UpdateDescriptions bool
+
+	// IncludeSensitive exports notes and examples of Sensitive tools. By
+	// default only their summary is exported, since human API references
+	// are often public.
+	IncludeSensitive bool
+}
+
+// Overlay is an OpenAPI Overlay document. Marshal it with encoding/json (or
+// a YAML encoder that honors json tags).
+type Overlay struct {
+	Overlay string          `json:"overlay"`
+	Info    OverlayInfo     `json:"info"`
+	Actions []OverlayAction `json:"actions"`
+}
+
+// OverlayInfo is the overlay's info object.
+type OverlayInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OverlayAction updates the operations matched by Target.
+type OverlayAction struct {
+	Target      string         `json:"target"`
+	Description string         `json:"description,omitempty"`
+	Update      map[string]any `json:"update"`
+}
+
+// agentDocs is the content of the overlay extension.
+type agentDocs struct {
+	Tool         string                 `json:"tool"`
+	Summary      string                 `json:"summary,omitempty"`
+	Notes        string                 `json:"notes,omitempty"`
+	Examples     []tooldocs.ToolExample `json:"examples,omitempty"`
+	ExternalRefs []string               `json:"externalRefs,omitempty"`
+}
+
+// OpenAPIOverlay produces an OpenAPI Overlay that adds the bundle's curated
+// docs to the operations of HTTP-backed tools, one action per tool. Each
+// action sets the extension (DefaultOverlayExtension unless configured) to
+// an object with the tool ID, summary, notes, examples, and external refs.
+//
+// It returns an error if opts.Operation is nil.
+func OpenAPIOverlay(b tooldocs.Bundle, opts OverlayOptions) (Overlay, error) {
+	if opts.Operation == nil {
+		return Overlay{}, errors.New("exporter: OverlayOptions.Operation is required")
+	}
+	ext := opts.Extension
+	if ext == "" {
+		ext = DefaultOverlayExtension
+	}
+
+	overlay := Overlay{
+		Overlay: OverlayVersion,
+		Info:    OverlayInfo{Title: opts.Title, Version: opts.Version},
+		Actions: []OverlayAction{},
+	}
+	if overlay.Info.Title == "" {
+		overlay.Info.Title = "tooldocs agent documentation"
+	}
+	if overlay.Info.Version == "" {
+		overlay.Info.Version = "1.0.0"
+	}
+
+	for _, id := range sortedIDs(b) {
+		op, ok := opts.Operation(id)
+		if !ok {
+			continue
+		}
+		entry := b.Tools[id]
+		docs := agentDocs{Tool: id, Summary: entry.Summary, ExternalRefs: entry.ExternalRefs}
+		if !entry.Sensitive || opts.IncludeSensitive {
+			docs.Notes = entry.Notes
+			docs.Examples = entry.Examples
+		}
+
+		update := map[string]any{ext: docs}
+		if opts.UpdateDescriptions {
+			if docs.Summary != "" {
+				update["summary"] = docs.Summary
+			}
+			if docs.Notes != "" {
+				update["description"] = docs.Notes
+			}
+		}
+		overlay.Actions = append(overlay.Actions, OverlayAction{
+			Target:      op.target(),
+			Description: "Agent docs for " + id,
+			Update:      update,
+		})
+	}
+	return overlay, nil
+}
+
+// quoteJSONPath escapes s for use inside a single-quoted JSONPath string.
+func quoteJSONPath(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
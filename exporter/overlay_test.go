@@ -0,0 +1,91 @@
+package exporter
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+func testBundle() tooldocs.Bundle {
+	return tooldocs.Bundle{Tools: map[string]tooldocs.DocEntry{
+		"billing:create_invoice": {
+			Summary:      "Create a draft invoice",
+			Notes:        "Amounts are in cents.",
+			Examples:     []tooldocs.ToolExample{{Title: "Minimal", Args: map[string]any{"customer": "cus_1", "amount": 500}}},
+			ExternalRefs: []string{"https://example.com/billing"},
+		},
+		"billing:refund": {
+			Summary:   "Refund a payment",
+			Notes:     "Escalation runbook: page on-call.",
+			Sensitive: true,
+		},
+		"billing:get_invoice": {Summary: "Fetch an invoice"},
+		"local:echo":          {Summary: "Echo input"},
+	}}
+}
+
+var testOperations = map[string]Operation{
+	"billing:create_invoice": {Method: "POST", Path: "/invoices"},
+	"billing:refund":         {Method: "post", Path: "/payments/{id}/refund"},
+	"billing:get_invoice":    {OperationID: "getInvoice"},
+}
+
+func TestOpenAPIOverlay(t *testing.T) {
+	overlay, err := OpenAPIOverlay(testBundle(), OverlayOptions{
+		Title: "Billing agent docs",
+		Operation: func(id string) (Operation, bool) {
+			op, ok := testOperations[id]
+			return op, ok
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.MarshalIndent(overlay, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("testdata/overlay.golden.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got)+"\n" != string(want) {
+		t.Errorf("overlay =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestOpenAPIOverlay_Options(t *testing.T) {
+	overlay, err := OpenAPIOverlay(testBundle(), OverlayOptions{
+		Extension:          "x-llm",
+		UpdateDescriptions: true,
+		IncludeSensitive:   true,
+		Operation: func(id string) (Operation, bool) {
+			return Operation{Method: "POST", Path: "/payments/{id}/refund"}, id == "billing:refund"
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overlay.Actions) != 1 {
+		t.Fatalf("actions = %+v", overlay.Actions)
+	}
+	update := overlay.Actions[0].Update
+	if update["summary"] != "Refund a payment" || update["description"] != "Escalation runbook: page on-call." {
+		t.Errorf("update = %+v", update)
+	}
+	if docs, ok := update["x-llm"].(agentDocs); !ok || docs.Notes == "" {
+		t.Errorf("extension = %+v", update["x-llm"])
+	}
+
+	if _, err := OpenAPIOverlay(testBundle(), OverlayOptions{}); err == nil {
+		t.Error("expected error without Operation")
+	}
+}
+
+func TestQuoteJSONPath(t *testing.T) {
+	if got := (Operation{Method: "GET", Path: `/it's`}).target(); got != `$.paths['/it\'s'].get` {
+		t.Errorf("target = %s", got)
+	}
+}
@@ -0,0 +1,48 @@
+package exporter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// ExportSite renders a live store as an llms.txt site (see LLMSTxt) covering
+// every catalog tool (see tooldocs.InMemoryStore.CatalogIDs), not only the
+// documented ones: indexed tools without docs get a page with the summary
+// derived from their definition. When opts.SchemaInfo is nil, parameter
+// tables come from the store's schema tier.
+//
+// Returns the error of enumerating the store's index, or of describing an
+// undocumented tool (other than tooldocs.ErrNotFound, which skips it).
+func ExportSite(s *tooldocs.InMemoryStore, opts SiteOptions) (map[string][]byte, error) {
+	ids, err := s.CatalogIDs()
+	if err != nil {
+		return nil, err
+	}
+	b := s.Bundle()
+	for _, id := range ids {
+		if _, ok := b.Tools[id]; ok {
+			continue
+		}
+		doc, err := s.DescribeTool(id, tooldocs.DetailSummary)
+		if errors.Is(err, tooldocs.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", id, err)
+		}
+		b.Tools[id] = tooldocs.DocEntry{Title: doc.Title, Summary: doc.Summary}
+	}
+
+	if opts.SchemaInfo == nil {
+		opts.SchemaInfo = func(id string) *tooldocs.SchemaInfo {
+			doc, err := s.DescribeTool(id, tooldocs.DetailSchema)
+			if err != nil {
+				return nil
+			}
+			return doc.SchemaInfo
+		}
+	}
+	return LLMSTxt(b, opts), nil
+}
@@ -0,0 +1,52 @@
+package exporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/tooldocs"
+	"github.com/jonwraymond/toolindex"
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestExportSite(t *testing.T) {
+	idx := toolindex.NewInMemoryIndex()
+	backend := toolmodel.ToolBackend{Kind: toolmodel.BackendKindLocal, Local: &toolmodel.LocalBackend{Name: "handler"}}
+	for _, tool := range []toolmodel.Tool{
+		{Namespace: "billing", Tool: mcp.Tool{Name: "get_invoice", Description: "Get an invoice", InputSchema: map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"id": map[string]any{"type": "string"}},
+			"required":   []any{"id"},
+		}}},
+		{Namespace: "billing", Tool: mcp.Tool{Name: "void_invoice", Description: "Void an invoice", InputSchema: map[string]any{"type": "object"}}},
+	} {
+		if err := idx.RegisterTool(tool, backend); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{Index: idx})
+	if err := store.RegisterDoc("billing:get_invoice", tooldocs.DocEntry{Summary: "Fetch an invoice by ID"}); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ExportSite(store, SiteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := string(files["llms.txt"])
+	for _, want := range []string{
+		"- [billing:get_invoice](tools/billing/get_invoice.md): Fetch an invoice by ID\n",
+		"- [billing:void_invoice](tools/billing/void_invoice.md): Void an invoice\n",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("llms.txt missing %q:\n%s", want, index)
+		}
+	}
+	if page := string(files["tools/billing/get_invoice.md"]); !strings.Contains(page, "| id | string | yes |") {
+		t.Errorf("page has no parameter table from the schema tier:\n%s", page)
+	}
+	if _, ok := files["tools/billing/void_invoice.md"]; !ok {
+		t.Error("no page for undocumented indexed tool")
+	}
+}
@@ -0,0 +1,51 @@
+package tooldocs
+
+import (
+	"github.com/jonwraymond/toolmodel"
+)
+
+// Facets counts tools by namespace and by tag, so catalog UIs can offer
+// filters with counts without one query per facet value.
+type Facets struct {
+	// Namespaces maps each namespace to its tool count. Tools without a
+	// namespace count under "".
+	Namespaces map[string]int `json:"namespaces"`
+
+	// Tags maps each normalized tool tag (see toolmodel.NormalizeTags) to
+	// its tool count. Tools whose definition cannot be resolved have no
+	// tags.
+	Tags map[string]int `json:"tags"`
+}
+
+// FacetCounts returns the facets of the given tools, or of every
+// documented tool when ids is empty.
+func (s *InMemoryStore) FacetCounts(ids []string) Facets {
+	if len(ids) == 0 {
+		ids = s.DocIDs()
+	}
+	f := Facets{Namespaces: make(map[string]int), Tags: make(map[string]int)}
+	for _, id := range ids {
+		namespace, tags := s.toolFacets(id)
+		f.add(namespace, tags)
+	}
+	return f
+}
+
+// add counts one tool.
+func (f *Facets) add(namespace string, tags []string) {
+	f.Namespaces[namespace]++
+	for _, tag := range tags {
+		f.Tags[tag]++
+	}
+}
+
+// toolFacets returns the namespace of id and the normalized tags of its
+// tool definition, resolving the tool outside any lock.
+func (s *InMemoryStore) toolFacets(id string) (string, []string) {
+	namespace, _, _ := toolmodel.ParseToolID(id)
+	tool, err := s.resolveTool(id)
+	if err != nil || tool == nil {
+		return namespace, nil
+	}
+	return namespace, toolmodel.NormalizeTags(tool.Tags)
+}
@@ -0,0 +1,77 @@
+package tooldocs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func newFacetStore(t *testing.T) *InMemoryStore {
+	t.Helper()
+	tags := map[string][]string{
+		"gh:create_issue": {"Issues", "write"},
+		"gh:list_issues":  {"issues"},
+		"jira:create":     {"issues", "write"},
+	}
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			ns, name, _ := toolmodel.ParseToolID(id)
+			if tags[id] == nil {
+				return nil, nil
+			}
+			tool := makeToolWithSchema(name, ns, "", map[string]any{"type": "object"})
+			tool.Tags = tags[id]
+			return &tool, nil
+		},
+	})
+	mustRegisterDoc(t, store, "gh:create_issue", DocEntry{Summary: "Create an issue"})
+	mustRegisterDoc(t, store, "gh:list_issues", DocEntry{Summary: "List issues"})
+	mustRegisterDoc(t, store, "jira:create", DocEntry{Summary: "Create an issue in Jira"})
+	mustRegisterDoc(t, store, "echo", DocEntry{Summary: "Echo an issue back"})
+	return store
+}
+
+func TestFacetCounts(t *testing.T) {
+	store := newFacetStore(t)
+	want := Facets{
+		Namespaces: map[string]int{"": 1, "gh": 2, "jira": 1},
+		Tags:       map[string]int{"issues": 3, "write": 2},
+	}
+	if got := store.FacetCounts(nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("FacetCounts = %+v, want %+v", got, want)
+	}
+	if got := store.FacetCounts([]string{"jira:create"}); got.Namespaces["jira"] != 1 || len(got.Namespaces) != 1 {
+		t.Errorf("FacetCounts(jira:create) = %+v", got)
+	}
+}
+
+func TestSearchDocs_Facets(t *testing.T) {
+	store := newFacetStore(t)
+	ctx := context.Background()
+
+	res := store.SearchDocs(ctx, "issue", SearchOptions{Facets: true, Limit: 1})
+	if res.Total != 4 || len(res.Hits) != 1 {
+		t.Fatalf("Total = %d, hits = %d; want 4 and 1", res.Total, len(res.Hits))
+	}
+	want := &Facets{
+		Namespaces: map[string]int{"": 1, "gh": 2, "jira": 1},
+		Tags:       map[string]int{"issues": 3, "write": 2},
+	}
+	if !reflect.DeepEqual(res.Facets, want) {
+		t.Errorf("Facets = %+v, want %+v (over all hits, not just the limit)", res.Facets, want)
+	}
+
+	res = store.SearchDocs(ctx, "create", SearchOptions{Tag: "WRITE", Namespace: "gh", Facets: true})
+	if res.Total != 1 || res.Hits[0].ID != "gh:create_issue" {
+		t.Errorf("filtered hits = %+v, want gh:create_issue", res.Hits)
+	}
+	if res.Facets.Namespaces["gh"] != 1 || res.Facets.Tags["write"] != 1 {
+		t.Errorf("filtered Facets = %+v", res.Facets)
+	}
+
+	if res := store.SearchDocs(ctx, "issue", SearchOptions{}); res.Facets != nil {
+		t.Errorf("Facets without SearchOptions.Facets = %+v, want nil", res.Facets)
+	}
+}
@@ -0,0 +1,365 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// ErrNoRoute is returned by FederatedStore for tool IDs whose namespace no
+// member serves. Reads wrap it together with ErrNotFound.
+var ErrNoRoute = errors.New("no member serves namespace")
+
+// FederationMember is one store of a FederatedStore.
+type FederationMember struct {
+	// Name identifies the member in provenance (SearchHit.Member, Route),
+	// e.g. "local", "vendor-mirror", or "generated".
+	Name string
+
+	// Store serves the member's namespaces.
+	Store Store
+
+	// Namespaces lists the tool ID namespaces routed to the member.
+	Namespaces []string
+
+	// Default routes the namespaces no member lists to this member. At
+	// most one member may be the default.
+	Default bool
+}
+
+// FederatedStore fronts several stores as one, routing each tool ID to the
+// member serving its namespace: for example, teams' docs in a local store,
+// a mirrored vendor catalog, and generated docs, each owning its own
+// namespaces. Reads and writes for an ID go to its member only; listings,
+// catalogs, and searches are merged across members, each member
+// contributing only the IDs routed to it, and search hits name the member
+// they came from.
+//
+// It implements Store and the context-aware read methods, so it can back
+// httpapi and graphqlapi handlers directly.
+type FederatedStore struct {
+	members  []FederationMember
+	routes   map[string]int // namespace -> member index
+	fallback int            // default member index, or -1
+}
+
+var _ Store = (*FederatedStore)(nil)
+
+// NewFederatedStore returns a FederatedStore over members.
+//
+// Returns an error if a member has no name or store, names repeat, a
+// namespace is listed twice, or more than one member is the default.
+func NewFederatedStore(members ...FederationMember) (*FederatedStore, error) {
+	f := &FederatedStore{routes: make(map[string]int), fallback: -1}
+	names := make(map[string]bool)
+	for i, m := range members {
+		if m.Name == "" || m.Store == nil {
+			return nil, fmt.Errorf("federation member %d: name and store are required", i)
+		}
+		if names[m.Name] {
+			return nil, fmt.Errorf("federation member %q: duplicate name", m.Name)
+		}
+		names[m.Name] = true
+		for _, ns := range m.Namespaces {
+			if j, ok := f.routes[ns]; ok {
+				return nil, fmt.Errorf("federation member %q: namespace %q already served by %q", m.Name, ns, members[j].Name)
+			}
+			f.routes[ns] = i
+		}
+		if m.Default {
+			if f.fallback >= 0 {
+				return nil, fmt.Errorf("federation member %q: %q is already the default", m.Name, members[f.fallback].Name)
+			}
+			f.fallback = i
+		}
+	}
+	f.members = members
+	return f, nil
+}
+
+// Route returns the name of the member serving id, or "" if none does.
+func (f *FederatedStore) Route(id string) string {
+	if i := f.member(id); i >= 0 {
+		return f.members[i].Name
+	}
+	return ""
+}
+
+// Member returns the store of the named member, or nil.
+func (f *FederatedStore) Member(name string) Store {
+	for _, m := range f.members {
+		if m.Name == name {
+			return m.Store
+		}
+	}
+	return nil
+}
+
+// member returns the index of the member serving id, or -1.
+func (f *FederatedStore) member(id string) int {
+	ns, _, _ := toolmodel.ParseToolID(id)
+	if i, ok := f.routes[ns]; ok {
+		return i
+	}
+	return f.fallback
+}
+
+// routed returns the store serving id.
+//
+// Returns ErrNoRoute if no member serves its namespace.
+func (f *FederatedStore) routed(id string) (Store, error) {
+	i := f.member(id)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoRoute, id)
+	}
+	return f.members[i].Store, nil
+}
+
+// DescribeTool is DescribeToolContext with a background context.
+func (f *FederatedStore) DescribeTool(id string, level DetailLevel) (ToolDoc, error) {
+	return f.DescribeToolContext(context.Background(), id, level)
+}
+
+// DescribeToolContext describes the tool in the member serving it, passing
+// ctx when the member accepts one.
+//
+// Returns ErrNotFound (wrapping ErrNoRoute) if no member serves the ID's
+// namespace, or the member's error.
+func (f *FederatedStore) DescribeToolContext(ctx context.Context, id string, level DetailLevel) (ToolDoc, error) {
+	store, err := f.routed(id)
+	if err != nil {
+		return ToolDoc{}, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return describeLayer(ctx, store, id, level)
+}
+
+// ListExamples is ListExamplesContext with a background context.
+func (f *FederatedStore) ListExamples(id string, maxExamples int) ([]ToolExample, error) {
+	return f.ListExamplesContext(context.Background(), id, maxExamples)
+}
+
+// ListExamplesContext lists the tool's examples from the member serving
+// it, passing ctx when the member accepts one.
+//
+// Returns ErrNotFound (wrapping ErrNoRoute) if no member serves the ID's
+// namespace, or the member's error.
+func (f *FederatedStore) ListExamplesContext(ctx context.Context, id string, maxExamples int) ([]ToolExample, error) {
+	store, err := f.routed(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	if cs, ok := store.(interface {
+		ListExamplesContext(context.Context, string, int) ([]ToolExample, error)
+	}); ok {
+		return cs.ListExamplesContext(ctx, id, maxExamples)
+	}
+	return store.ListExamples(id, maxExamples)
+}
+
+// DocIDs returns the sorted IDs members list (members with a DocIDs
+// method), keeping each only from the member it routes to.
+func (f *FederatedStore) DocIDs() []string {
+	var ids []string
+	for i, m := range f.members {
+		lister, ok := m.Store.(interface{ DocIDs() []string })
+		if !ok {
+			continue
+		}
+		for _, id := range lister.DocIDs() {
+			if f.member(id) == i {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// CatalogIDs returns the sorted catalog of every member: its CatalogIDs
+// when it has the method, else its DocIDs, keeping each ID only from the
+// member it routes to. Use Route for an ID's provenance.
+//
+// Returns the first member error, naming the member.
+func (f *FederatedStore) CatalogIDs() ([]string, error) {
+	var ids []string
+	for i, m := range f.members {
+		var member []string
+		switch lister := m.Store.(type) {
+		case interface{ CatalogIDs() ([]string, error) }:
+			var err error
+			if member, err = lister.CatalogIDs(); err != nil {
+				return nil, fmt.Errorf("%s: %w", m.Name, err)
+			}
+		case interface{ DocIDs() []string }:
+			member = lister.DocIDs()
+		}
+		for _, id := range member {
+			if f.member(id) == i {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// SearchDocs searches every member with a SearchDocs method and merges the
+// hits, ordered as by InMemoryStore.SearchDocs, with SearchHit.Member
+// naming each hit's member. Hits a member returns for IDs routed elsewhere
+// are dropped. Limit applies to the merged hits; Total and Facets cover
+// all of them.
+func (f *FederatedStore) SearchDocs(ctx context.Context, query string, opts SearchOptions) SearchResults {
+	memberOpts := opts
+	memberOpts.Limit = 0
+
+	results := SearchResults{Hits: []SearchHit{}}
+	if opts.Facets {
+		results.Facets = &Facets{Namespaces: map[string]int{}, Tags: map[string]int{}}
+	}
+	for i, m := range f.members {
+		searcher, ok := m.Store.(interface {
+			SearchDocs(context.Context, string, SearchOptions) SearchResults
+		})
+		if !ok {
+			continue
+		}
+		member := searcher.SearchDocs(ctx, query, memberOpts)
+		for _, hit := range member.Hits {
+			if f.member(hit.ID) == i {
+				hit.Member = m.Name
+				results.Hits = append(results.Hits, hit)
+			}
+		}
+		if results.Facets != nil && member.Facets != nil {
+			// Facets count the member's hits, including any routed
+			// elsewhere; members normally hold only their own namespaces.
+			mergeCounts(results.Facets.Namespaces, member.Facets.Namespaces)
+			mergeCounts(results.Facets.Tags, member.Facets.Tags)
+		}
+	}
+
+	hits := results.Hits
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Terms != hits[j].Terms {
+			return hits[i].Terms > hits[j].Terms
+		}
+		if hits[i].Hits != hits[j].Hits {
+			return hits[i].Hits > hits[j].Hits
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	results.Total = len(hits)
+	if opts.Limit > 0 && len(hits) > opts.Limit {
+		results.Hits = hits[:opts.Limit]
+	}
+	return results
+}
+
+// mergeCounts adds the counts of src to dst.
+func mergeCounts(dst, src map[string]int) {
+	for k, n := range src {
+		dst[k] += n
+	}
+}
+
+// RegisterDoc registers entry in the member serving id.
+//
+// Returns ErrNoRoute if no member serves the ID's namespace, or
+// ErrReadOnly if the member has no RegisterDoc method.
+func (f *FederatedStore) RegisterDoc(id string, entry DocEntry) error {
+	store, err := f.routed(id)
+	if err != nil {
+		return err
+	}
+	w, ok := store.(interface {
+		RegisterDoc(string, DocEntry) error
+	})
+	if !ok {
+		return fmt.Errorf("%w: RegisterDoc", ErrReadOnly)
+	}
+	return w.RegisterDoc(id, entry)
+}
+
+// RegisterExamples registers examples in the member serving id.
+//
+// Returns ErrNoRoute if no member serves the ID's namespace, or
+// ErrReadOnly if the member has no RegisterExamples method.
+func (f *FederatedStore) RegisterExamples(id string, examples []ToolExample) error {
+	store, err := f.routed(id)
+	if err != nil {
+		return err
+	}
+	w, ok := store.(interface {
+		RegisterExamples(string, []ToolExample) error
+	})
+	if !ok {
+		return fmt.Errorf("%w: RegisterExamples", ErrReadOnly)
+	}
+	return w.RegisterExamples(id, examples)
+}
+
+// AppendExamples appends examples in the member serving id.
+//
+// Returns ErrNoRoute if no member serves the ID's namespace, or
+// ErrReadOnly if the member has no AppendExamples method.
+func (f *FederatedStore) AppendExamples(id string, examples []ToolExample) error {
+	store, err := f.routed(id)
+	if err != nil {
+		return err
+	}
+	w, ok := store.(interface {
+		AppendExamples(string, []ToolExample) error
+	})
+	if !ok {
+		return fmt.Errorf("%w: AppendExamples", ErrReadOnly)
+	}
+	return w.AppendExamples(id, examples)
+}
+
+// Import splits entries by member and imports each share into its member
+// with opts, merging the reports (Changes sorted by ID). Members import
+// independently: a failing member does not undo the others.
+//
+// Returns ErrNoRoute, before importing anything, if no member serves some
+// entry's namespace, ErrReadOnly if a member receiving entries has no
+// Import method, and the members' errors, joined.
+func (f *FederatedStore) Import(entries map[string]DocEntry, opts ImportOptions) (ImportReport, error) {
+	shares := make(map[int]map[string]DocEntry)
+	for id, entry := range entries {
+		i := f.member(id)
+		if i < 0 {
+			return ImportReport{}, fmt.Errorf("%w: %s", ErrNoRoute, id)
+		}
+		if shares[i] == nil {
+			shares[i] = make(map[string]DocEntry)
+		}
+		shares[i][id] = entry
+	}
+
+	report := ImportReport{DryRun: opts.DryRun}
+	var errs []error
+	for i, m := range f.members {
+		share := shares[i]
+		if share == nil {
+			continue
+		}
+		w, ok := m.Store.(interface {
+			Import(map[string]DocEntry, ImportOptions) (ImportReport, error)
+		})
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %w: Import", m.Name, ErrReadOnly))
+			continue
+		}
+		member, err := w.Import(share, opts)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.Name, err))
+		}
+		report.Changes = append(report.Changes, member.Changes...)
+	}
+	sort.Slice(report.Changes, func(i, j int) bool { return report.Changes[i].ID < report.Changes[j].ID })
+	report.Totals = importTotals(report.Changes)
+	return report, errors.Join(errs...)
+}
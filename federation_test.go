@@ -0,0 +1,126 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// newFederation returns a federation of a "local" store serving ns and
+// acme, and a default "vendor" store, each with one documented tool, plus
+// a doc the vendor store holds for a namespace routed to local.
+func newFederation(t *testing.T) (*FederatedStore, *InMemoryStore, *InMemoryStore) {
+	t.Helper()
+	local := NewInMemoryStore(StoreOptions{})
+	vendor := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, local, "ns:search", DocEntry{Summary: "Search local tickets"})
+	mustRegisterDoc(t, vendor, "gh:search", DocEntry{Summary: "Search vendor tickets", Notes: "Vendor tickets are mirrored hourly."})
+	mustRegisterDoc(t, vendor, "ns:shadow", DocEntry{Summary: "Shadowed tickets doc"})
+	f, err := NewFederatedStore(
+		FederationMember{Name: "local", Store: local, Namespaces: []string{"ns", "acme"}},
+		FederationMember{Name: "vendor", Store: vendor, Default: true},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, local, vendor
+}
+
+func TestFederatedStore_Routing(t *testing.T) {
+	f, local, _ := newFederation(t)
+
+	if doc, err := f.DescribeTool("ns:search", DetailSummary); err != nil || doc.Summary != "Search local tickets" {
+		t.Errorf("ns:search = %+v, %v", doc, err)
+	}
+	if doc, err := f.DescribeTool("gh:search", DetailSummary); err != nil || doc.Summary != "Search vendor tickets" {
+		t.Errorf("gh:search = %+v, %v", doc, err)
+	}
+	// ns is routed to local, so the vendor's ns doc is not served.
+	if _, err := f.DescribeTool("ns:shadow", DetailSummary); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ns:shadow err = %v, want ErrNotFound", err)
+	}
+	if got := f.Route("gh:other"); got != "vendor" {
+		t.Errorf("Route(gh:other) = %q, want the default member", got)
+	}
+
+	if err := f.RegisterDoc("acme:deploy", DocEntry{Summary: "Deploy"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := local.GetDocEntry("acme:deploy"); err != nil {
+		t.Errorf("write not routed to local: %v", err)
+	}
+	if want := []string{"acme:deploy", "gh:search", "ns:search"}; !reflect.DeepEqual(f.DocIDs(), want) {
+		t.Errorf("DocIDs = %v, want %v", f.DocIDs(), want)
+	}
+	if ids, err := f.CatalogIDs(); err != nil || !reflect.DeepEqual(ids, f.DocIDs()) {
+		t.Errorf("CatalogIDs = %v, %v", ids, err)
+	}
+
+	report, err := f.Import(map[string]DocEntry{
+		"ns:a": {Summary: "A"},
+		"gh:b": {Summary: "B"},
+	}, ImportOptions{})
+	if err != nil || report.Totals.Created != 2 || len(report.Changes) != 2 || report.Changes[0].ID != "gh:b" {
+		t.Errorf("Import = %+v, %v", report, err)
+	}
+}
+
+func TestFederatedStore_NoRoute(t *testing.T) {
+	local := NewInMemoryStore(StoreOptions{})
+	f, err := NewFederatedStore(FederationMember{Name: "local", Store: local, Namespaces: []string{"ns"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.DescribeTool("gh:search", DetailSummary); !errors.Is(err, ErrNotFound) || !errors.Is(err, ErrNoRoute) {
+		t.Errorf("read err = %v, want ErrNotFound and ErrNoRoute", err)
+	}
+	if err := f.RegisterDoc("gh:search", DocEntry{Summary: "S"}); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("write err = %v, want ErrNoRoute", err)
+	}
+	if _, err := f.Import(map[string]DocEntry{"ns:a": {Summary: "A"}, "gh:b": {Summary: "B"}}, ImportOptions{}); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("Import err = %v, want ErrNoRoute", err)
+	}
+	if len(local.DocIDs()) != 0 {
+		t.Error("Import with an unroutable entry should import nothing")
+	}
+}
+
+func TestFederatedStore_Search(t *testing.T) {
+	f, _, _ := newFederation(t)
+	results := f.SearchDocs(context.Background(), "tickets", SearchOptions{Limit: 1, Facets: true})
+	if results.Total != 2 || len(results.Hits) != 1 {
+		t.Fatalf("results = %+v, want 2 hits limited to 1", results)
+	}
+	// The vendor hit matches in notes too, so it ranks first.
+	if hit := results.Hits[0]; hit.ID != "gh:search" || hit.Member != "vendor" {
+		t.Errorf("top hit = %+v, want gh:search from vendor", hit)
+	}
+	all := f.SearchDocs(context.Background(), "tickets", SearchOptions{})
+	for _, hit := range all.Hits {
+		if hit.ID == "ns:shadow" {
+			t.Error("hit for an ID routed to another member")
+		}
+		if hit.Member != f.Route(hit.ID) {
+			t.Errorf("hit %s from %q, routed to %q", hit.ID, hit.Member, f.Route(hit.ID))
+		}
+	}
+	if results.Facets == nil || results.Facets.Namespaces["gh"] != 1 {
+		t.Errorf("facets = %+v", results.Facets)
+	}
+}
+
+func TestNewFederatedStore_Invalid(t *testing.T) {
+	s := NewInMemoryStore(StoreOptions{})
+	tests := map[string][]FederationMember{
+		"no name":          {{Store: s}},
+		"duplicate name":   {{Name: "a", Store: s}, {Name: "a", Store: s}},
+		"shared namespace": {{Name: "a", Store: s, Namespaces: []string{"ns"}}, {Name: "b", Store: s, Namespaces: []string{"ns"}}},
+		"two defaults":     {{Name: "a", Store: s, Default: true}, {Name: "b", Store: s, Default: true}},
+	}
+	for name, members := range tests {
+		if _, err := NewFederatedStore(members...); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+}
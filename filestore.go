@@ -0,0 +1,248 @@
+package tooldocs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore is a Store that persists registered documentation to a bundle
+// file (see WriteBundle), so docs survive process restarts. Reads and
+// writes are served by an InMemoryStore configured with the same
+// StoreOptions; the file is loaded on first access and rewritten
+// atomically (temp file and rename) after every successful write.
+//
+// Documentation content is persisted with each doc's frozen flag and
+// expiry (see Bundle.State) and restored as saved on load, without
+// re-running registration checks (see InMemoryStore.Restore). Review
+// queues, experiments, and other store-managed state live in memory. A
+// missing file is an empty store. With StoreOptions.Encryptor, the file is a sealed
+// bundle (see SealBundle). StoreOptions.MaxDocs and MaxMemoryBytes are not
+// supported: every call returns ErrEvictionUnsupported.
+type FileStore struct {
+	path string
+	mem  *InMemoryStore
+	enc  Encryptor
+
+	loadOnce sync.Once
+	loadErr  error
+
+	saveMu sync.Mutex // serializes snapshots and renames
+}
+
+var _ Store = (*FileStore)(nil)
+
+// NewFileStore returns a FileStore backed by the bundle file at path. The
+// file is not read until first access.
+func NewFileStore(path string, opts StoreOptions) *FileStore {
+	return &FileStore{path: path, mem: NewInMemoryStore(opts), enc: opts.Encryptor}
+}
+
+// load reads the file into memory once. Every method calls it first and
+// returns its error, so a corrupt file fails loudly instead of serving (and
+// then overwriting) an empty store.
+func (f *FileStore) load() error {
+	f.loadOnce.Do(func() {
+		if opts := f.mem.opts; opts.MaxDocs > 0 || opts.MaxMemoryBytes > 0 {
+			f.loadErr = fmt.Errorf("load %s: %w", f.path, ErrEvictionUnsupported)
+			return
+		}
+		data, err := os.ReadFile(f.path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return
+		}
+		if err != nil {
+			f.loadErr = fmt.Errorf("load %s: %w", f.path, err)
+			return
+		}
+		b, err := ReadBundle(bytes.NewReader(data))
+		if err != nil {
+			f.loadErr = fmt.Errorf("load %s: %w", f.path, err)
+			return
+		}
+		if len(b.Sealed) > 0 && f.enc == nil {
+			f.loadErr = fmt.Errorf("load %s: bundle is sealed; set StoreOptions.Encryptor", f.path)
+			return
+		}
+		if b, err = OpenBundle(b, f.enc); err != nil {
+			f.loadErr = fmt.Errorf("load %s: %w", f.path, err)
+			return
+		}
+		if err := f.mem.Restore(b.storedDocs()); err != nil {
+			f.loadErr = fmt.Errorf("load %s: %w", f.path, err)
+		}
+	})
+	return f.loadErr
+}
+
+// save writes a snapshot of the store to a temp file next to path and
+// renames it into place.
+func (f *FileStore) save() error {
+	f.saveMu.Lock()
+	defer f.saveMu.Unlock()
+
+	dir, base := filepath.Split(f.path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, base+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("save %s: %w", f.path, err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	b := f.mem.stateBundle()
+	if f.enc != nil {
+		if b, err = SealBundle(b, f.enc); err != nil {
+			tmp.Close()
+			return fmt.Errorf("save %s: %w", f.path, err)
+		}
+	}
+	if err := WriteBundle(tmp, b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save %s: %w", f.path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save %s: %w", f.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("save %s: %w", f.path, err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("save %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// write loads the store, applies a write, and persists the result. When
+// saving fails the write stays applied in memory and the save error is
+// returned.
+func (f *FileStore) write(apply func() error) error {
+	if err := f.load(); err != nil {
+		return err
+	}
+	if err := apply(); err != nil {
+		return err
+	}
+	return f.save()
+}
+
+// RegisterDoc is InMemoryStore.RegisterDoc, persisted.
+func (f *FileStore) RegisterDoc(id string, entry DocEntry) error {
+	return f.write(func() error { return f.mem.RegisterDoc(id, entry) })
+}
+
+// RegisterDocWithOptions is InMemoryStore.RegisterDocWithOptions, persisted.
+func (f *FileStore) RegisterDocWithOptions(id string, entry DocEntry, opts RegisterOptions) error {
+	return f.write(func() error { return f.mem.RegisterDocWithOptions(id, entry, opts) })
+}
+
+// RegisterExamples is InMemoryStore.RegisterExamples, persisted.
+func (f *FileStore) RegisterExamples(id string, examples []ToolExample) error {
+	return f.write(func() error { return f.mem.RegisterExamples(id, examples) })
+}
+
+// AppendExamples is InMemoryStore.AppendExamples, persisted.
+func (f *FileStore) AppendExamples(id string, examples []ToolExample) error {
+	return f.write(func() error { return f.mem.AppendExamples(id, examples) })
+}
+
+// Import is InMemoryStore.Import, persisted unless opts.DryRun is set.
+func (f *FileStore) Import(entries map[string]DocEntry, opts ImportOptions) (ImportReport, error) {
+	if err := f.load(); err != nil {
+		return ImportReport{}, err
+	}
+	report, err := f.mem.Import(entries, opts)
+	if err != nil || opts.DryRun {
+		return report, err
+	}
+	return report, f.save()
+}
+
+// UnregisterWhere is InMemoryStore.UnregisterWhere, persisted.
+func (f *FileStore) UnregisterWhere(filter UnregisterFilter) ([]string, error) {
+	var removed []string
+	err := f.write(func() error {
+		var err error
+		removed, err = f.mem.UnregisterWhere(filter)
+		return err
+	})
+	return removed, err
+}
+
+// Freeze is InMemoryStore.Freeze, persisted.
+func (f *FileStore) Freeze(id string) error {
+	return f.write(func() error { return f.mem.Freeze(id) })
+}
+
+// Unfreeze is InMemoryStore.Unfreeze, persisted.
+func (f *FileStore) Unfreeze(id string) error {
+	return f.write(func() error { return f.mem.Unfreeze(id) })
+}
+
+// IsFrozen is InMemoryStore.IsFrozen over the persisted docs; it reports
+// false if the file cannot be loaded.
+func (f *FileStore) IsFrozen(id string) bool {
+	return f.load() == nil && f.mem.IsFrozen(id)
+}
+
+// DescribeTool implements Store.
+func (f *FileStore) DescribeTool(id string, level DetailLevel) (ToolDoc, error) {
+	return f.DescribeToolContext(context.Background(), id, level)
+}
+
+// DescribeToolContext is InMemoryStore.DescribeToolContext over the
+// persisted docs.
+func (f *FileStore) DescribeToolContext(ctx context.Context, id string, level DetailLevel) (ToolDoc, error) {
+	if err := f.load(); err != nil {
+		return ToolDoc{}, err
+	}
+	return f.mem.DescribeToolContext(ctx, id, level)
+}
+
+// ListExamples implements Store.
+func (f *FileStore) ListExamples(id string, maxExamples int) ([]ToolExample, error) {
+	return f.ListExamplesContext(context.Background(), id, maxExamples)
+}
+
+// ListExamplesContext is InMemoryStore.ListExamplesContext over the
+// persisted docs.
+func (f *FileStore) ListExamplesContext(ctx context.Context, id string, maxExamples int) ([]ToolExample, error) {
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	return f.mem.ListExamplesContext(ctx, id, maxExamples)
+}
+
+// GetDocEntry is InMemoryStore.GetDocEntry over the persisted docs.
+func (f *FileStore) GetDocEntry(id string) (StoredDoc, error) {
+	if err := f.load(); err != nil {
+		return StoredDoc{}, err
+	}
+	return f.mem.GetDocEntry(id)
+}
+
+// DocIDs returns the IDs of all persisted tools, sorted, or nil if the file
+// cannot be loaded.
+func (f *FileStore) DocIDs() []string {
+	if f.load() != nil {
+		return nil
+	}
+	return f.mem.DocIDs()
+}
+
+// Bundle returns a snapshot of the persisted documentation.
+//
+// Returns the load error if the file cannot be read.
+func (f *FileStore) Bundle() (Bundle, error) {
+	if err := f.load(); err != nil {
+		return Bundle{}, err
+	}
+	return f.mem.Bundle(), nil
+}
@@ -0,0 +1,187 @@
+package tooldocs
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileStore_PersistsAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.json")
+
+	store := NewFileStore(path, StoreOptions{})
+	if err := store.RegisterDoc("gh:search", DocEntry{
+		Summary:  "Search issues",
+		Examples: []ToolExample{{Title: "Open", Args: map[string]any{"q": "is:open"}}},
+	}); err != nil {
+		t.Fatalf("RegisterDoc failed: %v", err)
+	}
+	if err := store.AppendExamples("gh:search", []ToolExample{{Title: "Bugs", Args: map[string]any{"q": "label:bug"}}}); err != nil {
+		t.Fatalf("AppendExamples failed: %v", err)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d files, want only the store file", len(entries))
+	}
+
+	reopened := NewFileStore(path, StoreOptions{MaxExamples: 1})
+	doc, err := reopened.DescribeTool("gh:search", DetailSummary)
+	if err != nil || doc.Summary != "Search issues" {
+		t.Fatalf("DescribeTool after restart = %+v, %v", doc, err)
+	}
+	examples, err := reopened.ListExamples("gh:search", 5)
+	if err != nil || len(examples) != 1 {
+		t.Errorf("ListExamples = %d examples, %v; want 1 (MaxExamples)", len(examples), err)
+	}
+	stored, _ := reopened.GetDocEntry("gh:search")
+	if len(stored.Entry.Examples) != 2 {
+		t.Errorf("stored examples = %d, want 2", len(stored.Entry.Examples))
+	}
+
+	if _, err := reopened.UnregisterWhere(UnregisterFilter{NamespacePrefix: "gh"}); err != nil {
+		t.Fatal(err)
+	}
+	if ids := NewFileStore(path, StoreOptions{}).DocIDs(); len(ids) != 0 {
+		t.Errorf("DocIDs after unregister = %v, want none", ids)
+	}
+}
+
+func TestFileStore_RestoresAsSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.json")
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	store := NewFileStore(path, StoreOptions{DefaultDocTTL: time.Hour})
+	store.mem.now = clock.now
+	if err := store.RegisterDoc("gh:search", DocEntry{Summary: "searches issues."}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDoc("gh:list", DocEntry{Summary: "lists issues."}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Freeze("gh:search"); err != nil {
+		t.Fatal(err)
+	}
+	expires := clock.now().Add(time.Hour)
+
+	// Loading neither re-checks the summary profile nor goes through the
+	// write path: no change log entries, rate limit, or fresh TTL.
+	clock.advance(30 * time.Minute)
+	var log bytes.Buffer
+	reopened := NewFileStore(path, StoreOptions{
+		DefaultDocTTL:  time.Hour,
+		SummaryProfile: &SummaryProfile{RequireImperative: true},
+		ChangeLog:      &log,
+		WriteRateLimit: &RateLimit{PerSecond: 0.001, Burst: 1},
+	})
+	reopened.mem.now = clock.now
+	if ids := reopened.DocIDs(); len(ids) != 2 {
+		t.Fatalf("DocIDs = %v, want both docs", ids)
+	}
+	if !reopened.IsFrozen("gh:search") || reopened.IsFrozen("gh:list") {
+		t.Error("frozen flags were not restored")
+	}
+	if stored, _ := reopened.GetDocEntry("gh:list"); !stored.Expires.Equal(expires) {
+		t.Errorf("Expires = %v, want %v", stored.Expires, expires)
+	}
+	if log.Len() != 0 {
+		t.Errorf("change log after load = %q, want empty", log.String())
+	}
+	if h := reopened.mem.Health(); len(h.LastSync) != 0 {
+		t.Errorf("LastSync = %v, want no import recorded", h.LastSync)
+	}
+
+	clock.advance(time.Hour)
+	if _, err := reopened.DescribeTool("gh:list", DetailSummary); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expired doc err = %v, want ErrNotFound", err)
+	}
+	if _, err := reopened.DescribeTool("gh:search", DetailSummary); err != nil {
+		t.Errorf("frozen doc err = %v, want it kept past its TTL", err)
+	}
+}
+
+func TestFileStore_LazyLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.json")
+	store := NewFileStore(path, StoreOptions{})
+
+	// The file is read on first access, not at construction.
+	seed := NewFileStore(path, StoreOptions{})
+	if err := seed.RegisterDoc("gh:search", DocEntry{Summary: "Search issues"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.DescribeTool("gh:search", DetailSummary); err != nil {
+		t.Errorf("DescribeTool = %v, want docs written before first access", err)
+	}
+
+	if _, err := NewFileStore(filepath.Join(t.TempDir(), "missing.json"), StoreOptions{}).DescribeTool("gh:search", DetailSummary); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DescribeTool on missing file = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFileStore_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store := NewFileStore(path, StoreOptions{})
+	if _, err := store.DescribeTool("gh:search", DetailSummary); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("DescribeTool error = %v, want load error", err)
+	}
+	if err := store.RegisterDoc("gh:search", DocEntry{Summary: "Search"}); err == nil {
+		t.Error("RegisterDoc succeeded over a corrupt file")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "{not json" {
+		t.Errorf("corrupt file was overwritten: %q", data)
+	}
+}
+
+func TestFileStore_RejectsEviction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.json")
+	store := NewFileStore(path, StoreOptions{MaxDocs: 1})
+	if err := store.RegisterDoc("gh:search", DocEntry{Summary: "Search"}); !errors.Is(err, ErrEvictionUnsupported) {
+		t.Errorf("RegisterDoc error = %v, want ErrEvictionUnsupported", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file was written: %v", err)
+	}
+}
+
+func TestFileStore_Encryptor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs.json")
+	enc := testEncryptor(t)
+	secret := "Internal runbook: https://wiki.corp.internal/deploy"
+
+	store := NewFileStore(path, StoreOptions{Encryptor: enc})
+	if err := store.RegisterDoc("ops:deploy", DocEntry{
+		Summary:  "Deploy a service",
+		Notes:    secret,
+		Examples: []ToolExample{{Title: "Prod", Args: map[string]any{"url": "https://deploy.corp.internal"}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "corp.internal") || !strings.Contains(string(data), "Deploy a service") {
+		t.Errorf("file holds plaintext notes or examples, or lost the summary:\n%s", data)
+	}
+
+	reopened := NewFileStore(path, StoreOptions{Encryptor: enc})
+	stored, err := reopened.GetDocEntry("ops:deploy")
+	if err != nil || stored.Entry.Notes != secret || len(stored.Entry.Examples) != 1 {
+		t.Errorf("GetDocEntry after reopen = %+v, %v", stored, err)
+	}
+
+	if _, err := NewFileStore(path, StoreOptions{}).GetDocEntry("ops:deploy"); err == nil || !strings.Contains(err.Error(), "Encryptor") {
+		t.Errorf("load without Encryptor err = %v, want a sealed-bundle error", err)
+	}
+	wrongKey, _ := NewAESGCMEncryptor(bytes.Repeat([]byte{9}, 32))
+	if _, err := NewFileStore(path, StoreOptions{Encryptor: wrongKey}).GetDocEntry("ops:deploy"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("load with wrong key err = %v, want ErrDecrypt", err)
+	}
+}
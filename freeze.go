@@ -0,0 +1,97 @@
+package tooldocs
+
+import "fmt"
+
+// Freeze protects a tool's documentation from further writes.
+// While frozen, RegisterDoc and RegisterExamples for the ID return ErrFrozen,
+// so automated sync jobs (importers, mirrors) cannot overwrite manually
+// curated docs. Freezing an already frozen tool is a no-op.
+//
+// Returns ErrNotFound if no documentation is registered for the ID, or
+// ErrPersist if StoreOptions.Persister fails (the tool stays frozen).
+func (s *InMemoryStore) Freeze(id string) error {
+	return s.setFrozen(id, true)
+}
+
+// Unfreeze re-enables writes to a tool's documentation.
+// Unfreezing a tool that is not frozen is a no-op.
+//
+// Returns ErrNotFound if no documentation is registered for the ID, or
+// ErrPersist if StoreOptions.Persister fails.
+func (s *InMemoryStore) Unfreeze(id string) error {
+	return s.setFrozen(id, false)
+}
+
+// IsFrozen reports whether a tool's documentation is frozen.
+func (s *InMemoryStore) IsFrozen(id string) bool {
+	s.rlock()
+	defer s.runlock()
+
+	record := s.docs[id]
+	return record != nil && record.frozen
+}
+
+func (s *InMemoryStore) setFrozen(id string, frozen bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return err
+	}
+
+	record := s.docs[id]
+	if record == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if record.frozen == frozen {
+		return nil
+	}
+	record.frozen = frozen
+	op := ChangeUnfreeze
+	if frozen {
+		op = ChangeFreeze
+	}
+	return s.saved(op, "", id, record, nil)
+}
+
+// Seal makes the whole store read-only, e.g. once a service has loaded its
+// catalog at startup. Afterwards every write (RegisterDoc,
+// RegisterExamples, AppendExamples, Import, RegisterBatch, Freeze,
+// Unregister, and the like) returns ErrReadOnly, docs no longer expire or
+// refresh, and reads no longer take the store's lock, so concurrent
+// readers never contend. Sealing is one-way; sealing a sealed store is a
+// no-op.
+func (s *InMemoryStore) Seal() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sealed.Store(true)
+}
+
+// Sealed reports whether Seal was called.
+func (s *InMemoryStore) Sealed() bool {
+	return s.sealed.Load()
+}
+
+// writable returns ErrReadOnly if the store is sealed. Callers hold s.mu.
+func (s *InMemoryStore) writable() error {
+	if s.sealed.Load() {
+		return fmt.Errorf("%w: store is sealed", ErrReadOnly)
+	}
+	return nil
+}
+
+// rlock read-locks the store unless it is sealed. Sealing happens under
+// the write lock and nothing writes afterwards, so a reader seeing the
+// store sealed can read without the lock.
+func (s *InMemoryStore) rlock() {
+	if !s.sealed.Load() {
+		s.mu.RLock()
+	}
+}
+
+// runlock releases rlock. The store cannot be sealed while rlock holds the
+// read lock, so the two agree on whether it was taken.
+func (s *InMemoryStore) runlock() {
+	if !s.sealed.Load() {
+		s.mu.RUnlock()
+	}
+}
@@ -0,0 +1,185 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFreeze_BlocksWrites(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:tool", DocEntry{Summary: "curated"})
+
+	if err := store.Freeze("ns:tool"); err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+	if !store.IsFrozen("ns:tool") {
+		t.Fatal("IsFrozen = false, want true")
+	}
+
+	err := store.RegisterDoc("ns:tool", DocEntry{Summary: "synced"})
+	if !errors.Is(err, ErrFrozen) {
+		t.Fatalf("RegisterDoc error = %v, want ErrFrozen", err)
+	}
+	err = store.RegisterExamples("ns:tool", []ToolExample{{Title: "synced"}})
+	if !errors.Is(err, ErrFrozen) {
+		t.Fatalf("RegisterExamples error = %v, want ErrFrozen", err)
+	}
+
+	doc, err := store.DescribeTool("ns:tool", DetailSummary)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if doc.Summary != "curated" {
+		t.Errorf("Summary = %q, want %q", doc.Summary, "curated")
+	}
+
+	if err := store.Unfreeze("ns:tool"); err != nil {
+		t.Fatalf("Unfreeze failed: %v", err)
+	}
+	mustRegisterDoc(t, store, "ns:tool", DocEntry{Summary: "synced"})
+}
+
+func TestFreeze_NotFound(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+
+	if err := store.Freeze("missing:tool"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Freeze error = %v, want ErrNotFound", err)
+	}
+	if err := store.Unfreeze("missing:tool"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Unfreeze error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetDocEntry(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:tool", DocEntry{
+		Summary:      "summary",
+		Notes:        "notes",
+		Examples:     []ToolExample{{Title: "ex", Args: map[string]any{"q": "x"}}},
+		ExternalRefs: []string{"https://example.com"},
+	})
+
+	got, err := store.GetDocEntry("ns:tool")
+	if err != nil {
+		t.Fatalf("GetDocEntry failed: %v", err)
+	}
+	if got.ID != "ns:tool" || got.Entry.Summary != "summary" || got.Entry.Notes != "notes" {
+		t.Errorf("GetDocEntry = %+v", got)
+	}
+	if got.Frozen {
+		t.Error("Frozen = true, want false")
+	}
+
+	// Mutating the snapshot must not affect the store
+	got.Entry.Examples[0].Args["q"] = "mutated"
+	got.Entry.ExternalRefs[0] = "mutated"
+
+	if err := store.Freeze("ns:tool"); err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+	got, err = store.GetDocEntry("ns:tool")
+	if err != nil {
+		t.Fatalf("GetDocEntry failed: %v", err)
+	}
+	if !got.Frozen {
+		t.Error("Frozen = false, want true")
+	}
+	if got.Entry.Examples[0].Args["q"] != "x" || got.Entry.ExternalRefs[0] != "https://example.com" {
+		t.Errorf("stored entry was mutated: %+v", got.Entry)
+	}
+
+	if _, err := store.GetDocEntry("missing:tool"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetDocEntry error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSeal_RejectsWrites(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:tool", DocEntry{Summary: "Loaded at startup", Examples: []ToolExample{{Title: "one"}}})
+	store.Seal()
+	store.Seal()
+	if !store.Sealed() {
+		t.Fatal("Sealed = false, want true")
+	}
+
+	writes := map[string]error{
+		"RegisterDoc":      store.RegisterDoc("ns:tool", DocEntry{Summary: "Overwritten"}),
+		"RegisterExamples": store.RegisterExamples("ns:tool", []ToolExample{{Title: "two"}}),
+		"AppendExamples":   store.AppendExamples("ns:tool", []ToolExample{{Title: "two"}}),
+		"Freeze":           store.Freeze("ns:tool"),
+		"RegisterBatch": store.RegisterBatch(func(tx DocTx) error {
+			return tx.RegisterDoc("ns:other", DocEntry{Summary: "Batched"})
+		}),
+	}
+	_, writes["UnregisterWhere"] = store.UnregisterWhere(UnregisterFilter{NamespacePrefix: "ns"})
+	_, writes["Import"] = store.Import(map[string]DocEntry{"ns:other": {Summary: "Imported"}}, ImportOptions{})
+	for name, err := range writes {
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s error = %v, want ErrReadOnly", name, err)
+		}
+	}
+
+	report, err := store.Import(map[string]DocEntry{"ns:other": {Summary: "Imported"}}, ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry-run Import failed: %v", err)
+	}
+	if report.Totals.Created != 1 {
+		t.Errorf("dry-run Totals = %+v, want 1 created", report.Totals)
+	}
+
+	doc, err := store.DescribeTool("ns:tool", DetailSummary)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if doc.Summary != "Loaded at startup" {
+		t.Errorf("Summary = %q, want the startup doc", doc.Summary)
+	}
+	examples, err := store.ListExamples("ns:tool", 0)
+	if err != nil || len(examples) != 1 {
+		t.Errorf("ListExamples = %d examples, %v; want 1", len(examples), err)
+	}
+	if ids := store.DocIDs(); len(ids) != 1 {
+		t.Errorf("DocIDs = %v, want [ns:tool]", ids)
+	}
+}
+
+func TestSeal_DocsDoNotExpire(t *testing.T) {
+	store, clock := newTTLStore(StoreOptions{DefaultDocTTL: time.Minute})
+	mustRegisterDoc(t, store, "ns:tool", DocEntry{Summary: "Loaded at startup"})
+	store.Seal()
+
+	clock.advance(time.Hour)
+	if _, err := store.DescribeTool("ns:tool", DetailSummary); err != nil {
+		t.Fatalf("DescribeTool after TTL failed: %v", err)
+	}
+}
+
+func TestSeal_ConcurrentReads(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	for i := range 20 {
+		mustRegisterDoc(t, store, fmt.Sprintf("ns:tool%d", i), DocEntry{Summary: fmt.Sprintf("Searchable tool %d", i)})
+	}
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 50 {
+				id := fmt.Sprintf("ns:tool%d", (i+j)%20)
+				if _, err := store.DescribeTool(id, DetailSummary); err != nil {
+					t.Errorf("DescribeTool(%s) failed: %v", id, err)
+					return
+				}
+				store.SearchDocs(context.Background(), "searchable", SearchOptions{Limit: 5})
+				store.DocIDs()
+			}
+		}()
+	}
+	store.Seal()
+	wg.Wait()
+}
@@ -0,0 +1,94 @@
+package tooldocs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// errFuzzInvariant reports a broken invariant found by a fuzz entry point.
+var errFuzzInvariant = errors.New("fuzz invariant violated")
+
+// FuzzArgsJSON is a fuzz entry point for example Args handling. It decodes
+// data as a JSON object and runs it through the same normalization and cap
+// validation RegisterDoc applies, checking that:
+//
+//   - normalization is idempotent and does not alias its input, and
+//   - ValidateArgs is deterministic and agrees with RegisterExamples.
+//
+// Inputs that are not JSON objects are ignored (nil is returned). A panic or
+// a non-nil error indicates a bug. The signature suits native Go fuzzing as
+// well as external harnesses such as OSS-Fuzz.
+func FuzzArgsJSON(data []byte) error {
+	var args map[string]any
+	if err := json.Unmarshal(data, &args); err != nil || args == nil {
+		return nil
+	}
+
+	normalized := deepCopyArgs(args)
+	if again := deepCopyArgs(normalized); !reflect.DeepEqual(normalized, again) {
+		return fmt.Errorf("%w: normalization is not idempotent", errFuzzInvariant)
+	}
+	if !reflect.DeepEqual(args, normalized) {
+		return fmt.Errorf("%w: normalization changed decoded JSON", errFuzzInvariant)
+	}
+
+	stats, valid := ValidateArgs(normalized)
+	if again, validAgain := ValidateArgs(normalized); again != stats || validAgain != valid {
+		return fmt.Errorf("%w: ValidateArgs is not deterministic", errFuzzInvariant)
+	}
+
+	store := NewInMemoryStore(StoreOptions{})
+	err := store.RegisterExamples("fuzz:args", []ToolExample{{Title: "fuzz", Args: args}})
+	if valid != (err == nil) {
+		return fmt.Errorf("%w: ValidateArgs=%v but RegisterExamples error %v", errFuzzInvariant, valid, err)
+	}
+	if err != nil {
+		return nil
+	}
+
+	// Mutating the caller's Args must not affect the stored copy.
+	for k := range args {
+		args[k] = "mutated"
+	}
+	stored, err := store.ListExamples("fuzz:args", 1)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(stored[0].Args, normalized) {
+		return fmt.Errorf("%w: stored Args alias the input", errFuzzInvariant)
+	}
+	return nil
+}
+
+// FuzzSchemaJSON is a fuzz entry point for schema derivation. It derives
+// SchemaInfo from data as a raw JSON schema and renders the parameter table
+// in every format, checking that every derived parameter name is a declared
+// property or a required name. Malformed JSON must yield nil SchemaInfo
+// rather than a panic. A panic or a non-nil error indicates a bug.
+func FuzzSchemaJSON(data []byte) error {
+	info := deriveSchemaInfo(json.RawMessage(data))
+	if info == nil {
+		return nil
+	}
+
+	schema := schemaAsMap(json.RawMessage(data))
+	props, _ := schema["properties"].(map[string]any)
+	required := make(map[string]bool, len(info.Required))
+	for _, name := range info.Required {
+		required[name] = true
+	}
+	for _, name := range paramNames(info) {
+		if _, ok := props[name]; !ok && !required[name] {
+			return fmt.Errorf("%w: parameter %q is neither declared nor required", errFuzzInvariant, name)
+		}
+	}
+
+	for _, format := range []Format{FormatMarkdown, FormatText} {
+		if RenderParams(info, format) == "" {
+			return fmt.Errorf("%w: empty %s table for non-nil SchemaInfo", errFuzzInvariant, format)
+		}
+	}
+	return nil
+}
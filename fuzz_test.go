@@ -0,0 +1,71 @@
+package tooldocs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var argsSeeds = []string{
+	`{}`,
+	`{"repo": "a/b", "limit": 10}`,
+	`{"filter": {"labels": ["bug", "p1"], "state": null}, "dryRun": true}`,
+	`{"a": {"b": {"c": {"d": {"e": {"f": 1}}}}}}`,
+	`{"items": [[1, 2], [3, [4, [5]]]], "n": -1.5e300}`,
+	`{"": "", "\u0000": [{}]}`,
+	`[1, 2, 3]`,
+	`null`,
+}
+
+var schemaSeeds = []string{
+	`{"type": "object", "properties": {"q": {"type": "string"}}, "required": ["q"]}`,
+	`{"properties": {"limit": {"type": "integer", "minimum": 1, "maximum": 100, "default": 30}}}`,
+	`{"properties": {"state": {"enum": ["open", null, 1, {"x": 1}]}, "v": {"type": ["string", "null"]}}}`,
+	`{"properties": {"p": {"pattern": "|", "format": "uri", "multipleOf": 0.5, "const": [1]}}}`,
+	`{"required": "q", "properties": []}`,
+	`{"required": [1, null, ""], "properties": {"x": 5, "y": null}}`,
+	`{"properties": {"t": {"type": 7, "default": {"nested": [1]}}}}`,
+	`true`,
+	`{`,
+}
+
+func FuzzValidateArgs(f *testing.F) {
+	for _, seed := range argsSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := FuzzArgsJSON(data); err != nil {
+			t.Fatalf("%v\ninput: %s", err, data)
+		}
+	})
+}
+
+func FuzzDeepCopyValue(f *testing.F) {
+	for _, seed := range argsSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v any
+		if err := json.Unmarshal(data, &v); err != nil {
+			return
+		}
+		copied := deepCopyValue(v)
+		if !reflect.DeepEqual(v, copied) {
+			t.Fatalf("deepCopyValue(%s) = %#v", data, copied)
+		}
+		if jsonType(v) != jsonType(copied) {
+			t.Fatalf("jsonType changed for %s", data)
+		}
+	})
+}
+
+func FuzzDeriveSchemaInfo(f *testing.F) {
+	for _, seed := range schemaSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := FuzzSchemaJSON(data); err != nil {
+			t.Fatalf("%v\ninput: %s", err, data)
+		}
+	})
+}
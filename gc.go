@@ -0,0 +1,176 @@
+package tooldocs
+
+import (
+	"errors"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+)
+
+// GCOptions configures GC.
+type GCOptions struct {
+	// ExampleMaxIdle drops examples not served for longer than this: not
+	// returned by DescribeTool at DetailFull, DescribeTools, or
+	// ListExamples. Examples never served count from their registration.
+	// Zero keeps every example.
+	ExampleMaxIdle time.Duration
+
+	// DryRun reports what would be collected without collecting it.
+	DryRun bool
+}
+
+// CollectedExample is an example GC dropped, or would drop in a dry run.
+type CollectedExample struct {
+	// ToolID is the tool the example belongs to.
+	ToolID string `json:"toolId"`
+
+	// ID and Title identify the example.
+	ID    string `json:"id,omitempty"`
+	Title string `json:"title"`
+
+	// LastUsed is when the example was last served, or registered if it
+	// never was.
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+// GCReport lists what GC collected, or would collect in a dry run.
+type GCReport struct {
+	// DryRun reports whether the store was left unmodified.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Examples lists the collected examples, by tool ID and then in the
+	// tool's example order.
+	Examples []CollectedExample `json:"examples,omitempty"`
+}
+
+// GC applies retention policies to keep a long-running store from growing
+// without bound: it drops examples idle for longer than
+// opts.ExampleMaxIdle, judged by the store's usage stats, and reports what
+// it collected. Frozen docs are left untouched. Tools that lose examples
+// are recorded in the change log and persisted with op "gc". Usage stats
+// live in memory and start over when the store is created; run GC from a
+// ticker or a maintenance job once the store has served long enough for
+// them to be representative.
+//
+// Returns ErrReadOnly if the store is sealed (unless opts.DryRun is set),
+// or the report and ErrPersist if StoreOptions.Persister or ChangeLog
+// fails for any tool; collection is kept.
+func (s *InMemoryStore) GC(opts GCOptions) (GCReport, error) {
+	report := GCReport{DryRun: opts.DryRun}
+	if opts.ExampleMaxIdle <= 0 {
+		return report, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !opts.DryRun {
+		if err := s.writable(); err != nil {
+			return GCReport{}, err
+		}
+	}
+
+	ids := make([]string, 0, len(s.docs))
+	for id := range s.docs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	cutoff := s.now().Add(-opts.ExampleMaxIdle)
+	var persistErrs []error
+	for _, id := range ids {
+		record := s.docs[id]
+		if record.frozen || len(record.examples) == 0 {
+			continue
+		}
+		used := s.usage.lastUsed(id)
+		var kept []ToolExample
+		for _, ex := range record.examples {
+			last, ok := used[usageKey(ex)]
+			if !ok || !last.Before(cutoff) {
+				kept = append(kept, ex)
+				continue
+			}
+			report.Examples = append(report.Examples, CollectedExample{ToolID: id, ID: ex.ID, Title: ex.Title, LastUsed: last})
+		}
+		if opts.DryRun || len(kept) == len(record.examples) {
+			continue
+		}
+		record.examples = kept
+		s.stored(id, record)
+		persistErrs = append(persistErrs, s.saved(ChangeGC, "", id, record, nil))
+	}
+	return report, errors.Join(persistErrs...)
+}
+
+// exampleUsage records when each registered example was last served, for
+// GC. Reads record under its own lock, so they keep sharing s.mu.
+type exampleUsage struct {
+	mu   sync.Mutex
+	last map[string]map[string]time.Time // tool ID -> usageKey -> time
+}
+
+// usageKey identifies an example among its tool's examples for usage
+// tracking: by ID, or by title when it has none.
+func usageKey(ex ToolExample) string {
+	if ex.ID != "" {
+		return "id:" + ex.ID
+	}
+	return "title:" + ex.Title
+}
+
+// registered tracks exactly examples for id, starting new ones at now.
+// Callers hold s.mu.
+func (u *exampleUsage) registered(id string, examples []ToolExample, now time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if len(examples) == 0 {
+		delete(u.last, id)
+		return
+	}
+	prev := u.last[id]
+	next := make(map[string]time.Time, len(examples))
+	for _, ex := range examples {
+		key := usageKey(ex)
+		if t, ok := prev[key]; ok {
+			next[key] = t
+		} else {
+			next[key] = now
+		}
+	}
+	if u.last == nil {
+		u.last = make(map[string]map[string]time.Time)
+	}
+	u.last[id] = next
+}
+
+// served marks examples of id as used at now.
+func (u *exampleUsage) served(id string, examples []ToolExample, now time.Time) {
+	if len(examples) == 0 {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	last := u.last[id]
+	for _, ex := range examples {
+		// Examples not registered (e.g. generated from the schema or
+		// supplied by an experiment variant) are not tracked.
+		if _, ok := last[usageKey(ex)]; ok {
+			last[usageKey(ex)] = now
+		}
+	}
+}
+
+// lastUsed returns a copy of id's usage.
+func (u *exampleUsage) lastUsed(id string) map[string]time.Time {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return maps.Clone(u.last[id])
+}
+
+// forget stops tracking id.
+func (u *exampleUsage) forget(id string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.last, id)
+}
@@ -0,0 +1,74 @@
+package tooldocs
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGC_IdleExamples(t *testing.T) {
+	var log bytes.Buffer
+	store, clock := newTTLStore(StoreOptions{ChangeLog: &log})
+	start := clock.t
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "Tool a", Examples: []ToolExample{
+		{ID: "used", Title: "Used"},
+		{Title: "Idle"},
+	}})
+	mustRegisterDoc(t, store, "ns:frozen", DocEntry{Summary: "Frozen", Examples: []ToolExample{{Title: "Curated"}}})
+	if err := store.Freeze("ns:frozen"); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.advance(10 * 24 * time.Hour)
+	if _, err := store.ListExamples("ns:a", 1); err != nil {
+		t.Fatal(err)
+	}
+	clock.advance(10 * 24 * time.Hour)
+
+	opts := GCOptions{ExampleMaxIdle: 15 * 24 * time.Hour, DryRun: true}
+	report, err := store.GC(opts)
+	if err != nil {
+		t.Fatalf("dry-run GC failed: %v", err)
+	}
+	want := []CollectedExample{{ToolID: "ns:a", Title: "Idle", LastUsed: start}}
+	if !report.DryRun || len(report.Examples) != 1 || report.Examples[0] != want[0] {
+		t.Fatalf("dry-run report = %+v, want %+v", report, want)
+	}
+	if stored, _ := store.GetDocEntry("ns:a"); len(stored.Entry.Examples) != 2 {
+		t.Fatalf("dry run dropped examples: %d left", len(stored.Entry.Examples))
+	}
+
+	log.Reset()
+	opts.DryRun = false
+	if report, err = store.GC(opts); err != nil || len(report.Examples) != 1 {
+		t.Fatalf("GC = %+v, %v", report, err)
+	}
+	entry, err := store.GetDocEntry("ns:a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entry.Entry.Examples) != 1 || entry.Entry.Examples[0].ID != "used" {
+		t.Errorf("examples after GC = %+v, want only %q", entry.Entry.Examples, "used")
+	}
+	if !strings.Contains(log.String(), `"op":"gc"`) {
+		t.Errorf("change log = %q, want a gc change", log.String())
+	}
+	if frozen, _ := store.GetDocEntry("ns:frozen"); len(frozen.Entry.Examples) != 1 {
+		t.Error("GC dropped examples of a frozen doc")
+	}
+
+	// Re-registered examples start a new idle period.
+	if err := store.RegisterExamples("ns:a", []ToolExample{{Title: "Idle"}}); err != nil {
+		t.Fatal(err)
+	}
+	if report, _ := store.GC(opts); len(report.Examples) != 0 {
+		t.Errorf("GC collected fresh examples: %+v", report.Examples)
+	}
+
+	store.Seal()
+	if _, err := store.GC(opts); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("GC on sealed store error = %v, want ErrReadOnly", err)
+	}
+}
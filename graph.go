@@ -0,0 +1,173 @@
+package tooldocs
+
+import (
+	"cmp"
+	"slices"
+)
+
+// EdgeKind classifies an ordering edge between two tools.
+type EdgeKind string
+
+const (
+	// EdgeConsumesOutput means the dependent tool takes (part of) the other
+	// tool's output as input, e.g. "gh:merge_pr" consumes the output of
+	// "gh:create_pr".
+	EdgeConsumesOutput EdgeKind = "consumes-output"
+
+	// EdgeMustFollow means the dependent tool may only be called after the
+	// other tool, e.g. "ci:deploy" must follow "ci:build".
+	EdgeMustFollow EdgeKind = "must-follow"
+)
+
+// Dependency declares that the documented tool depends on another tool.
+type Dependency struct {
+	// Kind classifies the dependency.
+	Kind EdgeKind `json:"kind"`
+
+	// Tool is the ID of the tool depended on.
+	Tool string `json:"tool"`
+
+	// Description explains the dependency in one sentence (e.g. "uses the
+	// returned pull request number").
+	// Maximum length: MaxDescriptionLen (300 chars).
+	Description string `json:"description,omitempty"`
+}
+
+// ToolEdge is a directed edge of the tool dependency graph: From depends on
+// To.
+type ToolEdge struct {
+	From        string   `json:"from"`
+	To          string   `json:"to"`
+	Kind        EdgeKind `json:"kind"`
+	Description string   `json:"description,omitempty"`
+}
+
+// ToolGraph is the dependency graph declared across the catalog.
+type ToolGraph struct {
+	// Nodes lists every tool ID that appears in an edge, sorted.
+	Nodes []string `json:"nodes"`
+
+	// Edges lists all edges sorted by From, To, and Kind.
+	Edges []ToolEdge `json:"edges"`
+}
+
+// ToolGraph returns the dependency graph built from every registered
+// entry's Dependencies, plus a must-follow edge for each PrerequisiteTool
+// prerequisite naming a tool.
+func (s *InMemoryStore) ToolGraph() ToolGraph {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return buildGraph(s.docEdges(func(ToolEdge) bool { return true }))
+}
+
+// Before returns the tools that must or should be called before id, in a
+// valid call order (dependencies first), following edges transitively.
+// Cycles are broken arbitrarily; id itself is not included.
+func (g ToolGraph) Before(id string) []string {
+	deps := make(map[string][]string)
+	for _, e := range g.Edges {
+		deps[e.From] = append(deps[e.From], e.To)
+	}
+	var order []string
+	visited := map[string]bool{id: true}
+	var visit func(node string)
+	visit = func(node string) {
+		for _, dep := range deps[node] {
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			visit(dep)
+			order = append(order, dep)
+		}
+	}
+	visit(id)
+	return order
+}
+
+// toolEdges returns the sorted edges touching id, in either direction.
+// Callers must hold s.mu.
+func (s *InMemoryStore) toolEdges(id string) []ToolEdge {
+	edges := s.docEdges(func(e ToolEdge) bool { return e.From == id || e.To == id })
+	if len(edges) == 0 {
+		return nil
+	}
+	sortEdges(edges)
+	return edges
+}
+
+// docEdges collects the edges declared by registered entries that satisfy
+// keep. Callers must hold s.mu.
+func (s *InMemoryStore) docEdges(keep func(ToolEdge) bool) []ToolEdge {
+	var edges []ToolEdge
+	add := func(e ToolEdge) {
+		if e.To != "" && keep(e) && !slices.Contains(edges, e) {
+			edges = append(edges, e)
+		}
+	}
+	for from, record := range s.docs {
+		for _, d := range record.dependencies {
+			add(ToolEdge{From: from, To: d.Tool, Kind: d.Kind, Description: d.Description})
+		}
+		for _, p := range record.prerequisites {
+			if p.Kind == PrerequisiteTool {
+				add(ToolEdge{From: from, To: p.Tool, Kind: EdgeMustFollow, Description: p.Description})
+			}
+		}
+	}
+	return edges
+}
+
+// buildGraph sorts edges and derives the node list.
+func buildGraph(edges []ToolEdge) ToolGraph {
+	sortEdges(edges)
+	var nodes []string
+	for _, e := range edges {
+		nodes = append(nodes, e.From, e.To)
+	}
+	slices.Sort(nodes)
+	g := ToolGraph{Nodes: slices.Compact(nodes), Edges: edges}
+	if g.Nodes == nil {
+		g.Nodes = []string{}
+	}
+	if g.Edges == nil {
+		g.Edges = []ToolEdge{}
+	}
+	return g
+}
+
+// sortEdges orders edges by From, To, Kind, then Description.
+func sortEdges(edges []ToolEdge) {
+	slices.SortFunc(edges, func(a, b ToolEdge) int {
+		return cmp.Or(
+			cmp.Compare(a.From, b.From),
+			cmp.Compare(a.To, b.To),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Description, b.Description),
+		)
+	})
+}
+
+// truncateDependencies returns a copy of deps with descriptions cut to
+// MaxDescriptionLen.
+func truncateDependencies(deps []Dependency) []Dependency {
+	if deps == nil {
+		return nil
+	}
+	result := make([]Dependency, len(deps))
+	for i, d := range deps {
+		d.Description = truncateString(d.Description, MaxDescriptionLen)
+		result[i] = d
+	}
+	return result
+}
+
+// internDependencies interns the text of deps in place and returns it.
+func internDependencies(deps []Dependency) []Dependency {
+	for i := range deps {
+		deps[i].Kind = EdgeKind(intern(string(deps[i].Kind)))
+		deps[i].Tool = intern(deps[i].Tool)
+		deps[i].Description = intern(deps[i].Description)
+	}
+	return deps
+}
@@ -0,0 +1,106 @@
+package tooldocs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func newGraphStore(t *testing.T) *InMemoryStore {
+	t.Helper()
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			tool := makeToolWithSchema(id, "", id, map[string]any{"type": "object"})
+			return &tool, nil
+		},
+	})
+	mustRegisterDoc(t, store, "ci:build", DocEntry{Summary: "Build"})
+	mustRegisterDoc(t, store, "ci:test", DocEntry{
+		Summary:      "Test",
+		Dependencies: []Dependency{{Kind: EdgeConsumesOutput, Tool: "ci:build", Description: "uses the artifact ID"}},
+	})
+	mustRegisterDoc(t, store, "ci:deploy", DocEntry{
+		Summary:       "Deploy",
+		Dependencies:  []Dependency{{Kind: EdgeMustFollow, Tool: "ci:test"}},
+		Prerequisites: []Prerequisite{{Kind: PrerequisiteTool, Tool: "ci:build"}, {Kind: PrerequisiteSetup, Description: "unrelated"}},
+	})
+	return store
+}
+
+func TestToolGraph(t *testing.T) {
+	g := newGraphStore(t).ToolGraph()
+
+	wantNodes := []string{"ci:build", "ci:deploy", "ci:test"}
+	if !reflect.DeepEqual(g.Nodes, wantNodes) {
+		t.Errorf("Nodes = %v, want %v", g.Nodes, wantNodes)
+	}
+	wantEdges := []ToolEdge{
+		{From: "ci:deploy", To: "ci:build", Kind: EdgeMustFollow},
+		{From: "ci:deploy", To: "ci:test", Kind: EdgeMustFollow},
+		{From: "ci:test", To: "ci:build", Kind: EdgeConsumesOutput, Description: "uses the artifact ID"},
+	}
+	if !reflect.DeepEqual(g.Edges, wantEdges) {
+		t.Errorf("Edges = %+v, want %+v", g.Edges, wantEdges)
+	}
+
+	if got, want := g.Before("ci:deploy"), []string{"ci:build", "ci:test"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Before(deploy) = %v, want %v", got, want)
+	}
+	if got := g.Before("ci:build"); got != nil {
+		t.Errorf("Before(build) = %v, want nil", got)
+	}
+}
+
+func TestToolGraph_Empty(t *testing.T) {
+	g := NewInMemoryStore(StoreOptions{}).ToolGraph()
+	if g.Nodes == nil || g.Edges == nil || len(g.Nodes)+len(g.Edges) != 0 {
+		t.Errorf("empty graph = %+v, want empty non-nil slices", g)
+	}
+}
+
+func TestToolGraph_BeforeCycle(t *testing.T) {
+	g := ToolGraph{Edges: []ToolEdge{
+		{From: "a", To: "b", Kind: EdgeMustFollow},
+		{From: "b", To: "a", Kind: EdgeMustFollow},
+	}}
+	if got := g.Before("a"); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Before(a) = %v, want [b]", got)
+	}
+}
+
+func TestDescribeTool_Edges(t *testing.T) {
+	store := newGraphStore(t)
+
+	doc, err := store.DescribeTool("ci:test", DetailFull)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	want := []ToolEdge{
+		{From: "ci:deploy", To: "ci:test", Kind: EdgeMustFollow},
+		{From: "ci:test", To: "ci:build", Kind: EdgeConsumesOutput, Description: "uses the artifact ID"},
+	}
+	if !reflect.DeepEqual(doc.Edges, want) {
+		t.Errorf("Edges = %+v, want %+v", doc.Edges, want)
+	}
+
+	schema, err := store.DescribeTool("ci:test", DetailSchema)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if schema.Edges != nil {
+		t.Errorf("schema level Edges = %+v, want nil", schema.Edges)
+	}
+}
+
+func TestDependencies_MergeImport(t *testing.T) {
+	build := Dependency{Kind: EdgeMustFollow, Tool: "ci:build"}
+	test := Dependency{Kind: EdgeMustFollow, Tool: "ci:test"}
+	merged := MergeDocEntries(DocEntry{Dependencies: []Dependency{build}}, DocEntry{Dependencies: []Dependency{test, build}}, MergePolicy{})
+	if want := []Dependency{build, test}; !reflect.DeepEqual(merged.Dependencies, want) {
+		t.Errorf("merged Dependencies = %+v, want %+v", merged.Dependencies, want)
+	}
+	if fields := entryFieldChanges(DocEntry{}, merged); !reflect.DeepEqual(fields, []string{"dependencies"}) {
+		t.Errorf("entryFieldChanges = %v, want [dependencies]", fields)
+	}
+}
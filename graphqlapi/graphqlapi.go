@@ -0,0 +1,593 @@
+// Package graphqlapi serves a GraphQL query endpoint over a tooldocs.Store so
+// portals can fetch exactly the fields they need in one request.
+//
+// It implements the executable subset of GraphQL needed for read-only
+// queries: a single query operation with variables, aliases, arguments, and
+// nested selections. Fragments, directives, mutations, subscriptions, and
+// introspection are not supported; GET requests without a query return the
+// schema in SDL form (see Schema).
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// Schema describes the queries the handler answers, in GraphQL SDL.
+const Schema = `scalar JSON
+
+enum DetailLevel { SUMMARY SCHEMA FULL }
+
+type Query {
+  tool(id: ID!): Tool
+  tools(namespace: String): [Tool!]!
+  coverage(ids: [ID!]): Coverage!
+  graph: ToolGraph!
+}
+
+type Tool {
+  id: ID!
+  doc(level: DetailLevel = SUMMARY): ToolDoc
+  examples(max: Int): [Example!]!
+  coverage: ToolCoverage
+}
+
+type ToolDoc {
+  title: String
+  summary: String!
+  shortNotes: String
+  notes: String
+  sections: [Section!]
+  prerequisites: [Prerequisite!]
+  edges: [ToolEdge!]
+  externalRefs: [String!]
+  examples: [Example!]
+  schemaInfo: SchemaInfo
+  capabilities: Capabilities
+  effects: [Effect!]
+  idempotent: Boolean
+  retryPolicy: RetryPolicy
+  concurrency: Concurrency
+  clientRequirements: ClientRequirements
+  stale: Boolean
+  tool: JSON
+}
+
+type Example {
+  id: String
+  title: String!
+  description: String
+  args: JSON
+  resultHint: String
+  requires: [String!]
+  source: String
+}
+
+type Section {
+  title: String!
+  body: String!
+  tier: String
+}
+
+type Prerequisite {
+  kind: String!
+  description: String
+  tool: String
+}
+
+type Effect {
+  kind: String!
+  resource: String
+  description: String
+  irreversible: Boolean
+}
+
+type RetryPolicy {
+  safeToRetry: Boolean!
+  maxAttempts: Int
+  backoff: String
+  idempotencyKey: String
+}
+
+type Concurrency {
+  maxParallel: Int
+  serializationKey: String
+  group: String
+}
+
+type ClientRequirements {
+  sampling: Boolean
+  elicitation: Boolean
+  roots: Boolean
+  resources: Boolean
+}
+
+type ToolEdge {
+  from: ID!
+  to: ID!
+  kind: String!
+  description: String
+}
+
+type ToolGraph {
+  nodes: [ID!]!
+  edges: [ToolEdge!]!
+}
+
+type SchemaInfo {
+  required: [String!]
+  defaults: JSON
+  types: JSON
+  constraints: JSON
+}
+
+type Capabilities {
+  supportsPagination: Boolean!
+  requiresAuth: Boolean!
+  isDestructive: Boolean!
+  hasOutputSchema: Boolean!
+}
+
+type ToolCoverage {
+  id: ID!
+  documented: Boolean!
+  hasSummary: Boolean!
+  hasNotes: Boolean!
+  examples: Int!
+  externalRefs: Int!
+  source: String
+  frozen: Boolean
+}
+
+type Coverage {
+  tools: [ToolCoverage!]!
+  documented: Int!
+  withNotes: Int!
+  withExamples: Int!
+  bySource: JSON
+}
+`
+
+// Request limits. Queries are parsed recursively, so bounding the body and
+// the nesting keeps one request from exhausting the stack or memory, and
+// bounding the fields keeps a flat query of aliased lookups from fanning
+// out into thousands of store reads.
+const (
+	// MaxRequestBytes caps the size of a POST request body.
+	MaxRequestBytes = 1 << 20
+
+	// MaxDepth caps the nesting of selection sets, list and object values,
+	// and list types in a query.
+	MaxDepth = 16
+
+	// MaxFields caps the number of fields selected in a query, counting
+	// every alias and nested field once.
+	MaxFields = 256
+)
+
+// ContextStore is implemented by stores whose reads honor the caller's
+// context, such as *tooldocs.InMemoryStore. Grants added with
+// tooldocs.WithGrants (e.g. by auth middleware) then unlock the full tier of
+// Sensitive tools.
+type ContextStore interface {
+	DescribeToolContext(ctx context.Context, id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error)
+	ListExamplesContext(ctx context.Context, id string, maxExamples int) ([]tooldocs.ToolExample, error)
+}
+
+// Lister is implemented by stores that can enumerate documented tool IDs,
+// such as *tooldocs.InMemoryStore. It is required for the tools query.
+type Lister interface {
+	DocIDs() []string
+}
+
+// CoverageReporter is implemented by stores that report documentation
+// coverage, such as *tooldocs.InMemoryStore. It is required for the coverage
+// query and Tool.coverage field.
+type CoverageReporter interface {
+	CoverageReport(ids []string) tooldocs.CoverageReport
+}
+
+// Grapher is implemented by stores that expose the tool dependency graph,
+// such as *tooldocs.InMemoryStore. It is required for the graph query.
+type Grapher interface {
+	ToolGraph() tooldocs.ToolGraph
+}
+
+// Request is a GraphQL request body. OperationName is accepted for client
+// compatibility; documents may contain only one operation.
+type Request struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+}
+
+// Response is a GraphQL response body.
+type Response struct {
+	Data   map[string]any `json:"data"`
+	Errors []Error        `json:"errors,omitempty"`
+}
+
+// Error is a GraphQL error with the response path of the failing field.
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// Handler answers GraphQL queries against a store. Create one with NewHandler.
+type Handler struct {
+	store tooldocs.Store
+}
+
+// NewHandler returns a Handler serving store.
+func NewHandler(store tooldocs.Store) *Handler {
+	return &Handler{store: store}
+}
+
+// ServeHTTP implements http.Handler. It accepts POST requests with a JSON
+// Request body of up to MaxRequestBytes and GET requests with query,
+// variables, and operationName URL parameters.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		if req.Query == "" {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write([]byte(Schema))
+			return
+		}
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				writeResponse(w, http.StatusBadRequest, Response{Errors: []Error{{Message: "invalid variables: " + err.Error()}}})
+				return
+			}
+		}
+	case http.MethodPost:
+		body := http.MaxBytesReader(w, r.Body, MaxRequestBytes)
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			status := http.StatusBadRequest
+			if tooLarge := (*http.MaxBytesError)(nil); errors.As(err, &tooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			writeResponse(w, status, Response{Errors: []Error{{Message: "invalid request body: " + err.Error()}}})
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp := h.ExecuteContext(r.Context(), req)
+	status := http.StatusOK
+	if resp.Data == nil {
+		status = http.StatusBadRequest
+	}
+	writeResponse(w, status, resp)
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Execute runs a query with a background context.
+func (h *Handler) Execute(req Request) Response {
+	return h.ExecuteContext(context.Background(), req)
+}
+
+// ExecuteContext runs a query and returns its response. Syntax errors yield
+// a response with nil Data; field errors null the field and are reported in
+// Errors alongside partial data. ctx is passed to stores implementing
+// ContextStore.
+func (h *Handler) ExecuteContext(ctx context.Context, req Request) Response {
+	op, err := parseQuery(req.Query)
+	if err != nil {
+		return Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	vars := op.defaults
+	for k, v := range req.Variables {
+		vars[k] = v
+	}
+
+	ex := &executor{vars: vars}
+	data := ex.selectObject(h.queryRoot(ctx), op.selections, nil)
+	return Response{Data: data, Errors: ex.errors}
+}
+
+// resolver computes a field value from its arguments.
+type resolver func(args map[string]any) (any, error)
+
+// object is a value whose fields are computed on demand.
+type object map[string]resolver
+
+// queryRoot returns the Query type's resolvers.
+func (h *Handler) queryRoot(ctx context.Context) object {
+	return object{
+		"tool": func(args map[string]any) (any, error) {
+			id, _ := args["id"].(string)
+			if id == "" {
+				return nil, errors.New("argument id is required")
+			}
+			if _, err := h.describe(ctx, id, tooldocs.DetailSummary); err != nil {
+				if errors.Is(err, tooldocs.ErrNotFound) {
+					return nil, nil
+				}
+				return nil, err
+			}
+			return h.toolObject(ctx, id), nil
+		},
+		"tools": func(args map[string]any) (any, error) {
+			lister, ok := h.store.(Lister)
+			if !ok {
+				return nil, errors.New("store does not support listing")
+			}
+			ns, _ := args["namespace"].(string)
+			var tools []any
+			for _, id := range lister.DocIDs() {
+				if ns != "" && !strings.HasPrefix(id, ns+":") {
+					continue
+				}
+				tools = append(tools, h.toolObject(ctx, id))
+			}
+			if tools == nil {
+				tools = []any{}
+			}
+			return tools, nil
+		},
+		"coverage": func(args map[string]any) (any, error) {
+			reporter, ok := h.store.(CoverageReporter)
+			if !ok {
+				return nil, errors.New("store does not support coverage")
+			}
+			ids, err := stringList(args["ids"])
+			if err != nil {
+				return nil, fmt.Errorf("argument ids: %w", err)
+			}
+			return toJSONValue(reporter.CoverageReport(ids))
+		},
+		"graph": func(map[string]any) (any, error) {
+			grapher, ok := h.store.(Grapher)
+			if !ok {
+				return nil, errors.New("store does not support the tool graph")
+			}
+			return toJSONValue(grapher.ToolGraph())
+		},
+	}
+}
+
+// toolObject returns the Tool type's resolvers for id.
+func (h *Handler) toolObject(ctx context.Context, id string) object {
+	return object{
+		"id": func(map[string]any) (any, error) { return id, nil },
+		"doc": func(args map[string]any) (any, error) {
+			level := tooldocs.DetailSummary
+			if v, ok := args["level"].(string); ok && v != "" {
+				level = tooldocs.DetailLevel(strings.ToLower(v))
+			}
+			doc, err := h.describe(ctx, id, level)
+			if err != nil {
+				return nil, err
+			}
+			return toJSONValue(doc)
+		},
+		"examples": func(args map[string]any) (any, error) {
+			maxExamples := -1
+			if v, ok := args["max"]; ok && v != nil {
+				n, ok := toInt(v)
+				if !ok || n < 0 {
+					return nil, errors.New("argument max must be a non-negative integer")
+				}
+				maxExamples = n
+			}
+			if maxExamples < 0 {
+				// ListExamples treats zero as "none"; without max, return all.
+				maxExamples = int(^uint(0) >> 1)
+			}
+			examples, err := h.listExamples(ctx, id, maxExamples)
+			if err != nil {
+				return nil, err
+			}
+			if examples == nil {
+				examples = []tooldocs.ToolExample{}
+			}
+			return toJSONValue(examples)
+		},
+		"coverage": func(map[string]any) (any, error) {
+			reporter, ok := h.store.(CoverageReporter)
+			if !ok {
+				return nil, errors.New("store does not support coverage")
+			}
+			return toJSONValue(reporter.CoverageReport([]string{id}).Tools[0])
+		},
+	}
+}
+
+// describe calls DescribeToolContext when the store supports it.
+func (h *Handler) describe(ctx context.Context, id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error) {
+	if cs, ok := h.store.(ContextStore); ok {
+		return cs.DescribeToolContext(ctx, id, level)
+	}
+	return h.store.DescribeTool(id, level)
+}
+
+// listExamples calls ListExamplesContext when the store supports it.
+func (h *Handler) listExamples(ctx context.Context, id string, maxExamples int) ([]tooldocs.ToolExample, error) {
+	if cs, ok := h.store.(ContextStore); ok {
+		return cs.ListExamplesContext(ctx, id, maxExamples)
+	}
+	return h.store.ListExamples(id, maxExamples)
+}
+
+// executor evaluates selection sets and accumulates field errors.
+type executor struct {
+	vars   map[string]any
+	errors []Error
+}
+
+func (ex *executor) selectObject(obj object, sels []field, path []any) map[string]any {
+	out := make(map[string]any, len(sels))
+	for _, f := range sels {
+		fieldPath := appendPath(path, f.responseKey())
+		resolve, ok := obj[f.name]
+		if !ok {
+			ex.fail(fieldPath, fmt.Errorf("cannot query field %q", f.name))
+			out[f.responseKey()] = nil
+			continue
+		}
+		args, err := ex.resolveArgs(f.args)
+		if err != nil {
+			ex.fail(fieldPath, err)
+			out[f.responseKey()] = nil
+			continue
+		}
+		v, err := resolve(args)
+		if err != nil {
+			ex.fail(fieldPath, err)
+			out[f.responseKey()] = nil
+			continue
+		}
+		out[f.responseKey()] = ex.complete(v, f, fieldPath)
+	}
+	return out
+}
+
+// complete applies a field's selection set to a resolved value.
+func (ex *executor) complete(v any, f field, path []any) any {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case object:
+		if f.selections == nil {
+			ex.fail(path, fmt.Errorf("field %q requires a selection set", f.name))
+			return nil
+		}
+		return ex.selectObject(val, f.selections, path)
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = ex.complete(item, f, appendPath(path, i))
+		}
+		return out
+	case map[string]any:
+		if f.selections == nil {
+			return val // JSON scalar
+		}
+		out := make(map[string]any, len(f.selections))
+		for _, sub := range f.selections {
+			out[sub.responseKey()] = ex.complete(val[sub.name], sub, appendPath(path, sub.responseKey()))
+		}
+		return out
+	default:
+		if f.selections != nil {
+			ex.fail(path, fmt.Errorf("field %q is a scalar and cannot have a selection set", f.name))
+			return nil
+		}
+		return val
+	}
+}
+
+// resolveArgs substitutes variables into argument values.
+func (ex *executor) resolveArgs(args map[string]any) (map[string]any, error) {
+	out := make(map[string]any, len(args))
+	for k, v := range args {
+		rv, err := ex.resolveValue(v)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = rv
+	}
+	return out, nil
+}
+
+func (ex *executor) resolveValue(v any) (any, error) {
+	switch val := v.(type) {
+	case variable:
+		rv, ok := ex.vars[string(val)]
+		if !ok {
+			return nil, fmt.Errorf("variable $%s is not defined", val)
+		}
+		return rv, nil
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			rv, err := ex.resolveValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = rv
+		}
+		return out, nil
+	case map[string]any:
+		return ex.resolveArgs(val)
+	default:
+		return v, nil
+	}
+}
+
+func (ex *executor) fail(path []any, err error) {
+	ex.errors = append(ex.errors, Error{Message: err.Error(), Path: path})
+}
+
+func appendPath(path []any, elem any) []any {
+	out := make([]any, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, elem)
+}
+
+// toJSONValue converts v to its generic JSON form so selection sets can be
+// applied by field name.
+func toJSONValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// stringList converts a list argument to []string; nil stays nil.
+func stringList(v any) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]any)
+	if !ok {
+		return nil, errors.New("expected a list of strings")
+	}
+	out := make([]string, len(items))
+	for i, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, errors.New("expected a list of strings")
+		}
+		out[i] = s
+	}
+	return out, nil
+}
+
+// toInt converts literal and JSON-decoded numbers to int.
+func toInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case float64:
+		if n != float64(int(n)) {
+			return 0, false
+		}
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,218 @@
+package graphqlapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+func newTestHandler(t *testing.T) *Handler {
+	t.Helper()
+	store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{})
+	for id, entry := range map[string]tooldocs.DocEntry{
+		"github:get_repo": {
+			Summary: "Fetch repository metadata",
+			Notes:   "Needs auth.",
+			Examples: []tooldocs.ToolExample{
+				{Title: "Basic", Args: map[string]any{"repo": "a/b"}},
+				{Title: "Fork", Args: map[string]any{"repo": "c/d"}},
+			},
+		},
+		"github:list_issues": {
+			Summary:      "List issues",
+			Dependencies: []tooldocs.Dependency{{Kind: tooldocs.EdgeConsumesOutput, Tool: "github:get_repo"}},
+		},
+		"local:echo": {Summary: "Echo input"},
+	} {
+		if err := store.RegisterDoc(id, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewHandler(store)
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestExecute(t *testing.T) {
+	h := newTestHandler(t)
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		wantData  string
+	}{
+		{
+			name:     "tool with nested doc",
+			query:    `{ tool(id: "github:get_repo") { id doc { summary } } }`,
+			wantData: `{"tool":{"doc":{"summary":"Fetch repository metadata"},"id":"github:get_repo"}}`,
+		},
+		{
+			name:     "tool graph",
+			query:    `{ graph { nodes edges { from to kind } } }`,
+			wantData: `{"graph":{"edges":[{"from":"github:list_issues","kind":"consumes-output","to":"github:get_repo"}],"nodes":["github:get_repo","github:list_issues"]}}`,
+		},
+		{
+			name:     "missing tool is null",
+			query:    `query { tool(id: "nope") { id } }`,
+			wantData: `{"tool":null}`,
+		},
+		{
+			name:     "tools filtered by namespace with alias",
+			query:    `query Q { gh: tools(namespace: "github") { id } }`,
+			wantData: `{"gh":[{"id":"github:get_repo"},{"id":"github:list_issues"}]}`,
+		},
+		{
+			name:      "variables and enum level",
+			query:     `query Q($id: ID!, $max: Int = 1) { tool(id: $id) { examples(max: $max) { title args } doc(level: SUMMARY) { notes } } }`,
+			variables: map[string]any{"id": "github:get_repo"},
+			wantData:  `{"tool":{"doc":{"notes":null},"examples":[{"args":{"repo":"a/b"},"title":"Basic"}]}}`,
+		},
+		{
+			name:     "coverage",
+			query:    `{ coverage(ids: ["github:get_repo", "missing"]) { documented withExamples tools { id examples } } }`,
+			wantData: `{"coverage":{"documented":1,"tools":[{"examples":2,"id":"github:get_repo"},{"examples":0,"id":"missing"}],"withExamples":1}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := h.Execute(Request{Query: tt.query, Variables: tt.variables})
+			if len(resp.Errors) > 0 {
+				t.Fatalf("unexpected errors: %+v", resp.Errors)
+			}
+			if got := mustJSON(t, resp.Data); got != tt.wantData {
+				t.Errorf("data = %s\nwant  %s", got, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestExecute_FieldErrors(t *testing.T) {
+	h := newTestHandler(t)
+
+	resp := h.Execute(Request{Query: `{ tool(id: "local:echo") { id doc(level: FULL) { summary } bogus } }`})
+	if got := mustJSON(t, resp.Data); got != `{"tool":{"bogus":null,"doc":null,"id":"local:echo"}}` {
+		t.Errorf("data = %s", got)
+	}
+	if len(resp.Errors) != 2 {
+		t.Fatalf("errors = %+v, want 2", resp.Errors)
+	}
+	if want := []any{"tool", "doc"}; !reflect.DeepEqual(resp.Errors[0].Path, want) {
+		t.Errorf("path = %v, want %v", resp.Errors[0].Path, want)
+	}
+}
+
+func TestExecute_SyntaxErrors(t *testing.T) {
+	h := newTestHandler(t)
+	for _, q := range []string{
+		``,
+		`mutation { tool(id: "x") { id } }`,
+		`{ tool(id: "x") { ...F } }`,
+		`{ tool(id: "x") { id }`,
+		`{ tool(id: "x) { id } }`,
+		`{ a } { b }`,
+		strings.Repeat(`{ a `, MaxDepth+1) + strings.Repeat(`}`, MaxDepth+1),
+		`{ tool(id: ` + strings.Repeat(`[`, MaxDepth+1) + `"x"` + strings.Repeat(`]`, MaxDepth+1) + `) { id } }`,
+		`{ ` + strings.Repeat(`t: tool(id: "x") { id } `, MaxFields/2+1) + `}`,
+	} {
+		resp := h.Execute(Request{Query: q})
+		if resp.Data != nil || len(resp.Errors) == 0 {
+			t.Errorf("query %q: expected syntax error, got %+v", q, resp)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	srv := httptest.NewServer(newTestHandler(t))
+	defer srv.Close()
+
+	body := mustJSON(t, Request{Query: `{ tools { id } }`})
+	resp, err := http.Post(srv.URL, "application/json", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out Response
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if tools, _ := out.Data["tools"].([]any); len(tools) != 3 {
+		t.Errorf("tools = %v, want 3 entries", out.Data["tools"])
+	}
+
+	resp, err = http.Get(srv.URL + "?query=" + url.QueryEscape(`{ tool(id: $id) { id } }`) + "&variables=" + url.QueryEscape(`{"id":"local:echo"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out = Response{}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got := mustJSON(t, out.Data); got != `{"tool":{"id":"local:echo"}}` {
+		t.Errorf("GET data = %s", got)
+	}
+
+	resp, err = http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sdl bytes.Buffer
+	_, _ = sdl.ReadFrom(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(sdl.String(), "type Query") {
+		t.Errorf("GET without query should return the schema, got %q", sdl.String())
+	}
+
+	resp, err = http.Post(srv.URL, "application/json", strings.NewReader(`{"query": "{"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("syntax error status = %d, want 400", resp.StatusCode)
+	}
+
+	huge := `{"query": "{ tools { id } }", "variables": {"pad": "` + strings.Repeat("x", MaxRequestBytes) + `"}}`
+	resp, err = http.Post(srv.URL, "application/json", strings.NewReader(huge))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized body status = %d, want 413", resp.StatusCode)
+	}
+}
+
+func TestExecuteContext_Tenant(t *testing.T) {
+	store := tooldocs.NewTenantStore(tooldocs.TenantOptions{})
+	if err := store.Tenant("acme").RegisterDoc("acme:deploy", tooldocs.DocEntry{Summary: "Deploy"}); err != nil {
+		t.Fatal(err)
+	}
+	h := NewHandler(store)
+
+	// The tool query looks the tool up with the caller's context, so it
+	// is routed to the caller's tenant.
+	query := Request{Query: `{ tool(id: "acme:deploy") { id } }`}
+	if got := mustJSON(t, h.ExecuteContext(tooldocs.WithTenant(context.Background(), "acme"), query).Data); got != `{"tool":{"id":"acme:deploy"}}` {
+		t.Errorf("acme data = %s", got)
+	}
+	if got := mustJSON(t, h.ExecuteContext(tooldocs.WithTenant(context.Background(), "other"), query).Data); got != `{"tool":null}` {
+		t.Errorf("other tenant data = %s", got)
+	}
+}
@@ -0,0 +1,423 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// field is a parsed field selection.
+type field struct {
+	alias      string
+	name       string
+	args       map[string]any // literal values; variables are *variable
+	selections []field
+}
+
+// responseKey returns the key the field is reported under.
+func (f field) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+// variable is a reference to an operation variable inside an argument value.
+type variable string
+
+// operation is a parsed query operation.
+type operation struct {
+	defaults   map[string]any
+	selections []field
+}
+
+// parser is a recursive-descent parser for the executable subset of GraphQL
+// this package supports: a single query operation with variables, aliases,
+// arguments, and nested selections. Fragments, directives, mutations, and
+// subscriptions are rejected.
+type parser struct {
+	src    string
+	pos    int
+	depth  int // current nesting, capped at MaxDepth
+	fields int // fields parsed so far, capped at MaxFields
+}
+
+func parseQuery(src string) (operation, error) {
+	p := &parser{src: src}
+	op := operation{defaults: map[string]any{}}
+
+	p.skipIgnored()
+	if p.peek() != '{' {
+		kind := p.name()
+		if kind != "query" {
+			if kind == "" {
+				return op, p.errorf("expected query")
+			}
+			return op, p.errorf("unsupported operation %q", kind)
+		}
+		p.skipIgnored()
+		if isNameStart(p.peek()) {
+			p.name()
+			p.skipIgnored()
+		}
+		if p.peek() == '(' {
+			if err := p.variableDefinitions(op.defaults); err != nil {
+				return op, err
+			}
+		}
+	}
+
+	sels, err := p.selectionSet()
+	if err != nil {
+		return op, err
+	}
+	op.selections = sels
+	p.skipIgnored()
+	if p.pos < len(p.src) {
+		return op, p.errorf("unexpected %q after operation (only one operation is supported)", p.src[p.pos])
+	}
+	return op, nil
+}
+
+func (p *parser) variableDefinitions(defaults map[string]any) error {
+	p.pos++ // (
+	for {
+		p.skipIgnored()
+		if p.peek() == ')' {
+			p.pos++
+			p.skipIgnored()
+			return nil
+		}
+		if p.peek() != '$' {
+			return p.errorf("expected variable definition")
+		}
+		p.pos++
+		name := p.name()
+		p.skipIgnored()
+		if err := p.expect(':'); err != nil {
+			return err
+		}
+		if err := p.typeRef(); err != nil {
+			return err
+		}
+		p.skipIgnored()
+		if p.peek() == '=' {
+			p.pos++
+			p.skipIgnored()
+			v, err := p.value()
+			if err != nil {
+				return err
+			}
+			defaults[name] = v
+		}
+	}
+}
+
+func (p *parser) typeRef() error {
+	p.skipIgnored()
+	if p.peek() == '[' {
+		p.pos++
+		if err := p.enter(); err != nil {
+			return err
+		}
+		defer p.leave()
+		if err := p.typeRef(); err != nil {
+			return err
+		}
+		p.skipIgnored()
+		if err := p.expect(']'); err != nil {
+			return err
+		}
+	} else if p.name() == "" {
+		return p.errorf("expected type")
+	}
+	p.skipIgnored()
+	if p.peek() == '!' {
+		p.pos++
+	}
+	return nil
+}
+
+func (p *parser) selectionSet() ([]field, error) {
+	p.skipIgnored()
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	var fields []field
+	for {
+		p.skipIgnored()
+		switch c := p.peek(); {
+		case c == '}':
+			p.pos++
+			if len(fields) == 0 {
+				return nil, p.errorf("empty selection set")
+			}
+			return fields, nil
+		case c == '.':
+			return nil, p.errorf("fragments are not supported")
+		case c == '@':
+			return nil, p.errorf("directives are not supported")
+		case isNameStart(c):
+			if p.fields == MaxFields {
+				return nil, p.errorf("query selects more than %d fields", MaxFields)
+			}
+			p.fields++
+			f, err := p.field()
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, f)
+		default:
+			return nil, p.errorf("expected field or '}'")
+		}
+	}
+}
+
+func (p *parser) field() (field, error) {
+	f := field{name: p.name()}
+	p.skipIgnored()
+	if p.peek() == ':' {
+		p.pos++
+		p.skipIgnored()
+		f.alias = f.name
+		if f.name = p.name(); f.name == "" {
+			return f, p.errorf("expected field name after alias")
+		}
+		p.skipIgnored()
+	}
+	if p.peek() == '(' {
+		p.pos++
+		f.args = map[string]any{}
+		for {
+			p.skipIgnored()
+			if p.peek() == ')' {
+				p.pos++
+				break
+			}
+			name := p.name()
+			if name == "" {
+				return f, p.errorf("expected argument name")
+			}
+			p.skipIgnored()
+			if err := p.expect(':'); err != nil {
+				return f, err
+			}
+			p.skipIgnored()
+			v, err := p.value()
+			if err != nil {
+				return f, err
+			}
+			f.args[name] = v
+		}
+		p.skipIgnored()
+	}
+	if p.peek() == '@' {
+		return f, p.errorf("directives are not supported")
+	}
+	if p.peek() == '{' {
+		sels, err := p.selectionSet()
+		if err != nil {
+			return f, err
+		}
+		f.selections = sels
+	}
+	return f, nil
+}
+
+func (p *parser) value() (any, error) {
+	switch c := p.peek(); {
+	case c == '$':
+		p.pos++
+		name := p.name()
+		if name == "" {
+			return nil, p.errorf("expected variable name")
+		}
+		return variable(name), nil
+	case c == '"':
+		return p.stringValue()
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.number()
+	case c == '[':
+		p.pos++
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		list := []any{}
+		for {
+			p.skipIgnored()
+			if p.peek() == ']' {
+				p.pos++
+				return list, nil
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+	case c == '{':
+		p.pos++
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		obj := map[string]any{}
+		for {
+			p.skipIgnored()
+			if p.peek() == '}' {
+				p.pos++
+				return obj, nil
+			}
+			name := p.name()
+			p.skipIgnored()
+			if err := p.expect(':'); err != nil {
+				return nil, err
+			}
+			p.skipIgnored()
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			obj[name] = v
+		}
+	case isNameStart(c):
+		switch name := p.name(); name {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		default:
+			return name, nil // enum value
+		}
+	default:
+		return nil, p.errorf("expected value")
+	}
+}
+
+func (p *parser) stringValue() (string, error) {
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '"':
+			p.pos++
+			s, err := strconv.Unquote(p.src[start:p.pos])
+			if err != nil {
+				return "", p.errorf("invalid string: %v", err)
+			}
+			return s, nil
+		case '\n':
+			return "", p.errorf("unterminated string")
+		default:
+			p.pos++
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) number() (any, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	isFloat := false
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '.' || c == 'e' || c == 'E' || c == '+' || (c == '-' && isFloat) {
+			isFloat = true
+		} else if c < '0' || c > '9' {
+			break
+		}
+		p.pos++
+	}
+	text := p.src[start:p.pos]
+	if isFloat {
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", text)
+		}
+		return f, nil
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		return nil, p.errorf("invalid number %q", text)
+	}
+	return n, nil
+}
+
+func (p *parser) name() string {
+	start := p.pos
+	if p.pos < len(p.src) && isNameStart(p.src[p.pos]) {
+		p.pos++
+		for p.pos < len(p.src) && (isNameStart(p.src[p.pos]) || (p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+			p.pos++
+		}
+	}
+	return p.src[start:p.pos]
+}
+
+// enter descends one nesting level, failing past MaxDepth.
+func (p *parser) enter() error {
+	if p.depth == MaxDepth {
+		return p.errorf("query nests deeper than %d levels", MaxDepth)
+	}
+	p.depth++
+	return nil
+}
+
+// leave returns from a nesting level entered with enter.
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) expect(c byte) error {
+	if p.peek() != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) peek() byte {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+// skipIgnored skips whitespace, commas, and comments.
+func (p *parser) skipIgnored() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '#':
+			if i := strings.IndexByte(p.src[p.pos:], '\n'); i >= 0 {
+				p.pos += i
+			} else {
+				p.pos = len(p.src)
+			}
+		case c == ',' || unicode.IsSpace(rune(c)):
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("syntax error at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,25 @@
+module github.com/jonwraymond/tooldocs/grpcapi
+
+go 1.24.4
+
+require (
+	github.com/jonwraymond/tooldocs v0.2.0
+	github.com/jonwraymond/toolmodel v0.2.0
+	github.com/modelcontextprotocol/go-sdk v1.2.0
+	google.golang.org/grpc v1.75.1
+	google.golang.org/protobuf v1.36.10
+)
+
+require (
+	github.com/google/jsonschema-go v0.3.0 // indirect
+	github.com/jonwraymond/toolindex v0.3.0 // indirect
+	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/oauth2 v0.30.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/jonwraymond/tooldocs => ../
@@ -0,0 +1,326 @@
+// Package grpcapi serves a tooldocs.Store over gRPC and consumes one, so
+// documentation can be exposed and read between services.
+//
+// The protocol is defined in proto/tooldocs/v1/tooldocs.proto; package
+// tooldocspb holds the generated code (regenerate with go generate). Docs
+// travel as the JSON encoding of tooldocs.ToolDoc, as in httpapi, and
+// examples as typed messages.
+//
+// Store errors map to status codes and back: ErrNotFound to NotFound,
+// ErrNoTool to FailedPrecondition, ErrUnauthorized to PermissionDenied, and
+// ErrInvalidDetail to InvalidArgument. The trace ID (tooldocs.TraceID)
+// travels in the "x-request-id" metadata key; the server attaches it with
+// tooldocs.WithTraceID and echoes it in the response header.
+//
+// Dictionary compression: RegisterDictionary registers a shared-dictionary
+// compressor (see tooldocs.TrainDictionary) under a name derived from the
+// dictionary ID. Register it in both processes and have clients call with
+// grpc.UseCompressor(name), e.g. through grpc.WithDefaultCallOptions; the
+// server compresses responses with the compressor of the request.
+//
+// The server does not authenticate callers. To unlock the full tier of
+// Sensitive tools, install an interceptor that verifies the caller and adds
+// grants with tooldocs.WithGrants.
+package grpcapi
+
+//go:generate protoc -I proto --go_out=.. --go_opt=module=github.com/jonwraymond/tooldocs --go-grpc_out=.. --go-grpc_opt=module=github.com/jonwraymond/tooldocs tooldocs/v1/tooldocs.proto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jonwraymond/tooldocs"
+	"github.com/jonwraymond/tooldocs/grpcapi/tooldocspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TraceMetadata is the request and response metadata key carrying the
+// trace ID.
+const TraceMetadata = "x-request-id"
+
+// Server implements tooldocspb.ToolDocsServer over a store. Register it
+// with tooldocspb.RegisterToolDocsServer.
+type Server struct {
+	tooldocspb.UnimplementedToolDocsServer
+
+	store tooldocs.Store
+}
+
+// NewServer returns a Server serving store. Stores implementing
+// DescribeToolContext or ListExamplesContext receive the call context.
+func NewServer(store tooldocs.Store) *Server {
+	return &Server{store: store}
+}
+
+// DescribeTool implements tooldocspb.ToolDocsServer.
+func (s *Server) DescribeTool(ctx context.Context, req *tooldocspb.DescribeToolRequest) (*tooldocspb.DescribeToolResponse, error) {
+	ctx = incomingTrace(ctx)
+	level := tooldocs.DetailLevel(req.GetLevel())
+	if level == "" {
+		level = tooldocs.DetailSummary
+	}
+
+	var (
+		doc tooldocs.ToolDoc
+		err error
+	)
+	if cs, ok := s.store.(interface {
+		DescribeToolContext(context.Context, string, tooldocs.DetailLevel) (tooldocs.ToolDoc, error)
+	}); ok {
+		doc, err = cs.DescribeToolContext(ctx, req.GetId(), level)
+	} else {
+		doc, err = s.store.DescribeTool(req.GetId(), level)
+	}
+	if err != nil {
+		return nil, statusFor(err)
+	}
+	data, err := tooldocs.MarshalToolDoc(doc, tooldocs.MarshalOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encode doc: %v", err)
+	}
+	return &tooldocspb.DescribeToolResponse{Doc: data}, nil
+}
+
+// ListExamples implements tooldocspb.ToolDocsServer.
+func (s *Server) ListExamples(ctx context.Context, req *tooldocspb.ListExamplesRequest) (*tooldocspb.ListExamplesResponse, error) {
+	ctx = incomingTrace(ctx)
+	if req.GetMaxExamples() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_examples must be non-negative")
+	}
+
+	var (
+		examples []tooldocs.ToolExample
+		err      error
+	)
+	if cs, ok := s.store.(interface {
+		ListExamplesContext(context.Context, string, int) ([]tooldocs.ToolExample, error)
+	}); ok {
+		examples, err = cs.ListExamplesContext(ctx, req.GetId(), int(req.GetMaxExamples()))
+	} else {
+		examples, err = s.store.ListExamples(req.GetId(), int(req.GetMaxExamples()))
+	}
+	if err != nil {
+		return nil, statusFor(err)
+	}
+
+	resp := &tooldocspb.ListExamplesResponse{Examples: make([]*tooldocspb.Example, 0, len(examples))}
+	for _, ex := range examples {
+		msg, err := exampleToProto(ex)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "encode example %q: %v", ex.Title, err)
+		}
+		resp.Examples = append(resp.Examples, msg)
+	}
+	return resp, nil
+}
+
+// ListTools implements tooldocspb.ToolDocsServer. It answers Unimplemented
+// unless the store has a DocIDs method.
+func (s *Server) ListTools(context.Context, *tooldocspb.ListToolsRequest) (*tooldocspb.ListToolsResponse, error) {
+	lister, ok := s.store.(interface{ DocIDs() []string })
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "store does not support listing")
+	}
+	return &tooldocspb.ListToolsResponse{Ids: lister.DocIDs()}, nil
+}
+
+// DictionaryCompressorPrefix prefixes the names RegisterDictionary
+// registers compressors under.
+const DictionaryCompressorPrefix = "tooldocs-dict-"
+
+// RegisterDictionary registers a gRPC compressor deflating messages with d
+// and returns its name, DictionaryCompressorPrefix followed by d.ID. Like
+// encoding.RegisterCompressor, it must be called at initialization time,
+// before serving or dialing.
+func RegisterDictionary(d tooldocs.Dictionary) string {
+	c := dictCompressor{dict: d}
+	encoding.RegisterCompressor(c)
+	return c.Name()
+}
+
+// dictCompressor is an encoding.Compressor using a tooldocs.Dictionary.
+type dictCompressor struct {
+	dict tooldocs.Dictionary
+}
+
+func (c dictCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return c.dict.NewWriter(w), nil
+}
+
+func (c dictCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return c.dict.NewReader(r), nil
+}
+
+func (c dictCompressor) Name() string {
+	return DictionaryCompressorPrefix + c.dict.ID
+}
+
+// incomingTrace attaches the caller's trace ID, if any, and echoes it.
+func incomingTrace(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ids := md.Get(TraceMetadata)
+	if len(ids) == 0 || ids[0] == "" {
+		return ctx
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(TraceMetadata, ids[0]))
+	return tooldocs.WithTraceID(ctx, ids[0])
+}
+
+// statusFor maps a store error to a status error.
+func statusFor(err error) error {
+	code := codes.Unknown
+	switch {
+	case errors.Is(err, tooldocs.ErrNoTool):
+		code = codes.FailedPrecondition
+	case errors.Is(err, tooldocs.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, tooldocs.ErrUnauthorized):
+		code = codes.PermissionDenied
+	case errors.Is(err, tooldocs.ErrInvalidDetail):
+		code = codes.InvalidArgument
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, err.Error())
+}
+
+// exampleToProto converts an example, normalizing Args through JSON when
+// they hold values structpb cannot represent directly.
+func exampleToProto(ex tooldocs.ToolExample) (*tooldocspb.Example, error) {
+	args, err := structpb.NewStruct(ex.Args)
+	if err != nil {
+		data, jerr := json.Marshal(ex.Args)
+		if jerr != nil {
+			return nil, err
+		}
+		var normalized map[string]any
+		if jerr := json.Unmarshal(data, &normalized); jerr != nil {
+			return nil, err
+		}
+		if args, err = structpb.NewStruct(normalized); err != nil {
+			return nil, err
+		}
+	}
+	return &tooldocspb.Example{
+		Id:          ex.ID,
+		Title:       ex.Title,
+		Description: ex.Description,
+		Args:        args,
+		ResultHint:  ex.ResultHint,
+		Requires:    ex.Requires,
+		Source:      ex.Source,
+	}, nil
+}
+
+// exampleFromProto converts an example message.
+func exampleFromProto(msg *tooldocspb.Example) tooldocs.ToolExample {
+	return tooldocs.ToolExample{
+		ID:          msg.GetId(),
+		Title:       msg.GetTitle(),
+		Description: msg.GetDescription(),
+		Args:        msg.GetArgs().AsMap(),
+		ResultHint:  msg.GetResultHint(),
+		Requires:    msg.GetRequires(),
+		Source:      msg.GetSource(),
+	}
+}
+
+// Client is a tooldocs.Store backed by a remote ToolDocs service. It is
+// safe for concurrent use.
+type Client struct {
+	rpc tooldocspb.ToolDocsClient
+}
+
+var _ tooldocs.Store = (*Client)(nil)
+
+// NewClient returns a Client calling the service on cc, typically a
+// *grpc.ClientConn. Deadlines and retries come from the call context and
+// the connection's service config.
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{rpc: tooldocspb.NewToolDocsClient(cc)}
+}
+
+// DescribeTool is DescribeToolContext with a background context.
+func (c *Client) DescribeTool(id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error) {
+	return c.DescribeToolContext(context.Background(), id, level)
+}
+
+// DescribeToolContext fetches the tool's documentation at level.
+func (c *Client) DescribeToolContext(ctx context.Context, id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error) {
+	resp, err := c.rpc.DescribeTool(outgoingTrace(ctx), &tooldocspb.DescribeToolRequest{Id: id, Level: string(level)})
+	if err != nil {
+		return tooldocs.ToolDoc{}, errorFor(err, id)
+	}
+	var doc tooldocs.ToolDoc
+	if err := json.Unmarshal(resp.GetDoc(), &doc); err != nil {
+		return tooldocs.ToolDoc{}, fmt.Errorf("grpcapi: decode doc: %w", err)
+	}
+	return doc, nil
+}
+
+// ListExamples is ListExamplesContext with a background context.
+func (c *Client) ListExamples(id string, maxExamples int) ([]tooldocs.ToolExample, error) {
+	return c.ListExamplesContext(context.Background(), id, maxExamples)
+}
+
+// ListExamplesContext fetches up to maxExamples examples for the tool.
+func (c *Client) ListExamplesContext(ctx context.Context, id string, maxExamples int) ([]tooldocs.ToolExample, error) {
+	if maxExamples < 0 {
+		maxExamples = 0
+	}
+	resp, err := c.rpc.ListExamples(outgoingTrace(ctx), &tooldocspb.ListExamplesRequest{Id: id, MaxExamples: int32(min(maxExamples, 1<<31-1))})
+	if err != nil {
+		return nil, errorFor(err, id)
+	}
+	examples := make([]tooldocs.ToolExample, 0, len(resp.GetExamples()))
+	for _, msg := range resp.GetExamples() {
+		examples = append(examples, exampleFromProto(msg))
+	}
+	return examples, nil
+}
+
+// ListTools fetches the IDs of the service's documented tools.
+func (c *Client) ListTools(ctx context.Context) ([]string, error) {
+	resp, err := c.rpc.ListTools(outgoingTrace(ctx), &tooldocspb.ListToolsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetIds(), nil
+}
+
+// outgoingTrace adds the caller's trace ID, if any, to the call metadata.
+func outgoingTrace(ctx context.Context) context.Context {
+	if trace := tooldocs.TraceID(ctx); trace != "" {
+		return metadata.AppendToOutgoingContext(ctx, TraceMetadata, trace)
+	}
+	return ctx
+}
+
+// errorFor maps a status error back to a tooldocs sentinel error; other
+// errors are returned unchanged.
+func errorFor(err error, id string) error {
+	var sentinel error
+	switch status.Code(err) {
+	case codes.NotFound:
+		sentinel = tooldocs.ErrNotFound
+	case codes.FailedPrecondition:
+		sentinel = tooldocs.ErrNoTool
+	case codes.PermissionDenied:
+		sentinel = tooldocs.ErrUnauthorized
+	case codes.InvalidArgument:
+		sentinel = tooldocs.ErrInvalidDetail
+	default:
+		return err
+	}
+	return fmt.Errorf("%w: %s", sentinel, id)
+}
@@ -0,0 +1,212 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/jonwraymond/tooldocs"
+	"github.com/jonwraymond/tooldocs/grpcapi/tooldocspb"
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestStore(t *testing.T) *tooldocs.InMemoryStore {
+	t.Helper()
+	tool := &toolmodel.Tool{
+		Namespace: "github",
+		Tool:      mcp.Tool{Name: "get_repo", Description: "Get a repository", InputSchema: map[string]any{"type": "object"}},
+	}
+	store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{
+		Environment: []string{"sandbox"},
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			if id == tool.ToolID() || id == "github:delete_repo" {
+				return tool, nil
+			}
+			return nil, nil
+		},
+	})
+	for id, entry := range map[string]tooldocs.DocEntry{
+		"github:get_repo": {
+			Summary: "Fetch repository metadata",
+			Notes:   "Requires auth.",
+			Examples: []tooldocs.ToolExample{
+				{ID: "basic", Title: "Basic", Args: map[string]any{"repo": "a/b", "depth": 2, "tags": []any{"x"}}, Requires: []string{"sandbox"}},
+				{Title: "Fork", Args: map[string]any{"repo": "c/d"}},
+			},
+		},
+		"local:echo":         {Summary: "Echo input"},
+		"github:delete_repo": {Summary: "Delete a repository", Notes: "Runbook.", Sensitive: true},
+	} {
+		if err := store.RegisterDoc(id, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store
+}
+
+// dial serves store on an in-memory listener and returns a connected
+// Client. The interceptor, if any, wraps the server's unary calls; opts are
+// added to the client's dial options.
+func dial(t *testing.T, store tooldocs.Store, interceptor grpc.UnaryServerInterceptor, opts ...grpc.DialOption) (*Client, *grpc.ClientConn) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	var serverOpts []grpc.ServerOption
+	if interceptor != nil {
+		serverOpts = append(serverOpts, grpc.UnaryInterceptor(interceptor))
+	}
+	srv := grpc.NewServer(serverOpts...)
+	tooldocspb.RegisterToolDocsServer(srv, NewServer(store))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet", append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewClient(conn), conn
+}
+
+func TestRoundTrip(t *testing.T) {
+	c, _ := dial(t, newTestStore(t), nil)
+
+	doc, err := c.DescribeTool("github:get_repo", tooldocs.DetailFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Summary != "Fetch repository metadata" || doc.Notes != "Requires auth." || len(doc.Examples) != 2 {
+		t.Fatalf("doc = %+v", doc)
+	}
+	if doc.Tool == nil || doc.Tool.Name != "get_repo" {
+		t.Fatalf("doc.Tool = %+v", doc.Tool)
+	}
+
+	doc, err = c.DescribeTool("github:get_repo", "")
+	if err != nil || doc.Notes != "" || doc.Summary == "" {
+		t.Fatalf("default level: doc = %+v, err = %v", doc, err)
+	}
+
+	examples, err := c.ListExamples("github:get_repo", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(examples) != 1 {
+		t.Fatalf("examples = %+v", examples)
+	}
+	ex := examples[0]
+	if ex.ID != "basic" || ex.Title != "Basic" || ex.Args["repo"] != "a/b" || ex.Args["depth"] != float64(2) ||
+		len(ex.Requires) != 1 || ex.Requires[0] != "sandbox" {
+		t.Fatalf("example = %+v", ex)
+	}
+
+	ids, err := c.ListTools(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[0] != "github:delete_repo" {
+		t.Fatalf("ids = %v", ids)
+	}
+}
+
+func TestErrorMapping(t *testing.T) {
+	c, conn := dial(t, newTestStore(t), nil)
+
+	tests := []struct {
+		name  string
+		id    string
+		level tooldocs.DetailLevel
+		want  error
+		code  codes.Code
+	}{
+		{"not found", "github:missing", tooldocs.DetailSummary, tooldocs.ErrNotFound, codes.NotFound},
+		{"no tool", "local:echo", tooldocs.DetailSchema, tooldocs.ErrNoTool, codes.FailedPrecondition},
+		{"unauthorized", "github:delete_repo", tooldocs.DetailFull, tooldocs.ErrUnauthorized, codes.PermissionDenied},
+		{"invalid detail", "github:get_repo", "verbose", tooldocs.ErrInvalidDetail, codes.InvalidArgument},
+	}
+	raw := tooldocspb.NewToolDocsClient(conn)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.DescribeTool(tt.id, tt.level)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+			if tt.want == tooldocs.ErrNoTool && errors.Is(err, tooldocs.ErrNotFound) {
+				t.Errorf("ErrNoTool also matches ErrNotFound")
+			}
+			_, err = raw.DescribeTool(context.Background(), &tooldocspb.DescribeToolRequest{Id: tt.id, Level: string(tt.level)})
+			if got := status.Code(err); got != tt.code {
+				t.Errorf("status code = %v, want %v", got, tt.code)
+			}
+		})
+	}
+
+	if _, err := c.ListExamples("github:missing", 1); !errors.Is(err, tooldocs.ErrNotFound) {
+		t.Errorf("ListExamples err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGrantsAndTrace(t *testing.T) {
+	var trace string
+	store := newTestStore(t)
+	// Calls carrying "x-test-grant" metadata are authorized for sensitive docs.
+	grant := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, _ := metadata.FromIncomingContext(ctx); len(md.Get("x-test-grant")) > 0 {
+			ctx = tooldocs.WithGrants(ctx, tooldocs.GrantSensitiveDocs)
+		}
+		return handler(ctx, req)
+	}
+	c, _ := dial(t, traceStore{InMemoryStore: store, trace: &trace}, grant)
+
+	ctx := tooldocs.WithTraceID(context.Background(), "req-7")
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-test-grant", "1")
+	doc, err := c.DescribeToolContext(ctx, "github:delete_repo", tooldocs.DetailFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Notes != "Runbook." {
+		t.Errorf("notes = %q, want sensitive notes with grant", doc.Notes)
+	}
+	if trace != "req-7" {
+		t.Errorf("store saw trace %q, want req-7", trace)
+	}
+}
+
+// traceStore records the trace ID its describe calls receive.
+type traceStore struct {
+	*tooldocs.InMemoryStore
+	trace *string
+}
+
+func (s traceStore) DescribeToolContext(ctx context.Context, id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error) {
+	*s.trace = tooldocs.TraceID(ctx)
+	return s.InMemoryStore.DescribeToolContext(ctx, id, level)
+}
+
+func TestDictionaryCompressor(t *testing.T) {
+	store := newTestStore(t)
+	name := RegisterDictionary(store.TrainDictionary(0))
+	if encoding.GetCompressor(name) == nil {
+		t.Fatalf("compressor %q not registered", name)
+	}
+
+	c, _ := dial(t, store, nil, grpc.WithDefaultCallOptions(grpc.UseCompressor(name)))
+	doc, err := c.DescribeTool("github:get_repo", tooldocs.DetailFull)
+	if err != nil || doc.Notes != "Requires auth." {
+		t.Fatalf("doc = %+v, %v", doc, err)
+	}
+	if examples, err := c.ListExamples("github:get_repo", 0); err != nil || len(examples) != 2 {
+		t.Fatalf("examples = %+v, %v", examples, err)
+	}
+}
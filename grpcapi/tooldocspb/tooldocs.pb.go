@@ -0,0 +1,489 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: tooldocs/v1/tooldocs.proto
+
+package tooldocspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DescribeToolRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Canonical tool ID, e.g. "github:get_repo".
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Detail level: "summary" (default when empty), "schema", or "full".
+	Level         string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeToolRequest) Reset() {
+	*x = DescribeToolRequest{}
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeToolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeToolRequest) ProtoMessage() {}
+
+func (x *DescribeToolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeToolRequest.ProtoReflect.Descriptor instead.
+func (*DescribeToolRequest) Descriptor() ([]byte, []int) {
+	return file_tooldocs_v1_tooldocs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DescribeToolRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DescribeToolRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+type DescribeToolResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The tooldocs.ToolDoc encoded as JSON, as served by httpapi. The doc
+	// model grows with the package, so it is not mirrored field by field.
+	Doc           []byte `protobuf:"bytes,1,opt,name=doc,proto3" json:"doc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeToolResponse) Reset() {
+	*x = DescribeToolResponse{}
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeToolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeToolResponse) ProtoMessage() {}
+
+func (x *DescribeToolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeToolResponse.ProtoReflect.Descriptor instead.
+func (*DescribeToolResponse) Descriptor() ([]byte, []int) {
+	return file_tooldocs_v1_tooldocs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DescribeToolResponse) GetDoc() []byte {
+	if x != nil {
+		return x.Doc
+	}
+	return nil
+}
+
+type ListExamplesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Maximum number of examples; zero returns none.
+	MaxExamples   int32 `protobuf:"varint,2,opt,name=max_examples,json=maxExamples,proto3" json:"max_examples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExamplesRequest) Reset() {
+	*x = ListExamplesRequest{}
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExamplesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExamplesRequest) ProtoMessage() {}
+
+func (x *ListExamplesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExamplesRequest.ProtoReflect.Descriptor instead.
+func (*ListExamplesRequest) Descriptor() ([]byte, []int) {
+	return file_tooldocs_v1_tooldocs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListExamplesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListExamplesRequest) GetMaxExamples() int32 {
+	if x != nil {
+		return x.MaxExamples
+	}
+	return 0
+}
+
+type ListExamplesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Examples      []*Example             `protobuf:"bytes,1,rep,name=examples,proto3" json:"examples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExamplesResponse) Reset() {
+	*x = ListExamplesResponse{}
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExamplesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExamplesResponse) ProtoMessage() {}
+
+func (x *ListExamplesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExamplesResponse.ProtoReflect.Descriptor instead.
+func (*ListExamplesResponse) Descriptor() ([]byte, []int) {
+	return file_tooldocs_v1_tooldocs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListExamplesResponse) GetExamples() []*Example {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+// Example mirrors tooldocs.ToolExample.
+type Example struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Tool call arguments. Numbers are carried as doubles.
+	Args          *structpb.Struct `protobuf:"bytes,4,opt,name=args,proto3" json:"args,omitempty"`
+	ResultHint    string           `protobuf:"bytes,5,opt,name=result_hint,json=resultHint,proto3" json:"result_hint,omitempty"`
+	Requires      []string         `protobuf:"bytes,6,rep,name=requires,proto3" json:"requires,omitempty"`
+	Source        string           `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Example) Reset() {
+	*x = Example{}
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Example) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Example) ProtoMessage() {}
+
+func (x *Example) ProtoReflect() protoreflect.Message {
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Example.ProtoReflect.Descriptor instead.
+func (*Example) Descriptor() ([]byte, []int) {
+	return file_tooldocs_v1_tooldocs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Example) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Example) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Example) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Example) GetArgs() *structpb.Struct {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *Example) GetResultHint() string {
+	if x != nil {
+		return x.ResultHint
+	}
+	return ""
+}
+
+func (x *Example) GetRequires() []string {
+	if x != nil {
+		return x.Requires
+	}
+	return nil
+}
+
+func (x *Example) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type ListToolsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListToolsRequest) Reset() {
+	*x = ListToolsRequest{}
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListToolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListToolsRequest) ProtoMessage() {}
+
+func (x *ListToolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListToolsRequest.ProtoReflect.Descriptor instead.
+func (*ListToolsRequest) Descriptor() ([]byte, []int) {
+	return file_tooldocs_v1_tooldocs_proto_rawDescGZIP(), []int{5}
+}
+
+type ListToolsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListToolsResponse) Reset() {
+	*x = ListToolsResponse{}
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListToolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListToolsResponse) ProtoMessage() {}
+
+func (x *ListToolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tooldocs_v1_tooldocs_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListToolsResponse.ProtoReflect.Descriptor instead.
+func (*ListToolsResponse) Descriptor() ([]byte, []int) {
+	return file_tooldocs_v1_tooldocs_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListToolsResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+var File_tooldocs_v1_tooldocs_proto protoreflect.FileDescriptor
+
+const file_tooldocs_v1_tooldocs_proto_rawDesc = "" +
+	"\n" +
+	"\x1atooldocs/v1/tooldocs.proto\x12\vtooldocs.v1\x1a\x1cgoogle/protobuf/struct.proto\";\n" +
+	"\x13DescribeToolRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\"(\n" +
+	"\x14DescribeToolResponse\x12\x10\n" +
+	"\x03doc\x18\x01 \x01(\fR\x03doc\"H\n" +
+	"\x13ListExamplesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fmax_examples\x18\x02 \x01(\x05R\vmaxExamples\"H\n" +
+	"\x14ListExamplesResponse\x120\n" +
+	"\bexamples\x18\x01 \x03(\v2\x14.tooldocs.v1.ExampleR\bexamples\"\xd3\x01\n" +
+	"\aExample\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12+\n" +
+	"\x04args\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x04args\x12\x1f\n" +
+	"\vresult_hint\x18\x05 \x01(\tR\n" +
+	"resultHint\x12\x1a\n" +
+	"\brequires\x18\x06 \x03(\tR\brequires\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\"\x12\n" +
+	"\x10ListToolsRequest\"%\n" +
+	"\x11ListToolsResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids2\x80\x02\n" +
+	"\bToolDocs\x12S\n" +
+	"\fDescribeTool\x12 .tooldocs.v1.DescribeToolRequest\x1a!.tooldocs.v1.DescribeToolResponse\x12S\n" +
+	"\fListExamples\x12 .tooldocs.v1.ListExamplesRequest\x1a!.tooldocs.v1.ListExamplesResponse\x12J\n" +
+	"\tListTools\x12\x1d.tooldocs.v1.ListToolsRequest\x1a\x1e.tooldocs.v1.ListToolsResponseB4Z2github.com/jonwraymond/tooldocs/grpcapi/tooldocspbb\x06proto3"
+
+var (
+	file_tooldocs_v1_tooldocs_proto_rawDescOnce sync.Once
+	file_tooldocs_v1_tooldocs_proto_rawDescData []byte
+)
+
+func file_tooldocs_v1_tooldocs_proto_rawDescGZIP() []byte {
+	file_tooldocs_v1_tooldocs_proto_rawDescOnce.Do(func() {
+		file_tooldocs_v1_tooldocs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tooldocs_v1_tooldocs_proto_rawDesc), len(file_tooldocs_v1_tooldocs_proto_rawDesc)))
+	})
+	return file_tooldocs_v1_tooldocs_proto_rawDescData
+}
+
+var file_tooldocs_v1_tooldocs_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_tooldocs_v1_tooldocs_proto_goTypes = []any{
+	(*DescribeToolRequest)(nil),  // 0: tooldocs.v1.DescribeToolRequest
+	(*DescribeToolResponse)(nil), // 1: tooldocs.v1.DescribeToolResponse
+	(*ListExamplesRequest)(nil),  // 2: tooldocs.v1.ListExamplesRequest
+	(*ListExamplesResponse)(nil), // 3: tooldocs.v1.ListExamplesResponse
+	(*Example)(nil),              // 4: tooldocs.v1.Example
+	(*ListToolsRequest)(nil),     // 5: tooldocs.v1.ListToolsRequest
+	(*ListToolsResponse)(nil),    // 6: tooldocs.v1.ListToolsResponse
+	(*structpb.Struct)(nil),      // 7: google.protobuf.Struct
+}
+var file_tooldocs_v1_tooldocs_proto_depIdxs = []int32{
+	4, // 0: tooldocs.v1.ListExamplesResponse.examples:type_name -> tooldocs.v1.Example
+	7, // 1: tooldocs.v1.Example.args:type_name -> google.protobuf.Struct
+	0, // 2: tooldocs.v1.ToolDocs.DescribeTool:input_type -> tooldocs.v1.DescribeToolRequest
+	2, // 3: tooldocs.v1.ToolDocs.ListExamples:input_type -> tooldocs.v1.ListExamplesRequest
+	5, // 4: tooldocs.v1.ToolDocs.ListTools:input_type -> tooldocs.v1.ListToolsRequest
+	1, // 5: tooldocs.v1.ToolDocs.DescribeTool:output_type -> tooldocs.v1.DescribeToolResponse
+	3, // 6: tooldocs.v1.ToolDocs.ListExamples:output_type -> tooldocs.v1.ListExamplesResponse
+	6, // 7: tooldocs.v1.ToolDocs.ListTools:output_type -> tooldocs.v1.ListToolsResponse
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_tooldocs_v1_tooldocs_proto_init() }
+func file_tooldocs_v1_tooldocs_proto_init() {
+	if File_tooldocs_v1_tooldocs_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tooldocs_v1_tooldocs_proto_rawDesc), len(file_tooldocs_v1_tooldocs_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tooldocs_v1_tooldocs_proto_goTypes,
+		DependencyIndexes: file_tooldocs_v1_tooldocs_proto_depIdxs,
+		MessageInfos:      file_tooldocs_v1_tooldocs_proto_msgTypes,
+	}.Build()
+	File_tooldocs_v1_tooldocs_proto = out.File
+	file_tooldocs_v1_tooldocs_proto_goTypes = nil
+	file_tooldocs_v1_tooldocs_proto_depIdxs = nil
+}
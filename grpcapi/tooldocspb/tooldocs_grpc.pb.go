@@ -0,0 +1,221 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: tooldocs/v1/tooldocs.proto
+
+package tooldocspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ToolDocs_DescribeTool_FullMethodName = "/tooldocs.v1.ToolDocs/DescribeTool"
+	ToolDocs_ListExamples_FullMethodName = "/tooldocs.v1.ToolDocs/ListExamples"
+	ToolDocs_ListTools_FullMethodName    = "/tooldocs.v1.ToolDocs/ListTools"
+)
+
+// ToolDocsClient is the client API for ToolDocs service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ToolDocs serves a tooldocs.Store between services. See package grpcapi
+// for the Go server and client.
+//
+// Store errors are reported as status codes: NOT_FOUND for ErrNotFound,
+// FAILED_PRECONDITION for ErrNoTool (docs exist but the tool definition
+// cannot be resolved), PERMISSION_DENIED for ErrUnauthorized, and
+// INVALID_ARGUMENT for ErrInvalidDetail.
+type ToolDocsClient interface {
+	// DescribeTool returns a tool's documentation at one detail level.
+	DescribeTool(ctx context.Context, in *DescribeToolRequest, opts ...grpc.CallOption) (*DescribeToolResponse, error)
+	// ListExamples returns up to max_examples examples for a tool.
+	ListExamples(ctx context.Context, in *ListExamplesRequest, opts ...grpc.CallOption) (*ListExamplesResponse, error)
+	// ListTools returns the IDs of documented tools. Stores that cannot list
+	// their tools answer UNIMPLEMENTED.
+	ListTools(ctx context.Context, in *ListToolsRequest, opts ...grpc.CallOption) (*ListToolsResponse, error)
+}
+
+type toolDocsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewToolDocsClient(cc grpc.ClientConnInterface) ToolDocsClient {
+	return &toolDocsClient{cc}
+}
+
+func (c *toolDocsClient) DescribeTool(ctx context.Context, in *DescribeToolRequest, opts ...grpc.CallOption) (*DescribeToolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DescribeToolResponse)
+	err := c.cc.Invoke(ctx, ToolDocs_DescribeTool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolDocsClient) ListExamples(ctx context.Context, in *ListExamplesRequest, opts ...grpc.CallOption) (*ListExamplesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExamplesResponse)
+	err := c.cc.Invoke(ctx, ToolDocs_ListExamples_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolDocsClient) ListTools(ctx context.Context, in *ListToolsRequest, opts ...grpc.CallOption) (*ListToolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListToolsResponse)
+	err := c.cc.Invoke(ctx, ToolDocs_ListTools_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ToolDocsServer is the server API for ToolDocs service.
+// All implementations must embed UnimplementedToolDocsServer
+// for forward compatibility.
+//
+// ToolDocs serves a tooldocs.Store between services. See package grpcapi
+// for the Go server and client.
+//
+// Store errors are reported as status codes: NOT_FOUND for ErrNotFound,
+// FAILED_PRECONDITION for ErrNoTool (docs exist but the tool definition
+// cannot be resolved), PERMISSION_DENIED for ErrUnauthorized, and
+// INVALID_ARGUMENT for ErrInvalidDetail.
+type ToolDocsServer interface {
+	// DescribeTool returns a tool's documentation at one detail level.
+	DescribeTool(context.Context, *DescribeToolRequest) (*DescribeToolResponse, error)
+	// ListExamples returns up to max_examples examples for a tool.
+	ListExamples(context.Context, *ListExamplesRequest) (*ListExamplesResponse, error)
+	// ListTools returns the IDs of documented tools. Stores that cannot list
+	// their tools answer UNIMPLEMENTED.
+	ListTools(context.Context, *ListToolsRequest) (*ListToolsResponse, error)
+	mustEmbedUnimplementedToolDocsServer()
+}
+
+// UnimplementedToolDocsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedToolDocsServer struct{}
+
+func (UnimplementedToolDocsServer) DescribeTool(context.Context, *DescribeToolRequest) (*DescribeToolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeTool not implemented")
+}
+func (UnimplementedToolDocsServer) ListExamples(context.Context, *ListExamplesRequest) (*ListExamplesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExamples not implemented")
+}
+func (UnimplementedToolDocsServer) ListTools(context.Context, *ListToolsRequest) (*ListToolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTools not implemented")
+}
+func (UnimplementedToolDocsServer) mustEmbedUnimplementedToolDocsServer() {}
+func (UnimplementedToolDocsServer) testEmbeddedByValue()                  {}
+
+// UnsafeToolDocsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ToolDocsServer will
+// result in compilation errors.
+type UnsafeToolDocsServer interface {
+	mustEmbedUnimplementedToolDocsServer()
+}
+
+func RegisterToolDocsServer(s grpc.ServiceRegistrar, srv ToolDocsServer) {
+	// If the following call pancis, it indicates UnimplementedToolDocsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ToolDocs_ServiceDesc, srv)
+}
+
+func _ToolDocs_DescribeTool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeToolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolDocsServer).DescribeTool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolDocs_DescribeTool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolDocsServer).DescribeTool(ctx, req.(*DescribeToolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolDocs_ListExamples_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExamplesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolDocsServer).ListExamples(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolDocs_ListExamples_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolDocsServer).ListExamples(ctx, req.(*ListExamplesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolDocs_ListTools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListToolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolDocsServer).ListTools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolDocs_ListTools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolDocsServer).ListTools(ctx, req.(*ListToolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ToolDocs_ServiceDesc is the grpc.ServiceDesc for ToolDocs service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ToolDocs_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tooldocs.v1.ToolDocs",
+	HandlerType: (*ToolDocsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DescribeTool",
+			Handler:    _ToolDocs_DescribeTool_Handler,
+		},
+		{
+			MethodName: "ListExamples",
+			Handler:    _ToolDocs_ListExamples_Handler,
+		},
+		{
+			MethodName: "ListTools",
+			Handler:    _ToolDocs_ListTools_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tooldocs/v1/tooldocs.proto",
+}
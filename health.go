@@ -0,0 +1,139 @@
+package tooldocs
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Health is a point-in-time view of a store's operational state, suitable
+// for wiring into readiness/liveness probes of a docs service.
+type Health struct {
+	// OK is false when the backend is unreachable or the most recent import
+	// failed.
+	OK bool `json:"ok"`
+
+	// Docs is the number of tools with registered documentation.
+	Docs int `json:"docs"`
+
+	// Frozen is the number of frozen doc records.
+	Frozen int `json:"frozen"`
+
+	// Stale is the number of docs that are out of date: past their TTL
+	// (see StoreOptions.DefaultDocTTL), or for a tool whose input schema
+	// drifted in the latest maintenance run (see RunMaintenance). Stale
+	// docs do not affect OK.
+	Stale int `json:"stale"`
+
+	// LastSync maps a source kind to the time of its last successful Import.
+	// The kind is the part of ImportOptions.Source before the first ":"
+	// (e.g. "bundle", "mirror", "importer"); unattributed imports use "".
+	LastSync map[string]time.Time `json:"lastSync,omitempty"`
+
+	// ImportErrors counts failed imports since the store was created.
+	ImportErrors int `json:"importErrors"`
+
+	// LastImportError is the error of the most recent import, or "" if it
+	// succeeded.
+	LastImportError string `json:"lastImportError,omitempty"`
+
+	// Backend reports connectivity of the tool lookup backends.
+	Backend BackendHealth `json:"backend"`
+
+	// Maintenance summarizes the latest maintenance run (see
+	// RunMaintenance), or nil if none has run. Documentation problems it
+	// finds do not affect OK.
+	Maintenance *MaintenanceHealth `json:"maintenance,omitempty"`
+}
+
+// BackendHealth reports connectivity of the tool lookup backends.
+type BackendHealth struct {
+	// IndexConfigured reports whether StoreOptions.Index is set.
+	IndexConfigured bool `json:"indexConfigured"`
+
+	// IndexReachable reports whether the index answered a probe
+	// (ListNamespaces). False when no index is configured.
+	IndexReachable bool `json:"indexReachable"`
+
+	// ResolverConfigured reports whether StoreOptions.ToolResolver or
+	// BatchToolResolver is set.
+	ResolverConfigured bool `json:"resolverConfigured"`
+
+	// Error is the index probe error, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// healthState tracks import outcomes for Health.
+type healthState struct {
+	mu           sync.Mutex
+	lastSync     map[string]time.Time
+	importErrors int
+	lastErr      string
+}
+
+// recordImport records the outcome of an import from source at now.
+func (h *healthState) recordImport(source string, err error, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err != nil {
+		h.importErrors++
+		h.lastErr = err.Error()
+		return
+	}
+	if h.lastSync == nil {
+		h.lastSync = make(map[string]time.Time)
+	}
+	kind, _, _ := strings.Cut(source, ":")
+	h.lastSync[kind] = now
+	h.lastErr = ""
+}
+
+// Health reports the store's operational state. When an index is
+// configured it is probed with ListNamespaces, so Health may block for as
+// long as the index takes to answer.
+func (s *InMemoryStore) Health() Health {
+	h := Health{
+		Backend: BackendHealth{
+			IndexConfigured:    s.index != nil,
+			ResolverConfigured: s.hasResolver(),
+		},
+	}
+
+	if s.index != nil {
+		if _, err := s.index.ListNamespaces(); err != nil {
+			h.Backend.Error = err.Error()
+		} else {
+			h.Backend.IndexReachable = true
+		}
+	}
+
+	drifted := s.maintenance.drifted()
+	s.mu.RLock()
+	h.Docs = len(s.docs)
+	for id, record := range s.docs {
+		if record.frozen {
+			h.Frozen++
+		}
+		if s.expired(record) || drifted[id] {
+			h.Stale++
+		}
+	}
+	s.mu.RUnlock()
+
+	s.health.mu.Lock()
+	if len(s.health.lastSync) > 0 {
+		h.LastSync = make(map[string]time.Time, len(s.health.lastSync))
+		for k, v := range s.health.lastSync {
+			h.LastSync[k] = v
+		}
+	}
+	h.ImportErrors = s.health.importErrors
+	h.LastImportError = s.health.lastErr
+	s.health.mu.Unlock()
+
+	h.Maintenance = s.maintenance.summary()
+
+	h.OK = h.LastImportError == "" && (!h.Backend.IndexConfigured || h.Backend.IndexReachable)
+	return h
+}
@@ -0,0 +1,92 @@
+package tooldocs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolindex"
+)
+
+// failingIndex is a toolindex.Index whose discovery calls fail.
+type failingIndex struct {
+	toolindex.Index
+}
+
+func (failingIndex) ListNamespaces() ([]string, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestHealth(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{Index: toolindex.NewInMemoryIndex()})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "a"})
+	if err := store.Freeze("ns:a"); err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+
+	h := store.Health()
+	if !h.OK || h.Docs != 1 || h.Frozen != 1 || !h.Backend.IndexConfigured || !h.Backend.IndexReachable {
+		t.Errorf("Health = %+v", h)
+	}
+	if h.LastSync != nil {
+		t.Errorf("LastSync = %v, want nil before any import", h.LastSync)
+	}
+
+	if _, err := store.Import(map[string]DocEntry{"ns:b": {}}, ImportOptions{Source: "mirror:https://upstream"}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	h = store.Health()
+	if h.LastSync["mirror"].IsZero() {
+		t.Errorf("LastSync = %v, want mirror entry", h.LastSync)
+	}
+
+	if _, err := store.Import(nil, ImportOptions{Conflict: "bogus", Source: "bundle:a.json"}); err == nil {
+		t.Fatal("expected import error")
+	}
+	h = store.Health()
+	if h.OK || h.ImportErrors != 1 || h.LastImportError == "" {
+		t.Errorf("Health after failed import = %+v", h)
+	}
+	if _, ok := h.LastSync["bundle"]; ok {
+		t.Error("failed import must not update LastSync")
+	}
+
+	if _, err := store.Import(map[string]DocEntry{"ns:c": {}}, ImportOptions{Source: "bundle:a.json"}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if h = store.Health(); !h.OK || h.ImportErrors != 1 {
+		t.Errorf("Health after recovery = %+v", h)
+	}
+}
+
+func TestHealth_Stale(t *testing.T) {
+	store, clock := newTTLStore(StoreOptions{DefaultDocTTL: time.Hour})
+	if _, err := store.Import(map[string]DocEntry{
+		"ns:a": {Summary: "a"},
+		"ns:b": {Summary: "b"},
+	}, ImportOptions{Source: "bundle:a.json"}); err != nil {
+		t.Fatal(err)
+	}
+	if h := store.Health(); h.Stale != 0 || !h.LastSync["bundle"].Equal(clock.now()) {
+		t.Errorf("Health = %+v, want no stale docs and LastSync from the store clock", h)
+	}
+
+	clock.advance(2 * time.Hour)
+	if err := store.RegisterDocWithOptions("ns:a", DocEntry{Summary: "a"}, RegisterOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	mustRegisterDoc(t, store, "ns:c", DocEntry{Summary: "c"})
+	store.maintenance.record([]MaintenanceResult{{ID: "ns:c", Drifted: true}})
+	if h := store.Health(); h.Stale != 2 || !h.OK {
+		t.Errorf("Health = %+v, want 2 stale docs (ns:b expired, ns:c drifted)", h)
+	}
+}
+
+func TestHealth_IndexUnreachable(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{Index: failingIndex{}})
+
+	h := store.Health()
+	if h.OK || h.Backend.IndexReachable || h.Backend.Error == "" {
+		t.Errorf("Health = %+v, want unreachable index", h)
+	}
+}
@@ -0,0 +1,213 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// ErrHookPanic is matched (via errors.Is) by errors reporting a panic in a
+// user-supplied hook. Use errors.As with *HookPanicError for details.
+var ErrHookPanic = errors.New("hook panicked")
+
+// ErrLookupTimeout is returned when an Index.GetTool or ToolResolver call
+// does not answer within StoreOptions.LookupTimeout.
+var ErrLookupTimeout = errors.New("tool lookup timed out")
+
+// HookPanicError reports a panic recovered from a user-supplied hook such as
+// StoreOptions.ToolResolver, so one buggy hook cannot crash the serving
+// process.
+type HookPanicError struct {
+	// Hook names the hook that panicked (e.g. "ToolResolver").
+	Hook string
+
+	// Value is the value passed to panic.
+	Value any
+
+	// Stack is the goroutine stack captured at the panic.
+	Stack []byte
+}
+
+// Error implements error.
+func (e *HookPanicError) Error() string {
+	return fmt.Sprintf("%v: %s: %v", ErrHookPanic, e.Hook, e.Value)
+}
+
+// Unwrap returns ErrHookPanic.
+func (e *HookPanicError) Unwrap() error {
+	return ErrHookPanic
+}
+
+// Hook names reported in HookPanicError.Hook.
+const (
+	HookToolResolver        = "ToolResolver"
+	HookContextToolResolver = "ContextToolResolver"
+	HookBatchToolResolver   = "BatchToolResolver"
+	HookIndex               = "Index"
+	HookAuthorizeSensitive  = "AuthorizeSensitive"
+	HookLinkChecker         = "LinkChecker"
+	HookToolInvoker         = "ToolInvoker"
+	HookAssignVariant       = "AssignVariant"
+	HookOnExposure          = "OnExposure"
+	HookSummarizer          = "Summarizer"
+	HookOnRead              = "OnRead"
+	HookOnCallOutcome       = "OnCallOutcome"
+	HookPersister           = "Persister"
+	HookDocResolver         = "DocResolver"
+	HookAnswerer            = "Answerer"
+	HookResourceLoader      = "ResourceLoader"
+)
+
+// callHook runs fn, converting a panic into a *HookPanicError.
+func callHook[T any](hook string, fn func() (T, error)) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			result, err = zero, &HookPanicError{Hook: hook, Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn()
+}
+
+// hasResolver reports whether any resolver hook is configured.
+func (s *InMemoryStore) hasResolver() bool {
+	return s.ctxResolver != nil || s.toolResolver != nil || s.batchResolver != nil
+}
+
+// callResolver invokes StoreOptions.ContextToolResolver, else ToolResolver
+// (or BatchToolResolver for a single ID when neither is set), recovering
+// panics and applying StoreOptions.LookupTimeout.
+func (s *InMemoryStore) callResolver(ctx context.Context, id string) (*toolmodel.Tool, error) {
+	if s.ctxResolver != nil {
+		return s.callContextResolver(ctx, id)
+	}
+	if s.toolResolver == nil {
+		tools, err := s.callBatchResolver([]string{id})
+		return tools[id], err
+	}
+	return withTimeout(s.lookupTimeout, &s.abandoned, id, func() (*toolmodel.Tool, error) {
+		return callHook(HookToolResolver, func() (*toolmodel.Tool, error) {
+			return s.toolResolver(id)
+		})
+	})
+}
+
+// callContextResolver invokes StoreOptions.ContextToolResolver with ctx
+// bounded by StoreOptions.LookupTimeout, so a timed-out call is cancelled
+// rather than left running. A resolver that returns because the timeout
+// cancelled it fails with ErrLookupTimeout, like one that does not return.
+func (s *InMemoryStore) callContextResolver(ctx context.Context, id string) (*toolmodel.Tool, error) {
+	call := func() (*toolmodel.Tool, error) {
+		return callHook(HookContextToolResolver, func() (*toolmodel.Tool, error) {
+			return s.ctxResolver(ctx, id)
+		})
+	}
+	if s.lookupTimeout <= 0 {
+		return call()
+	}
+
+	timedOut := fmt.Errorf("%w: %s after %s", ErrLookupTimeout, id, s.lookupTimeout)
+	ctx, cancel := context.WithTimeoutCause(ctx, s.lookupTimeout, timedOut)
+	defer cancel()
+	tool, err := withTimeout(s.lookupTimeout, &s.abandoned, id, call)
+	if err != nil && context.Cause(ctx) == timedOut {
+		return nil, timedOut
+	}
+	return tool, err
+}
+
+// callBatchResolver invokes StoreOptions.BatchToolResolver, recovering
+// panics and applying StoreOptions.LookupTimeout to the whole batch.
+func (s *InMemoryStore) callBatchResolver(ids []string) (map[string]*toolmodel.Tool, error) {
+	return withTimeout(s.lookupTimeout, &s.abandoned, strings.Join(ids, ","), func() (map[string]*toolmodel.Tool, error) {
+		return callHook(HookBatchToolResolver, func() (map[string]*toolmodel.Tool, error) {
+			return s.batchResolver(ids)
+		})
+	})
+}
+
+// lookupIndex calls Index.GetTool, recovering panics and applying
+// StoreOptions.LookupTimeout.
+func (s *InMemoryStore) lookupIndex(id string) (*toolmodel.Tool, error) {
+	return withTimeout(s.lookupTimeout, &s.abandoned, id, func() (*toolmodel.Tool, error) {
+		return callHook(HookIndex, func() (*toolmodel.Tool, error) {
+			t, _, err := s.index.GetTool(id)
+			if err != nil {
+				return nil, err
+			}
+			return &t, nil
+		})
+	})
+}
+
+// maxAbandonedLookups bounds the timed-out lookups that may still be
+// running per store, so an upstream that hangs for good cannot pile up
+// goroutines.
+const maxAbandonedLookups = 64
+
+// Lookup states shared by withTimeout and the goroutine running the call.
+const (
+	lookupRunning int32 = iota
+	lookupFinished
+	lookupAbandoned
+)
+
+// withTimeout runs fn, giving up with ErrLookupTimeout after timeout. The
+// call is abandoned, not cancelled: it runs to completion in the background,
+// counted in abandoned until it returns. While maxAbandonedLookups calls are
+// abandoned, withTimeout fails at once without calling fn. A zero or
+// negative timeout calls fn directly.
+func withTimeout[T any](timeout time.Duration, abandoned *atomic.Int64, id string, fn func() (T, error)) (T, error) {
+	if timeout <= 0 {
+		return fn()
+	}
+	var zero T
+	if n := abandoned.Load(); n >= maxAbandonedLookups {
+		return zero, fmt.Errorf("%w: %s: %d timed-out lookups still running", ErrLookupTimeout, id, n)
+	}
+
+	type outcome struct {
+		value T
+		err   error
+	}
+	done := make(chan outcome, 1) // buffered so an abandoned call can finish
+	var state atomic.Int32
+	go func() {
+		v, err := fn()
+		done <- outcome{v, err}
+		if !state.CompareAndSwap(lookupRunning, lookupFinished) {
+			abandoned.Add(-1)
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case o := <-done:
+		return o.value, o.err
+	case <-timer.C:
+		abandoned.Add(1)
+		if !state.CompareAndSwap(lookupRunning, lookupAbandoned) {
+			// The call finished as the timer fired.
+			abandoned.Add(-1)
+			o := <-done
+			return o.value, o.err
+		}
+		return zero, fmt.Errorf("%w: %s after %s", ErrLookupTimeout, id, timeout)
+	}
+}
+
+// callAuthorize invokes StoreOptions.AuthorizeSensitive. A panic is treated
+// as a denial.
+func (s *InMemoryStore) callAuthorize(ctx context.Context, id string) bool {
+	ok, err := callHook(HookAuthorizeSensitive, func() (bool, error) {
+		return s.authorize(ctx, id), nil
+	})
+	return err == nil && ok
+}
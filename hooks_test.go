@@ -0,0 +1,248 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolindex"
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestToolResolverPanic(t *testing.T) {
+	s := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			panic("resolver bug")
+		},
+	})
+	if err := s.RegisterDoc("x:tool", DocEntry{Summary: "Tool"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := s.DescribeTool("x:tool", DetailSchema)
+	if !errors.Is(err, ErrHookPanic) {
+		t.Fatalf("err = %v, want ErrHookPanic", err)
+	}
+	var hpe *HookPanicError
+	if !errors.As(err, &hpe) {
+		t.Fatalf("err = %T, want *HookPanicError", err)
+	}
+	if hpe.Hook != HookToolResolver || hpe.Value != "resolver bug" {
+		t.Errorf("HookPanicError = %+v", hpe)
+	}
+	if !strings.Contains(string(hpe.Stack), "TestToolResolverPanic") {
+		t.Error("stack does not include the panicking call site")
+	}
+
+	// The summary tier does not need the tool and keeps working.
+	if doc, err := s.DescribeTool("x:tool", DetailSummary); err != nil || doc.Summary != "Tool" {
+		t.Errorf("summary = %+v, %v", doc, err)
+	}
+}
+
+func TestAuthorizeSensitivePanic(t *testing.T) {
+	tool := &toolmodel.Tool{Tool: mcp.Tool{Name: "secret", InputSchema: map[string]any{"type": "object"}}}
+	s := NewInMemoryStore(StoreOptions{
+		ToolResolver:       func(string) (*toolmodel.Tool, error) { return tool, nil },
+		AuthorizeSensitive: func(context.Context, string) bool { panic("auth bug") },
+	})
+	if err := s.RegisterDoc("secret", DocEntry{Summary: "Secret", Sensitive: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DescribeTool("secret", DetailFull); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("err = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestLinkCheckerPanic(t *testing.T) {
+	s := NewInMemoryStore(StoreOptions{})
+	if err := s.RegisterDoc("x:tool", DocEntry{Summary: "Tool", ExternalRefs: []string{"https://example.com"}}); err != nil {
+		t.Fatal(err)
+	}
+	results, err := s.RunMaintenance(context.Background(), MaintenanceOptions{
+		Checks:      []MaintenanceCheck{CheckLinks},
+		LinkChecker: func(context.Context, string) error { panic("checker bug") },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || len(results[0].BrokenLinks) != 1 ||
+		!strings.Contains(results[0].BrokenLinks[0].Error, "checker bug") {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestToolInvokerPanic(t *testing.T) {
+	s := NewInMemoryStore(StoreOptions{})
+	if err := s.RegisterDoc("x:tool", DocEntry{Summary: "Tool", Examples: []ToolExample{{Title: "Basic"}}}); err != nil {
+		t.Fatal(err)
+	}
+	report, err := s.ReplayExamples(context.Background(), "x:tool", ToolInvokerFunc(
+		func(context.Context, string, *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+			panic("invoker bug")
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Failed != 1 || !strings.Contains(report.Results[0].Error, "invoker bug") {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+// panickingIndex is a toolindex.Index whose GetTool panics.
+type panickingIndex struct {
+	toolindex.Index
+}
+
+func (panickingIndex) GetTool(string) (toolmodel.Tool, toolmodel.ToolBackend, error) {
+	panic("index bug")
+}
+
+func TestIndexPanic(t *testing.T) {
+	// No LookupTimeout: the index is called on the caller's goroutine.
+	s := NewInMemoryStore(StoreOptions{Index: panickingIndex{}})
+	mustRegisterDoc(t, s, "x:tool", DocEntry{Summary: "Tool"})
+
+	_, err := s.lookupIndex("x:tool")
+	var hpe *HookPanicError
+	if !errors.As(err, &hpe) || hpe.Hook != HookIndex {
+		t.Fatalf("err = %v, want a HookIndex *HookPanicError", err)
+	}
+	// Reads treat the failed lookup like a missing tool.
+	if _, err := s.DescribeTool("x:tool", DetailSchema); !errors.Is(err, ErrNoTool) {
+		t.Errorf("DescribeTool err = %v, want ErrNoTool", err)
+	}
+}
+
+// slowIndex is a toolindex.Index whose GetTool blocks until release closes.
+type slowIndex struct {
+	toolindex.Index
+	release chan struct{}
+}
+
+func (i slowIndex) GetTool(id string) (toolmodel.Tool, toolmodel.ToolBackend, error) {
+	<-i.release
+	return toolmodel.Tool{}, toolmodel.ToolBackend{}, toolindex.ErrNotFound
+}
+
+func TestLookupTimeout_Index(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	s := NewInMemoryStore(StoreOptions{
+		Index:         slowIndex{release: release},
+		LookupTimeout: 20 * time.Millisecond,
+	})
+	mustRegisterDoc(t, s, "x:tool", DocEntry{Summary: "Tool"})
+
+	start := time.Now()
+	_, err := s.DescribeTool("x:tool", DetailSchema)
+	if !errors.Is(err, ErrLookupTimeout) {
+		t.Fatalf("err = %v, want ErrLookupTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("DescribeTool took %s", elapsed)
+	}
+	if doc, err := s.DescribeTool("x:tool", DetailSummary); err != nil || doc.Summary != "Tool" {
+		t.Errorf("summary = %+v, %v", doc, err)
+	}
+}
+
+func TestLookupTimeout_IndexFallsBackToResolver(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	tool := &toolmodel.Tool{Tool: mcp.Tool{Name: "tool", InputSchema: map[string]any{"type": "object"}}}
+	s := NewInMemoryStore(StoreOptions{
+		Index:         slowIndex{release: release},
+		ToolResolver:  func(string) (*toolmodel.Tool, error) { return tool, nil },
+		LookupTimeout: 20 * time.Millisecond,
+	})
+	mustRegisterDoc(t, s, "tool", DocEntry{Summary: "Tool"})
+	if doc, err := s.DescribeTool("tool", DetailSchema); err != nil || doc.Tool == nil {
+		t.Errorf("DescribeTool = %+v, %v", doc, err)
+	}
+}
+
+func TestLookupTimeout_Resolver(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	s := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(string) (*toolmodel.Tool, error) {
+			<-release
+			return nil, nil
+		},
+		LookupTimeout: 20 * time.Millisecond,
+	})
+	mustRegisterDoc(t, s, "x:tool", DocEntry{Summary: "Tool"})
+	if _, err := s.DescribeTool("x:tool", DetailFull); !errors.Is(err, ErrLookupTimeout) {
+		t.Errorf("err = %v, want ErrLookupTimeout", err)
+	}
+
+	report, err := s.Reconcile(ReconcileOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Errors) != 1 || !strings.Contains(report.Errors["x:tool"], "timed out") {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestLookupTimeout_CancelsContextResolver(t *testing.T) {
+	cancelled := make(chan error, 1)
+	s := NewInMemoryStore(StoreOptions{
+		ContextToolResolver: func(ctx context.Context, id string) (*toolmodel.Tool, error) {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("resolver context has no deadline")
+			}
+			<-ctx.Done()
+			cancelled <- ctx.Err()
+			return nil, ctx.Err()
+		},
+		LookupTimeout: 20 * time.Millisecond,
+	})
+	mustRegisterDoc(t, s, "x:tool", DocEntry{Summary: "Tool"})
+	if _, err := s.DescribeTool("x:tool", DetailFull); !errors.Is(err, ErrLookupTimeout) {
+		t.Errorf("err = %v, want ErrLookupTimeout", err)
+	}
+	select {
+	case err := <-cancelled:
+		if err == nil {
+			t.Error("resolver ctx err = nil, want cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed-out resolver was not cancelled")
+	}
+}
+
+func TestLookupTimeout_BoundsAbandonedCalls(t *testing.T) {
+	release := make(chan struct{})
+	calls := make(chan struct{}, maxAbandonedLookups+1)
+	s := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(string) (*toolmodel.Tool, error) {
+			calls <- struct{}{}
+			<-release
+			return nil, nil
+		},
+		LookupTimeout: time.Millisecond,
+	})
+	mustRegisterDoc(t, s, "x:tool", DocEntry{Summary: "Tool"})
+	for range maxAbandonedLookups + 1 {
+		if _, err := s.DescribeTool("x:tool", DetailFull); !errors.Is(err, ErrLookupTimeout) {
+			t.Fatalf("err = %v, want ErrLookupTimeout", err)
+		}
+	}
+	if n := len(calls); n != maxAbandonedLookups {
+		t.Errorf("resolver called %d times, want %d", n, maxAbandonedLookups)
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for s.abandoned.Load() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d abandoned lookups never finished", s.abandoned.Load())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,115 @@
+package httpapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// Dictionary compression headers. A client holding the handler's
+// dictionary (from GET /dictionary) sends "Accept-Encoding: tooldocs-dict"
+// and its dictionary ID in DictionaryHeader; when the ID matches, /tools
+// responses are deflated with the dictionary and carry
+// "Content-Encoding: tooldocs-dict". Every /tools response names the
+// current dictionary in DictionaryHeader, so clients notice a retrained
+// one.
+const (
+	DictionaryEncoding = "tooldocs-dict"
+	DictionaryHeader   = "Tooldocs-Dictionary"
+)
+
+// UseDictionary enables dictionary compression of /tools responses with d
+// (see tooldocs.TrainDictionary) and serves it at GET /dictionary. Call it
+// before serving requests. It returns h.
+func (h *Handler) UseDictionary(d tooldocs.Dictionary) *Handler {
+	h.dict = &d
+	return h
+}
+
+func (h *Handler) getDictionary(w http.ResponseWriter, _ *http.Request) {
+	if h.dict == nil {
+		writeError(w, http.StatusNotFound, errors.New("no dictionary configured"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set(DictionaryHeader, h.dict.ID)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(h.dict.Data)
+}
+
+// compressing returns w wrapped to compress the response with the
+// handler's dictionary when r accepts it, and a function finishing the
+// response.
+func (h *Handler) compressing(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func()) {
+	if h.dict == nil || !strings.HasPrefix(r.URL.Path, "/tools") {
+		return w, func() {}
+	}
+	w.Header().Set(DictionaryHeader, h.dict.ID)
+	w.Header().Add("Vary", "Accept-Encoding, "+DictionaryHeader)
+	if r.Header.Get(DictionaryHeader) != h.dict.ID || !acceptsEncoding(r, DictionaryEncoding) {
+		return w, func() {}
+	}
+	dw := &dictWriter{ResponseWriter: w, dict: h.dict}
+	return dw, dw.finish
+}
+
+// acceptsEncoding reports whether the Accept-Encoding header lists coding
+// with a non-zero quality.
+func acceptsEncoding(r *http.Request, coding string) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(name), coding) {
+			continue
+		}
+		q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !ok {
+			return true
+		}
+		v, err := strconv.ParseFloat(q, 64)
+		return err == nil && v > 0
+	}
+	return false
+}
+
+// dictWriter compresses successful responses with a dictionary; error
+// responses are sent as is.
+type dictWriter struct {
+	http.ResponseWriter
+	dict  *tooldocs.Dictionary
+	zw    io.WriteCloser
+	wrote bool
+}
+
+func (w *dictWriter) WriteHeader(status int) {
+	if w.wrote {
+		return
+	}
+	w.wrote = true
+	if status == http.StatusOK {
+		w.Header().Set("Content-Encoding", DictionaryEncoding)
+		w.Header().Del("Content-Length")
+		w.zw = w.dict.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *dictWriter) Write(p []byte) (int, error) {
+	if !w.wrote {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.zw != nil {
+		return w.zw.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// finish flushes the compressed payload.
+func (w *dictWriter) finish() {
+	if w.zw != nil {
+		_ = w.zw.Close()
+	}
+}
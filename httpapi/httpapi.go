@@ -0,0 +1,257 @@
+// Package httpapi serves a tooldocs.Store over HTTP.
+//
+// The handler exposes a small JSON API:
+//
+//	GET /tools                        documented tool IDs (stores implementing Lister)
+//	GET /tools/{id}/doc?level=LEVEL   ToolDoc at summary, schema, or full (default summary)
+//	    &naming=snake_case            field naming (see tooldocs.MarshalToolDoc)
+//	GET /tools/{id}/examples?max=N    examples for a tool
+//	GET /dictionary                   compression dictionary (see UseDictionary)
+//
+// and a server-rendered HTML viewer for reviewing what agents will see:
+//
+//	GET /view/                        index of documented tools (stores implementing Lister)
+//	GET /view/{id}?tier=LEVEL         per-tier tabs with rendered examples
+//
+// Errors are reported as JSON objects of the form {"error": "..."} with
+// 404 for ErrNotFound and ErrNoTool, 403 for ErrUnauthorized, and 400 for
+// ErrInvalidDetail or bad query parameters. Store errors also carry a
+// "code" (see ErrorCode) so clients can tell ErrNotFound from ErrNoTool.
+//
+// The handler does not authenticate callers. To unlock the full tier of
+// Sensitive tools, wrap it in middleware that verifies the caller and adds
+// grants with tooldocs.WithGrants; they reach stores implementing
+// ContextStore through the request context.
+//
+// A trace ID from the X-Request-Id header, or else the trace-id of a W3C
+// traceparent header, is attached with tooldocs.WithTraceID and echoed in
+// the X-Request-Id response header, so store hooks can correlate reads.
+// The first Accept-Language tag is attached with tooldocs.WithLocale;
+// stores implementing Localizer translate store error messages for it.
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// Lister is implemented by stores that can enumerate documented tool IDs,
+// such as *tooldocs.InMemoryStore.
+type Lister interface {
+	DocIDs() []string
+}
+
+// ContextStore is implemented by stores whose reads honor the caller's
+// context, such as *tooldocs.InMemoryStore. The handler passes the request
+// context to these methods when available.
+type ContextStore interface {
+	DescribeToolContext(ctx context.Context, id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error)
+	ListExamplesContext(ctx context.Context, id string, maxExamples int) ([]tooldocs.ToolExample, error)
+}
+
+// Localizer is implemented by stores that translate their error messages
+// for the caller's locale, such as *tooldocs.InMemoryStore.
+type Localizer interface {
+	LocalizeError(ctx context.Context, err error) string
+}
+
+// Handler serves a store over HTTP. Create one with NewHandler.
+type Handler struct {
+	store tooldocs.Store
+	mux   *http.ServeMux
+	dict  *tooldocs.Dictionary // see UseDictionary
+}
+
+// NewHandler returns a Handler serving store.
+func NewHandler(store tooldocs.Store) *Handler {
+	h := &Handler{store: store, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /tools", h.listTools)
+	h.mux.HandleFunc("GET /tools/{id}/doc", h.getDoc)
+	h.mux.HandleFunc("GET /tools/{id}/examples", h.getExamples)
+	h.mux.HandleFunc("GET /dictionary", h.getDictionary)
+	h.mux.HandleFunc("GET /view/{$}", h.viewIndex)
+	h.mux.HandleFunc("GET /view/{id}", h.viewTool)
+	return h
+}
+
+// TraceHeader is the request and response header carrying the trace ID.
+const TraceHeader = "X-Request-Id"
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if id := requestTraceID(r); id != "" && tooldocs.TraceID(r.Context()) == "" {
+		r = r.WithContext(tooldocs.WithTraceID(r.Context(), id))
+		w.Header().Set(TraceHeader, id)
+	}
+	if locale := requestLocale(r); locale != "" && tooldocs.Locale(r.Context()) == "" {
+		r = r.WithContext(tooldocs.WithLocale(r.Context(), locale))
+	}
+	w, finish := h.compressing(w, r)
+	defer finish()
+	h.mux.ServeHTTP(w, r)
+}
+
+// requestLocale returns the first language tag of the Accept-Language
+// header, ignoring quality values and the "*" wildcard.
+func requestLocale(r *http.Request) string {
+	first, _, _ := strings.Cut(r.Header.Get("Accept-Language"), ",")
+	tag, _, _ := strings.Cut(first, ";")
+	if tag = strings.TrimSpace(tag); tag == "*" {
+		return ""
+	}
+	return tag
+}
+
+// requestTraceID returns the X-Request-Id header, or else the trace-id
+// field of a W3C traceparent header ("00-<trace-id>-<parent-id>-<flags>").
+func requestTraceID(r *http.Request) string {
+	if id := r.Header.Get(TraceHeader); id != "" {
+		return id
+	}
+	parts := strings.Split(r.Header.Get("Traceparent"), "-")
+	if len(parts) == 4 && len(parts[1]) == 32 {
+		return parts[1]
+	}
+	return ""
+}
+
+func (h *Handler) listTools(w http.ResponseWriter, _ *http.Request) {
+	lister, ok := h.store.(Lister)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, errors.New("store does not support listing"))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string][]string{"tools": lister.DocIDs()})
+}
+
+func (h *Handler) getDoc(w http.ResponseWriter, r *http.Request) {
+	level := tooldocs.DetailLevel(r.URL.Query().Get("level"))
+	if level == "" {
+		level = tooldocs.DetailSummary
+	}
+	doc, err := h.describe(r.Context(), r.PathValue("id"), level)
+	if err != nil {
+		h.writeStoreError(w, r, err)
+		return
+	}
+	data, err := tooldocs.MarshalToolDoc(doc, tooldocs.MarshalOptions{
+		Naming: tooldocs.FieldNaming(r.URL.Query().Get("naming")),
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(append(data, '\n'))
+}
+
+func (h *Handler) getExamples(w http.ResponseWriter, r *http.Request) {
+	maxExamples := 0
+	if v := r.URL.Query().Get("max"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, errors.New("max must be a non-negative integer"))
+			return
+		}
+		maxExamples = n
+	}
+	examples, err := h.listExamples(r.Context(), r.PathValue("id"), maxExamples)
+	if err != nil {
+		h.writeStoreError(w, r, err)
+		return
+	}
+	if examples == nil {
+		examples = []tooldocs.ToolExample{}
+	}
+	writeJSON(w, http.StatusOK, map[string][]tooldocs.ToolExample{"examples": examples})
+}
+
+// describe calls DescribeToolContext when the store supports it.
+func (h *Handler) describe(ctx context.Context, id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error) {
+	if cs, ok := h.store.(ContextStore); ok {
+		return cs.DescribeToolContext(ctx, id, level)
+	}
+	return h.store.DescribeTool(id, level)
+}
+
+// listExamples calls ListExamplesContext when the store supports it.
+func (h *Handler) listExamples(ctx context.Context, id string, maxExamples int) ([]tooldocs.ToolExample, error) {
+	if cs, ok := h.store.(ContextStore); ok {
+		return cs.ListExamplesContext(ctx, id, maxExamples)
+	}
+	return h.store.ListExamples(id, maxExamples)
+}
+
+// statusFor maps store errors to HTTP status codes.
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, tooldocs.ErrNotFound), errors.Is(err, tooldocs.ErrNoTool):
+		return http.StatusNotFound
+	case errors.Is(err, tooldocs.ErrUnauthorized):
+		return http.StatusForbidden
+	case errors.Is(err, tooldocs.ErrInvalidDetail):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Error codes sent in the "code" field of error responses for store errors.
+const (
+	CodeNotFound      = "not_found"
+	CodeNoTool        = "no_tool"
+	CodeUnauthorized  = "unauthorized"
+	CodeInvalidDetail = "invalid_detail"
+)
+
+// ErrorCode returns the error response code for a store error, or "" for
+// errors without one.
+func ErrorCode(err error) string {
+	switch {
+	case errors.Is(err, tooldocs.ErrNoTool):
+		return CodeNoTool
+	case errors.Is(err, tooldocs.ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, tooldocs.ErrUnauthorized):
+		return CodeUnauthorized
+	case errors.Is(err, tooldocs.ErrInvalidDetail):
+		return CodeInvalidDetail
+	default:
+		return ""
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeErrorMessage(w, status, err, err.Error())
+}
+
+// writeStoreError reports a store error, with the message translated for
+// the caller's locale when the store is a Localizer.
+func (h *Handler) writeStoreError(w http.ResponseWriter, r *http.Request, err error) {
+	msg := err.Error()
+	if l, ok := h.store.(Localizer); ok {
+		msg = l.LocalizeError(r.Context(), err)
+	}
+	writeErrorMessage(w, statusFor(err), err, msg)
+}
+
+func writeErrorMessage(w http.ResponseWriter, status int, err error, msg string) {
+	body := map[string]string{"error": msg}
+	if code := ErrorCode(err); code != "" {
+		body["code"] = code
+	}
+	writeJSON(w, status, body)
+}
@@ -0,0 +1,292 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/tooldocs"
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	tool := &toolmodel.Tool{
+		Namespace: "github",
+		Tool: mcp.Tool{
+			Name:        "get_repo",
+			Description: "Get a repository",
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"repo": map[string]any{"type": "string"}},
+				"required":   []any{"repo"},
+			},
+		},
+	}
+	store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			if id == tool.ToolID() || id == "github:delete_repo" {
+				return tool, nil
+			}
+			return nil, nil
+		},
+	})
+	if err := store.RegisterDoc("github:get_repo", tooldocs.DocEntry{
+		Summary:  "Fetch repository metadata",
+		Notes:    "Requires <auth>.",
+		Examples: []tooldocs.ToolExample{{Title: "Basic", Args: map[string]any{"repo": "a/b"}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDoc("local:echo", tooldocs.DocEntry{Summary: "Echo input"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDoc("github:delete_repo", tooldocs.DocEntry{Summary: "Delete a repository", Notes: "Confidential runbook.", Sensitive: true}); err != nil {
+		t.Fatal(err)
+	}
+	// Requests with the X-Test-Grant header are authorized for sensitive docs.
+	handler := NewHandler(store)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Test-Grant") != "" {
+			r = r.WithContext(tooldocs.WithGrants(r.Context(), tooldocs.GrantSensitiveDocs))
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestJSONAPI(t *testing.T) {
+	srv := newTestServer(t)
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"/tools", http.StatusOK, `"github:delete_repo","github:get_repo","local:echo"`},
+		{"/tools/github:delete_repo/doc?level=full", http.StatusForbidden, `"code":"unauthorized"`},
+		{"/tools/github:delete_repo/doc?level=schema", http.StatusOK, `"summary":"Delete a repository"`},
+		{"/tools/github:get_repo/doc", http.StatusOK, `"summary":"Fetch repository metadata"`},
+		{"/tools/github:get_repo/doc?level=full", http.StatusOK, `"notes":"Requires \u003cauth\u003e."`},
+		{"/tools/github:get_repo/doc?level=bogus", http.StatusBadRequest, `"error"`},
+		{"/tools/github:get_repo/doc?level=full&naming=snake_case", http.StatusOK, `"schema_info":`},
+		{"/tools/github:get_repo/doc?naming=kebab", http.StatusBadRequest, `"error"`},
+		{"/tools/local:echo/doc?level=schema", http.StatusNotFound, `"code":"no_tool"`},
+		{"/tools/missing/doc", http.StatusNotFound, `"code":"not_found"`},
+		{"/tools/github:get_repo/examples?max=1", http.StatusOK, `"title":"Basic"`},
+		{"/tools/github:get_repo/examples?max=x", http.StatusBadRequest, `"error"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			status, body := get(t, srv.URL+tt.path)
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", status, tt.wantStatus, body)
+			}
+			if !strings.Contains(body, tt.wantBody) {
+				t.Errorf("body %s missing %s", body, tt.wantBody)
+			}
+			if !json.Valid([]byte(body)) {
+				t.Errorf("body is not JSON: %s", body)
+			}
+		})
+	}
+}
+
+func TestSensitiveGrant(t *testing.T) {
+	srv := newTestServer(t)
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/tools/github:delete_repo/doc?level=full", nil)
+	req.Header.Set("X-Test-Grant", "1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "Confidential runbook.") {
+		t.Errorf("granted request = %d %s", resp.StatusCode, body)
+	}
+}
+
+func TestTraceID(t *testing.T) {
+	traces := make(chan string, 1)
+	store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{
+		OnRead: func(ctx context.Context, e tooldocs.ReadEvent) { traces <- e.TraceID },
+	})
+	if err := store.RegisterDoc("local:echo", tooldocs.DocEntry{Summary: "Echo input"}); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(NewHandler(store))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		header, value, want string
+	}{
+		{TraceHeader, "req-42", "req-42"},
+		{"Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"Traceparent", "garbage", ""},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/tools/local:echo/doc", nil)
+		req.Header.Set(tt.header, tt.value)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if got := <-traces; got != tt.want {
+			t.Errorf("%s %q: trace ID = %q, want %q", tt.header, tt.value, got, tt.want)
+		}
+		if got := resp.Header.Get(TraceHeader); got != tt.want {
+			t.Errorf("%s %q: response %s = %q, want %q", tt.header, tt.value, TraceHeader, got, tt.want)
+		}
+	}
+}
+
+func TestLocalizedErrors(t *testing.T) {
+	store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{
+		Catalogs: map[string]tooldocs.Catalog{"de": {"tool not found": "Werkzeug nicht gefunden"}},
+	})
+	srv := httptest.NewServer(NewHandler(store))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		acceptLanguage, want string
+	}{
+		{"de-CH, en;q=0.8", `"error":"Werkzeug nicht gefunden: missing"`},
+		{"fr;q=0.9", `"error":"tool not found: missing"`},
+		{"*", `"error":"tool not found: missing"`},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/tools/missing/doc", nil)
+		req.Header.Set("Accept-Language", tt.acceptLanguage)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !strings.Contains(string(body), tt.want) || !strings.Contains(string(body), `"code":"not_found"`) {
+			t.Errorf("Accept-Language %q: body %s, want %s and a stable code", tt.acceptLanguage, body, tt.want)
+		}
+	}
+}
+
+func TestViewer(t *testing.T) {
+	srv := newTestServer(t)
+
+	_, index := get(t, srv.URL+"/view/")
+	if !strings.Contains(index, `href="/view/github:get_repo"`) {
+		t.Errorf("index missing tool link:\n%s", index)
+	}
+
+	status, full := get(t, srv.URL+"/view/github:get_repo?tier=full")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d", status)
+	}
+	for _, want := range []string{
+		`<a href="?tier=full" class="active">full</a>`,
+		"Fetch repository metadata",
+		"Requires &lt;auth&gt;.",
+		"<h3>Basic</h3>",
+		"&#34;repo&#34;: &#34;a/b&#34;",
+		"Required: <code>repo</code>",
+	} {
+		if !strings.Contains(full, want) {
+			t.Errorf("full tier missing %q:\n%s", want, full)
+		}
+	}
+
+	_, summary := get(t, srv.URL+"/view/github:get_repo")
+	if strings.Contains(summary, "<h3>Basic</h3>") {
+		t.Error("summary tier should not render examples")
+	}
+
+	_, fallback := get(t, srv.URL+"/view/local:echo?tier=schema")
+	if !strings.Contains(fallback, "Tool definition unavailable") {
+		t.Errorf("expected fallback notice:\n%s", fallback)
+	}
+
+	_, restricted := get(t, srv.URL+"/view/github:delete_repo?tier=full")
+	if !strings.Contains(restricted, "restricted") || strings.Contains(restricted, "Confidential runbook") {
+		t.Errorf("sensitive full tier should be restricted:\n%s", restricted)
+	}
+
+	if status, _ := get(t, srv.URL+"/view/missing"); status != http.StatusNotFound {
+		t.Errorf("missing tool status = %d, want 404", status)
+	}
+}
+
+func TestDictionaryCompression(t *testing.T) {
+	store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{})
+	if err := store.RegisterDoc("local:echo", tooldocs.DocEntry{Summary: "Echo input back to the caller"}); err != nil {
+		t.Fatal(err)
+	}
+	dict := tooldocs.NewDictionary([]byte(`{"summary":"Echo input back to the caller"}`))
+	srv := httptest.NewServer(NewHandler(store).UseDictionary(dict))
+	t.Cleanup(srv.Close)
+
+	fetch := func(path, dictID string) (*http.Response, []byte) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		req.Header.Set("Accept-Encoding", DictionaryEncoding)
+		req.Header.Set(DictionaryHeader, dictID)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, body
+	}
+
+	resp, body := fetch("/dictionary", "")
+	if resp.StatusCode != http.StatusOK || string(body) != string(dict.Data) || resp.Header.Get(DictionaryHeader) != dict.ID {
+		t.Fatalf("GET /dictionary = %d %q", resp.StatusCode, body)
+	}
+
+	_, plain := get(t, srv.URL+"/tools/local:echo/doc")
+	resp, body = fetch("/tools/local:echo/doc", dict.ID)
+	if resp.Header.Get("Content-Encoding") != DictionaryEncoding {
+		t.Fatalf("Content-Encoding = %q, want %s", resp.Header.Get("Content-Encoding"), DictionaryEncoding)
+	}
+	if out, err := dict.Decompress(body); err != nil || string(out) != plain {
+		t.Errorf("decompressed = %q, %v; want %q", out, err, plain)
+	}
+
+	// A stale dictionary gets uncompressed responses naming the current one.
+	resp, body = fetch("/tools/local:echo/doc", "old")
+	if resp.Header.Get("Content-Encoding") != "" || string(body) != plain || resp.Header.Get(DictionaryHeader) != dict.ID {
+		t.Errorf("stale dictionary: encoding %q, header %q, body %q", resp.Header.Get("Content-Encoding"), resp.Header.Get(DictionaryHeader), body)
+	}
+
+	// Errors are not compressed.
+	resp, body = fetch("/tools/ns:missing/doc", dict.ID)
+	if resp.StatusCode != http.StatusNotFound || resp.Header.Get("Content-Encoding") != "" || !strings.Contains(string(body), "not_found") {
+		t.Errorf("error response = %d %q %q", resp.StatusCode, resp.Header.Get("Content-Encoding"), body)
+	}
+
+	status, msg := get(t, newTestServer(t).URL+"/dictionary")
+	if status != http.StatusNotFound || !strings.Contains(msg, "no dictionary") {
+		t.Errorf("GET /dictionary without one = %d %q", status, msg)
+	}
+}
@@ -0,0 +1,127 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// viewerTiers are the tabs shown for each tool, in display order.
+var viewerTiers = []tooldocs.DetailLevel{tooldocs.DetailSummary, tooldocs.DetailSchema, tooldocs.DetailFull}
+
+var viewerTemplates = template.Must(template.New("").Funcs(template.FuncMap{
+	"json": func(v any) string {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err.Error()
+		}
+		return string(data)
+	},
+}).Parse(`
+{{define "head"}}<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>{{.}} · tooldocs</title>
+<style>
+body{font-family:system-ui,sans-serif;max-width:60rem;margin:2rem auto;padding:0 1rem;color:#222}
+nav.tabs a{display:inline-block;padding:.4rem .9rem;border:1px solid #ccc;border-bottom:none;text-decoration:none;color:#333}
+nav.tabs a.active{background:#eef;font-weight:bold}
+section{border:1px solid #ccc;padding:1rem}
+pre{background:#f6f6f6;padding:.6rem;overflow-x:auto}
+.notice{color:#a50}
+</style></head><body>{{end}}
+
+{{define "index"}}{{template "head" "Tools"}}
+<h1>Documented tools</h1>
+<ul>{{range .}}<li><a href="/view/{{.}}">{{.}}</a></li>{{else}}<li>No documented tools.</li>{{end}}</ul>
+</body></html>{{end}}
+
+{{define "tool"}}{{template "head" .ID}}
+<p><a href="/view/">&larr; all tools</a></p>
+<h1>{{if .Doc.Title}}{{.Doc.Title}} <small>({{.ID}})</small>{{else}}{{.ID}}{{end}}</h1>
+<nav class="tabs">{{range .Tiers}}<a href="?tier={{.Level}}"{{if .Active}} class="active"{{end}}>{{.Level}}</a>{{end}}</nav>
+<section>
+{{if .Notice}}<p class="notice">{{.Notice}}</p>{{end}}
+<h2>Summary</h2><p>{{.Doc.Summary}}</p>
+{{with .Doc.SchemaInfo}}<h2>Parameters</h2>
+{{if .Required}}<p>Required: {{range $i, $p := .Required}}{{if $i}}, {{end}}<code>{{$p}}</code>{{end}}</p>{{end}}
+{{if .Types}}<ul>{{range $name, $types := .Types}}<li><code>{{$name}}</code>: {{range $i, $t := $types}}{{if $i}} | {{end}}{{$t}}{{end}}</li>{{end}}</ul>{{end}}
+{{end}}
+{{if .Doc.Effects}}<h2>Effects</h2><ul>{{range .Doc.Effects}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{if or .Doc.Idempotent .Doc.RetryPolicy}}<h2>Retries</h2><p>{{if .Doc.Idempotent}}Idempotent. {{end}}{{with .Doc.RetryPolicy}}{{.}}{{end}}</p>{{end}}
+{{with .Doc.Concurrency}}<h2>Concurrency</h2><p>{{.}}</p>{{end}}
+{{with .Doc.ClientRequirements}}<h2>Client requirements</h2><p>{{.}}</p>{{end}}
+{{with .Doc.Tool}}<h2>Input schema</h2><pre>{{json .InputSchema}}</pre>{{end}}
+{{if .Doc.ShortNotes}}<h2>Key constraints</h2><p>{{.Doc.ShortNotes}}</p>{{end}}
+{{if .Doc.Notes}}<h2>Notes</h2><pre>{{.Doc.Notes}}</pre>{{end}}
+{{range .Doc.Sections}}<h2>{{.Title}}</h2><pre>{{.Body}}</pre>{{end}}
+{{if .Doc.Prerequisites}}<h2>Prerequisites</h2><ul>{{range .Doc.Prerequisites}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{if .Doc.Edges}}<h2>Related tools</h2><ul>{{range .Doc.Edges}}<li>{{if eq .From $.ID}}needs <a href="/view/{{.To}}">{{.To}}</a>{{else}}used by <a href="/view/{{.From}}">{{.From}}</a>{{end}} ({{.Kind}}){{if .Description}}: {{.Description}}{{end}}</li>{{end}}</ul>{{end}}
+{{if .Doc.Examples}}<h2>Examples</h2>{{range .Doc.Examples}}
+<h3>{{.Title}}</h3>{{if .Requires}}<p><em>Requires:</em> {{range $i, $r := .Requires}}{{if $i}}, {{end}}<code>{{$r}}</code>{{end}}</p>{{end}}{{if .Description}}<p>{{.Description}}</p>{{end}}
+<pre>{{json .Args}}</pre>{{if .ResultHint}}<p><em>Result:</em> {{.ResultHint}}</p>{{end}}
+{{end}}{{end}}
+{{if .Doc.ExternalRefs}}<h2>References</h2><ul>{{range .Doc.ExternalRefs}}<li>{{.}}</li>{{end}}</ul>{{end}}
+</section>
+</body></html>{{end}}
+`))
+
+type viewerTab struct {
+	Level  tooldocs.DetailLevel
+	Active bool
+}
+
+type viewerPage struct {
+	ID     string
+	Doc    tooldocs.ToolDoc
+	Tiers  []viewerTab
+	Notice string
+}
+
+func (h *Handler) viewIndex(w http.ResponseWriter, _ *http.Request) {
+	lister, ok := h.store.(Lister)
+	if !ok {
+		http.Error(w, "store does not support listing", http.StatusNotImplemented)
+		return
+	}
+	render(w, "index", lister.DocIDs())
+}
+
+func (h *Handler) viewTool(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	level := tooldocs.DetailLevel(r.URL.Query().Get("tier"))
+	if level == "" {
+		level = tooldocs.DetailSummary
+	}
+
+	page := viewerPage{ID: id}
+	for _, tier := range viewerTiers {
+		page.Tiers = append(page.Tiers, viewerTab{Level: tier, Active: tier == level})
+	}
+
+	doc, err := h.describe(r.Context(), id, level)
+	switch {
+	case errors.Is(err, tooldocs.ErrNoTool):
+		// Docs exist but the tool definition is unavailable; fall back to the
+		// summary so reviewers still see what is registered.
+		page.Notice = "Tool definition unavailable; showing summary tier."
+		doc, err = h.describe(r.Context(), id, tooldocs.DetailSummary)
+	case errors.Is(err, tooldocs.ErrUnauthorized):
+		page.Notice = "Full documentation for this tool is restricted; showing schema tier."
+		doc, err = h.describe(r.Context(), id, tooldocs.DetailSchema)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), statusFor(err))
+		return
+	}
+	page.Doc = doc
+	render(w, "tool", page)
+}
+
+func render(w http.ResponseWriter, name string, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := viewerTemplates.ExecuteTemplate(w, name, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,342 @@
+// Package httpstore provides a tooldocs.Store that reads documentation from
+// a remote docs service speaking the httpapi JSON protocol:
+//
+//	GET {base}/tools                        documented tool IDs
+//	GET {base}/tools/{id}/doc?level=LEVEL   ToolDoc
+//	GET {base}/tools/{id}/examples?max=N    {"examples": [...]}
+//
+// so thin MCP servers can proxy docs from a central documentation service.
+// Error responses map back to tooldocs.ErrNotFound, ErrNoTool,
+// ErrUnauthorized, and ErrInvalidDetail (via the httpapi error code, or the
+// status code for servers that send none). Transport errors, 429, and 5xx
+// responses are retried with exponential backoff; each attempt has its own
+// timeout. The caller's trace ID (tooldocs.TraceID) is sent as
+// X-Request-Id. With Options.Compress, responses are compressed with the
+// service's shared dictionary.
+//
+// Wrap a Client in tooldocs.NewCachingStore to avoid a round trip per read.
+package httpstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonwraymond/tooldocs"
+	"github.com/jonwraymond/tooldocs/httpapi"
+)
+
+// Defaults applied by New for zero Options fields.
+const (
+	DefaultTimeout     = 10 * time.Second
+	DefaultMaxAttempts = 3
+	DefaultBackoff     = 100 * time.Millisecond
+)
+
+// maxErrorBody caps how much of an error response is read.
+const maxErrorBody = 4 << 10
+
+// Options configures New.
+type Options struct {
+	// HTTPClient sends requests. Nil means http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Timeout bounds each attempt. Zero means DefaultTimeout.
+	Timeout time.Duration
+
+	// MaxAttempts is the total number of attempts per read, including the
+	// first. Zero means DefaultMaxAttempts; 1 disables retries.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry, doubled for each
+	// further retry. Zero means DefaultBackoff.
+	Backoff time.Duration
+
+	// Header is added to every request, e.g. an Authorization header.
+	Header http.Header
+
+	// Compress requests dictionary-compressed responses (see
+	// httpapi.Handler.UseDictionary). The client fetches the service's
+	// dictionary on first use and again when the service reports a new
+	// one; reads are uncompressed while it has none.
+	Compress bool
+}
+
+// StatusError is returned for error responses that do not map to a
+// tooldocs sentinel error.
+type StatusError struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// Message is the server's "error" message, or the response status.
+	Message string
+}
+
+// Error implements error.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("docs service: %d: %s", e.StatusCode, e.Message)
+}
+
+// Client is a tooldocs.Store backed by a remote docs service. It is safe
+// for concurrent use.
+type Client struct {
+	base string // without trailing slash
+	opts Options
+
+	dictMu sync.Mutex
+	dict   *tooldocs.Dictionary
+	noDict bool // the service has no dictionary
+}
+
+var _ tooldocs.Store = (*Client)(nil)
+
+// New returns a Client for the docs service at baseURL, e.g.
+// "https://docs.internal/api".
+//
+// Returns an error if baseURL is not an absolute http or https URL.
+func New(baseURL string, opts Options) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("httpstore: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("httpstore: base URL must be an absolute http(s) URL without query: %q", baseURL)
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultBackoff
+	}
+	return &Client{base: strings.TrimSuffix(u.String(), "/"), opts: opts}, nil
+}
+
+// DescribeTool is DescribeToolContext with a background context.
+func (c *Client) DescribeTool(id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error) {
+	return c.DescribeToolContext(context.Background(), id, level)
+}
+
+// DescribeToolContext fetches the tool's documentation at level.
+func (c *Client) DescribeToolContext(ctx context.Context, id string, level tooldocs.DetailLevel) (tooldocs.ToolDoc, error) {
+	var doc tooldocs.ToolDoc
+	err := c.get(ctx, "/tools/"+url.PathEscape(id)+"/doc", url.Values{"level": {string(level)}}, id, &doc)
+	if err != nil {
+		return tooldocs.ToolDoc{}, err
+	}
+	return doc, nil
+}
+
+// ListExamples is ListExamplesContext with a background context.
+func (c *Client) ListExamples(id string, maxExamples int) ([]tooldocs.ToolExample, error) {
+	return c.ListExamplesContext(context.Background(), id, maxExamples)
+}
+
+// ListExamplesContext fetches up to maxExamples examples for the tool.
+func (c *Client) ListExamplesContext(ctx context.Context, id string, maxExamples int) ([]tooldocs.ToolExample, error) {
+	if maxExamples < 0 {
+		maxExamples = 0
+	}
+	var body struct {
+		Examples []tooldocs.ToolExample `json:"examples"`
+	}
+	err := c.get(ctx, "/tools/"+url.PathEscape(id)+"/examples", url.Values{"max": {strconv.Itoa(maxExamples)}}, id, &body)
+	if err != nil {
+		return nil, err
+	}
+	return body.Examples, nil
+}
+
+// ListTools fetches the IDs of the service's documented tools.
+func (c *Client) ListTools(ctx context.Context) ([]string, error) {
+	var body struct {
+		Tools []string `json:"tools"`
+	}
+	if err := c.get(ctx, "/tools", nil, "", &body); err != nil {
+		return nil, err
+	}
+	return body.Tools, nil
+}
+
+// get performs a GET with retries and decodes a 200 response into out.
+func (c *Client) get(ctx context.Context, path string, query url.Values, id string, out any) error {
+	rawURL := c.base + path
+	if len(query) > 0 {
+		rawURL += "?" + query.Encode()
+	}
+
+	delay := c.opts.Backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := c.try(ctx, rawURL, id, out)
+		if err == nil || !retry || attempt >= c.opts.MaxAttempts {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// try performs one attempt and reports whether a failure is retryable.
+func (c *Client) try(ctx context.Context, rawURL, id string, out any) (retry bool, err error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(attemptCtx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("httpstore: %w", err)
+	}
+	for k, vs := range c.opts.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+	dict := c.dictionary(attemptCtx)
+	if dict != nil {
+		req.Header.Set("Accept-Encoding", httpapi.DictionaryEncoding)
+		req.Header.Set(httpapi.DictionaryHeader, dict.ID)
+	}
+	if trace := tooldocs.TraceID(ctx); trace != "" {
+		req.Header.Set(httpapi.TraceHeader, trace)
+	}
+
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return true, fmt.Errorf("httpstore: %w", err)
+	}
+	defer resp.Body.Close()
+	if id := resp.Header.Get(httpapi.DictionaryHeader); c.opts.Compress && id != "" && (dict == nil || id != dict.ID) {
+		c.dictChanged()
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		body := io.Reader(resp.Body)
+		if resp.Header.Get("Content-Encoding") == httpapi.DictionaryEncoding {
+			if dict == nil {
+				return false, fmt.Errorf("httpstore: %w: unexpected %s response", tooldocs.ErrDictionary, httpapi.DictionaryEncoding)
+			}
+			body = dict.NewReader(resp.Body)
+		}
+		if err := json.NewDecoder(body).Decode(out); err != nil {
+			return true, fmt.Errorf("httpstore: decode response: %w", err)
+		}
+		return false, nil
+	}
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, responseError(resp, id)
+}
+
+// dictionary returns the service's dictionary when Compress is set,
+// fetching it if needed, or nil to read uncompressed. A failed fetch is
+// retried on the next read.
+func (c *Client) dictionary(ctx context.Context) *tooldocs.Dictionary {
+	if !c.opts.Compress {
+		return nil
+	}
+	c.dictMu.Lock()
+	defer c.dictMu.Unlock()
+	if c.dict != nil || c.noDict {
+		return c.dict
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base+"/dictionary", nil)
+	if err != nil {
+		return nil
+	}
+	for k, vs := range c.opts.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		c.noDict = true
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, tooldocs.DefaultDictionarySize+1))
+	if err != nil {
+		return nil
+	}
+	dict := tooldocs.NewDictionary(data)
+	if id := resp.Header.Get(httpapi.DictionaryHeader); id != "" && id != dict.ID {
+		return nil
+	}
+	c.dict = &dict
+	return c.dict
+}
+
+// dictChanged drops the client's dictionary after the service reported a
+// different one, so the next read fetches it.
+func (c *Client) dictChanged() {
+	c.dictMu.Lock()
+	c.dict = nil
+	c.noDict = false
+	c.dictMu.Unlock()
+}
+
+// responseError maps an error response to a tooldocs sentinel error, or a
+// *StatusError.
+func responseError(resp *http.Response, id string) error {
+	var body struct {
+		Error string `json:"error"`
+		Code  string `json:"code"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	_ = json.Unmarshal(data, &body)
+
+	var sentinel error
+	switch body.Code {
+	case httpapi.CodeNotFound:
+		sentinel = tooldocs.ErrNotFound
+	case httpapi.CodeNoTool:
+		sentinel = tooldocs.ErrNoTool
+	case httpapi.CodeUnauthorized:
+		sentinel = tooldocs.ErrUnauthorized
+	case httpapi.CodeInvalidDetail:
+		sentinel = tooldocs.ErrInvalidDetail
+	case "":
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			sentinel = tooldocs.ErrNotFound
+		case http.StatusForbidden:
+			sentinel = tooldocs.ErrUnauthorized
+		}
+	}
+	if sentinel != nil {
+		return fmt.Errorf("%w: %s", sentinel, id)
+	}
+
+	msg := body.Error
+	if msg == "" {
+		msg = http.StatusText(resp.StatusCode)
+	}
+	return &StatusError{StatusCode: resp.StatusCode, Message: msg}
+}
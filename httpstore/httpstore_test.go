@@ -0,0 +1,283 @@
+package httpstore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/tooldocs"
+	"github.com/jonwraymond/tooldocs/httpapi"
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// newTestHandler returns an httpapi handler over a store documenting
+// github:get_repo (resolvable) and local:echo (not resolvable).
+func newTestHandler(t *testing.T) http.Handler {
+	t.Helper()
+	tool := &toolmodel.Tool{
+		Namespace: "github",
+		Tool:      mcp.Tool{Name: "get_repo", Description: "Get a repository", InputSchema: map[string]any{"type": "object"}},
+	}
+	store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			if id == tool.ToolID() {
+				return tool, nil
+			}
+			return nil, nil
+		},
+	})
+	if err := store.RegisterDoc("github:get_repo", tooldocs.DocEntry{
+		Summary: "Fetch repository metadata",
+		Notes:   "Requires auth.",
+		Examples: []tooldocs.ToolExample{
+			{Title: "Basic", Args: map[string]any{"repo": "a/b"}},
+			{Title: "Fork", Args: map[string]any{"repo": "c/d"}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDoc("local:echo", tooldocs.DocEntry{Summary: "Echo input"}); err != nil {
+		t.Fatal(err)
+	}
+	return httpapi.NewHandler(store)
+}
+
+func newTestServer(t *testing.T, h http.Handler) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(t *testing.T, baseURL string, opts Options) *Client {
+	t.Helper()
+	c, err := New(baseURL, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	srv := newTestServer(t, newTestHandler(t))
+	c := newTestClient(t, srv.URL+"/", Options{})
+
+	doc, err := c.DescribeTool("github:get_repo", tooldocs.DetailFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Summary != "Fetch repository metadata" || doc.Notes != "Requires auth." || len(doc.Examples) != 2 {
+		t.Fatalf("doc = %+v", doc)
+	}
+	if doc.Tool == nil || doc.Tool.Name != "get_repo" {
+		t.Fatalf("doc.Tool = %+v", doc.Tool)
+	}
+
+	doc, err = c.DescribeTool("github:get_repo", tooldocs.DetailSummary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Notes != "" || doc.Tool != nil {
+		t.Fatalf("summary doc = %+v", doc)
+	}
+
+	examples, err := c.ListExamples("github:get_repo", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(examples) != 1 || examples[0].Title != "Basic" {
+		t.Fatalf("examples = %+v", examples)
+	}
+
+	ids, err := c.ListTools(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != "github:get_repo" || ids[1] != "local:echo" {
+		t.Fatalf("ids = %v", ids)
+	}
+}
+
+func TestClientErrorMapping(t *testing.T) {
+	srv := newTestServer(t, newTestHandler(t))
+	c := newTestClient(t, srv.URL, Options{})
+
+	if _, err := c.DescribeTool("github:missing", tooldocs.DetailSummary); !errors.Is(err, tooldocs.ErrNotFound) {
+		t.Fatalf("missing: err = %v, want ErrNotFound", err)
+	}
+	if _, err := c.ListExamples("github:missing", 1); !errors.Is(err, tooldocs.ErrNotFound) {
+		t.Fatalf("missing examples: err = %v, want ErrNotFound", err)
+	}
+	_, err := c.DescribeTool("local:echo", tooldocs.DetailSchema)
+	if !errors.Is(err, tooldocs.ErrNoTool) || errors.Is(err, tooldocs.ErrNotFound) {
+		t.Fatalf("unresolvable: err = %v, want ErrNoTool only", err)
+	}
+	if _, err := c.DescribeTool("github:get_repo", "verbose"); !errors.Is(err, tooldocs.ErrInvalidDetail) {
+		t.Fatalf("bad level: err = %v, want ErrInvalidDetail", err)
+	}
+}
+
+func TestClientStatusFallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("level") {
+		case "schema":
+			http.Error(w, "forbidden", http.StatusForbidden)
+		case "full":
+			http.Error(w, "teapot", http.StatusTeapot)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv.URL, Options{})
+
+	if _, err := c.DescribeTool("a:b", tooldocs.DetailSummary); !errors.Is(err, tooldocs.ErrNotFound) {
+		t.Fatalf("404: err = %v, want ErrNotFound", err)
+	}
+	if _, err := c.DescribeTool("a:b", tooldocs.DetailSchema); !errors.Is(err, tooldocs.ErrUnauthorized) {
+		t.Fatalf("403: err = %v, want ErrUnauthorized", err)
+	}
+	_, err := c.DescribeTool("a:b", tooldocs.DetailFull)
+	var se *StatusError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusTeapot {
+		t.Fatalf("418: err = %v, want *StatusError", err)
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	h := newTestHandler(t)
+	var calls, failures atomic.Int32
+	srv := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if failures.Add(-1) >= 0 {
+			http.Error(w, `{"error":"busy"}`, http.StatusServiceUnavailable)
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+
+	failures.Store(2)
+	c := newTestClient(t, srv.URL, Options{Backoff: time.Millisecond})
+	if _, err := c.DescribeTool("github:get_repo", tooldocs.DetailSummary); err != nil {
+		t.Fatalf("err = %v after retries", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("calls = %d, want 3", got)
+	}
+
+	calls.Store(0)
+	failures.Store(2)
+	c = newTestClient(t, srv.URL, Options{Backoff: time.Millisecond, MaxAttempts: 2})
+	_, err := c.DescribeTool("github:get_repo", tooldocs.DetailSummary)
+	var se *StatusError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusServiceUnavailable || se.Message != "busy" {
+		t.Fatalf("err = %v, want 503 StatusError", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("calls = %d, want 2", got)
+	}
+
+	// Mapped errors are not retried.
+	calls.Store(0)
+	if _, err := c.DescribeTool("github:missing", tooldocs.DetailSummary); !errors.Is(err, tooldocs.ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("calls = %d, want 1", got)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	h := newTestHandler(t)
+	var calls atomic.Int32
+	srv := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+
+	// The first attempt times out and is retried.
+	c := newTestClient(t, srv.URL, Options{Timeout: 20 * time.Millisecond, Backoff: time.Millisecond})
+	if _, err := c.ListExamples("github:get_repo", 1); err != nil {
+		t.Fatalf("err = %v after timeout retry", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("calls = %d, want 2", got)
+	}
+
+	// A cancelled caller context is not retried.
+	calls.Store(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.ListExamplesContext(ctx, "github:get_repo", 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestClientHeaders(t *testing.T) {
+	h := newTestHandler(t)
+	var trace, auth string
+	srv := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trace, auth = r.Header.Get(httpapi.TraceHeader), r.Header.Get("Authorization")
+		h.ServeHTTP(w, r)
+	}))
+
+	c := newTestClient(t, srv.URL, Options{Header: http.Header{"Authorization": {"Bearer t"}}})
+	ctx := tooldocs.WithTraceID(context.Background(), "req-42")
+	if _, err := c.DescribeToolContext(ctx, "github:get_repo", tooldocs.DetailSummary); err != nil {
+		t.Fatal(err)
+	}
+	if trace != "req-42" || auth != "Bearer t" {
+		t.Fatalf("trace = %q, auth = %q", trace, auth)
+	}
+}
+
+func TestNewRejectsBadURL(t *testing.T) {
+	for _, u := range []string{"", "docs.internal", "ftp://docs.internal", "http://docs.internal?x=1", "http://%zz"} {
+		if _, err := New(u, Options{}); err == nil {
+			t.Errorf("New(%q) succeeded", u)
+		}
+	}
+}
+
+func TestClientCompress(t *testing.T) {
+	h := newTestHandler(t).(*httpapi.Handler)
+	dict := tooldocs.NewDictionary([]byte(`"summary":"Fetch repository metadata","notes":"Requires auth."`))
+	h.UseDictionary(dict)
+	var compressed atomic.Int32
+	srv := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r)
+		if w.Header().Get("Content-Encoding") == httpapi.DictionaryEncoding {
+			compressed.Add(1)
+		}
+	}))
+
+	c := newTestClient(t, srv.URL, Options{Compress: true})
+	doc, err := c.DescribeTool("github:get_repo", tooldocs.DetailFull)
+	if err != nil || doc.Notes != "Requires auth." {
+		t.Fatalf("doc = %+v, %v", doc, err)
+	}
+	examples, err := c.ListExamples("github:get_repo", 0)
+	if err != nil || len(examples) != 2 {
+		t.Fatalf("examples = %+v, %v", examples, err)
+	}
+	if got := compressed.Load(); got != 2 {
+		t.Errorf("compressed responses = %d, want 2", got)
+	}
+
+	// Services without a dictionary are read uncompressed.
+	plain := newTestClient(t, newTestServer(t, newTestHandler(t)).URL, Options{Compress: true})
+	if doc, err := plain.DescribeTool("github:get_repo", tooldocs.DetailSummary); err != nil || doc.Summary == "" {
+		t.Errorf("uncompressed doc = %+v, %v", doc, err)
+	}
+}
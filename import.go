@@ -0,0 +1,495 @@
+package tooldocs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrImportConflict is returned by Import when ConflictFail is in effect and
+// at least one incoming entry targets a tool that already has documentation.
+var ErrImportConflict = errors.New("import conflict")
+
+// ConflictPolicy controls how Import treats entries for tools that already
+// have registered documentation.
+type ConflictPolicy string
+
+const (
+	// ConflictOverwrite replaces existing documentation with the incoming entry.
+	// This is the default when ImportOptions.Conflict is empty.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+
+	// ConflictSkipExisting leaves existing documentation untouched and only
+	// creates records for tools that have none.
+	ConflictSkipExisting ConflictPolicy = "skip-existing"
+
+	// ConflictMergeNotes merges the incoming entry onto the existing one with
+	// MergeDocEntries using NotesAppend: notes are appended, examples are
+	// merged by ID, and external refs are unioned.
+	ConflictMergeNotes ConflictPolicy = "merge-notes"
+
+	// ConflictMergeExamples keeps the existing documentation and adds the
+	// incoming examples to it, merged by ID as by MergeDocEntries.
+	ConflictMergeExamples ConflictPolicy = "merge-examples"
+
+	// ConflictFail rejects the whole import with ErrImportConflict if any
+	// incoming entry targets a tool that already has documentation.
+	ConflictFail ConflictPolicy = "fail"
+)
+
+// ImportAction describes what Import did (or would do, in a dry run) for a tool.
+type ImportAction string
+
+// Import actions reported in ImportChange.Action.
+const (
+	ImportCreated   ImportAction = "created"
+	ImportUpdated   ImportAction = "updated"
+	ImportMerged    ImportAction = "merged"
+	ImportSkipped   ImportAction = "skipped"
+	ImportUnchanged ImportAction = "unchanged"
+	ImportFailed    ImportAction = "failed"
+)
+
+// ImportOptions configures a bulk Import.
+type ImportOptions struct {
+	// Conflict selects how existing documentation is handled.
+	// Empty means ConflictOverwrite.
+	Conflict ConflictPolicy
+
+	// DryRun computes the report without modifying the store.
+	DryRun bool
+
+	// Source is recorded as DocEntry.Source for entries that do not set
+	// their own (e.g. "importer:openapi").
+	Source string
+
+	// SkipInvalid reports entries that fail validation (summary style,
+	// Args caps and types, example limits) as ImportFailed, with the error
+	// as the reason, and imports the rest. By default one invalid entry
+	// fails the whole import.
+	SkipInvalid bool
+
+	// Redact replaces secret values in example Args with RedactedValue
+	// before importing, as RedactArgs does, and reports their paths in
+	// ImportChange.Redacted. Use it for sources that may hold real
+	// credentials, such as API client collections.
+	Redact bool
+
+	// RedactKeys overrides DefaultRedactKeys for Redact when non-nil.
+	RedactKeys []string
+}
+
+// ImportChange reports the outcome of importing a single tool's entry.
+type ImportChange struct {
+	// ID is the tool ID.
+	ID string `json:"id"`
+
+	// Action is what happened to the tool's documentation.
+	Action ImportAction `json:"action"`
+
+	// Fields lists the DocEntry fields that changed
+	// (title, annotations, summary, shortNotes, notes, sections, examples,
+	// prerequisites, effects, dependencies, idempotent, retryPolicy,
+	// concurrency, clientRequirements, externalRefs, source, sensitive,
+	// render).
+	Fields []string `json:"fields,omitempty"`
+
+	// Reason explains skipped entries (e.g. "exists", "frozen") and
+	// failed ones (the validation error).
+	Reason string `json:"reason,omitempty"`
+
+	// Truncated lists the fields of the incoming entry that were cut to
+	// fit the length caps (e.g. "notes", "examples").
+	Truncated []string `json:"truncated,omitempty"`
+
+	// Redacted lists the example Args values replaced under
+	// ImportOptions.Redact, as "examples[<i>].args.<path>".
+	Redacted []string `json:"redacted,omitempty"`
+
+	// ExampleChanges details how examples already registered under the
+	// same ID changed (see DiffExamples).
+	ExampleChanges []ExampleDiff `json:"exampleChanges,omitempty"`
+}
+
+// ImportReport summarizes a bulk Import.
+type ImportReport struct {
+	// DryRun reports whether the store was left unmodified.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Totals counts Changes, so pipelines can summarize an import without
+	// walking them.
+	Totals ImportTotals `json:"totals"`
+
+	// Changes holds one entry per imported tool, sorted by ID.
+	Changes []ImportChange `json:"changes"`
+}
+
+// ImportTotals counts the changes of an import by action. Truncated and
+// Redacted count the changes with truncated fields or redacted values.
+type ImportTotals struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Merged    int `json:"merged"`
+	Skipped   int `json:"skipped"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+	Truncated int `json:"truncated"`
+	Redacted  int `json:"redacted"`
+}
+
+// importTotals counts changes.
+func importTotals(changes []ImportChange) ImportTotals {
+	var t ImportTotals
+	for _, c := range changes {
+		switch c.Action {
+		case ImportCreated:
+			t.Created++
+		case ImportUpdated:
+			t.Updated++
+		case ImportMerged:
+			t.Merged++
+		case ImportSkipped:
+			t.Skipped++
+		case ImportUnchanged:
+			t.Unchanged++
+		case ImportFailed:
+			t.Failed++
+		}
+		if len(c.Truncated) > 0 {
+			t.Truncated++
+		}
+		if len(c.Redacted) > 0 {
+			t.Redacted++
+		}
+	}
+	return t
+}
+
+// Count returns the number of changes with the given action.
+func (r ImportReport) Count(action ImportAction) int {
+	n := 0
+	for _, c := range r.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Import registers documentation for many tools at once, resolving
+// conflicts with existing documentation according to opts.Conflict.
+//
+// All entries are validated before any are applied, and the whole import is
+// applied under a single write lock, so a failed import leaves the store
+// unchanged. Frozen tools are always skipped with reason "frozen".
+// With opts.SkipInvalid, invalid entries are reported as ImportFailed
+// instead of failing the import.
+//
+// Returns ErrArgsTooLarge if any entry's examples exceed the Args caps,
+// ErrSummaryStyle if any summary violates StoreOptions.SummaryProfile,
+// ErrImportConflict if ConflictFail is in effect and any tool already has
+// documentation, ErrThrottled if WriteRateLimit is exceeded for opts.Source
+// (an import counts as a single write), or an error for an unknown policy.
+// Returns the report and ErrPersist if StoreOptions.Persister fails for any
+// tool; the import is kept.
+func (s *InMemoryStore) Import(entries map[string]DocEntry, opts ImportOptions) (ImportReport, error) {
+	report, err := s.importEntries(entries, opts)
+	if !opts.DryRun {
+		s.health.recordImport(opts.Source, err, s.now())
+	}
+	return report, err
+}
+
+// importEntries implements Import.
+func (s *InMemoryStore) importEntries(entries map[string]DocEntry, opts ImportOptions) (ImportReport, error) {
+	policy := opts.Conflict
+	if policy == "" {
+		policy = ConflictOverwrite
+	}
+	switch policy {
+	case ConflictOverwrite, ConflictSkipExisting, ConflictMergeNotes, ConflictMergeExamples, ConflictFail:
+		// valid
+	default:
+		return ImportReport{}, fmt.Errorf("unknown conflict policy: %s", policy)
+	}
+
+	if err := s.limiter.allow(opts.Source); err != nil {
+		return ImportReport{}, err
+	}
+
+	ids := make([]string, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	redactKeys := opts.RedactKeys
+	if redactKeys == nil {
+		redactKeys = DefaultRedactKeys
+	}
+
+	prepared := make(map[string]*docRecord, len(ids))
+	notes := make(map[string]ImportChange, len(ids)) // truncations, redactions, failures
+	for _, id := range ids {
+		entry := entries[id]
+		if entry.Source == "" {
+			entry.Source = opts.Source
+		}
+		var note ImportChange
+		if opts.Redact {
+			entry.Examples, note.Redacted = redactExamples(entry.Examples, redactKeys)
+		}
+		note.Truncated = entryFieldChanges(entry, entry.ValidateAndTruncate())
+
+		err := s.checkSummaryStyle(id, entry)
+		if err == nil {
+			var rec *docRecord
+			if rec, err = s.prepareRecord(entry); err == nil {
+				prepared[id] = rec
+			} else {
+				err = fmt.Errorf("%s: %w", id, err)
+			}
+		}
+		if err != nil {
+			if !opts.SkipInvalid {
+				return ImportReport{}, err
+			}
+			note = ImportChange{Action: ImportFailed, Reason: err.Error()}
+		}
+		notes[id] = note
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !opts.DryRun {
+		if err := s.writable(); err != nil {
+			return ImportReport{}, err
+		}
+	}
+
+	if policy == ConflictFail {
+		var conflicts []string
+		for _, id := range ids {
+			if prepared[id] != nil && s.docs[id] != nil {
+				conflicts = append(conflicts, id)
+			}
+		}
+		if len(conflicts) > 0 {
+			return ImportReport{}, fmt.Errorf("%w: %s", ErrImportConflict, strings.Join(conflicts, ", "))
+		}
+	}
+
+	// Merged records are validated before anything is written, so a failed
+	// import leaves the store unchanged.
+	if policy == ConflictMergeNotes || policy == ConflictMergeExamples {
+		for _, id := range ids {
+			existing := s.docs[id]
+			if prepared[id] == nil || existing == nil || existing.frozen {
+				continue
+			}
+			merged, err := s.prepareRecord(mergeImport(existing.entry(), prepared[id].entry(), policy))
+			if err != nil {
+				err = fmt.Errorf("%s: %w", id, err)
+				if !opts.SkipInvalid {
+					return ImportReport{}, err
+				}
+				notes[id] = ImportChange{Action: ImportFailed, Reason: err.Error()}
+				continue
+			}
+			prepared[id] = merged
+		}
+	}
+
+	report := ImportReport{DryRun: opts.DryRun, Changes: make([]ImportChange, 0, len(ids))}
+	var persistErrs []error
+	for _, id := range ids {
+		note := notes[id]
+		if note.Action == ImportFailed {
+			note.ID = id
+			report.Changes = append(report.Changes, note)
+			continue
+		}
+		incoming := prepared[id]
+		existing := s.docs[id]
+
+		if existing == nil {
+			report.Changes = append(report.Changes, ImportChange{
+				ID:        id,
+				Action:    ImportCreated,
+				Fields:    changedFields(&docRecord{}, incoming),
+				Truncated: note.Truncated,
+				Redacted:  note.Redacted,
+			})
+			if !opts.DryRun {
+				s.docs[id] = incoming
+				s.stored(id, incoming)
+				persistErrs = append(persistErrs, s.saved(ChangeImport, "", id, incoming, nil))
+			}
+			continue
+		}
+
+		if existing.frozen {
+			report.Changes = append(report.Changes, ImportChange{ID: id, Action: ImportSkipped, Reason: "frozen"})
+			continue
+		}
+		if policy == ConflictSkipExisting {
+			report.Changes = append(report.Changes, ImportChange{ID: id, Action: ImportSkipped, Reason: "exists"})
+			continue
+		}
+
+		action := ImportUpdated
+		if policy == ConflictMergeNotes || policy == ConflictMergeExamples {
+			action = ImportMerged
+		}
+
+		fields := changedFields(existing, incoming)
+		if len(fields) == 0 {
+			report.Changes = append(report.Changes, ImportChange{ID: id, Action: ImportUnchanged, Truncated: note.Truncated, Redacted: note.Redacted})
+			continue
+		}
+		exampleChanges := DiffExamples(existing.examples, incoming.examples)
+		report.Changes = append(report.Changes, ImportChange{
+			ID:             id,
+			Action:         action,
+			Fields:         fields,
+			Truncated:      note.Truncated,
+			Redacted:       note.Redacted,
+			ExampleChanges: exampleChanges,
+		})
+		if !opts.DryRun {
+			existing.setContent(incoming)
+			s.stored(id, existing)
+			persistErrs = append(persistErrs, s.saved(ChangeImport, "", id, existing, exampleChanges))
+		}
+	}
+
+	report.Totals = importTotals(report.Changes)
+	return report, errors.Join(persistErrs...)
+}
+
+// mergeImport merges an incoming entry onto the existing one under a
+// merging conflict policy.
+func mergeImport(existing, incoming DocEntry, policy ConflictPolicy) DocEntry {
+	if policy == ConflictMergeExamples {
+		existing.Examples = MergeDocEntries(DocEntry{Examples: existing.Examples}, DocEntry{Examples: incoming.Examples}, MergePolicy{}).Examples
+		return existing
+	}
+	return MergeDocEntries(existing, incoming, MergePolicy{Notes: NotesAppend})
+}
+
+// redactExamples returns examples with secret Args values replaced by
+// RedactedValue, and the paths of the replaced values. Examples without
+// secrets are returned as is.
+func redactExamples(examples []ToolExample, keys []string) ([]ToolExample, []string) {
+	var (
+		out   []ToolExample
+		paths []string
+	)
+	for i, ex := range examples {
+		args, found := redactArgs(ex.Args, keys)
+		if len(found) == 0 {
+			continue
+		}
+		if out == nil {
+			out = slices.Clone(examples)
+		}
+		out[i].Args = args
+		for _, p := range found {
+			paths = append(paths, fmt.Sprintf("examples[%d].args.%s", i, p))
+		}
+	}
+	if out == nil {
+		return examples, nil
+	}
+	return out, paths
+}
+
+// changedFields lists the content fields that differ between two records.
+func changedFields(before, after *docRecord) []string {
+	return entryFieldChanges(before.entry(), after.entry())
+}
+
+// entryFieldChanges lists the DocEntry fields (by JSON name) that differ
+// between two entries. Nil and empty slices compare equal.
+func entryFieldChanges(before, after DocEntry) []string {
+	var fields []string
+	if before.Title != after.Title {
+		fields = append(fields, "title")
+	}
+	if !reflect.DeepEqual(before.Annotations, after.Annotations) {
+		fields = append(fields, "annotations")
+	}
+	if before.Summary != after.Summary {
+		fields = append(fields, "summary")
+	}
+	if before.ShortNotes != after.ShortNotes {
+		fields = append(fields, "shortNotes")
+	}
+	if before.Notes != after.Notes {
+		fields = append(fields, "notes")
+	}
+	if !slicesEqual(before.Sections, after.Sections, func(a, b DocSection) bool { return a == b }) {
+		fields = append(fields, "sections")
+	}
+	if !slicesEqual(before.Examples, after.Examples, examplesEqual) {
+		fields = append(fields, "examples")
+	}
+	if !slicesEqual(before.Prerequisites, after.Prerequisites, func(a, b Prerequisite) bool { return a == b }) {
+		fields = append(fields, "prerequisites")
+	}
+	if !slicesEqual(before.Effects, after.Effects, func(a, b Effect) bool { return a == b }) {
+		fields = append(fields, "effects")
+	}
+	if !slicesEqual(before.Dependencies, after.Dependencies, func(a, b Dependency) bool { return a == b }) {
+		fields = append(fields, "dependencies")
+	}
+	if before.Idempotent != after.Idempotent {
+		fields = append(fields, "idempotent")
+	}
+	if !reflect.DeepEqual(before.RetryPolicy, after.RetryPolicy) {
+		fields = append(fields, "retryPolicy")
+	}
+	if !reflect.DeepEqual(before.Concurrency, after.Concurrency) {
+		fields = append(fields, "concurrency")
+	}
+	if !reflect.DeepEqual(before.ClientRequirements, after.ClientRequirements) {
+		fields = append(fields, "clientRequirements")
+	}
+	if !slicesEqual(before.ExternalRefs, after.ExternalRefs, func(a, b string) bool { return a == b }) {
+		fields = append(fields, "externalRefs")
+	}
+	if before.Source != after.Source {
+		fields = append(fields, "source")
+	}
+	if before.Sensitive != after.Sensitive {
+		fields = append(fields, "sensitive")
+	}
+	if !reflect.DeepEqual(before.Render, after.Render) {
+		fields = append(fields, "render")
+	}
+	return fields
+}
+
+// slicesEqual reports whether a and b hold equal elements; nil and empty
+// slices compare equal.
+func slicesEqual[T any](a, b []T, eq func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// examplesEqual reports whether two examples have identical content.
+func examplesEqual(a, b ToolExample) bool {
+	return a.ID == b.ID && a.Title == b.Title && a.Description == b.Description &&
+		a.ResultHint == b.ResultHint && reflect.DeepEqual(a.Args, b.Args) &&
+		slices.Equal(a.Requires, b.Requires) && a.Source == b.Source
+}
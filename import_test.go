@@ -0,0 +1,247 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImport_Overwrite(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "manual", Notes: "curated"})
+
+	report, err := store.Import(map[string]DocEntry{
+		"ns:a": {Summary: "synced"},
+		"ns:b": {Summary: "new"},
+	}, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	want := []ImportChange{
+		{ID: "ns:a", Action: ImportUpdated, Fields: []string{"summary", "notes"}},
+		{ID: "ns:b", Action: ImportCreated, Fields: []string{"summary"}},
+	}
+	if !reflect.DeepEqual(report.Changes, want) {
+		t.Errorf("Changes = %+v, want %+v", report.Changes, want)
+	}
+
+	got, _ := store.GetDocEntry("ns:a")
+	if got.Entry.Summary != "synced" || got.Entry.Notes != "" {
+		t.Errorf("ns:a = %+v, want overwritten", got.Entry)
+	}
+}
+
+func TestImport_SkipExisting(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "manual"})
+
+	report, err := store.Import(map[string]DocEntry{
+		"ns:a": {Summary: "synced"},
+		"ns:b": {Summary: "new"},
+	}, ImportOptions{Conflict: ConflictSkipExisting})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if report.Count(ImportSkipped) != 1 || report.Count(ImportCreated) != 1 {
+		t.Errorf("report = %+v", report)
+	}
+
+	got, _ := store.GetDocEntry("ns:a")
+	if got.Entry.Summary != "manual" {
+		t.Errorf("Summary = %q, want manual", got.Entry.Summary)
+	}
+}
+
+func TestImport_MergeNotes(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{
+		Summary:      "manual",
+		Notes:        "Requires auth.",
+		ExternalRefs: []string{"https://manual"},
+	})
+
+	report, err := store.Import(map[string]DocEntry{
+		"ns:a": {Notes: "Paginates via cursor."},
+	}, ImportOptions{Conflict: ConflictMergeNotes})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if report.Changes[0].Action != ImportMerged {
+		t.Errorf("Action = %q, want merged", report.Changes[0].Action)
+	}
+
+	got, _ := store.GetDocEntry("ns:a")
+	if got.Entry.Notes != "Requires auth.\n\nPaginates via cursor." {
+		t.Errorf("Notes = %q", got.Entry.Notes)
+	}
+	if got.Entry.Summary != "manual" || len(got.Entry.ExternalRefs) != 1 {
+		t.Errorf("existing fields not preserved: %+v", got.Entry)
+	}
+
+	// Re-importing the same notes is a no-op
+	report, err = store.Import(map[string]DocEntry{
+		"ns:a": {Notes: "Paginates via cursor."},
+	}, ImportOptions{Conflict: ConflictMergeNotes})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if report.Changes[0].Action != ImportUnchanged {
+		t.Errorf("Action = %q, want unchanged", report.Changes[0].Action)
+	}
+}
+
+func TestImport_Fail(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "manual"})
+
+	_, err := store.Import(map[string]DocEntry{
+		"ns:a": {Summary: "synced"},
+		"ns:b": {Summary: "new"},
+	}, ImportOptions{Conflict: ConflictFail})
+	if !errors.Is(err, ErrImportConflict) {
+		t.Fatalf("Import error = %v, want ErrImportConflict", err)
+	}
+	if _, err := store.GetDocEntry("ns:b"); !errors.Is(err, ErrNotFound) {
+		t.Error("failed import must not create records")
+	}
+}
+
+func TestImport_DryRun(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "manual"})
+
+	report, err := store.Import(map[string]DocEntry{
+		"ns:a": {Summary: "synced"},
+		"ns:b": {Summary: "new"},
+	}, ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if !report.DryRun || report.Count(ImportUpdated) != 1 || report.Count(ImportCreated) != 1 {
+		t.Errorf("report = %+v", report)
+	}
+
+	got, _ := store.GetDocEntry("ns:a")
+	if got.Entry.Summary != "manual" {
+		t.Errorf("dry run modified ns:a: %q", got.Entry.Summary)
+	}
+	if _, err := store.GetDocEntry("ns:b"); !errors.Is(err, ErrNotFound) {
+		t.Error("dry run created ns:b")
+	}
+}
+
+func TestImport_SkipsFrozen(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "manual"})
+	if err := store.Freeze("ns:a"); err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+
+	report, err := store.Import(map[string]DocEntry{"ns:a": {Summary: "synced"}}, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	want := []ImportChange{{ID: "ns:a", Action: ImportSkipped, Reason: "frozen"}}
+	if !reflect.DeepEqual(report.Changes, want) {
+		t.Errorf("Changes = %+v, want %+v", report.Changes, want)
+	}
+}
+
+func TestImport_ValidatesBeforeApplying(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+
+	deep := map[string]any{"a": map[string]any{"b": map[string]any{"c": map[string]any{"d": map[string]any{"e": map[string]any{"f": 1}}}}}}
+	_, err := store.Import(map[string]DocEntry{
+		"ns:a": {Summary: "ok"},
+		"ns:b": {Examples: []ToolExample{{Title: "deep", Args: deep}}},
+	}, ImportOptions{})
+	if !errors.Is(err, ErrArgsTooLarge) {
+		t.Fatalf("Import error = %v, want ErrArgsTooLarge", err)
+	}
+	if _, err := store.GetDocEntry("ns:a"); !errors.Is(err, ErrNotFound) {
+		t.Error("invalid import must not create records")
+	}
+}
+
+func TestImport_ValidatesMergesBeforeApplying(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{MaxExamplesPerTool: 1})
+	mustRegisterDoc(t, store, "b:old", DocEntry{Examples: []ToolExample{{Title: "one", Args: map[string]any{}}}})
+
+	_, err := store.Import(map[string]DocEntry{
+		"a:new": {Summary: "new"},
+		"b:old": {Examples: []ToolExample{{Title: "two", Args: map[string]any{}}}},
+	}, ImportOptions{Conflict: ConflictMergeNotes})
+	if !errors.Is(err, ErrExampleLimit) {
+		t.Fatalf("Import error = %v, want ErrExampleLimit", err)
+	}
+	if _, err := store.GetDocEntry("a:new"); !errors.Is(err, ErrNotFound) {
+		t.Error("failed merge import must not create records")
+	}
+}
+
+func TestImport_Report(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "manual"})
+
+	deep := map[string]any{"a": map[string]any{"b": map[string]any{"c": map[string]any{"d": map[string]any{"e": map[string]any{"f": 1}}}}}}
+	report, err := store.Import(map[string]DocEntry{
+		"ns:a": {Summary: "manual"},
+		"ns:b": {Examples: []ToolExample{{Title: "deep", Args: deep}}},
+		"ns:c": {Summary: strings.Repeat("x", MaxSummaryLen+10), Examples: []ToolExample{{
+			Title: "Login",
+			Args:  map[string]any{"user": "ann", "auth": map[string]any{"api_key": "k"}, "headers": []any{"Bearer abc123"}},
+		}}},
+	}, ImportOptions{SkipInvalid: true, Redact: true})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	failed, created := report.Changes[1], report.Changes[2]
+	if failed.Action != ImportFailed || !strings.Contains(failed.Reason, "ns:b") {
+		t.Errorf("ns:b = %+v, want failed with reason", failed)
+	}
+	if want := []string{"summary"}; !reflect.DeepEqual(created.Truncated, want) {
+		t.Errorf("Truncated = %v, want %v", created.Truncated, want)
+	}
+	if want := []string{"examples[0].args.auth.api_key", "examples[0].args.headers[0]"}; !reflect.DeepEqual(created.Redacted, want) {
+		t.Errorf("Redacted = %v, want %v", created.Redacted, want)
+	}
+	if want := (ImportTotals{Created: 1, Unchanged: 1, Failed: 1, Truncated: 1, Redacted: 1}); report.Totals != want {
+		t.Errorf("Totals = %+v, want %+v", report.Totals, want)
+	}
+
+	got, _ := store.GetDocEntry("ns:c")
+	if args := got.Entry.Examples[0].Args; args["user"] != "ann" || args["auth"].(map[string]any)["api_key"] != RedactedValue {
+		t.Errorf("stored Args = %v, want secrets redacted", args)
+	}
+	if _, err := store.GetDocEntry("ns:b"); !errors.Is(err, ErrNotFound) {
+		t.Error("failed entry must not be imported")
+	}
+}
+
+func TestImport_ExampleChanges(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "A", Examples: []ToolExample{
+		{ID: "basic", Title: "Basic", Args: map[string]any{"repo": "a/b"}},
+	}})
+
+	report, err := store.Import(map[string]DocEntry{"ns:a": {Summary: "A", Examples: []ToolExample{
+		{ID: "basic", Title: "Basic", Description: "Fetch a repo", Args: map[string]any{"repo": "a/b", "ref": "main"}},
+	}}}, ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ExampleDiff{{ID: "basic", Args: []ArgChange{{Path: "ref", New: "main"}}, NewDescription: "Fetch a repo"}}
+	if got := report.Changes[0].ExampleChanges; !reflect.DeepEqual(got, want) {
+		t.Errorf("ExampleChanges = %+v, want %+v", got, want)
+	}
+}
+
+func TestImport_UnknownPolicy(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	if _, err := store.Import(nil, ImportOptions{Conflict: "bogus"}); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
@@ -0,0 +1,91 @@
+// Package importer converts documentation maintained in other formats into
+// tooldocs entries.
+//
+// Each importer returns a map of tool ID to tooldocs.DocEntry ready for
+// (*tooldocs.InMemoryStore).Import, with DocEntry.Source set to
+// "importer:<format>":
+//
+//	entries, err := importer.FromPostman(f, importer.HTTPOptions{Namespace: "billing"})
+//	if err != nil { ... }
+//	report, err := store.Import(entries, tooldocs.ImportOptions{Conflict: tooldocs.ConflictMergeNotes, Redact: true})
+//
+// Importers are best-effort: content that does not fit the tooldocs model
+// (non-JSON bodies, unknown fields) is skipped rather than rejected, and
+// text is truncated to the usual caps by Import. The tooldocs.ImportReport
+// records per tool what was created, updated, skipped, failed, truncated,
+// or redacted.
+package importer
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// toolName converts a human label such as "Create Invoice" into a tool name
+// ("create_invoice"): lowercase letters and digits, with every other run of
+// characters collapsed to one underscore.
+func toolName(label string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(label) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			underscore = false
+			b.WriteRune(r)
+			continue
+		}
+		underscore = true
+	}
+	return b.String()
+}
+
+// splitSummary returns the first sentence (or line) of text as a summary and
+// the rest as notes.
+func splitSummary(text string) (summary, rest string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", ""
+	}
+	end := len(text)
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		end = i
+	}
+	if i := strings.Index(text[:end], ". "); i >= 0 {
+		end = i + 1
+	}
+	return strings.TrimSpace(text[:end]), strings.TrimSpace(text[end:])
+}
+
+// jsonObject decodes body as a JSON object, returning nil when it is not one.
+func jsonObject(body string) map[string]any {
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(body)), &obj); err != nil {
+		return nil
+	}
+	return obj
+}
+
+// joinNotes joins non-empty paragraphs with blank lines.
+func joinNotes(paragraphs ...string) string {
+	var kept []string
+	for _, p := range paragraphs {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, "\n\n")
+}
+
+// addEntry stores entry under id, merging with an entry already imported
+// for the same tool (e.g. two collection requests mapped to one tool).
+func addEntry(entries map[string]tooldocs.DocEntry, id string, entry tooldocs.DocEntry) {
+	if existing, ok := entries[id]; ok {
+		entry = tooldocs.MergeDocEntries(existing, entry, tooldocs.MergePolicy{Notes: tooldocs.NotesAppend})
+	}
+	entries[id] = entry
+}
@@ -0,0 +1,127 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// ManifestOptions configures FromMCPManifest.
+type ManifestOptions struct {
+	// Namespace prefixes tool IDs ("<namespace>:<tool>"). Empty means the
+	// manifest's "name" field; when that is also empty, tool IDs are bare
+	// tool names.
+	Namespace string
+}
+
+// mcpManifest is the subset of MCP server manifest formats the importer
+// reads: a tools/list result, or a server/extension manifest with a
+// top-level tools array.
+type mcpManifest struct {
+	Name          string             `json:"name"`
+	Documentation string             `json:"documentation"`
+	Tools         *[]mcpManifestTool `json:"tools"`
+}
+
+type mcpManifestTool struct {
+	Name          string               `json:"name"`
+	Description   string               `json:"description"`
+	Documentation string               `json:"documentation"`
+	Examples      []mcpManifestExample `json:"examples"`
+	Meta          struct {
+		Examples []mcpManifestExample `json:"examples"`
+	} `json:"_meta"`
+}
+
+// mcpManifestExample accepts the field spellings used by common manifest
+// formats.
+type mcpManifestExample struct {
+	Title       string         `json:"title"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Args        map[string]any `json:"args"`
+	Arguments   map[string]any `json:"arguments"`
+	Input       map[string]any `json:"input"`
+	Result      any            `json:"result"`
+	ResultHint  string         `json:"resultHint"`
+}
+
+// FromMCPManifest converts the documentation shipped with an MCP server into
+// doc entries. It accepts any JSON object with a top-level "tools" array,
+// which covers tools/list results and server or extension manifests.
+//
+// For each tool, the first sentence of its description becomes the summary
+// and the rest becomes the notes. Examples are read from the tool's
+// "examples" array or "_meta.examples"; arguments may be spelled "args",
+// "arguments", or "input", and a string "result" or "resultHint" becomes
+// the ResultHint. "documentation" URLs on the tool or manifest become
+// external refs. Tools without a name are skipped.
+//
+// Returns ErrFormat if r does not contain a JSON object with a tools array.
+func FromMCPManifest(r io.Reader, opts ManifestOptions) (map[string]tooldocs.DocEntry, error) {
+	var m mcpManifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("%w: mcp manifest: %v", ErrFormat, err)
+	}
+	if m.Tools == nil {
+		return nil, fmt.Errorf("%w: mcp manifest: missing tools array", ErrFormat)
+	}
+
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = m.Name
+	}
+
+	entries := make(map[string]tooldocs.DocEntry)
+	for _, tool := range *m.Tools {
+		if tool.Name == "" {
+			continue
+		}
+		id := tool.Name
+		if namespace != "" {
+			id = namespace + ":" + tool.Name
+		}
+
+		summary, notes := splitSummary(tool.Description)
+		entry := tooldocs.DocEntry{
+			Summary: summary,
+			Notes:   notes,
+			Source:  "importer:mcp-manifest",
+		}
+		for _, ex := range append(tool.Examples, tool.Meta.Examples...) {
+			entry.Examples = append(entry.Examples, ex.toolExample())
+		}
+		for _, ref := range []string{tool.Documentation, m.Documentation} {
+			if ref != "" {
+				entry.ExternalRefs = append(entry.ExternalRefs, ref)
+			}
+		}
+		addEntry(entries, id, entry)
+	}
+	return entries, nil
+}
+
+// toolExample converts a manifest example.
+func (ex mcpManifestExample) toolExample() tooldocs.ToolExample {
+	out := tooldocs.ToolExample{
+		Title:       ex.Title,
+		Description: ex.Description,
+		Args:        ex.Args,
+		ResultHint:  ex.ResultHint,
+	}
+	if out.Title == "" {
+		out.Title = ex.Name
+	}
+	if out.Args == nil {
+		out.Args = ex.Arguments
+	}
+	if out.Args == nil {
+		out.Args = ex.Input
+	}
+	if result, ok := ex.Result.(string); ok && out.ResultHint == "" {
+		out.ResultHint = result
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+func TestFromMCPManifest(t *testing.T) {
+	entries, err := FromMCPManifest(openFixture(t, "github.manifest.json"), ManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]tooldocs.DocEntry{
+		"github:get_repo": {
+			Summary: "Fetch repository metadata.",
+			Notes:   "Includes default branch and visibility.",
+			Examples: []tooldocs.ToolExample{
+				{Title: "Public repo", Args: map[string]any{"repo": "octo/hello"}, ResultHint: "Repository object"},
+			},
+			ExternalRefs: []string{"https://example.com/github-mcp"},
+			Source:       "importer:mcp-manifest",
+		},
+		"github:search_issues": {
+			Summary: "Search issues across repositories.",
+			Examples: []tooldocs.ToolExample{
+				{Title: "Open bugs", Description: "Open issues labeled bug.", Args: map[string]any{"q": "is:open label:bug"}, ResultHint: "Array of issues"},
+				{Title: "Structured result", Args: map[string]any{"q": "author:me"}},
+			},
+			ExternalRefs: []string{"https://example.com/github-mcp/search", "https://example.com/github-mcp"},
+			Source:       "importer:mcp-manifest",
+		},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("FromMCPManifest =\n%+v\nwant\n%+v", entries, want)
+	}
+}
+
+func TestFromMCPManifest_ToolsList(t *testing.T) {
+	input := `{"tools": [{"name": "echo", "description": "Echo the input.", "inputSchema": {"type": "object"}}]}`
+
+	entries, err := FromMCPManifest(strings.NewReader(input), ManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := entries["echo"]; !ok || len(entries) != 1 {
+		t.Errorf("entries = %+v, want bare ID echo", entries)
+	}
+
+	entries, err = FromMCPManifest(strings.NewReader(input), ManifestOptions{Namespace: "util"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries["util:echo"].Summary != "Echo the input." {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestFromMCPManifest_RejectsOtherFormats(t *testing.T) {
+	for _, input := range []string{`{"name": "x"}`, `[]`, `{"tools": {}}`} {
+		if _, err := FromMCPManifest(strings.NewReader(input), ManifestOptions{}); !errors.Is(err, ErrFormat) {
+			t.Errorf("FromMCPManifest(%s) err = %v, want ErrFormat", input, err)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+// ErrFormat is returned when input is not in the expected format.
+var ErrFormat = errors.New("unrecognized format")
+
+// HTTPRequest is a request from an API client collection, as seen by
+// HTTPOptions.ToolID.
+type HTTPRequest struct {
+	// Name is the request's display name.
+	Name string
+
+	// Folders lists the enclosing folder names, outermost first.
+	Folders []string
+
+	// Method is the HTTP method (e.g. "POST").
+	Method string
+
+	// URL is the raw request URL, possibly with client variables such as
+	// "{{baseUrl}}".
+	URL string
+}
+
+// HTTPOptions configures FromPostman and FromInsomnia.
+type HTTPOptions struct {
+	// Namespace prefixes generated tool IDs ("<namespace>:<name>").
+	// Empty means tool IDs have no namespace.
+	Namespace string
+
+	// ToolID maps a request to the ID of the HTTP-backed tool it documents.
+	// Returning "" skips the request. Nil derives the ID from Namespace and
+	// the request name ("Create Invoice" becomes "create_invoice").
+	ToolID func(req HTTPRequest) string
+}
+
+// toolID applies opts.ToolID or the default naming.
+func (opts HTTPOptions) toolID(req HTTPRequest) string {
+	if opts.ToolID != nil {
+		return opts.ToolID(req)
+	}
+	name := toolName(req.Name)
+	if name == "" {
+		return ""
+	}
+	if opts.Namespace == "" {
+		return name
+	}
+	return opts.Namespace + ":" + name
+}
+
+// postmanCollection is the subset of the Postman Collection v2.x format the
+// importer reads.
+type postmanCollection struct {
+	Info struct {
+		Schema string `json:"schema"`
+	} `json:"info"`
+	Item []postmanItem `json:"item"`
+}
+
+type postmanItem struct {
+	Name        string            `json:"name"`
+	Description postmanText       `json:"description"`
+	Item        []postmanItem     `json:"item"`
+	Request     *postmanRequest   `json:"request"`
+	Response    []postmanResponse `json:"response"`
+}
+
+type postmanRequest struct {
+	Method      string      `json:"method"`
+	URL         postmanURL  `json:"url"`
+	Description postmanText `json:"description"`
+	Body        *struct {
+		Mode string `json:"mode"`
+		Raw  string `json:"raw"`
+	} `json:"body"`
+}
+
+type postmanResponse struct {
+	Name            string          `json:"name"`
+	OriginalRequest *postmanRequest `json:"originalRequest"`
+	Status          string          `json:"status"`
+	Code            int             `json:"code"`
+}
+
+// postmanText is a description given either as a string or as
+// {"content": "...", "type": "text/markdown"}.
+type postmanText string
+
+func (t *postmanText) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = postmanText(s)
+		return nil
+	}
+	var obj struct {
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*t = postmanText(obj.Content)
+	return nil
+}
+
+// postmanURL is a URL given either as a string or as {"raw": "..."}.
+type postmanURL string
+
+func (u *postmanURL) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*u = postmanURL(s)
+		return nil
+	}
+	var obj struct {
+		Raw string `json:"raw"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*u = postmanURL(obj.Raw)
+	return nil
+}
+
+// FromPostman converts a Postman Collection (v2.0 or v2.1 JSON export) into
+// doc entries for the HTTP-backed tools its requests correspond to.
+//
+// For each request, the first sentence of its description becomes the
+// summary (the request name when there is no description) and the rest,
+// followed by the method and URL, becomes the notes. Each saved example
+// response whose request body is a JSON object becomes a ToolExample with
+// that body as Args and the response status as ResultHint; without saved
+// examples, a JSON request body becomes a single example. Folder
+// descriptions are not imported. Requests mapping to the same tool ID are
+// merged.
+//
+// Returns ErrFormat if r does not contain a Postman collection.
+func FromPostman(r io.Reader, opts HTTPOptions) (map[string]tooldocs.DocEntry, error) {
+	var c postmanCollection
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return nil, fmt.Errorf("%w: postman: %v", ErrFormat, err)
+	}
+	if !strings.Contains(c.Info.Schema, "getpostman.com") {
+		return nil, fmt.Errorf("%w: postman: missing collection schema", ErrFormat)
+	}
+
+	entries := make(map[string]tooldocs.DocEntry)
+	var walk func(items []postmanItem, folders []string)
+	walk = func(items []postmanItem, folders []string) {
+		for _, item := range items {
+			if item.Request == nil {
+				walk(item.Item, append(folders[:len(folders):len(folders)], item.Name))
+				continue
+			}
+			req := HTTPRequest{
+				Name:    item.Name,
+				Folders: folders,
+				Method:  strings.ToUpper(item.Request.Method),
+				URL:     string(item.Request.URL),
+			}
+			id := opts.toolID(req)
+			if id == "" {
+				continue
+			}
+			description := string(item.Request.Description)
+			if description == "" {
+				description = string(item.Description)
+			}
+			addEntry(entries, id, httpEntry(req, description, postmanExamples(item), "importer:postman"))
+		}
+	}
+	walk(c.Item, nil)
+	return entries, nil
+}
+
+// postmanExamples converts an item's saved responses (or, without any, its
+// request body) into examples.
+func postmanExamples(item postmanItem) []tooldocs.ToolExample {
+	var examples []tooldocs.ToolExample
+	for _, resp := range item.Response {
+		body := requestBody(resp.OriginalRequest)
+		if body == nil {
+			continue
+		}
+		ex := tooldocs.ToolExample{Title: resp.Name, Args: body}
+		if resp.Code != 0 {
+			ex.ResultHint = strings.TrimSpace(fmt.Sprintf("%d %s", resp.Code, resp.Status))
+		}
+		examples = append(examples, ex)
+	}
+	if len(examples) == 0 {
+		if body := requestBody(item.Request); body != nil {
+			examples = append(examples, tooldocs.ToolExample{Title: item.Name, Args: body})
+		}
+	}
+	return examples
+}
+
+// requestBody returns a raw JSON object body, or nil.
+func requestBody(req *postmanRequest) map[string]any {
+	if req == nil || req.Body == nil || req.Body.Mode != "raw" {
+		return nil
+	}
+	return jsonObject(req.Body.Raw)
+}
+
+// insomniaExport is the subset of the Insomnia v4 export format the
+// importer reads.
+type insomniaExport struct {
+	Type      string             `json:"_type"`
+	Format    int                `json:"__export_format"`
+	Resources []insomniaResource `json:"resources"`
+}
+
+type insomniaResource struct {
+	ID          string `json:"_id"`
+	Type        string `json:"_type"`
+	ParentID    string `json:"parentId"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	Body        struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	} `json:"body"`
+}
+
+// FromInsomnia converts an Insomnia export (format 4 JSON) into doc entries,
+// mapping requests as FromPostman does. Insomnia exports carry no saved
+// responses, so a JSON request body becomes the only example.
+//
+// Returns ErrFormat if r does not contain an Insomnia export.
+func FromInsomnia(r io.Reader, opts HTTPOptions) (map[string]tooldocs.DocEntry, error) {
+	var export insomniaExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("%w: insomnia: %v", ErrFormat, err)
+	}
+	if export.Type != "export" || export.Format != 4 {
+		return nil, fmt.Errorf("%w: insomnia: want export format 4", ErrFormat)
+	}
+
+	groups := make(map[string]insomniaResource)
+	for _, res := range export.Resources {
+		if res.Type == "request_group" {
+			groups[res.ID] = res
+		}
+	}
+	folders := func(parent string) []string {
+		var names []string
+		for seen := 0; seen < len(groups); seen++ {
+			group, ok := groups[parent]
+			if !ok {
+				break
+			}
+			names = append([]string{group.Name}, names...)
+			parent = group.ParentID
+		}
+		return names
+	}
+
+	entries := make(map[string]tooldocs.DocEntry)
+	for _, res := range export.Resources {
+		if res.Type != "request" {
+			continue
+		}
+		req := HTTPRequest{
+			Name:    res.Name,
+			Folders: folders(res.ParentID),
+			Method:  strings.ToUpper(res.Method),
+			URL:     res.URL,
+		}
+		id := opts.toolID(req)
+		if id == "" {
+			continue
+		}
+		var examples []tooldocs.ToolExample
+		if strings.Contains(res.Body.MimeType, "json") {
+			if body := jsonObject(res.Body.Text); body != nil {
+				examples = append(examples, tooldocs.ToolExample{Title: res.Name, Args: body})
+			}
+		}
+		addEntry(entries, id, httpEntry(req, res.Description, examples, "importer:insomnia"))
+	}
+	return entries, nil
+}
+
+// httpEntry builds the entry for one collection request.
+func httpEntry(req HTTPRequest, description string, examples []tooldocs.ToolExample, source string) tooldocs.DocEntry {
+	summary, rest := splitSummary(description)
+	if summary == "" {
+		summary = req.Name
+	}
+	var endpoint string
+	if req.URL != "" {
+		endpoint = "`" + strings.TrimSpace(req.Method+" "+req.URL) + "`"
+	}
+	return tooldocs.DocEntry{
+		Summary:  summary,
+		Notes:    joinNotes(rest, endpoint),
+		Examples: examples,
+		Source:   source,
+	}
+}
@@ -0,0 +1,131 @@
+package importer
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/tooldocs"
+)
+
+func openFixture(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestFromPostman(t *testing.T) {
+	entries, err := FromPostman(openFixture(t, "billing.postman_collection.json"), HTTPOptions{Namespace: "billing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]tooldocs.DocEntry{
+		"billing:create_invoice": {
+			Summary: "Create a draft invoice for a customer.",
+			Notes:   "Amounts are in cents.\nDrafts are not sent until finalized.\n\n`POST {{baseUrl}}/invoices`",
+			Examples: []tooldocs.ToolExample{{
+				Title:      "Minimal invoice",
+				Args:       map[string]any{"customer": "cus_1", "amount": float64(500)},
+				ResultHint: "201 Created",
+			}},
+			Source: "importer:postman",
+		},
+		"billing:list_invoices": {
+			Summary: "List invoices",
+			Notes:   "`GET {{baseUrl}}/invoices?limit=10`",
+			Source:  "importer:postman",
+		},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("FromPostman =\n%+v\nwant\n%+v", entries, want)
+	}
+
+	store := tooldocs.NewInMemoryStore(tooldocs.StoreOptions{})
+	if _, err := store.Import(entries, tooldocs.ImportOptions{}); err != nil {
+		t.Errorf("Import: %v", err)
+	}
+}
+
+func TestFromPostman_ToolIDMapping(t *testing.T) {
+	var seen []HTTPRequest
+	entries, err := FromPostman(openFixture(t, "billing.postman_collection.json"), HTTPOptions{
+		ToolID: func(req HTTPRequest) string {
+			seen = append(seen, req)
+			if req.Method != "POST" {
+				return ""
+			}
+			return "api:invoices.create"
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries["api:invoices.create"].Summary == "" {
+		t.Errorf("entries = %+v", entries)
+	}
+	if len(seen) != 2 || !reflect.DeepEqual(seen[0].Folders, []string{"Invoices"}) || seen[1].Folders != nil {
+		t.Errorf("requests = %+v", seen)
+	}
+}
+
+func TestFromInsomnia(t *testing.T) {
+	var folders [][]string
+	entries, err := FromInsomnia(openFixture(t, "billing.insomnia.json"), HTTPOptions{
+		ToolID: func(req HTTPRequest) string {
+			folders = append(folders, req.Folders)
+			return HTTPOptions{Namespace: "billing"}.toolID(req)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	create := entries["billing:create_invoice"]
+	if create.Summary != "Create a draft invoice for a customer." || create.Source != "importer:insomnia" {
+		t.Errorf("create_invoice = %+v", create)
+	}
+	if len(create.Examples) != 1 || create.Examples[0].Args["customer"] != "cus_1" {
+		t.Errorf("create_invoice examples = %+v", create.Examples)
+	}
+	upload := entries["billing:upload_receipt"]
+	if upload.Summary != "Upload receipt" || len(upload.Examples) != 0 {
+		t.Errorf("upload_receipt = %+v", upload)
+	}
+	if !reflect.DeepEqual(folders, [][]string{{"Invoices"}, nil}) {
+		t.Errorf("folders = %v", folders)
+	}
+}
+
+func TestHTTPImporters_RejectOtherFormats(t *testing.T) {
+	if _, err := FromPostman(strings.NewReader(`{"_type": "export"}`), HTTPOptions{}); !errors.Is(err, ErrFormat) {
+		t.Errorf("FromPostman err = %v, want ErrFormat", err)
+	}
+	if _, err := FromInsomnia(openFixture(t, "billing.postman_collection.json"), HTTPOptions{}); !errors.Is(err, ErrFormat) {
+		t.Errorf("FromInsomnia err = %v, want ErrFormat", err)
+	}
+	if _, err := FromPostman(strings.NewReader(`not json`), HTTPOptions{}); !errors.Is(err, ErrFormat) {
+		t.Errorf("FromPostman err = %v, want ErrFormat", err)
+	}
+}
+
+func TestToolName(t *testing.T) {
+	tests := map[string]string{
+		"Create Invoice":     "create_invoice",
+		"  GET /users/{id} ": "get_users_id",
+		"List-All (v2)":      "list_all_v2",
+		"":                   "",
+		"!!!":                "",
+	}
+	for in, want := range tests {
+		if got := toolName(in); got != want {
+			t.Errorf("toolName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrReadOnly is returned by LayeredStore and CachingStore writes when the
+// store they write to does not support the write, and by writes to a sealed
+// InMemoryStore (see InMemoryStore.Seal).
+var ErrReadOnly = errors.New("store is read-only")
+
+// LayeredStore is a Store that stacks stores in precedence order, e.g.
+// local overrides on top of an upstream read-only bundle. Reads query every
+// layer and merge the results, with higher layers winning; writes go to the
+// primary (first) layer only.
+//
+// It implements Store and the context-aware read methods, so it can back
+// httpapi and graphqlapi handlers directly. Layers that implement
+// DescribeToolContext or ListExamplesContext receive the caller's context.
+type LayeredStore struct {
+	layers []Store
+}
+
+var _ Store = (*LayeredStore)(nil)
+
+// NewLayeredStore returns a LayeredStore reading primary, then fallback,
+// then each of more, in that order.
+func NewLayeredStore(primary, fallback Store, more ...Store) *LayeredStore {
+	layers := append([]Store{primary, fallback}, more...)
+	return &LayeredStore{layers: layers}
+}
+
+// Primary returns the layer that receives writes.
+func (l *LayeredStore) Primary() Store {
+	return l.layers[0]
+}
+
+// DescribeTool is DescribeToolContext with a background context.
+func (l *LayeredStore) DescribeTool(id string, level DetailLevel) (ToolDoc, error) {
+	return l.DescribeToolContext(context.Background(), id, level)
+}
+
+// DescribeToolContext describes the tool in every layer and merges the
+// results top-down: each field is taken from the highest layer that sets
+// it (see layerToolDoc). Layers returning ErrNotFound, or ErrNoTool because
+// only a lower layer can resolve the tool, are skipped; give override layers
+// the same Index or ToolResolver as the layers below so their schema and
+// full tiers take part.
+//
+// For layers that expose their entries (GetDocEntry, as InMemoryStore
+// does), only the content of the layer's DocEntry takes part in the merge,
+// and layers with no entry for the tool contribute nothing but the tool
+// definition. Fields derived from the tool (a Summary taken from the tool
+// description, the Title, Capabilities) are filled in last, so a layer that
+// merely resolves the tool never hides a lower layer's curated summary.
+// Other layers' docs are merged as returned.
+//
+// Returns ErrNoTool if some layer has docs but none can resolve the tool,
+// ErrNotFound if no layer knows the tool, and any other layer error (e.g.
+// ErrUnauthorized, ErrInvalidDetail, a lookup failure) as is, without
+// consulting lower layers.
+func (l *LayeredStore) DescribeToolContext(ctx context.Context, id string, level DetailLevel) (ToolDoc, error) {
+	var (
+		doc, derived  ToolDoc
+		found, hasDoc bool
+		noTool        error
+	)
+	for _, layer := range l.layers {
+		d, err := describeLayer(ctx, layer, id, level)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			continue
+		case errors.Is(err, ErrNoTool):
+			if noTool == nil {
+				noTool = err
+			}
+			continue
+		case err != nil:
+			return ToolDoc{}, err
+		}
+		if !found {
+			derived, found = d, true
+		} else {
+			derived = layerToolDoc(derived, d)
+		}
+		content, ok := layerContent(layer, id, d)
+		if !ok {
+			continue
+		}
+		if !hasDoc {
+			doc, hasDoc = content, true
+		} else {
+			doc = layerToolDoc(doc, content)
+		}
+	}
+	switch {
+	case hasDoc:
+		return layerToolDoc(doc, derived), nil
+	case found:
+		return derived, nil
+	case noTool != nil:
+		return ToolDoc{}, noTool
+	}
+	return ToolDoc{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// ListExamples is ListExamplesContext with a background context.
+func (l *LayeredStore) ListExamples(id string, maxExamples int) ([]ToolExample, error) {
+	return l.ListExamplesContext(context.Background(), id, maxExamples)
+}
+
+// ListExamplesContext returns the examples of the highest layer that has
+// any, so an overriding layer replaces rather than extends lower examples.
+// Layers returning ErrNotFound are skipped.
+//
+// Returns ErrNotFound if no layer knows the tool, and any other layer error
+// as is, without consulting lower layers.
+func (l *LayeredStore) ListExamplesContext(ctx context.Context, id string, maxExamples int) ([]ToolExample, error) {
+	found := false
+	for _, layer := range l.layers {
+		var (
+			examples []ToolExample
+			err      error
+		)
+		if cs, ok := layer.(interface {
+			ListExamplesContext(context.Context, string, int) ([]ToolExample, error)
+		}); ok {
+			examples, err = cs.ListExamplesContext(ctx, id, maxExamples)
+		} else {
+			examples, err = layer.ListExamples(id, maxExamples)
+		}
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(examples) > 0 {
+			return examples, nil
+		}
+		found = true
+	}
+	if found {
+		return []ToolExample{}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// DocIDs returns the sorted union of the IDs of every layer that lists
+// its documented tools.
+func (l *LayeredStore) DocIDs() []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, layer := range l.layers {
+		lister, ok := layer.(interface{ DocIDs() []string })
+		if !ok {
+			continue
+		}
+		for _, id := range lister.DocIDs() {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// RegisterDoc registers entry in the primary layer.
+//
+// Returns ErrReadOnly if the primary layer has no RegisterDoc method.
+func (l *LayeredStore) RegisterDoc(id string, entry DocEntry) error {
+	w, ok := l.Primary().(interface {
+		RegisterDoc(string, DocEntry) error
+	})
+	if !ok {
+		return fmt.Errorf("%w: RegisterDoc", ErrReadOnly)
+	}
+	return w.RegisterDoc(id, entry)
+}
+
+// RegisterExamples registers examples in the primary layer.
+//
+// Returns ErrReadOnly if the primary layer has no RegisterExamples method.
+func (l *LayeredStore) RegisterExamples(id string, examples []ToolExample) error {
+	w, ok := l.Primary().(interface {
+		RegisterExamples(string, []ToolExample) error
+	})
+	if !ok {
+		return fmt.Errorf("%w: RegisterExamples", ErrReadOnly)
+	}
+	return w.RegisterExamples(id, examples)
+}
+
+// AppendExamples appends examples in the primary layer.
+//
+// Returns ErrReadOnly if the primary layer has no AppendExamples method.
+func (l *LayeredStore) AppendExamples(id string, examples []ToolExample) error {
+	w, ok := l.Primary().(interface {
+		AppendExamples(string, []ToolExample) error
+	})
+	if !ok {
+		return fmt.Errorf("%w: AppendExamples", ErrReadOnly)
+	}
+	return w.AppendExamples(id, examples)
+}
+
+// Import imports entries into the primary layer.
+//
+// Returns ErrReadOnly if the primary layer has no Import method.
+func (l *LayeredStore) Import(entries map[string]DocEntry, opts ImportOptions) (ImportReport, error) {
+	w, ok := l.Primary().(interface {
+		Import(map[string]DocEntry, ImportOptions) (ImportReport, error)
+	})
+	if !ok {
+		return ImportReport{}, fmt.Errorf("%w: Import", ErrReadOnly)
+	}
+	return w.Import(entries, opts)
+}
+
+// describeLayer describes id in one layer, passing ctx when it can.
+func describeLayer(ctx context.Context, layer Store, id string, level DetailLevel) (ToolDoc, error) {
+	if cs, ok := layer.(interface {
+		DescribeToolContext(context.Context, string, DetailLevel) (ToolDoc, error)
+	}); ok {
+		return cs.DescribeToolContext(ctx, id, level)
+	}
+	return layer.DescribeTool(id, level)
+}
+
+// layerContent returns the part of a layer's doc that comes from its
+// DocEntry for id, with the fields the layer derived from the tool cleared.
+// It reports false when the layer has no entry for id. Layers that do not
+// expose their entries return doc unchanged.
+func layerContent(layer Store, id string, doc ToolDoc) (ToolDoc, bool) {
+	entries, ok := layer.(interface {
+		GetDocEntry(string) (StoredDoc, error)
+	})
+	if !ok {
+		return doc, true
+	}
+	stored, err := entries.GetDocEntry(id)
+	if err != nil {
+		return ToolDoc{}, false
+	}
+	entry := stored.Entry
+	if entry.Summary == "" {
+		doc.Summary = ""
+	}
+	if entry.Title == "" {
+		doc.Title = ""
+	}
+	if entry.Title == "" && entry.Annotations == nil {
+		doc.Tool = nil
+	}
+	if entry.Annotations == nil && !hasIrreversibleEffect(entry.Effects) {
+		doc.Capabilities = nil
+	}
+	return doc, true
+}
+
+// layerToolDoc fills the fields over leaves unset from under. Pointer,
+// string, and slice fields are taken whole from the higher layer that sets
+// them; Idempotent and Stale are set if either layer sets them.
+func layerToolDoc(over, under ToolDoc) ToolDoc {
+	doc := over
+	if doc.Tool == nil {
+		doc.Tool = under.Tool
+	}
+	if doc.Title == "" {
+		doc.Title = under.Title
+	}
+	if doc.Summary == "" {
+		doc.Summary = under.Summary
+	}
+	if doc.SchemaInfo == nil {
+		doc.SchemaInfo = under.SchemaInfo
+	}
+	if doc.Capabilities == nil {
+		doc.Capabilities = under.Capabilities
+	}
+	if len(doc.Effects) == 0 {
+		doc.Effects = under.Effects
+	}
+	if doc.RetryPolicy == nil {
+		doc.RetryPolicy = under.RetryPolicy
+	}
+	if doc.Concurrency == nil {
+		doc.Concurrency = under.Concurrency
+	}
+	if doc.ClientRequirements == nil {
+		doc.ClientRequirements = under.ClientRequirements
+	}
+	if doc.ShortNotes == "" {
+		doc.ShortNotes = under.ShortNotes
+	}
+	if doc.Notes == "" {
+		doc.Notes = under.Notes
+	}
+	if len(doc.Sections) == 0 {
+		doc.Sections = under.Sections
+	}
+	if len(doc.Examples) == 0 {
+		doc.Examples = under.Examples
+	}
+	if len(doc.Prerequisites) == 0 {
+		doc.Prerequisites = under.Prerequisites
+	}
+	if len(doc.Edges) == 0 {
+		doc.Edges = under.Edges
+	}
+	if len(doc.ExternalRefs) == 0 {
+		doc.ExternalRefs = under.ExternalRefs
+	}
+	if len(doc.Omitted) == 0 {
+		doc.Omitted = under.Omitted
+	}
+	if doc.Experiment == nil {
+		doc.Experiment = under.Experiment
+	}
+	doc.Idempotent = doc.Idempotent || under.Idempotent
+	doc.Stale = doc.Stale || under.Stale
+	return doc
+}
@@ -0,0 +1,112 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// readOnlyStore hides the write methods of an InMemoryStore.
+type readOnlyStore struct{ Store }
+
+func TestLayeredStore(t *testing.T) {
+	resolver := func(id string) (*toolmodel.Tool, error) {
+		switch id {
+		case "gh:search":
+			tool := makeToolWithSchema("search", "gh", "Search", map[string]any{"type": "object"})
+			return &tool, nil
+		case "gh:list":
+			tool := makeToolWithSchema("list", "gh", "raw machine description", map[string]any{"type": "object"})
+			return &tool, nil
+		}
+		return nil, nil
+	}
+	upstream := NewInMemoryStore(StoreOptions{ToolResolver: resolver})
+	mustRegisterDoc(t, upstream, "gh:search", DocEntry{
+		Summary:  "Search issues",
+		Notes:    "Upstream notes.",
+		Examples: []ToolExample{{Title: "Upstream", Args: map[string]any{"q": "a"}}},
+	})
+	mustRegisterDoc(t, upstream, "gh:list", DocEntry{Summary: "List issues"})
+
+	local := NewInMemoryStore(StoreOptions{ToolResolver: resolver})
+	mustRegisterDoc(t, local, "gh:search", DocEntry{Title: "Search", Notes: "Local notes."})
+	mustRegisterDoc(t, local, "local:echo", DocEntry{Summary: "Echo"})
+
+	store := NewLayeredStore(local, readOnlyStore{upstream})
+
+	doc, err := store.DescribeTool("gh:search", DetailFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Title != "Search" || doc.Notes != "Local notes." || doc.Tool == nil {
+		t.Errorf("merged doc = %+v, want local title and notes with tool", doc)
+	}
+	// A summary derived from the tool description does not hide a lower
+	// layer's curated summary.
+	if doc.Summary != "Search issues" {
+		t.Errorf("merged summary = %q, want upstream summary", doc.Summary)
+	}
+	if len(doc.Examples) != 1 || doc.Examples[0].Title != "Upstream" {
+		t.Errorf("merged examples = %+v, want upstream examples", doc.Examples)
+	}
+
+	// ErrNotFound in one layer falls through to the next.
+	if doc, err := store.DescribeTool("gh:list", DetailSummary); err != nil || doc.Summary != "List issues" {
+		t.Errorf("DescribeTool(gh:list) = %+v, %v", doc, err)
+	}
+	// A layer without docs for the tool only supplies the tool definition.
+	if doc, err := NewLayeredStore(local, upstream).DescribeTool("gh:list", DetailSchema); err != nil || doc.Summary != "List issues" || doc.Tool == nil {
+		t.Errorf("DescribeTool(gh:list) = %+v, %v; want upstream summary with tool", doc, err)
+	}
+	// Without any docs, fields are derived from the tool.
+	bare := NewLayeredStore(NewInMemoryStore(StoreOptions{ToolResolver: resolver}), NewInMemoryStore(StoreOptions{}))
+	if doc, err := bare.DescribeTool("gh:list", DetailSchema); err != nil || doc.Summary != "raw machine description" || doc.Capabilities == nil {
+		t.Errorf("DescribeTool(gh:list) without docs = %+v, %v", doc, err)
+	}
+	if doc, err := store.DescribeTool("local:echo", DetailSummary); err != nil || doc.Summary != "Echo" {
+		t.Errorf("DescribeTool(local:echo) = %+v, %v", doc, err)
+	}
+	if _, err := store.DescribeTool("local:echo", DetailSchema); !errors.Is(err, ErrNoTool) {
+		t.Errorf("DescribeTool(local:echo, schema) error = %v, want ErrNoTool", err)
+	}
+	if _, err := store.DescribeTool("gh:missing", DetailSummary); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DescribeTool(missing) error = %v, want ErrNotFound", err)
+	}
+	if _, err := store.DescribeTool("gh:search", "bogus"); !errors.Is(err, ErrInvalidDetail) {
+		t.Errorf("DescribeTool(bogus) error = %v, want ErrInvalidDetail", err)
+	}
+
+	if examples, err := store.ListExamples("gh:search", 5); err != nil || len(examples) != 1 || examples[0].Title != "Upstream" {
+		t.Errorf("ListExamples = %+v, %v; want upstream example", examples, err)
+	}
+	if _, err := store.ListExamples("gh:missing", 5); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ListExamples(missing) error = %v, want ErrNotFound", err)
+	}
+
+	// readOnlyStore hides DocIDs, so only the local IDs are listed.
+	if got, want := store.DocIDs(), []string{"gh:search", "local:echo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DocIDs = %v, want %v", got, want)
+	}
+	if got, want := NewLayeredStore(local, upstream).DocIDs(), []string{"gh:list", "gh:search", "local:echo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DocIDs = %v, want %v", got, want)
+	}
+
+	// Writes reach the primary only.
+	if err := store.AppendExamples("gh:search", []ToolExample{{Title: "Local", Args: map[string]any{"q": "b"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if examples, _ := store.ListExamples("gh:search", 5); len(examples) != 1 || examples[0].Title != "Local" {
+		t.Errorf("ListExamples after write = %+v, want local example", examples)
+	}
+	if stored, _ := upstream.GetDocEntry("gh:search"); len(stored.Entry.Examples) != 1 {
+		t.Errorf("upstream examples = %d, want 1 (unchanged)", len(stored.Entry.Examples))
+	}
+
+	readOnly := NewLayeredStore(readOnlyStore{upstream}, local)
+	if err := readOnly.RegisterDoc("gh:new", DocEntry{Summary: "New"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("RegisterDoc on read-only primary error = %v, want ErrReadOnly", err)
+	}
+}
@@ -0,0 +1,48 @@
+package tooldocs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMaxExamplesPerTool(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{MaxExamplesPerTool: 2})
+	three := []ToolExample{{Title: "a"}, {Title: "b"}, {Title: "c"}}
+
+	if err := store.RegisterDoc("ns:tool", DocEntry{Examples: three}); !errors.Is(err, ErrExampleLimit) {
+		t.Errorf("RegisterDoc error = %v, want ErrExampleLimit", err)
+	}
+	if err := store.RegisterExamples("ns:tool", three); !errors.Is(err, ErrExampleLimit) {
+		t.Errorf("RegisterExamples error = %v, want ErrExampleLimit", err)
+	}
+	if _, err := store.Import(map[string]DocEntry{"ns:tool": {Examples: three}}, ImportOptions{}); !errors.Is(err, ErrExampleLimit) {
+		t.Errorf("Import error = %v, want ErrExampleLimit", err)
+	}
+
+	mustRegisterExamples(t, store, "ns:tool", three[:2])
+	if err := store.AppendExamples("ns:tool", three[2:]); !errors.Is(err, ErrExampleLimit) {
+		t.Errorf("AppendExamples error = %v, want ErrExampleLimit", err)
+	}
+
+	got, _ := store.ListExamples("ns:tool", 0)
+	if len(got) != 2 {
+		t.Errorf("len(examples) = %d, want 2 (failed append must not modify)", len(got))
+	}
+}
+
+func TestMaxExampleBytes(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{MaxExampleBytes: 200})
+
+	mustRegisterExamples(t, store, "ns:tool", []ToolExample{{Title: "small"}})
+
+	big := ToolExample{Title: "big", Description: strings.Repeat("x", 250)}
+	if err := store.RegisterExamples("ns:tool", []ToolExample{big}); !errors.Is(err, ErrExampleLimit) {
+		t.Errorf("RegisterExamples error = %v, want ErrExampleLimit", err)
+	}
+
+	medium := ToolExample{Title: "medium", Description: strings.Repeat("y", 100)}
+	if err := store.AppendExamples("ns:tool", []ToolExample{medium, medium}); !errors.Is(err, ErrExampleLimit) {
+		t.Errorf("AppendExamples error = %v, want ErrExampleLimit", err)
+	}
+}
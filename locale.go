@@ -0,0 +1,143 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Catalog translates the package's own user-facing text into one locale:
+// sentinel error messages and rendered boilerplate such as parameter table
+// headers, retry and concurrency descriptions, and synthesized summaries.
+// Keys are the English source text, exactly as the package emits it: the
+// Error() text of a sentinel (e.g. "tool required for schema/full level"),
+// a literal ("required"), or a fmt format ("max %d attempts"). Missing keys
+// fall back to English. A nil Catalog is valid and translates nothing.
+//
+// Documentation content registered by callers is never translated.
+type Catalog map[string]string
+
+type localeKey struct{}
+
+// WithLocale returns a context carrying the caller's locale as a BCP 47
+// tag, e.g. "de" or "pt-BR". The store selects the matching
+// StoreOptions.Catalogs entry for text it renders on that caller's behalf.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// Locale returns the locale carried by ctx, or "".
+func Locale(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// Text returns the translation of s, or s.
+func (c Catalog) Text(s string) string {
+	if t, ok := c[s]; ok && t != "" {
+		return t
+	}
+	return s
+}
+
+// Sprintf formats args with the translation of format. Translations must
+// keep the verbs of the source format, in order.
+func (c Catalog) Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(c.Text(format), args...)
+}
+
+// localizedErrors are the sentinels Catalog.Error translates.
+var localizedErrors = []error{
+	ErrNotFound, ErrInvalidDetail, ErrNoTool, ErrArgsTooLarge, ErrExampleLimit,
+	ErrUnauthorized, ErrFrozen, ErrThrottled, ErrSummaryStyle, ErrUnknownArgType,
+	ErrDuplicateExample, ErrFieldTooLong, ErrLookupTimeout, ErrReadOnly,
+	ErrBundleVersion, ErrImportConflict, ErrInvalidPlaceholder, ErrEmptyFilter,
+	ErrToolsetNotFound, ErrExperimentNotFound, ErrNoSubmission, ErrNoToolSource,
+	ErrDecrypt, ErrPersist, ErrNoAnswerer,
+}
+
+// Error returns err's message with the text of each package sentinel it
+// wraps replaced by its translation. Details added when wrapping, such as
+// tool IDs, are kept as is. The error itself is unchanged, so errors.Is
+// keeps working; use this only where the message is shown.
+func (c Catalog) Error(err error) string {
+	if err == nil {
+		return ""
+	}
+	msg := err.Error()
+	if len(c) == 0 {
+		return msg
+	}
+	for _, sentinel := range localizedErrors {
+		source := sentinel.Error()
+		if t := c.Text(source); t != source && errors.Is(err, sentinel) {
+			msg = strings.Replace(msg, source, t, 1)
+		}
+	}
+	return msg
+}
+
+// RenderParams is RenderParams with translated headers.
+func (c Catalog) RenderParams(info *SchemaInfo, format Format) string {
+	return renderParams(info, format, c)
+}
+
+// RetryPolicy is RetryPolicy.String, translated.
+func (c Catalog) RetryPolicy(p RetryPolicy) string {
+	return p.describe(c)
+}
+
+// Concurrency is Concurrency.String, translated.
+func (c Catalog) Concurrency(cc Concurrency) string {
+	return cc.describe(c)
+}
+
+// Catalog returns the StoreOptions.Catalogs entry for the caller's locale
+// (see WithLocale), or else for StoreOptions.Locale, or nil for English.
+func (s *InMemoryStore) Catalog(ctx context.Context) Catalog {
+	_, catalog := s.localeCatalog(ctx)
+	return catalog
+}
+
+// LocalizeError is Catalog(ctx).Error(err), for surfacing store errors to
+// the caller in their locale.
+func (s *InMemoryStore) LocalizeError(ctx context.Context, err error) string {
+	return s.Catalog(ctx).Error(err)
+}
+
+// localeCatalog returns the locale tag and catalog matching the caller:
+// the exact tag (ignoring case), then its base language ("pt" for
+// "pt-BR"), first for the context locale and then for the default locale.
+func (s *InMemoryStore) localeCatalog(ctx context.Context) (string, Catalog) {
+	if len(s.catalogs) == 0 {
+		return "", nil
+	}
+	for _, locale := range []string{Locale(ctx), s.locale} {
+		if locale == "" {
+			continue
+		}
+		tag := strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+		if c, ok := s.catalogs[tag]; ok {
+			return tag, c
+		}
+		if base, _, ok := strings.Cut(tag, "-"); ok {
+			if c, ok := s.catalogs[base]; ok {
+				return base, c
+			}
+		}
+	}
+	return "", nil
+}
+
+// normalizeCatalogs lowercases the locale tags of catalogs.
+func normalizeCatalogs(catalogs map[string]Catalog) map[string]Catalog {
+	if len(catalogs) == 0 {
+		return nil
+	}
+	out := make(map[string]Catalog, len(catalogs))
+	for tag, c := range catalogs {
+		out[strings.ToLower(strings.ReplaceAll(tag, "_", "-"))] = c
+	}
+	return out
+}
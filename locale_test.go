@@ -0,0 +1,109 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+var testCatalogDE = Catalog{
+	"tool not found":                      "Werkzeug nicht gefunden",
+	"tool required for schema/full level": "Werkzeugdefinition für Schema-/Vollansicht erforderlich",
+	"name":                                "Name",
+	"required":                            "Pflicht",
+	"yes":                                 "ja",
+	"safe to retry":                       "Wiederholung sicher",
+	"max %d attempts":                     "höchstens %d Versuche",
+	"max %d parallel":                     "höchstens %d parallel",
+	"group %s":                            "Gruppe %s",
+	"%s: requires %s":                     "%s: benötigt %s",
+}
+
+func TestCatalogError(t *testing.T) {
+	err := fmt.Errorf("describe: %w", fmt.Errorf("%w: gh:missing", ErrNotFound))
+	if got, want := testCatalogDE.Error(err), "describe: Werkzeug nicht gefunden: gh:missing"; got != want {
+		t.Errorf("Error = %q, want %q", got, want)
+	}
+	// Untranslated sentinels and foreign errors keep their English text.
+	if got := testCatalogDE.Error(fmt.Errorf("%w: gh:x", ErrFrozen)); got != "tool docs are frozen: gh:x" {
+		t.Errorf("untranslated = %q", got)
+	}
+	// Text that merely resembles a sentinel is not translated.
+	if got := testCatalogDE.Error(errors.New("tool not found")); got != "tool not found" {
+		t.Errorf("foreign error = %q", got)
+	}
+	var nilCatalog Catalog
+	if got := nilCatalog.Error(ErrNoTool); got != ErrNoTool.Error() {
+		t.Errorf("nil catalog = %q", got)
+	}
+}
+
+func TestCatalogRendering(t *testing.T) {
+	info := &SchemaInfo{Required: []string{"repo"}, Types: map[string][]string{"repo": {"string"}}}
+	table := testCatalogDE.RenderParams(info, FormatMarkdown)
+	if !strings.HasPrefix(table, "| Name | type | Pflicht | default | constraints |\n") || !strings.Contains(table, "| repo | string | ja |") {
+		t.Errorf("RenderParams =\n%s", table)
+	}
+	if RenderParams(info, FormatMarkdown) == table {
+		t.Error("RenderParams is translated without a catalog")
+	}
+
+	if got := testCatalogDE.RetryPolicy(RetryPolicy{SafeToRetry: true, MaxAttempts: 3}); got != "Wiederholung sicher; höchstens 3 Versuche" {
+		t.Errorf("RetryPolicy = %q", got)
+	}
+	if got := testCatalogDE.Concurrency(Concurrency{MaxParallel: 2, Group: "gh"}); got != "höchstens 2 parallel (Gruppe gh)" {
+		t.Errorf("Concurrency = %q", got)
+	}
+}
+
+func TestStoreLocale(t *testing.T) {
+	tool := makeToolWithSchema("create_ticket", "jira", "", map[string]any{
+		"type":     "object",
+		"required": []any{"title"},
+	})
+	store := NewInMemoryStore(StoreOptions{
+		SynthesizeSummaries: true,
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			if id == "jira:create_ticket" {
+				return &tool, nil
+			}
+			return nil, nil
+		},
+		Catalogs: map[string]Catalog{"de": testCatalogDE},
+	})
+	mustRegisterDoc(t, store, "local:echo", DocEntry{Summary: "Echo"})
+
+	de := WithLocale(context.Background(), "de-AT")
+	doc, err := store.DescribeToolContext(de, "jira:create_ticket", DetailSummary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Summary != "create_ticket: benötigt title" {
+		t.Errorf("localized synthesized summary = %q", doc.Summary)
+	}
+	doc, err = store.DescribeToolContext(context.Background(), "jira:create_ticket", DetailSummary)
+	if err != nil || doc.Summary != "create_ticket: requires title" {
+		t.Errorf("default summary = %q, %v", doc.Summary, err)
+	}
+
+	_, err = store.DescribeToolContext(de, "local:echo", DetailSchema)
+	if !errors.Is(err, ErrNoTool) {
+		t.Fatalf("err = %v, want ErrNoTool", err)
+	}
+	if got := store.LocalizeError(de, err); got != "Werkzeugdefinition für Schema-/Vollansicht erforderlich: local:echo" {
+		t.Errorf("LocalizeError = %q", got)
+	}
+	if got := store.LocalizeError(WithLocale(context.Background(), "fr"), err); got != err.Error() {
+		t.Errorf("LocalizeError without catalog = %q", got)
+	}
+
+	// StoreOptions.Locale is the default.
+	store = NewInMemoryStore(StoreOptions{Catalogs: map[string]Catalog{"DE": testCatalogDE}, Locale: "de"})
+	if store.Catalog(context.Background()) == nil {
+		t.Error("default locale catalog not selected")
+	}
+}
@@ -0,0 +1,219 @@
+package tooldocs
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"unsafe"
+)
+
+// ErrEvictionUnsupported is returned by FileStore and boltstore when
+// configured with StoreOptions.MaxDocs or MaxMemoryBytes. They serve every
+// doc from memory and persist what memory holds, so an evicted doc would be
+// dropped from durable storage.
+var ErrEvictionUnsupported = errors.New("MaxDocs and MaxMemoryBytes are not supported by persistent stores")
+
+// docLRU tracks the recency and estimated size of doc records for
+// StoreOptions.MaxDocs and MaxMemoryBytes. Writes update it under s.mu;
+// reads touch it under its own lock, so they keep sharing s.mu. A nil
+// docLRU (no limits configured) tracks nothing.
+type docLRU struct {
+	mu        sync.Mutex
+	order     *list.List // of IDs, most recently used first
+	elems     map[string]*list.Element
+	sizes     map[string]int
+	bytes     int
+	evictions int
+}
+
+func newDocLRU(maxDocs, maxBytes int) *docLRU {
+	if maxDocs <= 0 && maxBytes <= 0 {
+		return nil
+	}
+	return &docLRU{
+		order: list.New(),
+		elems: make(map[string]*list.Element),
+		sizes: make(map[string]int),
+	}
+}
+
+// touch marks id as most recently used.
+func (l *docLRU) touch(id string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	if elem := l.elems[id]; elem != nil {
+		l.order.MoveToFront(elem)
+	}
+	l.mu.Unlock()
+}
+
+// written records id's new size and marks it as most recently used.
+func (l *docLRU) written(id string, size int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elem := l.elems[id]; elem != nil {
+		l.order.MoveToFront(elem)
+	} else {
+		l.elems[id] = l.order.PushFront(id)
+	}
+	l.bytes += size - l.sizes[id]
+	l.sizes[id] = size
+}
+
+// forget stops tracking id.
+func (l *docLRU) forget(id string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elem := l.elems[id]; elem != nil {
+		l.order.Remove(elem)
+		delete(l.elems, id)
+	}
+	l.bytes -= l.sizes[id]
+	delete(l.sizes, id)
+}
+
+// oldest returns the least recently used ID that evictable accepts.
+func (l *docLRU) oldest(evictable func(id string) bool) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for elem := l.order.Back(); elem != nil; elem = elem.Prev() {
+		if id := elem.Value.(string); evictable(id) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// stored keeps the search index and LRU in step with a write to id's
+// record, then evicts least recently used docs while the store exceeds
+// StoreOptions.MaxDocs or MaxMemoryBytes. Callers hold s.mu.
+func (s *InMemoryStore) stored(id string, record *docRecord) {
+	s.searchIndex.update(id, record)
+	s.usage.registered(id, record.examples, s.now())
+	if s.lru == nil {
+		return
+	}
+	s.lru.written(id, recordSize(record))
+	for s.overLimits() {
+		// Frozen docs are protected from eviction, like any other write;
+		// the doc just written is kept even if it alone is over budget.
+		victim, ok := s.lru.oldest(func(other string) bool {
+			record := s.docs[other]
+			return other != id && record != nil && !record.frozen
+		})
+		if !ok {
+			return
+		}
+		s.dropped(victim)
+		if s.changeLog != nil || s.persister != nil {
+			s.evicted = append(s.evicted, victim)
+		}
+		s.lru.mu.Lock()
+		s.lru.evictions++
+		s.lru.mu.Unlock()
+	}
+}
+
+// dropped removes id's record from the store, its search index, and the
+// LRU. Callers hold s.mu.
+func (s *InMemoryStore) dropped(id string) {
+	delete(s.docs, id)
+	s.searchIndex.remove(id)
+	s.lru.forget(id)
+	s.usage.forget(id)
+}
+
+// overLimits reports whether the store exceeds MaxDocs or MaxMemoryBytes.
+// Callers hold s.mu.
+func (s *InMemoryStore) overLimits() bool {
+	if s.maxDocs > 0 && len(s.docs) > s.maxDocs {
+		return true
+	}
+	if s.maxMemory <= 0 {
+		return false
+	}
+	s.lru.mu.Lock()
+	defer s.lru.mu.Unlock()
+	return s.lru.bytes > s.maxMemory
+}
+
+// Per-value overheads used by recordSize.
+var (
+	recordOverhead  = int(unsafe.Sizeof(docRecord{}))
+	exampleOverhead = int(unsafe.Sizeof(ToolExample{}))
+	sectionOverhead = int(unsafe.Sizeof(DocSection{}))
+	stringOverhead  = int(unsafe.Sizeof(""))
+	anyOverhead     = int(unsafe.Sizeof(any(nil)))
+)
+
+// recordSize estimates the memory a doc record retains: its fields, text,
+// sections, examples, and example args. Interned text shared with other
+// records is counted in full, so the estimate errs high.
+func recordSize(r *docRecord) int {
+	size := recordOverhead + len(r.title) + len(r.summary) + len(r.shortNotes) + len(r.notes) + len(r.source)
+	for _, sec := range r.sections {
+		size += sectionOverhead + len(sec.Title) + len(sec.Body) + len(sec.Tier)
+	}
+	for _, ex := range r.examples {
+		size += exampleOverhead + len(ex.ID) + len(ex.Title) + len(ex.Description) + len(ex.ResultHint) + len(ex.Source)
+		for _, req := range ex.Requires {
+			size += stringOverhead + len(req)
+		}
+		size += valueSize(ex.Args)
+	}
+	for _, p := range r.prerequisites {
+		size += int(unsafe.Sizeof(p)) + len(p.Kind) + len(p.Description) + len(p.Tool)
+	}
+	for _, e := range r.effects {
+		size += int(unsafe.Sizeof(e)) + len(e.Kind) + len(e.Resource) + len(e.Description)
+	}
+	for _, d := range r.dependencies {
+		size += int(unsafe.Sizeof(d)) + len(d.Kind) + len(d.Tool) + len(d.Description)
+	}
+	for _, ref := range r.externalRefs {
+		size += stringOverhead + len(ref)
+	}
+	if r.annotations != nil {
+		size += int(unsafe.Sizeof(*r.annotations)) + len(r.annotations.Title)
+	}
+	if r.retryPolicy != nil {
+		size += int(unsafe.Sizeof(*r.retryPolicy)) + len(r.retryPolicy.Backoff)
+	}
+	if r.concurrency != nil {
+		size += int(unsafe.Sizeof(*r.concurrency))
+	}
+	if r.clientReqs != nil {
+		size += int(unsafe.Sizeof(*r.clientReqs))
+	}
+	if r.render != nil {
+		size += int(unsafe.Sizeof(*r.render))
+	}
+	return size
+}
+
+// valueSize estimates the memory held by a JSON-like value.
+func valueSize(v any) int {
+	switch v := v.(type) {
+	case string:
+		return anyOverhead + len(v)
+	case map[string]any:
+		size := anyOverhead
+		for k, item := range v {
+			size += stringOverhead + len(k) + valueSize(item)
+		}
+		return size
+	case []any:
+		size := anyOverhead
+		for _, item := range v {
+			size += valueSize(item)
+		}
+		return size
+	default:
+		return anyOverhead
+	}
+}
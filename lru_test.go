@@ -0,0 +1,117 @@
+package tooldocs
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMaxDocs_EvictsLeastRecentlyUsed(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{MaxDocs: 3})
+	for _, id := range []string{"ns:a", "ns:b", "ns:c"} {
+		mustRegisterDoc(t, store, id, DocEntry{Summary: "Doc " + id})
+	}
+	// Reading ns:a makes ns:b the least recently used.
+	if _, err := store.DescribeTool("ns:a", DetailSummary); err != nil {
+		t.Fatal(err)
+	}
+	mustRegisterDoc(t, store, "ns:d", DocEntry{Summary: "Doc ns:d"})
+	if want := []string{"ns:a", "ns:c", "ns:d"}; !reflect.DeepEqual(store.DocIDs(), want) {
+		t.Errorf("DocIDs = %v, want %v", store.DocIDs(), want)
+	}
+	if got := searchIDs(store, "doc"); len(got) != 3 {
+		t.Errorf("search after eviction = %v, want the 3 remaining docs", got)
+	}
+
+	// Frozen docs are never evicted.
+	if err := store.Freeze("ns:c"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Summary("ns:a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.ListExamples("ns:d", 1); err != nil {
+		t.Fatal(err)
+	}
+	mustRegisterDoc(t, store, "ns:e", DocEntry{Summary: "Doc ns:e"})
+	if want := []string{"ns:c", "ns:d", "ns:e"}; !reflect.DeepEqual(store.DocIDs(), want) {
+		t.Errorf("DocIDs = %v, want %v", store.DocIDs(), want)
+	}
+
+	st := store.Stats()
+	if st.Docs != 3 || st.Evictions != 2 {
+		t.Errorf("Stats = %+v, want 3 docs after 2 evictions", st)
+	}
+
+	// Removed docs no longer count against the cap.
+	if _, err := store.UnregisterWhere(UnregisterFilter{Match: func(id string) bool { return id == "ns:d" }}); err != nil {
+		t.Fatal(err)
+	}
+	mustRegisterDoc(t, store, "ns:f", DocEntry{Summary: "Doc ns:f"})
+	if st := store.Stats(); st.Docs != 3 || st.Evictions != 2 {
+		t.Errorf("Stats after unregister = %+v, want no further eviction", st)
+	}
+}
+
+func TestMaxDocs_RecordsEvictions(t *testing.T) {
+	var log bytes.Buffer
+	p := &recordingPersister{}
+	store := NewInMemoryStore(StoreOptions{MaxDocs: 2, ChangeLog: &log, Persister: p})
+	for _, id := range []string{"ns:a", "ns:b", "ns:c"} {
+		mustRegisterDoc(t, store, id, DocEntry{Summary: "Doc " + id})
+	}
+
+	want := []string{"save ns:a", "save ns:b", "save ns:c", "delete ns:a"}
+	if !reflect.DeepEqual(p.calls, want) {
+		t.Errorf("persister calls = %v, want %v", p.calls, want)
+	}
+	if !strings.Contains(log.String(), `"op":"evict","id":"ns:a"`) {
+		t.Errorf("change log has no eviction of ns:a:\n%s", log.String())
+	}
+
+	replayed := NewInMemoryStore(StoreOptions{})
+	if _, err := replayed.ReplayLog(bytes.NewReader(log.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := replayed.DocIDs(), store.DocIDs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("replayed DocIDs = %v, want %v", got, want)
+	}
+}
+
+func TestMaxMemoryBytes(t *testing.T) {
+	entry := func(i int) DocEntry {
+		return DocEntry{
+			Summary:  fmt.Sprintf("Doc %d", i),
+			Notes:    strings.Repeat("n", 500),
+			Examples: []ToolExample{{Title: "Basic", Args: map[string]any{"q": strings.Repeat("x", 100), "n": 1}}},
+		}
+	}
+	probe := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, probe, "ns:0", entry(0))
+	perDoc := probe.Stats().MemoryBytes
+	if perDoc < 600 {
+		t.Fatalf("MemoryBytes = %d, want at least the text and args held", perDoc)
+	}
+
+	store := NewInMemoryStore(StoreOptions{MaxMemoryBytes: 4*perDoc + perDoc/2})
+	for i := range 10 {
+		mustRegisterDoc(t, store, fmt.Sprintf("ns:%d", i), entry(i))
+	}
+	st := store.Stats()
+	if st.Docs != 4 || st.Evictions != 6 || st.MemoryBytes > 4*perDoc+perDoc/2 {
+		t.Errorf("Stats = %+v, want 4 docs within budget", st)
+	}
+	if want := []string{"ns:6", "ns:7", "ns:8", "ns:9"}; !reflect.DeepEqual(store.DocIDs(), want) {
+		t.Errorf("DocIDs = %v, want the most recent %v", store.DocIDs(), want)
+	}
+
+	// A doc over budget on its own is kept, evicting the rest.
+	tight := NewInMemoryStore(StoreOptions{MaxMemoryBytes: 100})
+	mustRegisterDoc(t, tight, "ns:a", entry(0))
+	mustRegisterDoc(t, tight, "ns:b", entry(1))
+	if want := []string{"ns:b"}; !reflect.DeepEqual(tight.DocIDs(), want) {
+		t.Errorf("DocIDs = %v, want %v", tight.DocIDs(), want)
+	}
+}
@@ -0,0 +1,413 @@
+package tooldocs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaintenanceCheck names a check performed by RunMaintenance.
+type MaintenanceCheck string
+
+// Maintenance checks.
+const (
+	// CheckLinks verifies that http(s) ExternalRefs are reachable.
+	CheckLinks MaintenanceCheck = "links"
+
+	// CheckDrift detects input schema changes since the tool was first
+	// checked (or since AcknowledgeDrift).
+	CheckDrift MaintenanceCheck = "drift"
+
+	// CheckExamples verifies example args against the current input schema.
+	CheckExamples MaintenanceCheck = "examples"
+)
+
+// DefaultMaintenanceInterval is used when MaintenanceOptions.Interval is zero.
+const DefaultMaintenanceInterval = time.Hour
+
+// MaintenanceOptions configures RunMaintenance and StartMaintenance.
+type MaintenanceOptions struct {
+	// Interval is the time between runs for StartMaintenance.
+	// Zero means DefaultMaintenanceInterval.
+	Interval time.Duration
+
+	// Checks selects the checks to run. Empty means all checks.
+	Checks []MaintenanceCheck
+
+	// LinkChecker reports whether a URL is reachable. Nil means an HTTP HEAD
+	// request (falling back to GET when HEAD is not allowed) with a 10s
+	// timeout, treating status codes >= 400 as broken. A panic is recovered
+	// and reported as a broken link.
+	LinkChecker func(ctx context.Context, url string) error
+}
+
+// MaintenanceResult records the outcome of the latest maintenance run for
+// one tool.
+type MaintenanceResult struct {
+	// ID is the tool ID.
+	ID string `json:"id"`
+
+	// CheckedAt is when the tool was last checked.
+	CheckedAt time.Time `json:"checkedAt"`
+
+	// BrokenLinks lists unreachable ExternalRefs.
+	BrokenLinks []LinkProblem `json:"brokenLinks,omitempty"`
+
+	// SchemaHash is the hash of the tool's current input schema; empty when
+	// the tool could not be resolved.
+	SchemaHash string `json:"schemaHash,omitempty"`
+
+	// Drifted reports whether the input schema changed since the baseline
+	// recorded in BaselineSchemaHash.
+	Drifted bool `json:"drifted,omitempty"`
+
+	// BaselineSchemaHash is the schema hash drift is measured against.
+	BaselineSchemaHash string `json:"baselineSchemaHash,omitempty"`
+
+	// InvalidExamples lists examples whose args no longer fit the schema.
+	InvalidExamples []ExampleProblem `json:"invalidExamples,omitempty"`
+
+	// Error reports why the tool could not be fully checked (e.g. the tool
+	// definition could not be resolved).
+	Error string `json:"error,omitempty"`
+}
+
+// Healthy reports whether the result has no problems.
+func (r MaintenanceResult) Healthy() bool {
+	return len(r.BrokenLinks) == 0 && !r.Drifted && len(r.InvalidExamples) == 0 && r.Error == ""
+}
+
+// LinkProblem describes an unreachable external reference.
+type LinkProblem struct {
+	URL   string `json:"url"`
+	Error string `json:"error"`
+}
+
+// ExampleProblem describes an example whose args do not fit the schema.
+type ExampleProblem struct {
+	// Example is the example ID, or its title when the ID is empty.
+	Example string `json:"example"`
+	Error   string `json:"error"`
+}
+
+// MaintenanceHealth summarizes the latest maintenance run for Health.
+type MaintenanceHealth struct {
+	LastRun         time.Time `json:"lastRun"`
+	Runs            int       `json:"runs"`
+	ToolsChecked    int       `json:"toolsChecked"`
+	BrokenLinks     int       `json:"brokenLinks"`
+	DriftedTools    int       `json:"driftedTools"`
+	InvalidExamples int       `json:"invalidExamples"`
+	Unresolved      int       `json:"unresolved"`
+}
+
+// maintenanceState holds maintenance results and drift baselines.
+type maintenanceState struct {
+	mu        sync.Mutex
+	results   map[string]MaintenanceResult
+	baselines map[string]string
+	lastRun   time.Time
+	runs      int
+}
+
+// StartMaintenance runs RunMaintenance immediately and then every
+// opts.Interval in a background goroutine until ctx is canceled.
+// Results are available from MaintenanceResults and Health.
+//
+// Returns an error if opts.Interval is negative or opts.Checks names an
+// unknown check.
+func (s *InMemoryStore) StartMaintenance(ctx context.Context, opts MaintenanceOptions) error {
+	if opts.Interval < 0 {
+		return fmt.Errorf("maintenance interval must not be negative: %s", opts.Interval)
+	}
+	if err := validateChecks(opts.Checks); err != nil {
+		return err
+	}
+	interval := opts.Interval
+	if interval == 0 {
+		interval = DefaultMaintenanceInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			_, _ = s.RunMaintenance(ctx, opts)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// RunMaintenance performs one maintenance pass over every documented tool
+// and records the results, replacing those of the previous run. It returns
+// the results sorted by tool ID.
+//
+// The first time a tool's schema is seen its hash becomes the drift
+// baseline; later runs flag the tool as drifted until AcknowledgeDrift is
+// called. Tools whose definition cannot be resolved are reported with Error
+// set and skip drift and example checks.
+//
+// Returns ctx.Err() if ctx is canceled mid-run; results for the tools
+// checked so far are still recorded.
+func (s *InMemoryStore) RunMaintenance(ctx context.Context, opts MaintenanceOptions) ([]MaintenanceResult, error) {
+	if err := validateChecks(opts.Checks); err != nil {
+		return nil, err
+	}
+	enabled := func(c MaintenanceCheck) bool {
+		if len(opts.Checks) == 0 {
+			return true
+		}
+		for _, want := range opts.Checks {
+			if want == c {
+				return true
+			}
+		}
+		return false
+	}
+	checkLink := opts.LinkChecker
+	if checkLink == nil {
+		checkLink = defaultLinkChecker
+	}
+
+	var results []MaintenanceResult
+	var runErr error
+	for _, id := range s.DocIDs() {
+		if err := ctx.Err(); err != nil {
+			runErr = err
+			break
+		}
+		stored, err := s.GetDocEntry(id)
+		if err != nil {
+			continue // removed concurrently
+		}
+		result := MaintenanceResult{ID: id, CheckedAt: time.Now()}
+
+		if enabled(CheckLinks) {
+			for _, ref := range stored.Entry.ExternalRefs {
+				if !strings.HasPrefix(ref, "http://") && !strings.HasPrefix(ref, "https://") {
+					continue
+				}
+				if _, err := callHook(HookLinkChecker, func() (struct{}, error) {
+					return struct{}{}, checkLink(ctx, ref)
+				}); err != nil {
+					result.BrokenLinks = append(result.BrokenLinks, LinkProblem{URL: ref, Error: err.Error()})
+				}
+			}
+		}
+
+		if enabled(CheckDrift) || enabled(CheckExamples) {
+			tool, err := s.resolveTool(id)
+			switch {
+			case err != nil:
+				result.Error = err.Error()
+			case tool == nil:
+				result.Error = ErrNoTool.Error()
+			default:
+				schema := schemaAsMap(tool.InputSchema)
+				if enabled(CheckDrift) {
+					result.SchemaHash = schemaHash(tool.InputSchema)
+					result.BaselineSchemaHash = s.maintenance.baseline(id, result.SchemaHash)
+					result.Drifted = result.SchemaHash != result.BaselineSchemaHash
+				}
+				if enabled(CheckExamples) {
+					for _, ex := range stored.Entry.Examples {
+						if err := checkArgsShape(schema, ex.Args); err != nil {
+							result.InvalidExamples = append(result.InvalidExamples, ExampleProblem{Example: exampleName(ex), Error: err.Error()})
+						}
+					}
+				}
+			}
+		}
+		results = append(results, result)
+	}
+
+	s.maintenance.record(results)
+	return results, runErr
+}
+
+// MaintenanceResults returns the results of the latest maintenance run,
+// sorted by tool ID.
+func (s *InMemoryStore) MaintenanceResults() []MaintenanceResult {
+	s.maintenance.mu.Lock()
+	defer s.maintenance.mu.Unlock()
+
+	results := make([]MaintenanceResult, 0, len(s.maintenance.results))
+	for _, r := range s.maintenance.results {
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].ID < results[j].ID })
+	return results
+}
+
+// AcknowledgeDrift resets a tool's drift baseline so the next maintenance
+// run measures drift against the schema seen then.
+func (s *InMemoryStore) AcknowledgeDrift(id string) {
+	s.maintenance.mu.Lock()
+	defer s.maintenance.mu.Unlock()
+	delete(s.maintenance.baselines, id)
+}
+
+// baseline returns the drift baseline for id, recording hash as the
+// baseline if none exists.
+func (m *maintenanceState) baseline(id, hash string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.baselines == nil {
+		m.baselines = make(map[string]string)
+	}
+	if b, ok := m.baselines[id]; ok {
+		return b
+	}
+	m.baselines[id] = hash
+	return hash
+}
+
+// record replaces the stored results with those of a completed run.
+func (m *maintenanceState) record(results []MaintenanceResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results = make(map[string]MaintenanceResult, len(results))
+	for _, r := range results {
+		m.results[r.ID] = r
+	}
+	m.lastRun = time.Now()
+	m.runs++
+}
+
+// drifted returns the IDs of the tools the latest run found drifted.
+func (m *maintenanceState) drifted() map[string]bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var ids map[string]bool
+	for id, r := range m.results {
+		if r.Drifted {
+			if ids == nil {
+				ids = make(map[string]bool)
+			}
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// summary returns the Health view of the latest run, or nil if maintenance
+// has never run.
+func (m *maintenanceState) summary() *MaintenanceHealth {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.runs == 0 {
+		return nil
+	}
+	h := &MaintenanceHealth{LastRun: m.lastRun, Runs: m.runs, ToolsChecked: len(m.results)}
+	for _, r := range m.results {
+		h.BrokenLinks += len(r.BrokenLinks)
+		h.InvalidExamples += len(r.InvalidExamples)
+		if r.Drifted {
+			h.DriftedTools++
+		}
+		if r.Error != "" {
+			h.Unresolved++
+		}
+	}
+	return h
+}
+
+func validateChecks(checks []MaintenanceCheck) error {
+	for _, c := range checks {
+		switch c {
+		case CheckLinks, CheckDrift, CheckExamples:
+		default:
+			return fmt.Errorf("unknown maintenance check: %s", c)
+		}
+	}
+	return nil
+}
+
+// schemaHash returns a stable hash of a schema's JSON encoding.
+// encoding/json sorts map keys, so equal schemas hash equally.
+func schemaHash(schema any) string {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// checkArgsShape reports why args do not fit schema, using the same rules as
+// FindToolsByArgsShape. A nil schema accepts any args.
+func checkArgsShape(schema map[string]any, args map[string]any) error {
+	if schema == nil {
+		return nil
+	}
+	for _, name := range toStringSlice(schema["required"]) {
+		if _, ok := args[name]; !ok {
+			return fmt.Errorf("missing required parameter %q", name)
+		}
+	}
+	props, _ := schema["properties"].(map[string]any)
+	closed := schema["additionalProperties"] == false
+
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop, declared := props[name].(map[string]any)
+		if !declared {
+			if closed {
+				return fmt.Errorf("unknown parameter %q", name)
+			}
+			continue
+		}
+		if types := schemaTypes(prop["type"]); !valueMatchesTypes(args[name], types) {
+			return fmt.Errorf("parameter %q: got %s, want %s", name, jsonType(args[name]), strings.Join(types, " or "))
+		}
+	}
+	return nil
+}
+
+var linkClient = &http.Client{Timeout: 10 * time.Second}
+
+// defaultLinkChecker issues a HEAD request (GET if HEAD is rejected) and
+// treats status codes >= 400 as broken.
+func defaultLinkChecker(ctx context.Context, url string) error {
+	status, err := probeLink(ctx, http.MethodHead, url)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = probeLink(ctx, http.MethodGet, url)
+	}
+	if err != nil {
+		return err
+	}
+	if status >= 400 {
+		return errors.New(http.StatusText(status))
+	}
+	return nil
+}
+
+func probeLink(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := linkClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,165 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestRunMaintenance(t *testing.T) {
+	var mu sync.Mutex
+	tool := makeToolWithSchema("get_repo", "github", "Get repo", map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"repo": map[string]any{"type": "string"}},
+		"required":   []any{"repo"},
+	})
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if id == "github:get_repo" {
+				copied := tool
+				return &copied, nil
+			}
+			return nil, nil
+		},
+	})
+	mustRegisterDoc(t, store, "github:get_repo", DocEntry{
+		Summary:      "Get a repository",
+		Examples:     []ToolExample{{ID: "basic", Title: "Basic", Args: map[string]any{"repo": "a/b"}}},
+		ExternalRefs: []string{"https://ok.example", "https://broken.example", "RFC 7231"},
+	})
+	mustRegisterDoc(t, store, "local:orphan", DocEntry{Summary: "No tool"})
+
+	var checked []string
+	opts := MaintenanceOptions{
+		LinkChecker: func(_ context.Context, url string) error {
+			checked = append(checked, url)
+			if url == "https://broken.example" {
+				return errors.New("404")
+			}
+			return nil
+		},
+	}
+
+	results, err := store.RunMaintenance(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("RunMaintenance: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("results = %d, want 2", len(results))
+	}
+	repo, orphan := results[0], results[1]
+	if len(checked) != 2 {
+		t.Errorf("checked links = %v, want only http(s) refs", checked)
+	}
+	if len(repo.BrokenLinks) != 1 || repo.BrokenLinks[0].URL != "https://broken.example" {
+		t.Errorf("BrokenLinks = %+v", repo.BrokenLinks)
+	}
+	if repo.Drifted || repo.SchemaHash == "" || repo.SchemaHash != repo.BaselineSchemaHash {
+		t.Errorf("first run should set baseline without drift: %+v", repo)
+	}
+	if len(repo.InvalidExamples) != 0 {
+		t.Errorf("InvalidExamples = %+v", repo.InvalidExamples)
+	}
+	if orphan.Error == "" || orphan.Healthy() {
+		t.Errorf("unresolvable tool should report an error: %+v", orphan)
+	}
+
+	// Rename the required parameter: schema drifts and the example breaks.
+	mu.Lock()
+	tool.InputSchema = map[string]any{
+		"type":                 "object",
+		"properties":           map[string]any{"name": map[string]any{"type": "string"}},
+		"required":             []any{"name"},
+		"additionalProperties": false,
+	}
+	mu.Unlock()
+
+	opts.Checks = []MaintenanceCheck{CheckDrift, CheckExamples}
+	results, err = store.RunMaintenance(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("RunMaintenance: %v", err)
+	}
+	repo = results[0]
+	if !repo.Drifted {
+		t.Error("expected drift after schema change")
+	}
+	if len(repo.InvalidExamples) != 1 || repo.InvalidExamples[0].Example != "basic" {
+		t.Errorf("InvalidExamples = %+v", repo.InvalidExamples)
+	}
+	if repo.BrokenLinks != nil {
+		t.Error("link check should be skipped when not selected")
+	}
+
+	h := store.Health()
+	if h.Maintenance == nil || h.Maintenance.Runs != 2 || h.Maintenance.DriftedTools != 1 ||
+		h.Maintenance.InvalidExamples != 1 || h.Maintenance.Unresolved != 1 {
+		t.Errorf("Health().Maintenance = %+v", h.Maintenance)
+	}
+	if !h.OK {
+		t.Error("maintenance findings should not affect OK")
+	}
+
+	store.AcknowledgeDrift("github:get_repo")
+	results, _ = store.RunMaintenance(context.Background(), opts)
+	if results[0].Drifted {
+		t.Error("drift should clear after AcknowledgeDrift")
+	}
+	if got := store.MaintenanceResults(); len(got) != 2 || got[0].ID != "github:get_repo" {
+		t.Errorf("MaintenanceResults = %+v", got)
+	}
+}
+
+func TestRunMaintenance_InvalidCheck(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	if _, err := store.RunMaintenance(context.Background(), MaintenanceOptions{Checks: []MaintenanceCheck{"bogus"}}); err == nil {
+		t.Error("expected error for unknown check")
+	}
+	if err := store.StartMaintenance(context.Background(), MaintenanceOptions{Interval: -time.Second}); err == nil {
+		t.Error("expected error for negative interval")
+	}
+}
+
+func TestStartMaintenance(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ext:tool", DocEntry{
+		Summary:      "External",
+		ExternalRefs: []string{srv.URL + "/ok", srv.URL + "/missing"},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := store.StartMaintenance(ctx, MaintenanceOptions{Interval: 10 * time.Millisecond, Checks: []MaintenanceCheck{CheckLinks}}); err != nil {
+		t.Fatalf("StartMaintenance: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if h := store.Health().Maintenance; h != nil && h.Runs >= 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("maintenance did not run repeatedly")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	results := store.MaintenanceResults()
+	if len(results) != 1 || len(results[0].BrokenLinks) != 1 || results[0].BrokenLinks[0].URL != srv.URL+"/missing" {
+		t.Errorf("results = %+v", results)
+	}
+}
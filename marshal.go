@@ -0,0 +1,154 @@
+package tooldocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// FieldNaming selects how MarshalToolDoc names JSON fields.
+type FieldNaming string
+
+const (
+	// NamingCamelCase uses the struct tags as declared (e.g. "schemaInfo").
+	// This is the default when MarshalOptions.Naming is empty.
+	NamingCamelCase FieldNaming = "camelCase"
+
+	// NamingSnakeCase converts field names to snake_case (e.g. "schema_info").
+	NamingSnakeCase FieldNaming = "snake_case"
+)
+
+// MarshalOptions configures MarshalToolDoc.
+type MarshalOptions struct {
+	// Naming selects the field naming convention. Empty means NamingCamelCase.
+	Naming FieldNaming
+
+	// OmitEmpty also drops fields that the default encoding always emits,
+	// when they hold zero values: an empty summary, an example's empty title
+	// or description, and false capability flags.
+	OmitEmpty bool
+}
+
+// docObjectFields are the ToolDoc fields whose values are themselves
+// tooldocs-defined objects (or arrays of them) and are renamed recursively.
+// Every other value is copied as-is: parameter names in SchemaInfo maps,
+// example Args, and the MCP tool definition keep their original keys.
+var docObjectFields = map[string]bool{
+	"schemaInfo":         true,
+	"capabilities":       true,
+	"effects":            true,
+	"retryPolicy":        true,
+	"concurrency":        true,
+	"clientRequirements": true,
+	"sections":           true,
+	"examples":           true,
+	"prerequisites":      true,
+	"edges":              true,
+	"experiment":         true,
+}
+
+// MarshalToolDoc encodes doc as JSON with the given naming and omission
+// rules. Only fields defined by this package are renamed; the embedded MCP
+// tool definition ("tool"), SchemaInfo map keys, and example Args are
+// emitted unchanged since their keys are wire-format or parameter names.
+//
+// With default options the output is identical to json.Marshal(doc).
+// Otherwise object keys are sorted.
+func MarshalToolDoc(doc ToolDoc, opts MarshalOptions) ([]byte, error) {
+	switch opts.Naming {
+	case "", NamingCamelCase, NamingSnakeCase:
+		// valid
+	default:
+		return nil, fmt.Errorf("unknown field naming: %s", opts.Naming)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil || (opts.Naming != NamingSnakeCase && !opts.OmitEmpty) {
+		return data, err
+	}
+
+	var tree map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return json.Marshal(rewriteFields(tree, opts))
+}
+
+// rewriteFields renames and prunes the keys of a tooldocs-defined object,
+// recursing into docObjectFields.
+func rewriteFields(obj map[string]any, opts MarshalOptions) map[string]any {
+	out := make(map[string]any, len(obj))
+	for key, value := range obj {
+		if docObjectFields[key] {
+			value = rewriteValue(value, opts)
+		}
+		if opts.OmitEmpty && isEmptyJSON(value) {
+			continue
+		}
+		if opts.Naming == NamingSnakeCase {
+			key = snakeCase(key)
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// rewriteValue applies rewriteFields to an object or to each object in an
+// array.
+func rewriteValue(v any, opts MarshalOptions) any {
+	switch val := v.(type) {
+	case map[string]any:
+		return rewriteFields(val, opts)
+	case []any:
+		for i, item := range val {
+			val[i] = rewriteValue(item, opts)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// isEmptyJSON reports whether a decoded JSON value is null, false, "", or an
+// empty array or object.
+func isEmptyJSON(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !val
+	case string:
+		return val == ""
+	case []any:
+		return len(val) == 0
+	case map[string]any:
+		return len(val) == 0
+	default:
+		return false
+	}
+}
+
+// snakeCase converts a camelCase identifier to snake_case. Runs of capitals
+// are treated as one word ("resultURL" becomes "result_url").
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					b.WriteByte('_')
+				}
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,126 @@
+package tooldocs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func marshalTestDoc() ToolDoc {
+	return ToolDoc{
+		Tool: &toolmodel.Tool{Tool: mcp.Tool{
+			Name:        "list_issues",
+			InputSchema: map[string]any{"type": "object", "properties": map[string]any{"pageToken": map[string]any{"type": "string"}}},
+		}},
+		Summary: "List issues",
+		SchemaInfo: &SchemaInfo{
+			Types:    map[string][]string{"pageToken": {"string"}},
+			Defaults: map[string]any{"perPage": 30},
+		},
+		Capabilities: &Capabilities{SupportsPagination: true},
+		Examples: []ToolExample{{
+			Title:      "First page",
+			Args:       map[string]any{"perPage": 10},
+			ResultHint: "Array of issues",
+		}},
+		ExternalRefs: []string{"https://example.com"},
+	}
+}
+
+func decodeJSON(t *testing.T, data []byte) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMarshalToolDoc_DefaultMatchesJSON(t *testing.T) {
+	doc := marshalTestDoc()
+	want, _ := json.Marshal(doc)
+	got, err := MarshalToolDoc(doc, MarshalOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("default output = %s, want %s", got, want)
+	}
+}
+
+func TestMarshalToolDoc_SnakeCase(t *testing.T) {
+	data, err := MarshalToolDoc(marshalTestDoc(), MarshalOptions{Naming: NamingSnakeCase})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := decodeJSON(t, data)
+
+	for _, key := range []string{"schema_info", "capabilities", "external_refs", "tool", "summary"} {
+		if _, ok := m[key]; !ok {
+			t.Errorf("missing key %q in %s", key, data)
+		}
+	}
+	caps := m["capabilities"].(map[string]any)
+	if caps["supports_pagination"] != true || caps["has_output_schema"] != false {
+		t.Errorf("capabilities = %v", caps)
+	}
+	ex := m["examples"].([]any)[0].(map[string]any)
+	if ex["result_hint"] != "Array of issues" {
+		t.Errorf("example = %v", ex)
+	}
+	// Parameter names and the MCP tool definition keep their keys.
+	if _, ok := ex["args"].(map[string]any)["perPage"]; !ok {
+		t.Errorf("args keys renamed: %v", ex["args"])
+	}
+	info := m["schema_info"].(map[string]any)
+	if _, ok := info["types"].(map[string]any)["pageToken"]; !ok {
+		t.Errorf("schema info keys renamed: %v", info)
+	}
+	tool := m["tool"].(map[string]any)
+	if _, ok := tool["inputSchema"]; !ok {
+		t.Errorf("tool keys renamed: %v", tool)
+	}
+}
+
+func TestMarshalToolDoc_OmitEmpty(t *testing.T) {
+	doc := ToolDoc{
+		Capabilities: &Capabilities{RequiresAuth: true},
+		Examples:     []ToolExample{{Args: map[string]any{"flag": false}}},
+	}
+	data, err := MarshalToolDoc(doc, MarshalOptions{OmitEmpty: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"capabilities": map[string]any{"requiresAuth": true},
+		"examples":     []any{map[string]any{"args": map[string]any{"flag": false}}},
+	}
+	if got := decodeJSON(t, data); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %s", data)
+	}
+}
+
+func TestMarshalToolDoc_UnknownNaming(t *testing.T) {
+	if _, err := MarshalToolDoc(ToolDoc{}, MarshalOptions{Naming: "kebab"}); err == nil {
+		t.Error("expected error for unknown naming")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"summary":            "summary",
+		"schemaInfo":         "schema_info",
+		"supportsPagination": "supports_pagination",
+		"resultURL":          "result_url",
+		"HTTPStatus":         "http_status",
+		"page2Token":         "page2_token",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package tooldocs
+
+import "strings"
+
+// NotesMerge selects how overlay Notes combine with base Notes.
+type NotesMerge string
+
+const (
+	// NotesReplace uses the overlay Notes when non-empty, otherwise the base Notes.
+	// This is the default when MergePolicy.Notes is empty.
+	NotesReplace NotesMerge = "replace"
+
+	// NotesAppend appends the overlay Notes to the base Notes, separated by a
+	// blank line. Overlay Notes already contained in the base are not repeated.
+	NotesAppend NotesMerge = "append"
+)
+
+// MergePolicy configures MergeDocEntries.
+type MergePolicy struct {
+	// Notes selects how notes are combined. Empty means NotesReplace.
+	Notes NotesMerge
+}
+
+// MergeDocEntries layers overlay on top of base and returns the result.
+// It is the single merge implementation used by overlays and import
+// conflict handling.
+//
+// Rules:
+//   - Title, Summary, ShortNotes, Source: overlay wins when non-empty.
+//   - Annotations: overlay wins when non-nil.
+//   - Notes: combined according to policy.Notes, truncated to MaxNotesLen.
+//   - Sections: matched by Title; an overlay section replaces the base
+//     section with the same title in place, others are appended.
+//   - Examples: matched by ID; an overlay example replaces the base example
+//     with the same ID in place. Overlay examples without a matching ID are
+//     appended, unless an identical example is already present.
+//   - Prerequisites, Effects, Dependencies, ExternalRefs: order-preserving
+//     union (base first, then new overlay items).
+//   - RetryPolicy, Concurrency, ClientRequirements, Render: overlay wins
+//     when non-nil.
+//   - Sensitive, Idempotent: sticky; set if either input sets it.
+//
+// The result is a deep copy; neither input is modified.
+func MergeDocEntries(base, overlay DocEntry, policy MergePolicy) DocEntry {
+	result := DocEntry{
+		Title:       base.Title,
+		Annotations: copyAnnotations(base.Annotations),
+		Summary:     base.Summary,
+		Notes:       mergeNotes(base.Notes, overlay.Notes, policy.Notes),
+	}
+	if overlay.Title != "" {
+		result.Title = overlay.Title
+	}
+	if overlay.Annotations != nil {
+		result.Annotations = copyAnnotations(overlay.Annotations)
+	}
+	if overlay.Summary != "" {
+		result.Summary = overlay.Summary
+	}
+	result.ShortNotes = base.ShortNotes
+	if overlay.ShortNotes != "" {
+		result.ShortNotes = overlay.ShortNotes
+	}
+
+	examples := copyExamples(base.Examples)
+	byID := make(map[string]int, len(examples))
+	for i, ex := range examples {
+		if ex.ID != "" {
+			byID[ex.ID] = i
+		}
+	}
+	for _, ex := range copyExamples(overlay.Examples) {
+		if i, ok := byID[ex.ID]; ok && ex.ID != "" {
+			examples[i] = ex
+			continue
+		}
+		if containsExample(examples, ex) {
+			continue
+		}
+		if ex.ID != "" {
+			byID[ex.ID] = len(examples)
+		}
+		examples = append(examples, ex)
+	}
+	if len(examples) > 0 {
+		result.Examples = examples
+	}
+
+	result.Sections = mergeSections(base.Sections, overlay.Sections)
+	result.Prerequisites = union(base.Prerequisites, overlay.Prerequisites)
+	result.Effects = union(base.Effects, overlay.Effects)
+	result.Dependencies = union(base.Dependencies, overlay.Dependencies)
+	result.ExternalRefs = union(base.ExternalRefs, overlay.ExternalRefs)
+
+	result.Source = base.Source
+	if overlay.Source != "" {
+		result.Source = overlay.Source
+	}
+	result.RetryPolicy = copyRetryPolicy(base.RetryPolicy)
+	if overlay.RetryPolicy != nil {
+		result.RetryPolicy = copyRetryPolicy(overlay.RetryPolicy)
+	}
+	result.Concurrency = copyConcurrency(base.Concurrency)
+	if overlay.Concurrency != nil {
+		result.Concurrency = copyConcurrency(overlay.Concurrency)
+	}
+	result.ClientRequirements = copyClientRequirements(base.ClientRequirements)
+	if overlay.ClientRequirements != nil {
+		result.ClientRequirements = copyClientRequirements(overlay.ClientRequirements)
+	}
+	result.Render = copyRenderHints(base.Render)
+	if overlay.Render != nil {
+		result.Render = copyRenderHints(overlay.Render)
+	}
+	result.Idempotent = base.Idempotent || overlay.Idempotent
+	result.Sensitive = base.Sensitive || overlay.Sensitive
+
+	return result
+}
+
+// mergeNotes combines base and overlay notes according to mode.
+func mergeNotes(base, overlay string, mode NotesMerge) string {
+	if overlay == "" {
+		return base
+	}
+	if mode != NotesAppend {
+		return overlay
+	}
+	switch {
+	case strings.Contains(base, overlay):
+		return base
+	case base == "":
+		return overlay
+	default:
+		return truncateString(base+"\n\n"+overlay, MaxNotesLen)
+	}
+}
+
+// containsExample reports whether examples already holds an example equal to ex.
+func containsExample(examples []ToolExample, ex ToolExample) bool {
+	for _, existing := range examples {
+		if examplesEqual(existing, ex) {
+			return true
+		}
+	}
+	return false
+}
+
+// union returns the order-preserving union of a and b.
+func union[T comparable](a, b []T) []T {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	seen := make(map[T]struct{}, len(a)+len(b))
+	result := make([]T, 0, len(a)+len(b))
+	for _, list := range [][]T{a, b} {
+		for _, s := range list {
+			if _, ok := seen[s]; ok {
+				continue
+			}
+			seen[s] = struct{}{}
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package tooldocs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeDocEntries(t *testing.T) {
+	base := DocEntry{
+		Summary: "base summary",
+		Notes:   "Base notes.",
+		Examples: []ToolExample{
+			{ID: "list", Title: "List", Args: map[string]any{"limit": 10}},
+			{Title: "Unnamed"},
+		},
+		ExternalRefs: []string{"https://a", "https://b"},
+	}
+	overlay := DocEntry{
+		Notes: "Overlay notes.",
+		Examples: []ToolExample{
+			{ID: "list", Title: "List (curated)", Args: map[string]any{"limit": 5}},
+			{Title: "Unnamed"},
+			{ID: "get", Title: "Get"},
+		},
+		ExternalRefs: []string{"https://b", "https://c"},
+	}
+
+	got := MergeDocEntries(base, overlay, MergePolicy{})
+	if got.Summary != "base summary" {
+		t.Errorf("Summary = %q, want base summary", got.Summary)
+	}
+	if got.Notes != "Overlay notes." {
+		t.Errorf("Notes = %q, want overlay notes (replace)", got.Notes)
+	}
+
+	titles := make([]string, len(got.Examples))
+	for i, ex := range got.Examples {
+		titles[i] = ex.Title
+	}
+	if want := []string{"List (curated)", "Unnamed", "Get"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("example titles = %v, want %v", titles, want)
+	}
+	if want := []string{"https://a", "https://b", "https://c"}; !reflect.DeepEqual(got.ExternalRefs, want) {
+		t.Errorf("ExternalRefs = %v, want %v", got.ExternalRefs, want)
+	}
+
+	// Inputs are not modified and the result does not alias them
+	got.Examples[0].Args["limit"] = 99
+	if base.Examples[0].Args["limit"] != 10 || overlay.Examples[0].Args["limit"] != 5 {
+		t.Error("MergeDocEntries result aliases its inputs")
+	}
+}
+
+func TestMergeDocEntries_NotesAppend(t *testing.T) {
+	tests := []struct {
+		name          string
+		base, overlay string
+		want          string
+	}{
+		{"both", "Base.", "Overlay.", "Base.\n\nOverlay."},
+		{"empty base", "", "Overlay.", "Overlay."},
+		{"empty overlay", "Base.", "", "Base."},
+		{"already present", "Base.\n\nOverlay.", "Overlay.", "Base.\n\nOverlay."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeDocEntries(DocEntry{Notes: tt.base}, DocEntry{Notes: tt.overlay}, MergePolicy{Notes: NotesAppend})
+			if got.Notes != tt.want {
+				t.Errorf("Notes = %q, want %q", got.Notes, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeDocEntries_SummaryOverride(t *testing.T) {
+	got := MergeDocEntries(DocEntry{Summary: "base"}, DocEntry{Summary: "overlay"}, MergePolicy{})
+	if got.Summary != "overlay" {
+		t.Errorf("Summary = %q, want overlay", got.Summary)
+	}
+}
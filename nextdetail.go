@@ -0,0 +1,145 @@
+package tooldocs
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// DetailHint tells an agent which detail level to request next for a tool
+// and roughly what it would cost, so it can decide whether escalating is
+// worth the context.
+type DetailHint struct {
+	// Level is the level to request: DetailFull when notes, examples, or
+	// other full-tier content was omitted, else DetailSchema.
+	Level DetailLevel `json:"level"`
+
+	// Tokens estimates the size of the doc at Level, on the scale of
+	// EstimateTokens.
+	Tokens int `json:"tokens"`
+}
+
+// nextDetail returns the hint for a doc at level whose summary is summary,
+// or the zero hint when no higher level would return more or the higher
+// levels cannot be served: they need a tool, and a caller not authorized
+// for a sensitive doc is never pointed at its full tier.
+func (s *InMemoryStore) nextDetail(ctx context.Context, id string, entry DocEntry, tool *toolmodel.Tool, level DetailLevel, summary string, maxExamples int) DetailHint {
+	if level == DetailFull || tool == nil {
+		return DetailHint{}
+	}
+	available := 0
+	for _, ex := range entry.Examples {
+		if len(s.unmetRequirements(ex)) == 0 {
+			available++
+		}
+	}
+	target := DetailSchema
+	if hasFullTierContent(entry, available) && (!entry.Sensitive || s.authorizedForSensitive(ctx, id)) {
+		target = DetailFull
+	} else if level == DetailSchema {
+		return DetailHint{}
+	}
+
+	size := 30 + len(summary) + len(entry.Title) + toolJSONLen(tool)
+	size += len(entry.ShortNotes)
+	for _, e := range entry.Effects {
+		size += 40 + len(e.Kind) + len(e.Resource) + len(e.Description)
+	}
+	if entry.RetryPolicy != nil {
+		size += 60 + len(entry.RetryPolicy.Backoff)
+	}
+	if entry.Concurrency != nil {
+		size += 60
+	}
+	if entry.ClientRequirements != nil {
+		size += 60
+	}
+	for _, sec := range entry.Sections {
+		if tierRank(sec.Tier) <= tierRank(target) {
+			size += 40 + len(sec.Title) + len(sec.Body)
+		}
+	}
+	if target == DetailFull {
+		size += len(entry.Notes)
+		for _, p := range entry.Prerequisites {
+			size += 40 + len(p.Kind) + len(p.Description) + len(p.Tool)
+		}
+		for _, ref := range entry.ExternalRefs {
+			size += 4 + len(ref)
+		}
+		shown := 0
+		for _, ex := range entry.Examples {
+			if maxExamples > 0 && shown == maxExamples {
+				break
+			}
+			if len(s.unmetRequirements(ex)) == 0 {
+				shown++
+				size += 60 + len(ex.ID) + len(ex.Title) + len(ex.Description) + len(ex.ResultHint) + jsonLen(ex.Args)
+			}
+		}
+	}
+	return DetailHint{Level: target, Tokens: (size + 3) / 4}
+}
+
+// hasFullTierContent reports whether entry has content only the full level
+// returns, given its number of available examples.
+func hasFullTierContent(entry DocEntry, examples int) bool {
+	if entry.Notes != "" || len(entry.Prerequisites) > 0 || len(entry.ExternalRefs) > 0 || examples > 0 {
+		return true
+	}
+	for _, sec := range entry.Sections {
+		if tierRank(sec.Tier) == tierRank(DetailFull) {
+			return true
+		}
+	}
+	return false
+}
+
+// toolJSONLen approximates the JSON length of tool.
+func toolJSONLen(tool *toolmodel.Tool) int {
+	size := 80 + len(tool.Name) + len(tool.Namespace) + len(tool.Title) + len(tool.Description)
+	size += jsonLen(tool.InputSchema) + jsonLen(tool.OutputSchema)
+	if tool.Annotations != nil {
+		size += 80 + len(tool.Annotations.Title)
+	}
+	return size
+}
+
+// jsonLen approximates the JSON length of a JSON-like value without
+// encoding it, so hints stay cheap on the summary path.
+func jsonLen(v any) int {
+	switch v := v.(type) {
+	case nil:
+		return 4
+	case string:
+		return len(v) + 2
+	case bool:
+		return 5
+	case json.RawMessage:
+		return len(v)
+	case []byte:
+		return len(v)
+	case map[string]any:
+		size := 2
+		for k, item := range v {
+			size += len(k) + 4 + jsonLen(item)
+		}
+		return size
+	case []any:
+		size := 2
+		for _, item := range v {
+			size += jsonLen(item) + 1
+		}
+		return size
+	case []string:
+		size := 2
+		for _, item := range v {
+			size += len(item) + 3
+		}
+		return size
+	default:
+		// Numbers, and schema types this walk does not know.
+		return 8
+	}
+}
@@ -0,0 +1,81 @@
+package tooldocs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestDescribeTool_NextDetail(t *testing.T) {
+	tool := makeToolWithSchema("search", "gh", "Search issues", map[string]any{
+		"type":     "object",
+		"required": []any{"q"},
+		"properties": map[string]any{
+			"q":     map[string]any{"type": "string", "description": "GitHub search query"},
+			"limit": map[string]any{"type": "integer", "minimum": 1, "maximum": 100},
+		},
+	})
+	resolver := func(string) (*toolmodel.Tool, error) { return &tool, nil }
+	store := NewInMemoryStore(StoreOptions{ToolResolver: resolver})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{
+		Summary:    "Search issues and pull requests",
+		ShortNotes: "Queries use GitHub search syntax.",
+		Notes:      "Results are capped at 1000 per query; narrow with qualifiers such as repo: and is:open.",
+		Examples: []ToolExample{
+			{Title: "Open bugs", Description: "Open issues labeled bug.", Args: map[string]any{"q": "is:open label:bug", "limit": 20}},
+		},
+	})
+
+	for _, level := range []DetailLevel{DetailSummary, DetailSchema} {
+		doc, err := store.DescribeTool("gh:search", level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if doc.NextDetail.Level != DetailFull {
+			t.Fatalf("%s: NextDetail = %+v, want full", level, doc.NextDetail)
+		}
+		full, err := store.DescribeTool("gh:search", DetailFull)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The estimate need only be in the right ballpark.
+		if got, want := doc.NextDetail.Tokens, EstimateTokens(full); got < want/2 || got > want*2 {
+			t.Errorf("%s: NextDetail.Tokens = %d, full doc estimates %d", level, got, want)
+		}
+	}
+	if doc, _ := store.DescribeTool("gh:search", DetailFull); doc.NextDetail != (DetailHint{}) {
+		t.Errorf("full: NextDetail = %+v, want none", doc.NextDetail)
+	}
+
+	// With nothing beyond the schema tier, summaries point at schema and
+	// schema docs carry no hint.
+	mustRegisterDoc(t, store, "gh:list", DocEntry{Summary: "List issues"})
+	if doc, _ := store.DescribeTool("gh:list", DetailSummary); doc.NextDetail.Level != DetailSchema || doc.NextDetail.Tokens == 0 {
+		t.Errorf("summary of schema-only doc: NextDetail = %+v, want schema", doc.NextDetail)
+	}
+	if doc, _ := store.DescribeTool("gh:list", DetailSchema); doc.NextDetail != (DetailHint{}) {
+		t.Errorf("schema of schema-only doc: NextDetail = %+v, want none", doc.NextDetail)
+	}
+
+	// Without a tool the higher levels cannot be served.
+	orphan := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, orphan, "ext:a", DocEntry{Summary: "A", Notes: "Notes"})
+	if doc, _ := orphan.DescribeTool("ext:a", DetailSummary); doc.NextDetail != (DetailHint{}) {
+		t.Errorf("toolless summary: NextDetail = %+v, want none", doc.NextDetail)
+	}
+}
+
+func TestDescribeTool_NextDetailSensitive(t *testing.T) {
+	tool := makeToolWithSchema("rotate", "ops", "Rotate keys", map[string]any{"type": "object"})
+	store := NewInMemoryStore(StoreOptions{ToolResolver: func(string) (*toolmodel.Tool, error) { return &tool, nil }})
+	mustRegisterDoc(t, store, "ops:rotate", DocEntry{Summary: "Rotate keys", Notes: "Internal runbook.", Sensitive: true})
+
+	if doc, _ := store.DescribeTool("ops:rotate", DetailSummary); doc.NextDetail.Level != DetailSchema {
+		t.Errorf("unauthorized: NextDetail = %+v, want schema", doc.NextDetail)
+	}
+	ctx := WithGrants(context.Background(), GrantSensitiveDocs)
+	if doc, _ := store.DescribeToolContext(ctx, "ops:rotate", DetailSchema); doc.NextDetail.Level != DetailFull {
+		t.Errorf("authorized: NextDetail = %+v, want full", doc.NextDetail)
+	}
+}
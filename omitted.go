@@ -0,0 +1,113 @@
+package tooldocs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// omittedContent lists the content of full, a doc as DetailFull would
+// return it, that a doc at level leaves out, for ToolDoc.Omitted: field
+// labels and "section <title>" for each tier above level, in tier order.
+// Examples are counted separately (see omittedExamples).
+func omittedContent(full ToolDoc, level DetailLevel) []string {
+	var out []string
+	add := func(present bool, label string) {
+		if present {
+			out = append(out, label)
+		}
+	}
+	for _, tier := range []DetailLevel{DetailSchema, DetailFull} {
+		if tierRank(tier) <= tierRank(level) {
+			continue
+		}
+		if tier == DetailSchema {
+			add(full.Tool != nil, "schema")
+			add(full.ShortNotes != "", "shortNotes")
+			add(len(full.Effects) > 0, "effects")
+			add(full.RetryPolicy != nil, "retryPolicy")
+			add(full.Concurrency != nil, "concurrency")
+			add(full.ClientRequirements != nil, "clientRequirements")
+		} else {
+			add(full.Notes != "", "notes")
+			add(len(full.Prerequisites) > 0, "prerequisites")
+			add(len(full.ExternalRefs) > 0, "externalRefs")
+		}
+		for _, sec := range full.Sections {
+			if tierRank(sec.Tier) == tierRank(tier) {
+				out = append(out, "section "+sec.Title)
+			}
+		}
+	}
+	return out
+}
+
+// omittedExamples appends the label for the examples of total a doc showing
+// shown leaves out: "3 examples" when none are shown, "2 more examples"
+// when some are.
+func omittedExamples(labels []string, total, shown int) []string {
+	n := total - shown
+	switch {
+	case n <= 0:
+		return labels
+	case shown == 0 && n == 1:
+		return append(labels, "1 example")
+	case shown == 0:
+		return append(labels, fmt.Sprintf("%d examples", n))
+	case n == 1:
+		return append(labels, "1 more example")
+	default:
+		return append(labels, fmt.Sprintf("%d more examples", n))
+	}
+}
+
+// splitOmittedExamples separates the examples label from labels. It returns
+// the other labels and the total examples, given the doc shows shown: a
+// "more" label counts in addition to them, a plain one (examples attached
+// to a doc at a lower level, as by Render) includes them.
+func splitOmittedExamples(labels []string, shown int) ([]string, int) {
+	rest := make([]string, 0, len(labels))
+	total := shown
+	for _, label := range labels {
+		var n int
+		switch {
+		case strings.HasSuffix(label, "more example"), strings.HasSuffix(label, "more examples"):
+			if _, err := fmt.Sscanf(label, "%d", &n); err == nil {
+				total = shown + n
+				continue
+			}
+		case strings.HasSuffix(label, " example"), strings.HasSuffix(label, " examples"):
+			if _, err := fmt.Sscanf(label, "%d", &n); err == nil {
+				total = max(n, shown)
+				continue
+			}
+		}
+		rest = append(rest, label)
+	}
+	return rest, total
+}
+
+// omitted returns ToolDoc.Omitted for a doc at level built from entry and
+// tool that shows shown examples.
+func (s *InMemoryStore) omitted(entry DocEntry, tool *toolmodel.Tool, level DetailLevel, shown int) []string {
+	full := ToolDoc{
+		Tool:               tool,
+		ShortNotes:         entry.ShortNotes,
+		Effects:            entry.Effects,
+		RetryPolicy:        entry.RetryPolicy,
+		Concurrency:        entry.Concurrency,
+		ClientRequirements: entry.ClientRequirements,
+		Notes:              entry.Notes,
+		Sections:           entry.Sections,
+		Prerequisites:      entry.Prerequisites,
+		ExternalRefs:       entry.ExternalRefs,
+	}
+	available := 0
+	for _, ex := range entry.Examples {
+		if len(s.unmetRequirements(ex)) == 0 {
+			available++
+		}
+	}
+	return omittedExamples(omittedContent(full, level), available, shown)
+}
@@ -0,0 +1,75 @@
+package tooldocs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestDescribeTool_Omitted(t *testing.T) {
+	tool := makeToolWithSchema("deploy", "ops", "Deploy", map[string]any{"type": "object"})
+	store := NewInMemoryStore(StoreOptions{
+		MaxExamples:  2,
+		ToolResolver: func(string) (*toolmodel.Tool, error) { return &tool, nil },
+	})
+	mustRegisterDoc(t, store, "ops:deploy", DocEntry{
+		Summary:    "Deploy a service",
+		ShortNotes: "Needs a clean tree.",
+		Notes:      "Deploys take a few minutes.",
+		Sections: []DocSection{
+			{Title: "Auth", Body: "Token required.", Tier: DetailSchema},
+			{Title: "Rollback", Body: "Redeploy the previous tag."},
+		},
+		Examples: []ToolExample{{Title: "one"}, {Title: "two"}, {Title: "three"}, {Title: "four"}},
+	})
+
+	tests := []struct {
+		level DetailLevel
+		want  []string
+	}{
+		{DetailSummary, []string{"schema", "shortNotes", "section Auth", "notes", "section Rollback", "4 examples"}},
+		{DetailSchema, []string{"notes", "section Rollback", "4 examples"}},
+		{DetailFull, []string{"2 more examples"}},
+	}
+	for _, tt := range tests {
+		doc, err := store.DescribeTool("ops:deploy", tt.level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(doc.Omitted, tt.want) {
+			t.Errorf("%s: Omitted = %q, want %q", tt.level, doc.Omitted, tt.want)
+		}
+	}
+
+	// Nothing is left out of a bare tool's summary but its schema.
+	bare := NewInMemoryStore(StoreOptions{ToolResolver: func(string) (*toolmodel.Tool, error) { return &tool, nil }})
+	if doc, err := bare.DescribeTool("ops:deploy", DetailFull); err != nil || doc.Omitted != nil {
+		t.Errorf("full bare doc = %q, %v; want nothing omitted", doc.Omitted, err)
+	}
+	if doc, err := bare.DescribeTool("ops:deploy", DetailSummary); err != nil || !reflect.DeepEqual(doc.Omitted, []string{"schema"}) {
+		t.Errorf("summary bare doc = %q, %v; want [schema]", doc.Omitted, err)
+	}
+}
+
+func TestRender_Omitted(t *testing.T) {
+	_, view := newBudgetStore(t, RenderPolicy{Level: DetailSchema, Examples: 1})
+	out, err := view.Render(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"notes", "1 more example"}; !reflect.DeepEqual(out.Docs[0].Doc.Omitted, want) {
+		t.Errorf("schema plus one example: Omitted = %q, want %q", out.Docs[0].Doc.Omitted, want)
+	}
+
+	full, _ := newBudgetStore(t, RenderPolicy{Level: DetailFull})
+	described, err := full.DescribeToolsContext(context.Background(), []string{"ci:build"}, DetailFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shaped := shapeRendering(described, DetailSummary, 0)
+	if want := []string{"schema", "notes", "2 examples"}; !reflect.DeepEqual(shaped.Docs[0].Doc.Omitted, want) {
+		t.Errorf("degraded to summary: Omitted = %q, want %q", shaped.Docs[0].Doc.Omitted, want)
+	}
+}
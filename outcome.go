@@ -0,0 +1,200 @@
+package tooldocs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+)
+
+// maxPendingDescribes bounds the describes remembered for pairing with
+// call outcomes; the oldest are forgotten first.
+const maxPendingDescribes = 4096
+
+// CallOutcome pairs a tool call's result, reported with RecordCallOutcome,
+// with the describe that preceded it in the same trace. It is passed to
+// StoreOptions.OnCallOutcome.
+type CallOutcome struct {
+	// ToolID is the tool that was called.
+	ToolID string `json:"toolId"`
+
+	// TraceID is TraceID(ctx) of the call, or a stable hash of it when
+	// StoreOptions.AnonymizeOutcomes is set. Empty for untraced calls.
+	TraceID string `json:"traceId,omitempty"`
+
+	// Described reports whether the tool was described earlier in the same
+	// trace, and Level at which detail level (the last one).
+	Described bool        `json:"described"`
+	Level     DetailLevel `json:"level,omitempty"`
+
+	// Success is the outcome reported by the caller.
+	Success bool `json:"success"`
+}
+
+// DocOutcomeStats aggregates the call outcomes of one tool, split by
+// whether its docs were read first.
+type DocOutcomeStats struct {
+	ToolID              string `json:"toolId"`
+	DescribedCalls      int64  `json:"describedCalls"`
+	DescribedFailures   int64  `json:"describedFailures"`
+	UndescribedCalls    int64  `json:"undescribedCalls"`
+	UndescribedFailures int64  `json:"undescribedFailures"`
+}
+
+// DescribedFailureRate returns the failure rate of calls made after reading
+// the docs, or 0 when there were none.
+func (s DocOutcomeStats) DescribedFailureRate() float64 {
+	if s.DescribedCalls == 0 {
+		return 0
+	}
+	return float64(s.DescribedFailures) / float64(s.DescribedCalls)
+}
+
+// outcomeState pairs describes with call outcomes and aggregates them.
+type outcomeState struct {
+	mu      sync.Mutex
+	pending map[outcomeKey]DetailLevel
+	order   []outcomeKey // FIFO for eviction; may hold consumed keys
+	stats   map[string]*DocOutcomeStats
+}
+
+type outcomeKey struct{ trace, id string }
+
+// noteDescribe remembers a successful describe in a trace. Untraced
+// describes cannot be paired and are not recorded.
+func (o *outcomeState) noteDescribe(trace, id string, level DetailLevel) {
+	if trace == "" {
+		return
+	}
+	key := outcomeKey{trace, id}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.pending == nil {
+		o.pending = make(map[outcomeKey]DetailLevel)
+	}
+	if _, ok := o.pending[key]; !ok {
+		o.order = append(o.order, key)
+	}
+	o.pending[key] = level
+	for len(o.pending) > maxPendingDescribes {
+		delete(o.pending, o.order[0])
+		o.order = o.order[1:]
+	}
+	if len(o.order) > 2*maxPendingDescribes {
+		o.compact()
+	}
+}
+
+// compact drops consumed keys from order.
+func (o *outcomeState) compact() {
+	live := o.order[:0]
+	for _, key := range o.order {
+		if _, ok := o.pending[key]; ok {
+			live = append(live, key)
+		}
+	}
+	o.order = live
+}
+
+// record consumes the describe paired with a call outcome, if any, and
+// counts the outcome.
+func (o *outcomeState) record(trace, id string, success bool) CallOutcome {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	e := CallOutcome{ToolID: id, TraceID: trace, Success: success}
+	if trace != "" {
+		key := outcomeKey{trace, id}
+		if level, ok := o.pending[key]; ok {
+			e.Described, e.Level = true, level
+			delete(o.pending, key)
+		}
+	}
+
+	if o.stats == nil {
+		o.stats = make(map[string]*DocOutcomeStats)
+	}
+	st := o.stats[id]
+	if st == nil {
+		st = &DocOutcomeStats{ToolID: id}
+		o.stats[id] = st
+	}
+	switch {
+	case e.Described:
+		st.DescribedCalls++
+		if !success {
+			st.DescribedFailures++
+		}
+	default:
+		st.UndescribedCalls++
+		if !success {
+			st.UndescribedFailures++
+		}
+	}
+	return e
+}
+
+// RecordCallOutcome reports whether a call of tool id succeeded. The
+// outcome is paired with the tool's last DescribeTool in the same trace
+// (see WithTraceID), counted in CallOutcomeStats, and passed to
+// StoreOptions.OnCallOutcome. Each describe pairs with at most one
+// outcome.
+func (s *InMemoryStore) RecordCallOutcome(ctx context.Context, id string, success bool) {
+	e := s.outcomes.record(TraceID(ctx), id, success)
+	if s.onOutcome == nil {
+		return
+	}
+	if s.anonymize && e.TraceID != "" {
+		sum := sha256.Sum256([]byte(e.TraceID))
+		e.TraceID = hex.EncodeToString(sum[:8])
+	}
+	_, _ = callHook(HookOnCallOutcome, func() (struct{}, error) {
+		s.onOutcome(ctx, e)
+		return struct{}{}, nil
+	})
+}
+
+// CallOutcomeStats returns the aggregated call outcomes per tool, sorted by
+// tool ID.
+func (s *InMemoryStore) CallOutcomeStats() []DocOutcomeStats {
+	s.outcomes.mu.Lock()
+	defer s.outcomes.mu.Unlock()
+
+	out := make([]DocOutcomeStats, 0, len(s.outcomes.stats))
+	for _, st := range s.outcomes.stats {
+		out = append(out, *st)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ToolID < out[j].ToolID })
+	return out
+}
+
+// FailingDocs returns the tools whose docs correlate with failed calls:
+// those with at least minCalls described calls whose described failure
+// rate exceeds the catalog-wide failure rate of all reported calls. They
+// are the best candidates for rewrites, and are sorted by described
+// failure rate, highest first (then by tool ID).
+func (s *InMemoryStore) FailingDocs(minCalls int) []DocOutcomeStats {
+	stats := s.CallOutcomeStats()
+
+	var calls, failures int64
+	for _, st := range stats {
+		calls += st.DescribedCalls + st.UndescribedCalls
+		failures += st.DescribedFailures + st.UndescribedFailures
+	}
+	if calls == 0 {
+		return nil
+	}
+	baseline := float64(failures) / float64(calls)
+
+	var out []DocOutcomeStats
+	for _, st := range stats {
+		if st.DescribedCalls >= int64(minCalls) && st.DescribedCalls > 0 && st.DescribedFailureRate() > baseline {
+			out = append(out, st)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].DescribedFailureRate() > out[j].DescribedFailureRate()
+	})
+	return out
+}
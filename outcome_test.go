@@ -0,0 +1,125 @@
+package tooldocs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestRecordCallOutcome_BatchDescribe(t *testing.T) {
+	var events []CallOutcome
+	store := NewInMemoryStore(StoreOptions{
+		OnCallOutcome: func(_ context.Context, e CallOutcome) { events = append(events, e) },
+	})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Summary: "Search issues"})
+
+	ctx := WithTraceID(context.Background(), "turn-1")
+	if _, err := store.DescribeToolsContext(ctx, []string{"gh:search", "gh:missing"}, DetailSummary); err != nil {
+		t.Fatal(err)
+	}
+	store.RecordCallOutcome(ctx, "gh:search", true)
+	store.RecordCallOutcome(ctx, "gh:missing", true)
+
+	want := []CallOutcome{
+		{ToolID: "gh:search", TraceID: "turn-1", Success: true, Described: true, Level: DetailSummary},
+		{ToolID: "gh:missing", TraceID: "turn-1", Success: true},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+}
+
+func TestRecordCallOutcome(t *testing.T) {
+	var events []CallOutcome
+	store := NewInMemoryStore(StoreOptions{
+		OnCallOutcome: func(_ context.Context, e CallOutcome) { events = append(events, e) },
+	})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Summary: "Search issues"})
+
+	ctx := WithTraceID(context.Background(), "turn-1")
+	if _, err := store.DescribeToolContext(ctx, "gh:search", DetailSummary); err != nil {
+		t.Fatal(err)
+	}
+	store.RecordCallOutcome(ctx, "gh:search", false)
+	// The describe was consumed; a second call in the trace is undescribed.
+	store.RecordCallOutcome(ctx, "gh:search", true)
+	store.RecordCallOutcome(context.Background(), "gh:search", true)
+
+	want := []CallOutcome{
+		{ToolID: "gh:search", TraceID: "turn-1", Described: true, Level: DetailSummary},
+		{ToolID: "gh:search", TraceID: "turn-1", Success: true},
+		{ToolID: "gh:search", Success: true},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+	wantStats := []DocOutcomeStats{{ToolID: "gh:search", DescribedCalls: 1, DescribedFailures: 1, UndescribedCalls: 2}}
+	if got := store.CallOutcomeStats(); !reflect.DeepEqual(got, wantStats) {
+		t.Errorf("CallOutcomeStats = %+v, want %+v", got, wantStats)
+	}
+}
+
+func TestRecordCallOutcome_Anonymized(t *testing.T) {
+	var got []string
+	store := NewInMemoryStore(StoreOptions{
+		AnonymizeOutcomes: true,
+		OnCallOutcome:     func(_ context.Context, e CallOutcome) { got = append(got, e.TraceID) },
+	})
+	for _, trace := range []string{"turn-1", "turn-1", "turn-2"} {
+		store.RecordCallOutcome(WithTraceID(context.Background(), trace), "gh:search", true)
+	}
+	if got[0] == "turn-1" || got[0] == "" || got[0] != got[1] || got[0] == got[2] {
+		t.Errorf("anonymized trace IDs = %q, want stable distinct hashes", got)
+	}
+}
+
+func TestFailingDocs(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	for _, id := range []string{"gh:search", "gh:create", "gh:delete"} {
+		mustRegisterDoc(t, store, id, DocEntry{Summary: id})
+	}
+	call := func(id string, n int, success bool) {
+		for i := 0; i < n; i++ {
+			ctx := WithTraceID(context.Background(), fmt.Sprintf("%s-%t-%d", id, success, i))
+			if _, err := store.DescribeToolContext(ctx, id, DetailSummary); err != nil {
+				t.Fatal(err)
+			}
+			store.RecordCallOutcome(ctx, id, success)
+		}
+	}
+	call("gh:search", 9, true)
+	call("gh:search", 1, false)
+	call("gh:create", 2, true)
+	call("gh:create", 3, false)
+	call("gh:delete", 1, false)
+
+	var ids []string
+	for _, st := range store.FailingDocs(2) {
+		ids = append(ids, st.ToolID)
+	}
+	if want := []string{"gh:create"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("FailingDocs(2) = %v, want %v", ids, want)
+	}
+	ids = ids[:0]
+	for _, st := range store.FailingDocs(1) {
+		ids = append(ids, st.ToolID)
+	}
+	if want := []string{"gh:delete", "gh:create"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("FailingDocs(1) = %v, want %v", ids, want)
+	}
+}
+
+func TestOutcomeState_Evicts(t *testing.T) {
+	var o outcomeState
+	for i := 0; i < maxPendingDescribes+10; i++ {
+		o.noteDescribe("trace", strconv.Itoa(i), DetailSummary)
+	}
+	if len(o.pending) != maxPendingDescribes {
+		t.Errorf("pending = %d, want %d", len(o.pending), maxPendingDescribes)
+	}
+	if e := o.record("trace", "0", true); e.Described {
+		t.Error("oldest describe was not evicted")
+	}
+}
@@ -0,0 +1,70 @@
+package tooldocs
+
+import (
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestDescribeTool_TitleAndAnnotationsOverride(t *testing.T) {
+	tool := makeToolWithSchema("create_ticket_v2_gen", "jira", "Create a ticket", map[string]any{"type": "object"})
+	tool.Title = "create_ticket_v2_gen"
+	tool.Annotations = &mcp.ToolAnnotations{ReadOnlyHint: true}
+
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(_ string) (*toolmodel.Tool, error) {
+			return &tool, nil
+		},
+	})
+
+	destructive := true
+	mustRegisterDoc(t, store, "jira:create_ticket_v2_gen", DocEntry{
+		Title:       "Create Jira ticket",
+		Annotations: &mcp.ToolAnnotations{DestructiveHint: &destructive},
+	})
+
+	doc, err := store.DescribeTool("jira:create_ticket_v2_gen", DetailSummary)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if doc.Title != "Create Jira ticket" {
+		t.Errorf("summary Title = %q, want override", doc.Title)
+	}
+
+	doc, err = store.DescribeTool("jira:create_ticket_v2_gen", DetailSchema)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if doc.Title != "Create Jira ticket" || doc.Tool.Title != "Create Jira ticket" {
+		t.Errorf("Title = %q, Tool.Title = %q, want override", doc.Title, doc.Tool.Title)
+	}
+	if doc.Tool.Annotations == nil || doc.Tool.Annotations.ReadOnlyHint ||
+		doc.Tool.Annotations.DestructiveHint == nil || !*doc.Tool.Annotations.DestructiveHint {
+		t.Errorf("Annotations = %+v, want override", doc.Tool.Annotations)
+	}
+
+	// The resolver-owned tool must not be modified
+	if tool.Title != "create_ticket_v2_gen" || !tool.Annotations.ReadOnlyHint {
+		t.Errorf("upstream tool was modified: %+v", tool)
+	}
+}
+
+func TestDescribeTool_TitleFallback(t *testing.T) {
+	tool := makeToolWithSchema("search", "ns", "Search", map[string]any{"type": "object"})
+	tool.Annotations = &mcp.ToolAnnotations{Title: "Search things"}
+
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(_ string) (*toolmodel.Tool, error) {
+			return &tool, nil
+		},
+	})
+
+	doc, err := store.DescribeTool("ns:search", DetailSchema)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if doc.Title != "Search things" {
+		t.Errorf("Title = %q, want annotations title", doc.Title)
+	}
+}
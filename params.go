@@ -0,0 +1,194 @@
+package tooldocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Format selects a text rendering format.
+type Format string
+
+// Rendering formats.
+const (
+	// FormatMarkdown renders a Markdown table.
+	FormatMarkdown Format = "markdown"
+
+	// FormatText renders aligned plain-text columns.
+	FormatText Format = "text"
+)
+
+// constraintKeywords are the JSON Schema keywords reported as constraints,
+// in display order, with their labels.
+var constraintKeywords = []struct{ keyword, label string }{
+	{"enum", "enum"},
+	{"const", "const"},
+	{"format", "format"},
+	{"pattern", "pattern"},
+	{"minimum", "min"},
+	{"exclusiveMinimum", "min (exclusive)"},
+	{"maximum", "max"},
+	{"exclusiveMaximum", "max (exclusive)"},
+	{"multipleOf", "multipleOf"},
+	{"minLength", "minLength"},
+	{"maxLength", "maxLength"},
+	{"minItems", "minItems"},
+	{"maxItems", "maxItems"},
+	{"uniqueItems", "uniqueItems"},
+}
+
+// schemaConstraints renders the validation keywords of a property schema.
+func schemaConstraints(prop map[string]any) []string {
+	var out []string
+	for _, kw := range constraintKeywords {
+		v, ok := prop[kw.keyword]
+		if !ok {
+			continue
+		}
+		if kw.keyword == "enum" {
+			values := toAnySlice(v)
+			parts := make([]string, len(values))
+			for i, value := range values {
+				parts[i] = formatValue(value)
+			}
+			out = append(out, "enum: "+strings.Join(parts, ", "))
+			continue
+		}
+		out = append(out, kw.label+": "+formatValue(v))
+	}
+	return out
+}
+
+// toAnySlice converts typed slices (e.g. []string) to []any.
+func toAnySlice(v any) []any {
+	if s, ok := deepCopyValue(v).([]any); ok {
+		return s
+	}
+	return nil
+}
+
+// formatValue renders a schema value compactly: strings bare, everything
+// else as JSON.
+func formatValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(normalizeNumeric(v))
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// RenderParams renders info as a compact parameter table with name, type,
+// required, default, and constraints columns. Required parameters come
+// first in schema order, then the rest alphabetically. Unknown formats
+// render as FormatText. Returns "" for nil or empty info. See
+// Catalog.RenderParams for translated headers.
+func RenderParams(info *SchemaInfo, format Format) string {
+	return renderParams(info, format, nil)
+}
+
+// renderParams implements RenderParams, translating headers with c.
+func renderParams(info *SchemaInfo, format Format, c Catalog) string {
+	names := paramNames(info)
+	if len(names) == 0 {
+		return ""
+	}
+
+	required := make(map[string]bool, len(info.Required))
+	for _, name := range info.Required {
+		required[name] = true
+	}
+
+	rows := make([][5]string, len(names))
+	for i, name := range names {
+		row := [5]string{name, strings.Join(info.Types[name], " | "), "", "", strings.Join(info.Constraints[name], "; ")}
+		if required[name] {
+			row[2] = c.Text("yes")
+		}
+		if def, ok := info.Defaults[name]; ok {
+			row[3] = formatValue(def)
+		}
+		rows[i] = row
+	}
+
+	header := [5]string{c.Text("name"), c.Text("type"), c.Text("required"), c.Text("default"), c.Text("constraints")}
+	var b strings.Builder
+	if format == FormatMarkdown {
+		writeMarkdownRow(&b, header)
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, row := range rows {
+			writeMarkdownRow(&b, row)
+		}
+		return b.String()
+	}
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, row := range append([][5]string{header}, rows...) {
+		for i, cell := range row {
+			if cell == "" {
+				cell = "-"
+			}
+			if i > 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			fmt.Fprint(tw, cell)
+		}
+		fmt.Fprintln(tw)
+	}
+	_ = tw.Flush()
+	// tabwriter pads the final column; trim trailing spaces per line.
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// paramNames returns the parameters described by info: required ones in
+// schema order, then the rest sorted.
+func paramNames(info *SchemaInfo) []string {
+	if info == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, name := range info.Required {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	var rest []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			rest = append(rest, name)
+		}
+	}
+	for name := range info.Types {
+		add(name)
+	}
+	for name := range info.Defaults {
+		add(name)
+	}
+	for name := range info.Constraints {
+		add(name)
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// writeMarkdownRow writes a Markdown table row, escaping cell pipes.
+func writeMarkdownRow(b *strings.Builder, row [5]string) {
+	b.WriteString("|")
+	for _, cell := range row {
+		b.WriteString(" ")
+		b.WriteString(strings.ReplaceAll(cell, "|", `\|`))
+		b.WriteString(" |")
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,67 @@
+package tooldocs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func paramsSchema() map[string]any {
+	return map[string]any{
+		"type":     "object",
+		"required": []any{"repo", "state"},
+		"properties": map[string]any{
+			"repo":  map[string]any{"type": "string", "pattern": "^[^/]+/[^/]+$"},
+			"state": map[string]any{"type": "string", "enum": []string{"open", "closed"}, "default": "open"},
+			"limit": map[string]any{"type": "integer", "minimum": 1, "maximum": 100, "default": 30},
+			"label": map[string]any{"type": []any{"string", "null"}},
+			"extra": map[string]any{"description": "untyped"},
+		},
+	}
+}
+
+func TestDeriveSchemaInfo_Constraints(t *testing.T) {
+	info := deriveSchemaInfo(paramsSchema())
+	want := map[string][]string{
+		"repo":  {"pattern: ^[^/]+/[^/]+$"},
+		"state": {"enum: open, closed"},
+		"limit": {"min: 1", "max: 100"},
+	}
+	if !reflect.DeepEqual(info.Constraints, want) {
+		t.Errorf("Constraints = %v, want %v", info.Constraints, want)
+	}
+}
+
+func TestRenderParams(t *testing.T) {
+	info := deriveSchemaInfo(paramsSchema())
+
+	wantMarkdown := "| name | type | required | default | constraints |\n" +
+		"|---|---|---|---|---|\n" +
+		"| repo | string | yes |  | pattern: ^[^/]+/[^/]+$ |\n" +
+		"| state | string | yes | open | enum: open, closed |\n" +
+		"| label | string \\| null |  |  |  |\n" +
+		"| limit | integer |  | 30 | min: 1; max: 100 |\n"
+	if got := RenderParams(info, FormatMarkdown); got != wantMarkdown {
+		t.Errorf("markdown =\n%s\nwant\n%s", got, wantMarkdown)
+	}
+
+	wantText := "name   type           required  default  constraints\n" +
+		"repo   string         yes       -        pattern: ^[^/]+/[^/]+$\n" +
+		"state  string         yes       open     enum: open, closed\n" +
+		"label  string | null  -         -        -\n" +
+		"limit  integer        -         30       min: 1; max: 100\n"
+	if got := RenderParams(info, FormatText); got != wantText {
+		t.Errorf("text =\n%s\nwant\n%s", got, wantText)
+	}
+	if RenderParams(info, "bogus") != wantText {
+		t.Error("unknown format should render as text")
+	}
+}
+
+func TestRenderParams_Empty(t *testing.T) {
+	if got := RenderParams(nil, FormatMarkdown); got != "" {
+		t.Errorf("nil info = %q, want empty", got)
+	}
+	if got := RenderParams(&SchemaInfo{}, FormatText); got != "" {
+		t.Errorf("empty info = %q, want empty", got)
+	}
+}
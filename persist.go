@@ -0,0 +1,109 @@
+package tooldocs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPersist is returned by writes whose StoreOptions.Persister call or
+// change log append failed. The in-memory write has already been applied;
+// the durable copy lags until the next successful save of the tool.
+var ErrPersist = errors.New("persist failed")
+
+// Persister mirrors the store's documentation to durable storage. It is
+// called after each successful write, under the store's write lock, so calls
+// arrive in write order; implementations must not call back into the store.
+type Persister interface {
+	// SaveDoc stores id's documentation as it now stands: after
+	// RegisterDoc, RegisterExamples, AppendExamples, Import, schema example
+	// generation, Approve, Freeze, and Unfreeze.
+	SaveDoc(id string, doc StoredDoc) error
+
+	// DeleteDoc removes id's documentation, after UnregisterWhere, a
+	// pruning Reconcile, or eviction under StoreOptions.MaxDocs or
+	// MaxMemoryBytes.
+	DeleteDoc(id string) error
+}
+
+// saved records a write that left id's documentation as record, appending
+// it to the change log (with the write's example diffs, if any) and passing
+// it to the Persister, then records the docs the write evicted. An empty
+// author means StoreOptions.Author. Callers hold s.mu.
+func (s *InMemoryStore) saved(op ChangeOp, author, id string, record *docRecord, changes []ExampleDiff) error {
+	evicted := s.evicted
+	s.evicted = nil
+	if s.changeLog == nil && s.persister == nil {
+		return nil
+	}
+	var errs []error
+	doc := StoredDoc{ID: id, Entry: record.entry(), Frozen: record.frozen, Expires: record.expires}
+	if err := s.logChange(op, author, id, &doc, changes); err != nil {
+		errs = append(errs, err)
+	} else if s.persister != nil {
+		_, err := callHook(HookPersister, func() (struct{}, error) {
+			return struct{}{}, s.persister.SaveDoc(id, doc)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s: %w", ErrPersist, id, err))
+		}
+	}
+	for _, victim := range evicted {
+		errs = append(errs, s.removed(ChangeEvict, victim))
+	}
+	return errors.Join(errs...)
+}
+
+// deleted records the removal of id's documentation, like saved.
+func (s *InMemoryStore) deleted(id string) error {
+	return s.removed(ChangeDelete, id)
+}
+
+// removed records the removal of id's documentation by op (ChangeDelete or
+// ChangeEvict), appending it to the change log and passing it to the
+// Persister. Callers hold s.mu.
+func (s *InMemoryStore) removed(op ChangeOp, id string) error {
+	if err := s.logChange(op, "", id, nil, nil); err != nil {
+		return err
+	}
+	if s.persister == nil {
+		return nil
+	}
+	_, err := callHook(HookPersister, func() (struct{}, error) {
+		return struct{}{}, s.persister.DeleteDoc(id)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrPersist, id, err)
+	}
+	return nil
+}
+
+// Restore loads documentation read back from durable storage, such as a
+// FileStore file or a boltstore database, replacing any docs registered
+// under the same IDs. Docs are restored as they were saved: entries are
+// not validated against the store's caps or summary profile, frozen flags
+// and expiry times are kept, and nothing is logged, persisted, counted
+// against WriteRateLimit, or recorded as an import.
+//
+// Returns ErrReadOnly if the store is sealed, or an error for a doc with
+// an empty ID.
+func (s *InMemoryStore) Restore(docs []StoredDoc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return err
+	}
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return errors.New("restore: doc has no tool ID")
+		}
+	}
+	for _, doc := range docs {
+		record := newRecord(doc.Entry, copyExamples(doc.Entry.Examples))
+		record.frozen = doc.Frozen
+		record.expires = doc.Expires
+		s.docs[doc.ID] = record
+		s.stored(doc.ID, record)
+	}
+	s.evicted = nil // restored docs are not logged or persisted
+	return nil
+}
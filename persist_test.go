@@ -0,0 +1,98 @@
+package tooldocs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// recordingPersister records Persister calls as "save id" / "delete id".
+type recordingPersister struct {
+	calls []string
+	docs  map[string]StoredDoc
+	fail  error
+}
+
+func (p *recordingPersister) SaveDoc(id string, doc StoredDoc) error {
+	p.calls = append(p.calls, "save "+id)
+	if p.docs == nil {
+		p.docs = make(map[string]StoredDoc)
+	}
+	p.docs[id] = doc
+	return p.fail
+}
+
+func (p *recordingPersister) DeleteDoc(id string) error {
+	p.calls = append(p.calls, "delete "+id)
+	delete(p.docs, id)
+	return p.fail
+}
+
+func TestPersister(t *testing.T) {
+	p := &recordingPersister{}
+	store := NewInMemoryStore(StoreOptions{Persister: p})
+
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "A", Source: "manual"})
+	if err := store.RegisterExamples("ns:a", []ToolExample{{Title: "one"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AppendExamples("ns:a", []ToolExample{{Title: "two"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Import(map[string]DocEntry{"ns:b": {Summary: "B"}}, ImportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Import(map[string]DocEntry{"ns:c": {Summary: "C"}}, ImportOptions{DryRun: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Freeze("ns:a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Freeze("ns:a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDoc("ns:a", DocEntry{Summary: "rejected"}); !errors.Is(err, ErrFrozen) {
+		t.Fatalf("err = %v, want ErrFrozen", err)
+	}
+	if _, err := store.UnregisterWhere(UnregisterFilter{NamespacePrefix: "ns"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"save ns:a", "save ns:a", "save ns:a", "save ns:b", "save ns:a", "delete ns:b"}
+	if !reflect.DeepEqual(p.calls, want) {
+		t.Errorf("calls = %v, want %v", p.calls, want)
+	}
+	doc := p.docs["ns:a"]
+	if !doc.Frozen || doc.Entry.Summary != "A" || len(doc.Entry.Examples) != 2 {
+		t.Errorf("persisted ns:a = %+v", doc)
+	}
+}
+
+func TestPersister_Errors(t *testing.T) {
+	boom := errors.New("disk full")
+	store := NewInMemoryStore(StoreOptions{Persister: &recordingPersister{fail: boom}})
+
+	err := store.RegisterDoc("ns:a", DocEntry{Summary: "A"})
+	if !errors.Is(err, ErrPersist) || !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want ErrPersist wrapping the persister error", err)
+	}
+	if _, err := store.GetDocEntry("ns:a"); err != nil {
+		t.Errorf("write was not kept: %v", err)
+	}
+
+	report, err := store.Import(map[string]DocEntry{"ns:b": {Summary: "B"}}, ImportOptions{})
+	if !errors.Is(err, ErrPersist) || report.Totals.Created != 1 {
+		t.Errorf("Import = %+v, %v", report.Totals, err)
+	}
+
+	panicky := NewInMemoryStore(StoreOptions{Persister: panicPersister{}})
+	if err := panicky.RegisterDoc("ns:a", DocEntry{Summary: "A"}); !errors.Is(err, ErrHookPanic) {
+		t.Errorf("err = %v, want ErrHookPanic", err)
+	}
+}
+
+type panicPersister struct{}
+
+func (panicPersister) SaveDoc(id string, doc StoredDoc) error { panic(fmt.Sprint("save ", id)) }
+func (panicPersister) DeleteDoc(id string) error              { panic(fmt.Sprint("delete ", id)) }
@@ -0,0 +1,179 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// ErrInvalidPlaceholder is returned when a placeholder name is not
+// UPPER_SNAKE_CASE.
+var ErrInvalidPlaceholder = errors.New("invalid placeholder name")
+
+// placeholderPattern matches a placeholder such as "<PROJECT_ID>" in an
+// example Args string.
+var placeholderPattern = regexp.MustCompile(`<([A-Z][A-Z0-9_]*)>`)
+
+var placeholderName = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// Placeholder documents a value that examples leave generic, written as
+// "<NAME>" inside example Args strings.
+type Placeholder struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// RegisterPlaceholder records what a placeholder stands for, e.g.
+// ("PROJECT_ID", "GCP project that owns the resource"), replacing any
+// previous description. name is given without angle brackets.
+//
+// Returns ErrInvalidPlaceholder if name is not UPPER_SNAKE_CASE.
+func (s *InMemoryStore) RegisterPlaceholder(name, description string) error {
+	if !placeholderName.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidPlaceholder, name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return err
+	}
+	s.placeholders[name] = description
+	return nil
+}
+
+// Placeholders returns the registered placeholders sorted by name.
+func (s *InMemoryStore) Placeholders() []Placeholder {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]Placeholder, 0, len(s.placeholders))
+	for name, desc := range s.placeholders {
+		out = append(out, Placeholder{Name: name, Description: desc})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// ExamplePlaceholders returns the sorted, distinct placeholder names used
+// in the examples' Args, so a UI can prompt for exactly the values it needs.
+func ExamplePlaceholders(examples []ToolExample) []string {
+	seen := make(map[string]bool)
+	for _, ex := range examples {
+		for _, v := range ex.Args {
+			collectPlaceholders(v, seen)
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func collectPlaceholders(v any, seen map[string]bool) {
+	switch val := v.(type) {
+	case string:
+		for _, m := range placeholderPattern.FindAllStringSubmatch(val, -1) {
+			seen[m[1]] = true
+		}
+	case map[string]any:
+		for _, item := range val {
+			collectPlaceholders(item, seen)
+		}
+	case []any:
+		for _, item := range val {
+			collectPlaceholders(item, seen)
+		}
+	case []string:
+		for _, item := range val {
+			collectPlaceholders(item, seen)
+		}
+	case map[string]string:
+		for _, item := range val {
+			collectPlaceholders(item, seen)
+		}
+	}
+}
+
+// SubstitutePlaceholders returns a deep copy of args with placeholders
+// replaced from values (keyed by name, without angle brackets). A string
+// that is exactly one placeholder becomes the value itself, so "<LIMIT>"
+// can become the number 10; placeholders inside longer strings are
+// replaced by the value formatted with fmt.Sprint. Placeholders without a
+// value are left as written. args is never modified.
+func SubstitutePlaceholders(args map[string]any, values map[string]any) map[string]any {
+	copied := deepCopyArgs(args)
+	if len(values) == 0 {
+		return copied
+	}
+	for k, v := range copied {
+		copied[k] = substituteValue(v, values)
+	}
+	return copied
+}
+
+// substituteValue replaces placeholders in v, which has already been
+// normalized by deepCopyValue.
+func substituteValue(v any, values map[string]any) any {
+	switch val := v.(type) {
+	case string:
+		if m := placeholderPattern.FindStringSubmatch(val); m != nil && m[0] == val {
+			if sub, ok := values[m[1]]; ok {
+				return deepCopyValue(sub)
+			}
+			return val
+		}
+		return placeholderPattern.ReplaceAllStringFunc(val, func(p string) string {
+			if sub, ok := values[p[1:len(p)-1]]; ok {
+				return fmt.Sprint(sub)
+			}
+			return p
+		})
+	case map[string]any:
+		for k, item := range val {
+			val[k] = substituteValue(item, values)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = substituteValue(item, values)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// ConcretizeExamples returns the tool's examples, as ListExamples would,
+// with placeholders in their Args replaced from values (see
+// SubstitutePlaceholders).
+func (s *InMemoryStore) ConcretizeExamples(id string, maxExamples int, values map[string]any) ([]ToolExample, error) {
+	examples, err := s.ListExamples(id, maxExamples)
+	if err != nil {
+		return nil, err
+	}
+	for i := range examples {
+		examples[i].Args = SubstitutePlaceholders(examples[i].Args, values)
+	}
+	return examples, nil
+}
+
+type placeholderValuesKey struct{}
+
+// WithPlaceholderValues returns a context carrying placeholder values for
+// ReplayExamples, so the same generic examples can be replayed against
+// each environment.
+func WithPlaceholderValues(ctx context.Context, values map[string]any) context.Context {
+	return context.WithValue(ctx, placeholderValuesKey{}, values)
+}
+
+// PlaceholderValues returns the placeholder values carried by ctx, or nil.
+func PlaceholderValues(ctx context.Context) map[string]any {
+	values, _ := ctx.Value(placeholderValuesKey{}).(map[string]any)
+	return values
+}
@@ -0,0 +1,93 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestSubstitutePlaceholders(t *testing.T) {
+	args := map[string]any{
+		"project": "<PROJECT_ID>",
+		"topic":   "projects/<PROJECT_ID>/topics/<TOPIC>",
+		"limit":   "<LIMIT>",
+		"labels":  []string{"<LABEL>", "fixed"},
+		"nested":  map[string]any{"region": "<REGION>"},
+	}
+	got := SubstitutePlaceholders(args, map[string]any{
+		"PROJECT_ID": "acme-prod",
+		"LIMIT":      10,
+		"LABEL":      "bug",
+		"TOPIC":      "events",
+	})
+	want := map[string]any{
+		"project": "acme-prod",
+		"topic":   "projects/acme-prod/topics/events",
+		"limit":   10,
+		"labels":  []any{"bug", "fixed"},
+		"nested":  map[string]any{"region": "<REGION>"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SubstitutePlaceholders = %#v, want %#v", got, want)
+	}
+	if args["project"] != "<PROJECT_ID>" {
+		t.Error("SubstitutePlaceholders modified args")
+	}
+
+	names := ExamplePlaceholders([]ToolExample{{Args: args}, {Args: map[string]any{"q": "<REGION> <Q>"}}})
+	if want := []string{"LABEL", "LIMIT", "PROJECT_ID", "Q", "REGION", "TOPIC"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ExamplePlaceholders = %v, want %v", names, want)
+	}
+}
+
+func TestPlaceholderRegistry(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	if err := store.RegisterPlaceholder("project-id", "GCP project"); !errors.Is(err, ErrInvalidPlaceholder) {
+		t.Errorf("RegisterPlaceholder(project-id) error = %v, want ErrInvalidPlaceholder", err)
+	}
+	for _, p := range []Placeholder{{"REGION", "Deployment region"}, {"PROJECT_ID", "GCP project"}} {
+		if err := store.RegisterPlaceholder(p.Name, p.Description); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []Placeholder{{"PROJECT_ID", "GCP project"}, {"REGION", "Deployment region"}}
+	if got := store.Placeholders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Placeholders = %v, want %v", got, want)
+	}
+}
+
+func TestPlaceholders_ConcretizeAndReplay(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "gcp:get_bucket", DocEntry{
+		Summary:  "Get a bucket",
+		Examples: []ToolExample{{Title: "Get", Args: map[string]any{"bucket": "<PROJECT_ID>-assets"}}},
+	})
+	values := map[string]any{"PROJECT_ID": "acme"}
+
+	examples, err := store.ConcretizeExamples("gcp:get_bucket", 0, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := examples[0].Args["bucket"]; got != "acme-assets" {
+		t.Errorf("concretized bucket = %v, want acme-assets", got)
+	}
+	if stored, _ := store.GetDocEntry("gcp:get_bucket"); stored.Entry.Examples[0].Args["bucket"] != "<PROJECT_ID>-assets" {
+		t.Error("ConcretizeExamples modified the stored example")
+	}
+
+	var replayed any
+	invoker := ToolInvokerFunc(func(_ context.Context, _ string, params *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+		replayed = params.Arguments.(map[string]any)["bucket"]
+		return &mcp.CallToolResult{}, nil
+	})
+	ctx := WithPlaceholderValues(context.Background(), values)
+	if _, err := store.ReplayExamples(ctx, "gcp:get_bucket", invoker); err != nil {
+		t.Fatal(err)
+	}
+	if replayed != "acme-assets" {
+		t.Errorf("replayed bucket = %v, want acme-assets", replayed)
+	}
+}
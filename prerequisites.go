@@ -0,0 +1,70 @@
+package tooldocs
+
+// PrerequisiteKind classifies a Prerequisite.
+type PrerequisiteKind string
+
+const (
+	// PrerequisiteSetup is a one-time setup step outside the tool catalog
+	// (e.g. "install the GitHub app on the repository").
+	PrerequisiteSetup PrerequisiteKind = "setup"
+
+	// PrerequisiteTool is another tool that must be called first; Tool
+	// names it (e.g. "ci:build" before "ci:deploy").
+	PrerequisiteTool PrerequisiteKind = "tool"
+
+	// PrerequisiteState is a condition that must hold when the tool is
+	// called (e.g. "the branch has no open merge conflicts").
+	PrerequisiteState PrerequisiteKind = "state"
+)
+
+// Prerequisite is something that must be done or true before a tool is
+// called. Prerequisites are returned at DetailFull so agents learn ordering
+// constraints (build before deploy) from data rather than prose.
+type Prerequisite struct {
+	// Kind classifies the prerequisite.
+	Kind PrerequisiteKind `json:"kind"`
+
+	// Description states the prerequisite in one sentence.
+	// Maximum length: MaxDescriptionLen (300 chars).
+	Description string `json:"description,omitempty"`
+
+	// Tool is the ID of the tool to call first, for PrerequisiteTool.
+	Tool string `json:"tool,omitempty"`
+}
+
+// String renders the prerequisite on one line, e.g.
+// "tool ci:build: artifacts must exist" or "state: branch is clean".
+func (p Prerequisite) String() string {
+	label := string(p.Kind)
+	if p.Tool != "" {
+		label += " " + p.Tool
+	}
+	if p.Description == "" {
+		return label
+	}
+	return label + ": " + p.Description
+}
+
+// truncatePrerequisites returns a copy of prereqs with descriptions cut to
+// MaxDescriptionLen.
+func truncatePrerequisites(prereqs []Prerequisite) []Prerequisite {
+	if prereqs == nil {
+		return nil
+	}
+	result := make([]Prerequisite, len(prereqs))
+	for i, p := range prereqs {
+		p.Description = truncateString(p.Description, MaxDescriptionLen)
+		result[i] = p
+	}
+	return result
+}
+
+// internPrerequisites interns the text of prereqs in place and returns it.
+func internPrerequisites(prereqs []Prerequisite) []Prerequisite {
+	for i := range prereqs {
+		prereqs[i].Kind = PrerequisiteKind(intern(string(prereqs[i].Kind)))
+		prereqs[i].Description = intern(prereqs[i].Description)
+		prereqs[i].Tool = intern(prereqs[i].Tool)
+	}
+	return prereqs
+}
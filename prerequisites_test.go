@@ -0,0 +1,106 @@
+package tooldocs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestPrerequisites_FullLevelOnly(t *testing.T) {
+	tool := makeToolWithSchema("deploy", "ci", "Deploy a build", map[string]any{"type": "object"})
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) { return &tool, nil },
+	})
+	prereqs := []Prerequisite{
+		{Kind: PrerequisiteTool, Tool: "ci:build", Description: "Deploy needs a build artifact."},
+		{Kind: PrerequisiteState, Description: "The target environment is unlocked."},
+	}
+	mustRegisterDoc(t, store, "ci:deploy", DocEntry{Summary: "Deploy", Prerequisites: prereqs})
+
+	full, err := store.DescribeTool("ci:deploy", DetailFull)
+	if err != nil {
+		t.Fatalf("DescribeTool(full) failed: %v", err)
+	}
+	if !reflect.DeepEqual(full.Prerequisites, prereqs) {
+		t.Errorf("Prerequisites = %+v, want %+v", full.Prerequisites, prereqs)
+	}
+
+	schema, err := store.DescribeTool("ci:deploy", DetailSchema)
+	if err != nil {
+		t.Fatalf("DescribeTool(schema) failed: %v", err)
+	}
+	if schema.Prerequisites != nil {
+		t.Errorf("schema level Prerequisites = %+v, want nil", schema.Prerequisites)
+	}
+
+	// Returned slices are copies.
+	full.Prerequisites[0].Tool = "mutated"
+	again, _ := store.DescribeTool("ci:deploy", DetailFull)
+	if again.Prerequisites[0].Tool != "ci:build" {
+		t.Errorf("store mutated through returned doc: %+v", again.Prerequisites[0])
+	}
+}
+
+func TestPrerequisites_Truncated(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Prerequisites: []Prerequisite{
+		{Kind: PrerequisiteSetup, Description: strings.Repeat("x", MaxDescriptionLen+10)},
+	}})
+	stored, _ := store.GetDocEntry("ns:a")
+	if got := len(stored.Entry.Prerequisites[0].Description); got != MaxDescriptionLen {
+		t.Errorf("description length = %d, want %d", got, MaxDescriptionLen)
+	}
+}
+
+func TestPrerequisite_String(t *testing.T) {
+	tests := []struct {
+		p    Prerequisite
+		want string
+	}{
+		{Prerequisite{Kind: PrerequisiteTool, Tool: "ci:build", Description: "artifacts must exist"}, "tool ci:build: artifacts must exist"},
+		{Prerequisite{Kind: PrerequisiteTool, Tool: "ci:build"}, "tool ci:build"},
+		{Prerequisite{Kind: PrerequisiteState, Description: "branch is clean"}, "state: branch is clean"},
+	}
+	for _, tt := range tests {
+		if got := tt.p.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestPrerequisites_MergeAndImport(t *testing.T) {
+	build := Prerequisite{Kind: PrerequisiteTool, Tool: "ci:build"}
+	unlocked := Prerequisite{Kind: PrerequisiteState, Description: "unlocked"}
+
+	merged := MergeDocEntries(
+		DocEntry{Prerequisites: []Prerequisite{build}},
+		DocEntry{Prerequisites: []Prerequisite{build, unlocked}},
+		MergePolicy{},
+	)
+	if want := []Prerequisite{build, unlocked}; !reflect.DeepEqual(merged.Prerequisites, want) {
+		t.Errorf("merged Prerequisites = %+v, want %+v", merged.Prerequisites, want)
+	}
+
+	fields := entryFieldChanges(DocEntry{Prerequisites: []Prerequisite{build}}, merged)
+	if !reflect.DeepEqual(fields, []string{"prerequisites"}) {
+		t.Errorf("entryFieldChanges = %v, want [prerequisites]", fields)
+	}
+}
+
+func TestDocBuilder_AddPrerequisite(t *testing.T) {
+	entry, err := NewDocBuilder().
+		AddPrerequisite(Prerequisite{Kind: PrerequisiteTool, Tool: "ci:build"}).
+		Build()
+	if err != nil || len(entry.Prerequisites) != 1 {
+		t.Fatalf("Build() = %+v, %v", entry, err)
+	}
+
+	_, err = NewDocBuilder().
+		AddPrerequisite(Prerequisite{Kind: PrerequisiteSetup, Description: strings.Repeat("x", MaxDescriptionLen+1)}).
+		Build()
+	if err == nil || !strings.Contains(err.Error(), "prerequisite 0") {
+		t.Errorf("Build() error = %v, want ErrFieldTooLong for prerequisite 0", err)
+	}
+}
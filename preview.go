@@ -0,0 +1,76 @@
+package tooldocs
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CallPreview is a tool call under construction, as returned by
+// PreviewCall.
+type CallPreview struct {
+	// Args are the caller's arguments with schema defaults filled in for
+	// unset top-level parameters.
+	Args map[string]any `json:"args"`
+
+	// Defaulted lists, sorted, the parameters filled from schema defaults.
+	Defaulted []string `json:"defaulted,omitempty"`
+
+	// Missing lists, in schema order, the required parameters that are
+	// still unset and have no default.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// Complete reports whether every required parameter has a value.
+func (p CallPreview) Complete() bool {
+	return len(p.Missing) == 0
+}
+
+// PreviewCall fills the parameters partialArgs leaves unset with the
+// defaults from the tool's input schema and reports which required
+// parameters are still missing, so agents and humans can build a valid call
+// step by step from docs data. partialArgs is deep-copied and never
+// modified; a parameter set to nil counts as set. Only top-level
+// parameters are considered.
+//
+// Returns ErrNotFound if neither tool nor docs exist for the ID, ErrNoTool
+// if docs exist but no tool can be resolved, or the resolver's error.
+func (s *InMemoryStore) PreviewCall(id string, partialArgs map[string]any) (CallPreview, error) {
+	tool, err := s.resolveTool(id)
+	tool, _, err = s.withStaleFallback(id, tool, err)
+	if err != nil {
+		return CallPreview{}, err
+	}
+	if tool == nil {
+		s.mu.RLock()
+		_, hasDoc := s.docs[id]
+		s.mu.RUnlock()
+		if !hasDoc {
+			return CallPreview{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		return CallPreview{}, fmt.Errorf("%w: %s", ErrNoTool, id)
+	}
+
+	args := deepCopyArgs(partialArgs)
+	if args == nil {
+		args = map[string]any{}
+	}
+	preview := CallPreview{Args: args}
+
+	info := deriveSchemaInfo(tool.InputSchema)
+	if info == nil {
+		return preview, nil
+	}
+	for name, def := range info.Defaults {
+		if _, set := args[name]; !set {
+			args[name] = deepCopyValue(def)
+			preview.Defaulted = append(preview.Defaulted, name)
+		}
+	}
+	sort.Strings(preview.Defaulted)
+	for _, name := range info.Required {
+		if _, set := args[name]; !set {
+			preview.Missing = append(preview.Missing, name)
+		}
+	}
+	return preview, nil
+}
@@ -0,0 +1,63 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestPreviewCall(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			if id != "gh:search" {
+				return nil, nil
+			}
+			tool := makeToolWithSchema("search", "gh", "Search", map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"repo":  map[string]any{"type": "string"},
+					"q":     map[string]any{"type": "string"},
+					"limit": map[string]any{"type": "integer", "default": 30},
+					"state": map[string]any{"type": "string", "default": "open"},
+				},
+				"required": []any{"repo", "q", "limit"},
+			})
+			return &tool, nil
+		},
+	})
+
+	partial := map[string]any{"q": "is:bug", "state": "closed"}
+	preview, err := store.PreviewCall("gh:search", partial)
+	if err != nil {
+		t.Fatalf("PreviewCall failed: %v", err)
+	}
+	want := CallPreview{
+		Args:      map[string]any{"q": "is:bug", "state": "closed", "limit": 30.0},
+		Defaulted: []string{"limit"},
+		Missing:   []string{"repo"},
+	}
+	if !reflect.DeepEqual(preview, want) {
+		t.Errorf("PreviewCall = %+v, want %+v", preview, want)
+	}
+	if preview.Complete() {
+		t.Error("Complete() = true with a missing required parameter")
+	}
+	if len(partial) != 2 {
+		t.Errorf("PreviewCall modified partialArgs: %v", partial)
+	}
+
+	preview, err = store.PreviewCall("gh:search", map[string]any{"repo": "a/b", "q": "x"})
+	if err != nil || !preview.Complete() {
+		t.Errorf("PreviewCall = %+v, %v; want complete", preview, err)
+	}
+
+	if _, err := store.PreviewCall("gh:missing", nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("PreviewCall(unknown) error = %v, want ErrNotFound", err)
+	}
+	mustRegisterDoc(t, store, "gh:docsonly", DocEntry{Summary: "Docs only"})
+	if _, err := store.PreviewCall("gh:docsonly", nil); !errors.Is(err, ErrNoTool) {
+		t.Errorf("PreviewCall(docs only) error = %v, want ErrNoTool", err)
+	}
+}
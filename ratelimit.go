@@ -0,0 +1,85 @@
+package tooldocs
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrThrottled is returned by registration APIs when the caller's source
+// has exceeded StoreOptions.WriteRateLimit.
+var ErrThrottled = errors.New("write rate limit exceeded")
+
+// RateLimit configures token-bucket write-rate limiting.
+type RateLimit struct {
+	// PerSecond is the sustained number of writes allowed per second per key.
+	PerSecond float64
+
+	// Burst is the maximum number of writes allowed in a burst per key.
+	// Values below 1 are treated as 1.
+	Burst int
+}
+
+// writeLimiter is a keyed token-bucket limiter. Each key (a DocEntry.Source
+// or ImportOptions.Source; "" for unattributed writes) has its own bucket.
+type writeLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newWriteLimiter returns a limiter for cfg, or nil if cfg disables limiting.
+func newWriteLimiter(cfg *RateLimit) *writeLimiter {
+	if cfg == nil || cfg.PerSecond <= 0 {
+		return nil
+	}
+	burst := cfg.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	return &writeLimiter{
+		rate:    cfg.PerSecond,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// allow consumes a token for key.
+//
+// Returns ErrThrottled if the key's bucket is empty.
+func (l *writeLimiter) allow(key string) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.buckets[key]
+	if b == nil {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return fmt.Errorf("%w: source %q", ErrThrottled, key)
+	}
+	b.tokens--
+	return nil
+}
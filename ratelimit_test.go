@@ -0,0 +1,43 @@
+package tooldocs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWriteRateLimit(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{
+		WriteRateLimit: &RateLimit{PerSecond: 1, Burst: 2},
+	})
+	now := time.Unix(0, 0)
+	store.limiter.now = func() time.Time { return now }
+
+	sync := DocEntry{Summary: "synced", Source: "mirror:upstream"}
+	mustRegisterDoc(t, store, "ns:a", sync)
+	mustRegisterDoc(t, store, "ns:b", sync)
+	if err := store.RegisterDoc("ns:c", sync); !errors.Is(err, ErrThrottled) {
+		t.Fatalf("RegisterDoc error = %v, want ErrThrottled", err)
+	}
+
+	// Other sources have their own budget
+	mustRegisterDoc(t, store, "ns:c", DocEntry{Summary: "manual", Source: "manual"})
+
+	// Tokens refill over time
+	now = now.Add(time.Second)
+	mustRegisterDoc(t, store, "ns:d", sync)
+	if err := store.RegisterDoc("ns:e", sync); !errors.Is(err, ErrThrottled) {
+		t.Fatalf("RegisterDoc error = %v, want ErrThrottled", err)
+	}
+
+	if _, err := store.Import(map[string]DocEntry{"ns:f": {}}, ImportOptions{Source: "mirror:upstream"}); !errors.Is(err, ErrThrottled) {
+		t.Errorf("Import error = %v, want ErrThrottled", err)
+	}
+}
+
+func TestWriteRateLimit_Disabled(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	for i := 0; i < 100; i++ {
+		mustRegisterExamples(t, store, "ns:a", []ToolExample{{Title: "ex"}})
+	}
+}
@@ -0,0 +1,116 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jonwraymond/toolindex"
+)
+
+// ErrNoToolSource is returned by Reconcile when the store has neither an
+// Index nor a ToolResolver, so no doc could be confirmed live.
+var ErrNoToolSource = errors.New("no index or tool resolver configured")
+
+// ReconcileOptions configures Reconcile.
+type ReconcileOptions struct {
+	// Prune removes orphaned docs. Frozen docs are reported but never pruned.
+	Prune bool
+}
+
+// ReconcileReport lists docs whose tools no longer exist.
+type ReconcileReport struct {
+	// Checked is the number of documented tools examined.
+	Checked int `json:"checked"`
+
+	// Orphaned lists doc IDs whose tools resolve via neither the index nor
+	// the resolver, sorted.
+	Orphaned []string `json:"orphaned,omitempty"`
+
+	// Pruned lists the orphaned IDs that were removed (Prune only).
+	Pruned []string `json:"pruned,omitempty"`
+
+	// Errors maps doc IDs to lookup errors. These tools could not be
+	// classified and are never pruned.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// Reconcile reports documentation orphaned by deleted tools: doc records
+// whose IDs resolve via neither the index nor the ToolResolver. With
+// opts.Prune, orphaned records that are not frozen are removed.
+//
+// A tool counts as missing only on a definitive answer: the index returning
+// toolindex.ErrNotFound (or no index configured) and the resolver returning
+// nil or an error wrapping ErrNotFound (or no resolver configured). Any
+// other lookup error is reported in Errors and leaves the doc in place, so
+// a backend outage cannot wipe the catalog.
+//
+// Returns ErrNoToolSource if neither an index nor a resolver is configured,
+// or the report and ErrPersist if StoreOptions.Persister fails for a pruned
+// doc.
+func (s *InMemoryStore) Reconcile(opts ReconcileOptions) (ReconcileReport, error) {
+	if s.index == nil && !s.hasResolver() {
+		return ReconcileReport{}, ErrNoToolSource
+	}
+
+	var report ReconcileReport
+	for _, id := range s.DocIDs() {
+		report.Checked++
+		live, err := s.toolExists(id)
+		switch {
+		case err != nil:
+			if report.Errors == nil {
+				report.Errors = make(map[string]string)
+			}
+			report.Errors[id] = err.Error()
+		case !live:
+			report.Orphaned = append(report.Orphaned, id)
+		}
+	}
+
+	if !opts.Prune || len(report.Orphaned) == 0 {
+		return report, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return report, err
+	}
+	var errs []error
+	for _, id := range report.Orphaned {
+		record := s.docs[id]
+		if record == nil || record.frozen {
+			continue
+		}
+		s.dropped(id)
+		report.Pruned = append(report.Pruned, id)
+		errs = append(errs, s.deleted(id))
+	}
+	return report, errors.Join(errs...)
+}
+
+// toolExists reports whether id resolves via the index or resolver. It
+// returns an error when a backend fails without a definitive not-found.
+func (s *InMemoryStore) toolExists(id string) (bool, error) {
+	if s.index != nil {
+		_, err := s.lookupIndex(id)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, toolindex.ErrNotFound) {
+			return false, fmt.Errorf("index: %w", err)
+		}
+	}
+	if s.hasResolver() {
+		tool, err := s.callResolver(context.Background(), id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("resolver: %w", err)
+		}
+		return tool != nil, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,88 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolindex"
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestReconcile(t *testing.T) {
+	idx := toolindex.NewInMemoryIndex()
+	backend := toolmodel.ToolBackend{
+		Kind:  toolmodel.BackendKindLocal,
+		Local: &toolmodel.LocalBackend{Name: "handler"},
+	}
+	live := makeToolWithSchema("live", "ns", "Live tool", map[string]any{"type": "object"})
+	if err := idx.RegisterTool(live, backend); err != nil {
+		t.Fatalf("RegisterTool failed: %v", err)
+	}
+
+	store := NewInMemoryStore(StoreOptions{
+		Index: idx,
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			switch id {
+			case "ext:resolved":
+				tool := makeToolWithSchema("resolved", "ext", "Resolved", map[string]any{"type": "object"})
+				return &tool, nil
+			case "ext:flaky":
+				return nil, errors.New("timeout")
+			case "ext:gone":
+				return nil, ErrNotFound
+			}
+			return nil, nil
+		},
+	})
+	for _, id := range []string{"ns:live", "ext:resolved", "ext:flaky", "ext:gone", "ns:deleted", "ns:frozen"} {
+		mustRegisterDoc(t, store, id, DocEntry{Summary: id})
+	}
+	if err := store.Freeze("ns:frozen"); err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+
+	report, err := store.Reconcile(ReconcileOptions{})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if report.Checked != 6 {
+		t.Errorf("Checked = %d, want 6", report.Checked)
+	}
+	wantOrphans := []string{"ext:gone", "ns:deleted", "ns:frozen"}
+	if !reflect.DeepEqual(report.Orphaned, wantOrphans) {
+		t.Errorf("Orphaned = %v, want %v", report.Orphaned, wantOrphans)
+	}
+	if report.Pruned != nil {
+		t.Errorf("Pruned = %v without Prune", report.Pruned)
+	}
+	if _, ok := report.Errors["ext:flaky"]; !ok || len(report.Errors) != 1 {
+		t.Errorf("Errors = %v, want ext:flaky", report.Errors)
+	}
+	if len(store.DocIDs()) != 6 {
+		t.Error("report-only reconcile must not remove docs")
+	}
+
+	report, err = store.Reconcile(ReconcileOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if want := []string{"ext:gone", "ns:deleted"}; !reflect.DeepEqual(report.Pruned, want) {
+		t.Errorf("Pruned = %v, want %v", report.Pruned, want)
+	}
+	if want := []string{"ext:flaky", "ext:resolved", "ns:frozen", "ns:live"}; !reflect.DeepEqual(store.DocIDs(), want) {
+		t.Errorf("remaining = %v, want %v", store.DocIDs(), want)
+	}
+}
+
+func TestReconcile_NoToolSource(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "a"})
+
+	if _, err := store.Reconcile(ReconcileOptions{Prune: true}); !errors.Is(err, ErrNoToolSource) {
+		t.Errorf("err = %v, want ErrNoToolSource", err)
+	}
+	if len(store.DocIDs()) != 1 {
+		t.Error("docs must be untouched")
+	}
+}
@@ -0,0 +1,110 @@
+package tooldocs
+
+import "slices"
+
+// ExampleFormat selects how renderers present a tool's examples.
+type ExampleFormat string
+
+const (
+	// ExampleFormatJSON shows each example's Args as an indented JSON
+	// object. It is the default.
+	ExampleFormatJSON ExampleFormat = "json"
+
+	// ExampleFormatCall shows each example as a complete JSON-RPC
+	// tools/call request (see ExampleCallRequests).
+	ExampleFormatCall ExampleFormat = "call"
+
+	// ExampleFormatInline shows each example's Args as one-line JSON, for
+	// tools with many small examples.
+	ExampleFormatInline ExampleFormat = "inline"
+)
+
+// Section names for RenderHints.SectionOrder, in default page order.
+const (
+	SectionParameters    = "parameters"
+	SectionEffects       = "effects"
+	SectionRetries       = "retries"
+	SectionConcurrency   = "concurrency"
+	SectionNotes         = "notes"
+	SectionCustom        = "sections" // DocEntry.Sections, each as its own heading
+	SectionPrerequisites = "prerequisites"
+	SectionDependencies  = "dependencies"
+	SectionExamples      = "examples"
+	SectionReferences    = "references"
+)
+
+var defaultSectionOrder = []string{
+	SectionParameters,
+	SectionEffects,
+	SectionRetries,
+	SectionConcurrency,
+	SectionNotes,
+	SectionCustom,
+	SectionPrerequisites,
+	SectionDependencies,
+	SectionExamples,
+	SectionReferences,
+}
+
+// RenderHints are per-tool presentation preferences for rendered docs
+// (exporter pages and similar), for the few tools that need special
+// presentation. They do not change what DescribeTool returns.
+type RenderHints struct {
+	// ExampleFormat selects the example presentation. Empty or unknown
+	// values mean ExampleFormatJSON.
+	ExampleFormat ExampleFormat `json:"exampleFormat,omitempty"`
+
+	// OmitSchema drops the parameter table derived from the input schema,
+	// e.g. for tools whose generated schema is misleading or huge.
+	OmitSchema bool `json:"omitSchema,omitempty"`
+
+	// SectionOrder lists section names (Section*) to render first, in
+	// this order. Unlisted sections follow in default order; unknown names
+	// are ignored.
+	SectionOrder []string `json:"sectionOrder,omitempty"`
+}
+
+// Sections returns the effective section order: SectionOrder's known,
+// distinct names followed by the remaining sections in default order. It
+// is safe to call on a nil *RenderHints.
+func (h *RenderHints) Sections() []string {
+	if h == nil || len(h.SectionOrder) == 0 {
+		return slices.Clone(defaultSectionOrder)
+	}
+	order := make([]string, 0, len(defaultSectionOrder))
+	for _, name := range h.SectionOrder {
+		if slices.Contains(defaultSectionOrder, name) && !slices.Contains(order, name) {
+			order = append(order, name)
+		}
+	}
+	for _, name := range defaultSectionOrder {
+		if !slices.Contains(order, name) {
+			order = append(order, name)
+		}
+	}
+	return order
+}
+
+// Format returns the effective example format. It is safe to call on a
+// nil *RenderHints.
+func (h *RenderHints) Format() ExampleFormat {
+	if h == nil {
+		return ExampleFormatJSON
+	}
+	switch h.ExampleFormat {
+	case ExampleFormatCall, ExampleFormatInline:
+		return h.ExampleFormat
+	default:
+		return ExampleFormatJSON
+	}
+}
+
+// copyRenderHints returns a deep copy of h.
+func copyRenderHints(h *RenderHints) *RenderHints {
+	if h == nil {
+		return nil
+	}
+	c := *h
+	c.SectionOrder = slices.Clone(h.SectionOrder)
+	return &c
+}
@@ -0,0 +1,49 @@
+package tooldocs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenderHints(t *testing.T) {
+	var nilHints *RenderHints
+	if got := nilHints.Sections(); !reflect.DeepEqual(got, defaultSectionOrder) {
+		t.Errorf("nil Sections = %v, want default order", got)
+	}
+	if got := nilHints.Format(); got != ExampleFormatJSON {
+		t.Errorf("nil Format = %q, want json", got)
+	}
+
+	hints := &RenderHints{
+		ExampleFormat: "yaml",
+		SectionOrder:  []string{SectionExamples, "unknown", SectionNotes, SectionExamples},
+	}
+	want := []string{
+		SectionExamples, SectionNotes,
+		SectionParameters, SectionEffects, SectionRetries, SectionConcurrency,
+		SectionCustom, SectionPrerequisites, SectionDependencies, SectionReferences,
+	}
+	if got := hints.Sections(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sections = %v, want %v", got, want)
+	}
+	if got := hints.Format(); got != ExampleFormatJSON {
+		t.Errorf("Format(unknown) = %q, want json", got)
+	}
+}
+
+func TestRenderHints_StoredAndMerged(t *testing.T) {
+	hints := &RenderHints{ExampleFormat: ExampleFormatCall, SectionOrder: []string{SectionExamples}}
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Summary: "Search", Render: hints})
+	hints.SectionOrder[0] = SectionNotes
+
+	stored, _ := store.GetDocEntry("gh:search")
+	if got := stored.Entry.Render; got == nil || got.ExampleFormat != ExampleFormatCall || got.SectionOrder[0] != SectionExamples {
+		t.Errorf("stored Render = %+v, want a copy of the registered hints", got)
+	}
+
+	merged := MergeDocEntries(stored.Entry, DocEntry{Render: &RenderHints{OmitSchema: true}}, MergePolicy{})
+	if merged.Render == nil || !merged.Render.OmitSchema || merged.Render.ExampleFormat != "" {
+		t.Errorf("merged Render = %+v, want overlay hints", merged.Render)
+	}
+}
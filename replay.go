@@ -0,0 +1,165 @@
+package tooldocs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxResponseSummary caps the length of ExampleReplay.Response.
+const maxResponseSummary = 200
+
+// ToolInvoker executes a tools/call for ReplayExamples. id is the tool ID;
+// params.Name is the tool name part of the ID. A panic is recovered and
+// reported as a failed replay.
+type ToolInvoker interface {
+	InvokeTool(ctx context.Context, id string, params *mcp.CallToolParams) (*mcp.CallToolResult, error)
+}
+
+// ToolInvokerFunc adapts a function to a ToolInvoker.
+type ToolInvokerFunc func(ctx context.Context, id string, params *mcp.CallToolParams) (*mcp.CallToolResult, error)
+
+// InvokeTool calls f.
+func (f ToolInvokerFunc) InvokeTool(ctx context.Context, id string, params *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+	return f(ctx, id, params)
+}
+
+// ExampleReplay is the outcome of replaying one example.
+type ExampleReplay struct {
+	// Example is the example ID, or its title when the ID is empty.
+	Example string `json:"example"`
+
+	// Passed reports whether the call returned without error and the result
+	// did not set IsError.
+	Passed bool `json:"passed"`
+
+	// Skipped reports that the example was not run because its Requires
+	// are not met by StoreOptions.Environment. Error lists them.
+	Skipped bool `json:"skipped,omitempty"`
+
+	// Error is the invoker error or, for IsError results, the response text.
+	Error string `json:"error,omitempty"`
+
+	// Response summarizes the result: its text content, or its structured
+	// content as JSON, truncated to 200 bytes.
+	Response string `json:"response,omitempty"`
+
+	// Duration is how long the call took.
+	Duration time.Duration `json:"duration"`
+}
+
+// ReplayReport summarizes ReplayExamples for one tool.
+type ReplayReport struct {
+	ID      string          `json:"id"`
+	Results []ExampleReplay `json:"results"`
+	Passed  int             `json:"passed"`
+	Failed  int             `json:"failed"`
+	Skipped int             `json:"skipped,omitempty"`
+}
+
+// ReplayExamples executes each of a tool's registered examples through
+// invoker, in order, and reports pass/fail with a response summary, turning
+// documentation examples into a lightweight contract-test suite.
+//
+// All registered examples are replayed regardless of StoreOptions.MaxExamples,
+// except those whose Requires are not met by StoreOptions.Environment, which
+// are reported as skipped. Placeholders in example Args are replaced from
+// the values carried by WithPlaceholderValues, if any.
+// Replay stops early if ctx is canceled, returning the partial report and
+// ctx.Err(). Returns ErrNotFound if the tool has no registered documentation
+// and an error if id is not a valid tool ID.
+func (s *InMemoryStore) ReplayExamples(ctx context.Context, id string, invoker ToolInvoker) (ReplayReport, error) {
+	_, name, err := toolmodel.ParseToolID(id)
+	if err != nil {
+		return ReplayReport{}, fmt.Errorf("%w: %s", err, id)
+	}
+	stored, err := s.GetDocEntry(id)
+	if err != nil {
+		return ReplayReport{}, err
+	}
+
+	report := ReplayReport{ID: id, Results: make([]ExampleReplay, 0, len(stored.Entry.Examples))}
+	for _, ex := range stored.Entry.Examples {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if unmet := s.unmetRequirements(ex); len(unmet) > 0 {
+			report.Skipped++
+			report.Results = append(report.Results, ExampleReplay{
+				Example: exampleName(ex),
+				Skipped: true,
+				Error:   "unmet requirements: " + strings.Join(unmet, ", "),
+			})
+			continue
+		}
+		if values := PlaceholderValues(ctx); values != nil {
+			ex.Args = SubstitutePlaceholders(ex.Args, values)
+		}
+		result := replayExample(ctx, id, name, ex, invoker)
+		if result.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+// replayExample invokes a single example and classifies the outcome.
+func replayExample(ctx context.Context, id, name string, ex ToolExample, invoker ToolInvoker) ExampleReplay {
+	replay := ExampleReplay{Example: exampleName(ex)}
+
+	start := time.Now()
+	res, err := callHook(HookToolInvoker, func() (*mcp.CallToolResult, error) {
+		return invoker.InvokeTool(ctx, id, ExampleCallParams(name, ex))
+	})
+	replay.Duration = time.Since(start)
+
+	switch {
+	case err != nil:
+		replay.Error = err.Error()
+	case res == nil:
+		replay.Error = "invoker returned no result"
+	default:
+		replay.Response = summarizeResult(res)
+		if res.IsError {
+			replay.Error = replay.Response
+		} else {
+			replay.Passed = true
+		}
+	}
+	return replay
+}
+
+// exampleName identifies an example in reports: its ID, or its title when
+// the ID is empty.
+func exampleName(ex ToolExample) string {
+	if ex.ID != "" {
+		return ex.ID
+	}
+	return ex.Title
+}
+
+// summarizeResult renders a call result's text content, falling back to its
+// structured content as JSON, truncated to maxResponseSummary.
+func summarizeResult(res *mcp.CallToolResult) string {
+	var parts []string
+	for _, c := range res.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	summary := strings.Join(parts, "\n")
+	if summary == "" && res.StructuredContent != nil {
+		if data, err := json.Marshal(res.StructuredContent); err == nil {
+			summary = string(data)
+		}
+	}
+	return truncateString(summary, maxResponseSummary)
+}
@@ -0,0 +1,91 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestReplayExamples(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{MaxExamples: 1})
+	mustRegisterDoc(t, store, "github:get_repo", DocEntry{
+		Summary: "Get a repository",
+		Examples: []ToolExample{
+			{ID: "ok", Title: "Existing repo", Args: map[string]any{"repo": "a/b"}},
+			{Title: "Missing repo", Args: map[string]any{"repo": "nope/nope"}},
+			{Title: "Transport failure", Args: map[string]any{"repo": "x/y"}},
+			{Title: "Structured", Args: map[string]any{"repo": "s/s"}},
+		},
+	})
+
+	var names []string
+	invoker := ToolInvokerFunc(func(_ context.Context, id string, params *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+		if id != "github:get_repo" {
+			t.Errorf("id = %q", id)
+		}
+		names = append(names, params.Name)
+		switch params.Arguments.(map[string]any)["repo"] {
+		case "a/b":
+			return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: strings.Repeat("x", 300)}}}, nil
+		case "nope/nope":
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not found"}}}, nil
+		case "s/s":
+			return &mcp.CallToolResult{StructuredContent: map[string]any{"stars": 3}}, nil
+		default:
+			return nil, errors.New("connection reset")
+		}
+	})
+
+	report, err := store.ReplayExamples(context.Background(), "github:get_repo", invoker)
+	if err != nil {
+		t.Fatalf("ReplayExamples failed: %v", err)
+	}
+	if len(report.Results) != 4 {
+		t.Fatalf("results = %d, want all 4 examples despite MaxExamples", len(report.Results))
+	}
+	if report.Passed != 2 || report.Failed != 2 {
+		t.Errorf("passed/failed = %d/%d, want 2/2", report.Passed, report.Failed)
+	}
+	if names[0] != "get_repo" {
+		t.Errorf("params.Name = %q, want tool name", names[0])
+	}
+
+	ok, missing, transport, structured := report.Results[0], report.Results[1], report.Results[2], report.Results[3]
+	if ok.Example != "ok" || !ok.Passed || len(ok.Response) != maxResponseSummary {
+		t.Errorf("ok = %+v", ok)
+	}
+	if missing.Example != "Missing repo" || missing.Passed || missing.Error != "not found" {
+		t.Errorf("missing = %+v", missing)
+	}
+	if transport.Passed || transport.Error != "connection reset" {
+		t.Errorf("transport = %+v", transport)
+	}
+	if !structured.Passed || structured.Response != `{"stars":3}` {
+		t.Errorf("structured = %+v", structured)
+	}
+}
+
+func TestReplayExamples_Errors(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	invoker := ToolInvokerFunc(func(context.Context, string, *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{}, nil
+	})
+
+	if _, err := store.ReplayExamples(context.Background(), "ns:missing", invoker); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+	if _, err := store.ReplayExamples(context.Background(), "a:b:c", invoker); err == nil {
+		t.Error("expected error for invalid tool ID")
+	}
+
+	mustRegisterDoc(t, store, "ns:tool", DocEntry{Examples: []ToolExample{{Title: "a"}, {Title: "b"}}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err := store.ReplayExamples(ctx, "ns:tool", invoker)
+	if !errors.Is(err, context.Canceled) || len(report.Results) != 0 {
+		t.Errorf("canceled replay = %+v, %v", report, err)
+	}
+}
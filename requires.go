@@ -0,0 +1,39 @@
+package tooldocs
+
+// environmentSet converts StoreOptions.Environment to a lookup set.
+func environmentSet(env []string) map[string]bool {
+	if len(env) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(env))
+	for _, e := range env {
+		set[e] = true
+	}
+	return set
+}
+
+// unmetRequirements returns the requirements of ex not satisfied by the
+// store's Environment, in declaration order.
+func (s *InMemoryStore) unmetRequirements(ex ToolExample) []string {
+	var unmet []string
+	for _, req := range ex.Requires {
+		if !s.environment[req] {
+			unmet = append(unmet, req)
+		}
+	}
+	return unmet
+}
+
+// availableExamples filters out examples whose requirements are not met.
+func (s *InMemoryStore) availableExamples(examples []ToolExample) []ToolExample {
+	if examples == nil {
+		return nil
+	}
+	kept := make([]ToolExample, 0, len(examples))
+	for _, ex := range examples {
+		if len(s.unmetRequirements(ex)) == 0 {
+			kept = append(kept, ex)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,124 @@
+package tooldocs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func requiresEntry() DocEntry {
+	return DocEntry{
+		Summary: "Deploy a service",
+		Examples: []ToolExample{
+			{ID: "dry-run", Title: "Dry run", Args: map[string]any{"dry": true}},
+			{ID: "sandbox", Title: "Sandbox deploy", Args: map[string]any{"env": "sbx"}, Requires: []string{RequireSandbox}},
+			{ID: "beta", Title: "Canary", Args: map[string]any{"canary": true},
+				Requires: []string{RequireSandbox, RequireFeatureFlagPrefix + "canary"}},
+		},
+	}
+}
+
+func exampleIDs(examples []ToolExample) []string {
+	ids := make([]string, len(examples))
+	for i, ex := range examples {
+		ids[i] = ex.ID
+	}
+	return ids
+}
+
+func TestRequires_FiltersExamples(t *testing.T) {
+	tests := []struct {
+		name string
+		env  []string
+		want []string
+	}{
+		{"no environment", nil, []string{"dry-run"}},
+		{"sandbox", []string{RequireSandbox}, []string{"dry-run", "sandbox"}},
+		{"sandbox with flag", []string{RequireSandbox, "feature-flag:canary"}, []string{"dry-run", "sandbox", "beta"}},
+		{"flag only", []string{"feature-flag:canary"}, []string{"dry-run"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewInMemoryStore(StoreOptions{Environment: tt.env})
+			mustRegisterDoc(t, store, "ops:deploy", requiresEntry())
+
+			examples, err := store.ListExamples("ops:deploy", 10)
+			if err != nil {
+				t.Fatalf("ListExamples failed: %v", err)
+			}
+			if got := exampleIDs(examples); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListExamples = %v, want %v", got, tt.want)
+			}
+
+			// Filtering happens before the MaxExamples cap.
+			capped := NewInMemoryStore(StoreOptions{Environment: tt.env, MaxExamples: 1})
+			mustRegisterDoc(t, capped, "ops:deploy", DocEntry{Examples: requiresEntry().Examples[1:]})
+			examples, _ = capped.ListExamples("ops:deploy", 10)
+			if len(tt.want) > 1 && len(examples) != 1 {
+				t.Errorf("capped ListExamples = %v, want 1 available example", exampleIDs(examples))
+			}
+
+			// GetDocEntry is unfiltered and preserves Requires.
+			stored, _ := store.GetDocEntry("ops:deploy")
+			if len(stored.Entry.Examples) != 3 || !reflect.DeepEqual(stored.Entry.Examples[1].Requires, []string{RequireSandbox}) {
+				t.Errorf("GetDocEntry examples = %+v", stored.Entry.Examples)
+			}
+		})
+	}
+}
+
+func TestRequires_DescribeToolFull(t *testing.T) {
+	tool := makeToolWithSchema("deploy", "ops", "Deploy", map[string]any{"type": "object"})
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(string) (*toolmodel.Tool, error) { return &tool, nil },
+	})
+	mustRegisterDoc(t, store, "ops:deploy", requiresEntry())
+
+	doc, err := store.DescribeTool("ops:deploy", DetailFull)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if got := exampleIDs(doc.Examples); !reflect.DeepEqual(got, []string{"dry-run"}) {
+		t.Errorf("examples = %v, want only unrestricted examples", got)
+	}
+}
+
+func TestRequires_ReplaySkipsUnmet(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{Environment: []string{RequireSandbox}})
+	mustRegisterDoc(t, store, "ops:deploy", requiresEntry())
+
+	var called []string
+	invoker := ToolInvokerFunc(func(_ context.Context, _ string, params *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+		called = append(called, params.Name)
+		return &mcp.CallToolResult{}, nil
+	})
+	report, err := store.ReplayExamples(context.Background(), "ops:deploy", invoker)
+	if err != nil {
+		t.Fatalf("ReplayExamples failed: %v", err)
+	}
+	if len(called) != 2 || report.Passed != 2 || report.Skipped != 1 || report.Failed != 0 {
+		t.Errorf("report = %+v, calls = %d", report, len(called))
+	}
+	skipped := report.Results[2]
+	if !skipped.Skipped || skipped.Passed || skipped.Error != "unmet requirements: feature-flag:canary" {
+		t.Errorf("skipped result = %+v", skipped)
+	}
+}
+
+func TestRequires_ChangeDetection(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ops:deploy", requiresEntry())
+
+	updated := requiresEntry()
+	updated.Examples[0].Requires = []string{"needs-credential:aws"}
+	report, err := store.Import(map[string]DocEntry{"ops:deploy": updated}, ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if fields := report.Changes[0].Fields; !reflect.DeepEqual(fields, []string{"examples"}) {
+		t.Errorf("Fields = %v, want [examples]", fields)
+	}
+}
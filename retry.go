@@ -0,0 +1,83 @@
+package tooldocs
+
+import (
+	"strings"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// RetryPolicy tells orchestration layers how to retry a failed call of a
+// tool. It is returned at DetailSchema and DetailFull.
+type RetryPolicy struct {
+	// SafeToRetry reports whether a failed or timed-out call may be retried
+	// without risking duplicate effects.
+	SafeToRetry bool `json:"safeToRetry"`
+
+	// MaxAttempts is the suggested total number of attempts, including the
+	// first. Zero means no recommendation.
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+
+	// Backoff describes the suggested delay between attempts (e.g.
+	// "exponential from 1s, max 30s; honor Retry-After").
+	// Maximum length: MaxResultHintLen (200 chars).
+	Backoff string `json:"backoff,omitempty"`
+
+	// IdempotencyKey names the input parameter that carries a
+	// caller-chosen idempotency key. Reusing the key across attempts makes
+	// retries safe even when SafeToRetry is false for keyless calls.
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+}
+
+// String renders the policy on one line, e.g.
+// "safe to retry; max 3 attempts; backoff: exponential from 1s;
+// idempotency key: request_id".
+func (p RetryPolicy) String() string {
+	return p.describe(nil)
+}
+
+// describe implements String, translating with c.
+func (p RetryPolicy) describe(c Catalog) string {
+	parts := []string{c.Text("not safe to retry")}
+	if p.SafeToRetry {
+		parts[0] = c.Text("safe to retry")
+	}
+	if p.MaxAttempts > 0 {
+		parts = append(parts, c.Sprintf("max %d attempts", p.MaxAttempts))
+	}
+	if p.Backoff != "" {
+		parts = append(parts, c.Sprintf("backoff: %s", p.Backoff))
+	}
+	if p.IdempotencyKey != "" {
+		parts = append(parts, c.Sprintf("idempotency key: %s", p.IdempotencyKey))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// copyRetryPolicy returns a copy of p, or nil.
+func copyRetryPolicy(p *RetryPolicy) *RetryPolicy {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}
+
+// truncateRetryPolicy returns a copy of p with Backoff cut to
+// MaxResultHintLen.
+func truncateRetryPolicy(p *RetryPolicy) *RetryPolicy {
+	c := copyRetryPolicy(p)
+	if c != nil {
+		c.Backoff = truncateString(c.Backoff, MaxResultHintLen)
+	}
+	return c
+}
+
+// isIdempotent reports whether the docs or the tool's MCP annotations
+// (idempotent or read-only hint) mark the tool idempotent.
+func isIdempotent(entry DocEntry, tool *toolmodel.Tool) bool {
+	if entry.Idempotent {
+		return true
+	}
+	a := tool.Annotations
+	return a != nil && (a.IdempotentHint || a.ReadOnlyHint)
+}
@@ -0,0 +1,116 @@
+package tooldocs
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestRetryPolicy_SchemaLevel(t *testing.T) {
+	tool := makeToolWithSchema("charge", "billing", "Charge a card", map[string]any{"type": "object"})
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) { return &tool, nil },
+	})
+	policy := &RetryPolicy{MaxAttempts: 3, Backoff: "exponential from 1s", IdempotencyKey: "request_id"}
+	mustRegisterDoc(t, store, "billing:charge", DocEntry{Summary: "Charge", Idempotent: true, RetryPolicy: policy})
+
+	doc, err := store.DescribeTool("billing:charge", DetailSchema)
+	if err != nil {
+		t.Fatalf("DescribeTool failed: %v", err)
+	}
+	if !doc.Idempotent {
+		t.Error("Idempotent = false, want true")
+	}
+	if !reflect.DeepEqual(doc.RetryPolicy, policy) {
+		t.Errorf("RetryPolicy = %+v, want %+v", doc.RetryPolicy, policy)
+	}
+
+	// The stored policy is detached from the caller's and the returned value.
+	policy.MaxAttempts = 9
+	doc.RetryPolicy.Backoff = "mutated"
+	again, _ := store.DescribeTool("billing:charge", DetailFull)
+	if again.RetryPolicy.MaxAttempts != 3 || again.RetryPolicy.Backoff != "exponential from 1s" {
+		t.Errorf("stored policy mutated: %+v", again.RetryPolicy)
+	}
+}
+
+func TestIdempotent_FromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations *mcp.ToolAnnotations
+		want        bool
+	}{
+		{"none", nil, false},
+		{"idempotent hint", &mcp.ToolAnnotations{IdempotentHint: true}, true},
+		{"read only", &mcp.ToolAnnotations{ReadOnlyHint: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := makeToolWithSchema("get", "ns", "Get", map[string]any{"type": "object"})
+			tool.Annotations = tt.annotations
+			store := NewInMemoryStore(StoreOptions{
+				ToolResolver: func(id string) (*toolmodel.Tool, error) { return &tool, nil },
+			})
+			doc, err := store.DescribeTool("ns:get", DetailSchema)
+			if err != nil {
+				t.Fatalf("DescribeTool failed: %v", err)
+			}
+			if doc.Idempotent != tt.want {
+				t.Errorf("Idempotent = %v, want %v", doc.Idempotent, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_TruncateMergeImport(t *testing.T) {
+	long := DocEntry{RetryPolicy: &RetryPolicy{Backoff: strings.Repeat("x", MaxResultHintLen+1)}}
+	if got := len(long.ValidateAndTruncate().RetryPolicy.Backoff); got != MaxResultHintLen {
+		t.Errorf("backoff length = %d, want %d", got, MaxResultHintLen)
+	}
+
+	base := DocEntry{Idempotent: true, RetryPolicy: &RetryPolicy{MaxAttempts: 2}}
+	merged := MergeDocEntries(base, DocEntry{}, MergePolicy{})
+	if !merged.Idempotent || merged.RetryPolicy == nil || merged.RetryPolicy == base.RetryPolicy {
+		t.Errorf("merge with empty overlay = %+v, want copied base policy", merged)
+	}
+	merged = MergeDocEntries(base, DocEntry{RetryPolicy: &RetryPolicy{SafeToRetry: true}}, MergePolicy{})
+	if !merged.RetryPolicy.SafeToRetry || merged.RetryPolicy.MaxAttempts != 0 {
+		t.Errorf("overlay policy not applied: %+v", merged.RetryPolicy)
+	}
+
+	fields := entryFieldChanges(DocEntry{}, base)
+	if want := []string{"idempotent", "retryPolicy"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("entryFieldChanges = %v, want %v", fields, want)
+	}
+}
+
+func TestRetryPolicy_String(t *testing.T) {
+	p := RetryPolicy{SafeToRetry: true, MaxAttempts: 3, Backoff: "exponential", IdempotencyKey: "request_id"}
+	want := "safe to retry; max 3 attempts; backoff: exponential; idempotency key: request_id"
+	if got := p.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := (RetryPolicy{}).String(); got != "not safe to retry" {
+		t.Errorf("String() = %q, want %q", got, "not safe to retry")
+	}
+}
+
+func TestMarshalToolDoc_RetryPolicySnakeCase(t *testing.T) {
+	doc := ToolDoc{Summary: "s", RetryPolicy: &RetryPolicy{SafeToRetry: true, IdempotencyKey: "request_id"}}
+	data, err := MarshalToolDoc(doc, MarshalOptions{Naming: NamingSnakeCase})
+	if err != nil {
+		t.Fatalf("MarshalToolDoc failed: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	policy, _ := got["retry_policy"].(map[string]any)
+	if policy["safe_to_retry"] != true || policy["idempotency_key"] != "request_id" {
+		t.Errorf("retry_policy = %v", got["retry_policy"])
+	}
+}
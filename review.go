@@ -0,0 +1,212 @@
+package tooldocs
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNoSubmission is returned by Approve and Reject when a tool has no
+// pending review submission.
+var ErrNoSubmission = errors.New("no pending submission")
+
+// ReviewStatus is the state of a review submission.
+type ReviewStatus string
+
+// Review statuses.
+const (
+	ReviewPending  ReviewStatus = "pending"
+	ReviewApproved ReviewStatus = "approved"
+	ReviewRejected ReviewStatus = "rejected"
+)
+
+// Submission is a draft DocEntry awaiting (or having received) human review.
+// Drafts are not visible through DescribeTool or ListExamples; approval
+// publishes them as the tool's documentation.
+type Submission struct {
+	// ID is the tool ID.
+	ID string `json:"id"`
+
+	// Entry is the proposed documentation, validated and truncated as by
+	// RegisterDoc.
+	Entry DocEntry `json:"entry"`
+
+	// Submitter identifies who (or which pipeline) proposed the entry.
+	Submitter string `json:"submitter,omitempty"`
+
+	// SubmittedAt is when the entry was submitted.
+	SubmittedAt time.Time `json:"submittedAt"`
+
+	// Status is the review outcome.
+	Status ReviewStatus `json:"status"`
+
+	// Reviewer and Comment are set by Approve or Reject.
+	Reviewer string `json:"reviewer,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+
+	// ReviewedAt is when the submission was approved or rejected.
+	ReviewedAt time.Time `json:"reviewedAt,omitzero"`
+
+	// ExampleChanges details how the draft changes examples already
+	// published under the same ID (see DiffExamples). It is computed at
+	// submission and again when the draft is approved.
+	ExampleChanges []ExampleDiff `json:"exampleChanges,omitempty"`
+}
+
+// reviewState holds pending drafts and decided submissions. Its lock is
+// always taken before the store lock.
+type reviewState struct {
+	mu      sync.Mutex
+	pending map[string]Submission
+	history map[string][]Submission
+}
+
+// SubmitForReview queues entry as a draft for id, replacing any draft
+// already pending for that tool. The entry is validated now so that cap
+// violations surface to the submitter rather than the reviewer.
+//
+// Returns ErrArgsTooLarge, ErrExampleLimit, ErrDuplicateExample, or
+// ErrSummaryStyle if the entry would be rejected by RegisterDoc, and
+// ErrThrottled if
+// WriteRateLimit is exceeded for entry.Source.
+func (s *InMemoryStore) SubmitForReview(id string, entry DocEntry, submitter string) error {
+	if err := s.limiter.allow(entry.Source); err != nil {
+		return err
+	}
+	if err := s.checkSummaryStyle(id, entry); err != nil {
+		return err
+	}
+	prepared, err := s.prepareRecord(entry)
+	if err != nil {
+		return err
+	}
+
+	s.review.mu.Lock()
+	defer s.review.mu.Unlock()
+	if s.review.pending == nil {
+		s.review.pending = make(map[string]Submission)
+	}
+	s.review.pending[id] = Submission{
+		ID:             id,
+		Entry:          prepared.entry(),
+		Submitter:      submitter,
+		SubmittedAt:    time.Now(),
+		Status:         ReviewPending,
+		ExampleChanges: s.publishedExampleChanges(id, prepared.examples),
+	}
+	return nil
+}
+
+// publishedExampleChanges diffs examples against those currently published
+// for id.
+func (s *InMemoryStore) publishedExampleChanges(id string, examples []ToolExample) []ExampleDiff {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record := s.docs[id]
+	if record == nil {
+		return nil
+	}
+	return DiffExamples(record.examples, examples)
+}
+
+// ListPending returns pending submissions, oldest first.
+func (s *InMemoryStore) ListPending() []Submission {
+	s.review.mu.Lock()
+	defer s.review.mu.Unlock()
+
+	subs := make([]Submission, 0, len(s.review.pending))
+	for _, sub := range s.review.pending {
+		subs = append(subs, copySubmission(sub))
+	}
+	sort.Slice(subs, func(i, j int) bool {
+		if !subs[i].SubmittedAt.Equal(subs[j].SubmittedAt) {
+			return subs[i].SubmittedAt.Before(subs[j].SubmittedAt)
+		}
+		return subs[i].ID < subs[j].ID
+	})
+	return subs
+}
+
+// Approve publishes the pending draft for id as its documentation, replacing
+// any existing doc, and records the reviewer and comment.
+//
+// Returns ErrNoSubmission if nothing is pending for id, or ErrFrozen if the
+// tool's documentation is frozen; the draft stays pending in that case.
+// Returns the approved submission and ErrPersist if StoreOptions.Persister
+// or ChangeLog fails.
+func (s *InMemoryStore) Approve(id, reviewer, comment string) (Submission, error) {
+	s.review.mu.Lock()
+	defer s.review.mu.Unlock()
+
+	sub, ok := s.review.pending[id]
+	if !ok {
+		return Submission{}, fmt.Errorf("%w: %s", ErrNoSubmission, id)
+	}
+	prepared, err := s.prepareRecord(sub.Entry)
+	if err != nil {
+		return Submission{}, err
+	}
+	sub.ExampleChanges = s.publishedExampleChanges(id, prepared.examples)
+	err = s.publish(ChangeApprove, reviewer, id, prepared)
+	if err != nil && !errors.Is(err, ErrPersist) {
+		return Submission{}, err
+	}
+	// A persist failure leaves the draft published, so it is decided anyway.
+	return s.review.decide(sub, ReviewApproved, reviewer, comment), err
+}
+
+// Reject discards the pending draft for id, recording the reviewer and
+// comment so the submitter can revise and resubmit.
+//
+// Returns ErrNoSubmission if nothing is pending for id.
+func (s *InMemoryStore) Reject(id, reviewer, comment string) (Submission, error) {
+	s.review.mu.Lock()
+	defer s.review.mu.Unlock()
+
+	sub, ok := s.review.pending[id]
+	if !ok {
+		return Submission{}, fmt.Errorf("%w: %s", ErrNoSubmission, id)
+	}
+	return s.review.decide(sub, ReviewRejected, reviewer, comment), nil
+}
+
+// ReviewHistory returns the approved and rejected submissions for id,
+// oldest first.
+func (s *InMemoryStore) ReviewHistory(id string) []Submission {
+	s.review.mu.Lock()
+	defer s.review.mu.Unlock()
+
+	history := make([]Submission, len(s.review.history[id]))
+	for i, sub := range s.review.history[id] {
+		history[i] = copySubmission(sub)
+	}
+	return history
+}
+
+// decide moves a pending submission into history. Callers hold r.mu.
+func (r *reviewState) decide(sub Submission, status ReviewStatus, reviewer, comment string) Submission {
+	sub.Status = status
+	sub.Reviewer = reviewer
+	sub.Comment = comment
+	sub.ReviewedAt = time.Now()
+
+	delete(r.pending, sub.ID)
+	if r.history == nil {
+		r.history = make(map[string][]Submission)
+	}
+	r.history[sub.ID] = append(r.history[sub.ID], sub)
+	return copySubmission(sub)
+}
+
+// copySubmission returns a copy of sub whose entry does not alias stored
+// slices or maps.
+func copySubmission(sub Submission) Submission {
+	sub.Entry.Examples = copyExamples(sub.Entry.Examples)
+	sub.Entry.ExternalRefs = append([]string(nil), sub.Entry.ExternalRefs...)
+	sub.Entry.Annotations = copyAnnotations(sub.Entry.Annotations)
+	sub.ExampleChanges = copyExampleDiffs(sub.ExampleChanges)
+	return sub
+}
@@ -0,0 +1,132 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReviewQueue(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "Published"})
+
+	if err := store.SubmitForReview("ns:a", DocEntry{Summary: "Draft v1", Source: "capture:log"}, "capture-bot"); err != nil {
+		t.Fatalf("SubmitForReview failed: %v", err)
+	}
+	if err := store.SubmitForReview("ns:b", DocEntry{Summary: strings.Repeat("x", MaxSummaryLen+10)}, "gen"); err != nil {
+		t.Fatalf("SubmitForReview failed: %v", err)
+	}
+
+	// Drafts are invisible to readers.
+	if doc, _ := store.DescribeTool("ns:a", DetailSummary); doc.Summary != "Published" {
+		t.Errorf("summary = %q, draft must not be visible", doc.Summary)
+	}
+	if _, err := store.DescribeTool("ns:b", DetailSummary); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unpublished tool err = %v, want ErrNotFound", err)
+	}
+
+	pending := store.ListPending()
+	if len(pending) != 2 || pending[0].ID != "ns:a" || pending[0].Status != ReviewPending || pending[0].Submitter != "capture-bot" {
+		t.Fatalf("pending = %+v", pending)
+	}
+	if len(pending[1].Entry.Summary) != MaxSummaryLen {
+		t.Errorf("draft should be truncated at submission, got %d chars", len(pending[1].Entry.Summary))
+	}
+
+	// Resubmission replaces the pending draft.
+	if err := store.SubmitForReview("ns:a", DocEntry{Summary: "Draft v2"}, "capture-bot"); err != nil {
+		t.Fatal(err)
+	}
+	if pending := store.ListPending(); pending[0].ExampleChanges != nil {
+		t.Errorf("ExampleChanges = %+v, want none without examples", pending[0].ExampleChanges)
+	}
+
+	approved, err := store.Approve("ns:a", "alice", "LGTM")
+	if err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+	if approved.Status != ReviewApproved || approved.Reviewer != "alice" || approved.Comment != "LGTM" || approved.ReviewedAt.IsZero() {
+		t.Errorf("approved = %+v", approved)
+	}
+	if doc, _ := store.DescribeTool("ns:a", DetailSummary); doc.Summary != "Draft v2" {
+		t.Errorf("summary after approve = %q, want Draft v2", doc.Summary)
+	}
+
+	rejected, err := store.Reject("ns:b", "bob", "Summary is too long")
+	if err != nil {
+		t.Fatalf("Reject failed: %v", err)
+	}
+	if rejected.Status != ReviewRejected || rejected.Comment != "Summary is too long" {
+		t.Errorf("rejected = %+v", rejected)
+	}
+	if _, err := store.DescribeTool("ns:b", DetailSummary); !errors.Is(err, ErrNotFound) {
+		t.Error("rejected draft must not be published")
+	}
+
+	if len(store.ListPending()) != 0 {
+		t.Errorf("pending = %+v, want empty", store.ListPending())
+	}
+	if h := store.ReviewHistory("ns:b"); len(h) != 1 || h[0].Reviewer != "bob" {
+		t.Errorf("history = %+v", h)
+	}
+	if _, err := store.Approve("ns:b", "alice", ""); !errors.Is(err, ErrNoSubmission) {
+		t.Errorf("Approve without submission err = %v, want ErrNoSubmission", err)
+	}
+}
+
+func TestReviewQueue_ExampleChanges(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "Published", Examples: []ToolExample{
+		{ID: "basic", Title: "Basic", Args: map[string]any{"repo": "a/b"}},
+	}})
+
+	draft := DocEntry{Summary: "Published", Examples: []ToolExample{
+		{ID: "basic", Title: "Basic", Args: map[string]any{"repo": "a/c"}},
+	}}
+	if err := store.SubmitForReview("ns:a", draft, "bot"); err != nil {
+		t.Fatal(err)
+	}
+	want := []ExampleDiff{{ID: "basic", Args: []ArgChange{{Path: "repo", Old: "a/b", New: "a/c"}}}}
+	if got := store.ListPending()[0].ExampleChanges; !reflect.DeepEqual(got, want) {
+		t.Errorf("pending ExampleChanges = %+v, want %+v", got, want)
+	}
+
+	// Approval diffs against the docs published at that time.
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "Published", Examples: []ToolExample{
+		{ID: "basic", Title: "Basic", Args: map[string]any{"repo": "a/c"}},
+	}})
+	approved, err := store.Approve("ns:a", "alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if approved.ExampleChanges != nil {
+		t.Errorf("approved ExampleChanges = %+v, want none", approved.ExampleChanges)
+	}
+}
+
+func TestReviewQueue_Errors(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{MaxExamplesPerTool: 1})
+
+	tooMany := DocEntry{Examples: []ToolExample{{Title: "a"}, {Title: "b"}}}
+	if err := store.SubmitForReview("ns:a", tooMany, "gen"); !errors.Is(err, ErrExampleLimit) {
+		t.Errorf("SubmitForReview err = %v, want ErrExampleLimit", err)
+	}
+
+	mustRegisterDoc(t, store, "ns:frozen", DocEntry{Summary: "Locked"})
+	if err := store.Freeze("ns:frozen"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SubmitForReview("ns:frozen", DocEntry{Summary: "Change"}, "gen"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Approve("ns:frozen", "alice", ""); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Approve frozen err = %v, want ErrFrozen", err)
+	}
+	if len(store.ListPending()) != 1 {
+		t.Error("draft should stay pending when approval fails")
+	}
+	if _, err := store.Reject("ns:missing", "bob", ""); !errors.Is(err, ErrNoSubmission) {
+		t.Errorf("Reject err = %v, want ErrNoSubmission", err)
+	}
+}
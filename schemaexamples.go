@@ -0,0 +1,128 @@
+package tooldocs
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// ExampleSourceSchema is the ToolExample.Source of examples converted from
+// the "examples" keyword of a tool's input schema.
+const ExampleSourceSchema = "schema"
+
+// SchemaExamples converts the top-level "examples" entries of the tool's
+// input schema into ToolExamples titled "Schema example N" with Source
+// ExampleSourceSchema. Entries that are not JSON objects are skipped, since
+// they cannot be tool arguments. It returns nil when the schema has none.
+func SchemaExamples(tool *toolmodel.Tool) []ToolExample {
+	if tool == nil {
+		return nil
+	}
+	schema := schemaAsMap(tool.InputSchema)
+	if schema == nil {
+		return nil
+	}
+	raw, _ := deepCopyValue(schema["examples"]).([]any)
+
+	var examples []ToolExample
+	for _, entry := range raw {
+		args, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		examples = append(examples, ToolExample{
+			Title:  "Schema example " + strconv.Itoa(len(examples)+1),
+			Args:   args,
+			Source: ExampleSourceSchema,
+		})
+	}
+	return examples
+}
+
+// IngestSchemaExamples registers SchemaExamples for each tool in ids (every
+// documented tool when ids is empty), so schemas that already ship examples
+// need no duplicate manual registration. It returns the number of schema
+// examples stored.
+//
+// Each call replaces the tool's previous schema-sourced examples and keeps
+// every other example, so re-running after a schema change neither
+// duplicates nor leaves stale entries. The DuplicateExamples policy is
+// applied against the kept examples. Tools the index and resolver do not
+// know, and tools whose schema has no examples, are skipped.
+//
+// Returns ErrThrottled if WriteRateLimit is exceeded for
+// ExampleSourceSchema, the lookup error of a failed tool lookup, or the
+// errors of AppendExamples (e.g. ErrArgsTooLarge, ErrExampleLimit,
+// ErrFrozen, ErrPersist), stopping at the first failing tool.
+func (s *InMemoryStore) IngestSchemaExamples(ids []string) (int, error) {
+	if err := s.limiter.allow(ExampleSourceSchema); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		ids = s.DocIDs()
+	}
+
+	stored := 0
+	for _, id := range ids {
+		tool, err := s.resolveTool(id)
+		if err != nil {
+			return stored, fmt.Errorf("ingest schema examples %s: %w", id, err)
+		}
+		examples := SchemaExamples(tool)
+		if len(examples) == 0 {
+			continue
+		}
+		n, err := s.replaceSchemaExamples(id, examples)
+		stored += n
+		if err != nil {
+			return stored, fmt.Errorf("ingest schema examples %s: %w", id, err)
+		}
+	}
+	return stored, nil
+}
+
+// replaceSchemaExamples swaps the tool's schema-sourced examples for
+// examples, returning how many were stored.
+func (s *InMemoryStore) replaceSchemaExamples(id string, examples []ToolExample) (int, error) {
+	prepared, err := s.prepareExamples(examples)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return 0, err
+	}
+
+	record, exists := s.docs[id]
+	if exists && record.frozen {
+		return 0, fmt.Errorf("%w: %s", ErrFrozen, id)
+	}
+
+	var kept []ToolExample
+	if exists {
+		for _, ex := range record.examples {
+			if ex.Source != ExampleSourceSchema {
+				kept = append(kept, ex)
+			}
+		}
+	}
+	added, err := s.dedupExamples(kept, prepared)
+	if err != nil {
+		return 0, err
+	}
+	combined := append(kept, added...)
+	if err := s.checkExampleLimits(combined); err != nil {
+		return 0, err
+	}
+
+	if !exists {
+		record = &docRecord{}
+		s.docs[id] = record
+	}
+	record.examples = combined
+	s.stored(id, record)
+	return len(added), s.saved(ChangeSchemaExamples, "", id, record, nil)
+}
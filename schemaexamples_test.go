@@ -0,0 +1,84 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestSchemaExamples(t *testing.T) {
+	tool := makeToolWithSchema("search", "gh", "Search", map[string]any{
+		"type":     "object",
+		"examples": []any{map[string]any{"q": "is:open"}, "not an object", map[string]any{"q": "label:bug", "limit": 5}},
+	})
+	want := []ToolExample{
+		{Title: "Schema example 1", Args: map[string]any{"q": "is:open"}, Source: ExampleSourceSchema},
+		{Title: "Schema example 2", Args: map[string]any{"q": "label:bug", "limit": 5}, Source: ExampleSourceSchema},
+	}
+	if got := SchemaExamples(&tool); !reflect.DeepEqual(got, want) {
+		t.Errorf("SchemaExamples = %+v, want %+v", got, want)
+	}
+
+	plain := makeToolWithSchema("get", "gh", "Get", map[string]any{"type": "object"})
+	if got := SchemaExamples(&plain); got != nil {
+		t.Errorf("SchemaExamples(no examples) = %+v, want nil", got)
+	}
+	if got := SchemaExamples(nil); got != nil {
+		t.Errorf("SchemaExamples(nil) = %+v, want nil", got)
+	}
+}
+
+func TestIngestSchemaExamples(t *testing.T) {
+	schemaExamples := []any{map[string]any{"q": "is:open"}}
+	store := NewInMemoryStore(StoreOptions{
+		DuplicateExamples: DuplicatesCollapse,
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			switch id {
+			case "gh:search":
+				tool := makeToolWithSchema("search", "gh", "Search", map[string]any{"type": "object", "examples": schemaExamples})
+				return &tool, nil
+			case "gh:broken":
+				return nil, errors.New("upstream unavailable")
+			}
+			return nil, nil
+		},
+	})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{
+		Summary:  "Search issues",
+		Examples: []ToolExample{{Title: "Authored", Args: map[string]any{"q": "author:me"}}},
+	})
+	mustRegisterDoc(t, store, "gh:unknown", DocEntry{Summary: "Not in the index"})
+
+	n, err := store.IngestSchemaExamples(nil)
+	if err != nil || n != 1 {
+		t.Fatalf("IngestSchemaExamples = %d, %v; want 1", n, err)
+	}
+
+	// Re-running after a schema change replaces, rather than accumulates,
+	// schema examples and keeps authored ones.
+	schemaExamples = []any{map[string]any{"q": "is:closed"}, map[string]any{"q": "is:draft"}}
+	if n, err := store.IngestSchemaExamples([]string{"gh:search"}); err != nil || n != 2 {
+		t.Fatalf("IngestSchemaExamples = %d, %v; want 2", n, err)
+	}
+	stored, _ := store.GetDocEntry("gh:search")
+	var got []string
+	for _, ex := range stored.Entry.Examples {
+		got = append(got, ex.Source+"|"+ex.Args["q"].(string))
+	}
+	if want := []string{"|author:me", "schema|is:closed", "schema|is:draft"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("examples = %v, want %v", got, want)
+	}
+
+	if _, err := store.IngestSchemaExamples([]string{"gh:broken"}); err == nil {
+		t.Error("IngestSchemaExamples with failing resolver succeeded")
+	}
+
+	if err := store.Freeze("gh:search"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.IngestSchemaExamples([]string{"gh:search"}); !errors.Is(err, ErrFrozen) {
+		t.Errorf("IngestSchemaExamples(frozen) error = %v, want ErrFrozen", err)
+	}
+}
@@ -0,0 +1,40 @@
+#!/usr/bin/env bash
+# Compare benchmark results between two git revisions.
+#
+# Usage: scripts/bench-compare.sh [BASE [HEAD]]
+#
+# BASE defaults to origin/main and HEAD to the working tree. Each side runs
+# the ./benchmarks suite COUNT times (default 10); results are summarized
+# with benchstat (go install golang.org/x/perf/cmd/benchstat@latest) when it
+# is installed, otherwise the raw outputs are left in the output directory.
+set -euo pipefail
+
+base="${1:-origin/main}"
+head="${2:-}"
+count="${COUNT:-10}"
+out="$(mktemp -d)"
+
+run() {
+  go test ./benchmarks -run '^$' -bench . -benchmem -count "$count" >"$1"
+}
+
+root="$(git rev-parse --show-toplevel)"
+cd "$root"
+
+worktree="$out/base"
+git worktree add --quiet --detach "$worktree" "$base"
+trap 'git worktree remove --force "$worktree"' EXIT
+(cd "$worktree" && run "$out/base.txt")
+
+if [[ -n "$head" ]]; then
+  git -C "$worktree" checkout --quiet --detach "$head"
+  (cd "$worktree" && run "$out/head.txt")
+else
+  run "$out/head.txt"
+fi
+
+if command -v benchstat >/dev/null 2>&1; then
+  benchstat "$out/base.txt" "$out/head.txt"
+else
+  echo "benchstat not found; raw results in $out"
+fi
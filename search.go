@@ -0,0 +1,422 @@
+package tooldocs
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// DefaultSnippetLen is the snippet length SearchDocs uses when
+// SearchOptions.SnippetLen is zero.
+const DefaultSnippetLen = 120
+
+// Search fields reported in Snippet.Field.
+const (
+	SearchFieldSummary = "summary"
+	SearchFieldNotes   = "notes"
+	SearchFieldExample = "example"
+)
+
+// snippetEllipsis marks text trimmed from either end of a snippet.
+const snippetEllipsis = "…"
+
+// SearchOptions configures SearchDocs.
+type SearchOptions struct {
+	// Limit caps the number of hits. Zero means no limit.
+	Limit int
+
+	// SnippetLen is the approximate length, in bytes, of each snippet
+	// before ellipses are added. Zero means DefaultSnippetLen.
+	SnippetLen int
+
+	// Namespace, when set, keeps only tools in that namespace.
+	Namespace string
+
+	// Tag, when set, keeps only tools whose definition carries the tag
+	// (compared after toolmodel.NormalizeTags).
+	Tag string
+
+	// Facets requests SearchResults.Facets over all filtered hits. Tag
+	// counts resolve each hit's tool definition.
+	Facets bool
+}
+
+// SearchResults is the result of SearchDocs.
+type SearchResults struct {
+	// Hits holds the best hits, at most SearchOptions.Limit.
+	Hits []SearchHit `json:"hits"`
+
+	// Total is the number of hits before SearchOptions.Limit.
+	Total int `json:"total"`
+
+	// Facets counts all Total hits by namespace and tag, when
+	// SearchOptions.Facets is set.
+	Facets *Facets `json:"facets,omitempty"`
+}
+
+// Match is the byte range [Start, End) of a query term within Snippet.Text.
+type Match struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Snippet is a short excerpt of one documentation field around the query
+// terms it matched.
+type Snippet struct {
+	// Field is SearchFieldSummary, SearchFieldNotes, or SearchFieldExample.
+	Field string `json:"field"`
+
+	// Example is the example ID or title when Field is SearchFieldExample.
+	Example string `json:"example,omitempty"`
+
+	// Text is the excerpt, with newlines flattened to spaces and an
+	// ellipsis where text was trimmed.
+	Text string `json:"text"`
+
+	// Matches locates the matched terms in Text, in order.
+	Matches []Match `json:"matches"`
+}
+
+// Highlight returns Text with each match wrapped in open and close, e.g.
+// "**" and "**" for Markdown or "<mark>" and "</mark>" for HTML (escape
+// Text first in that case).
+func (sn Snippet) Highlight(open, close string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range sn.Matches {
+		b.WriteString(sn.Text[last:m.Start])
+		b.WriteString(open)
+		b.WriteString(sn.Text[m.Start:m.End])
+		b.WriteString(close)
+		last = m.End
+	}
+	b.WriteString(sn.Text[last:])
+	return b.String()
+}
+
+// SearchHit is one tool returned by SearchDocs.
+type SearchHit struct {
+	ID string `json:"id"`
+
+	// Terms is the number of distinct query terms the tool matched.
+	Terms int `json:"terms"`
+
+	// Hits is the total number of term occurrences across searched fields.
+	Hits int `json:"hits"`
+
+	// Snippets holds at most one excerpt per matching field: summary, notes,
+	// then examples in registration order.
+	Snippets []Snippet `json:"snippets"`
+
+	// Member names the FederatedStore member the hit came from; it is
+	// empty for hits from a single store.
+	Member string `json:"member,omitempty"`
+}
+
+// searchDoc is the searchable text of one tool, copied under the read lock.
+type searchDoc struct {
+	id        string
+	summary   string
+	notes     string
+	examples  []ToolExample
+	sensitive bool
+}
+
+// SearchDocs returns documented tools whose summary, notes, or example
+// titles and descriptions contain the query's terms, each with snippets
+// locating the matches, so callers can judge relevance without fetching the
+// full tier of every hit. Only tools the store's search index lists for the
+// terms are scanned; the index is kept current on every write (see
+// RebuildIndex).
+//
+// The query is split into lowercase words and StoreOptions.SearchStopWords
+// are dropped; each remaining term matches any word that starts with it or
+// with one of its StoreOptions.SearchSynonyms, ignoring case. Hits are
+// ordered by distinct terms matched, then by total occurrences, then by ID.
+// Notes and examples of Sensitive tools are searched only when the caller
+// is authorized for their full tier (see StoreOptions.AuthorizeSensitive),
+// and examples whose Requires are not met by StoreOptions.Environment are
+// not searched at all.
+// Hits can be narrowed by SearchOptions.Namespace and Tag, and counted by
+// namespace and tag with SearchOptions.Facets. A query with no terms left
+// has no hits.
+func (s *InMemoryStore) SearchDocs(ctx context.Context, query string, opts SearchOptions) SearchResults {
+	results := SearchResults{Hits: []SearchHit{}}
+	if opts.Facets {
+		results.Facets = &Facets{Namespaces: map[string]int{}, Tags: map[string]int{}}
+	}
+	terms := s.search.terms(query)
+	if len(terms) == 0 {
+		return results
+	}
+	var tag string
+	if opts.Tag != "" {
+		normalized := toolmodel.NormalizeTags([]string{opts.Tag})
+		if len(normalized) == 0 {
+			return results
+		}
+		tag = normalized[0]
+	}
+	snippetLen := opts.SnippetLen
+	if snippetLen <= 0 {
+		snippetLen = DefaultSnippetLen
+	}
+
+	s.rlock()
+	candidates := s.searchIndex.candidates(terms)
+	docs := make([]searchDoc, 0, len(candidates))
+	for id := range candidates {
+		record := s.docs[id]
+		if s.expired(record) {
+			continue
+		}
+		docs = append(docs, searchDoc{
+			id:        id,
+			summary:   record.summary,
+			notes:     record.notes,
+			examples:  s.availableExamples(record.examples),
+			sensitive: record.sensitive,
+		})
+	}
+	s.runlock()
+
+	var hits []SearchHit
+	for _, doc := range docs {
+		full := !doc.sensitive || s.authorizedForSensitive(ctx, doc.id)
+		hit := SearchHit{ID: doc.id}
+		matched := make(map[string]bool, len(terms))
+
+		add := func(field, example, text string) {
+			matches := findTerms(text, terms, matched)
+			if len(matches) == 0 {
+				return
+			}
+			hit.Hits += len(matches)
+			sn := makeSnippet(text, matches, snippetLen)
+			sn.Field, sn.Example = field, example
+			hit.Snippets = append(hit.Snippets, sn)
+		}
+		add(SearchFieldSummary, "", doc.summary)
+		if full {
+			add(SearchFieldNotes, "", doc.notes)
+			for _, ex := range doc.examples {
+				text := ex.Title
+				if ex.Description != "" {
+					text += ": " + ex.Description
+				}
+				add(SearchFieldExample, exampleName(ex), text)
+			}
+		}
+
+		if hit.Hits == 0 {
+			continue
+		}
+		if opts.Namespace != "" || tag != "" || opts.Facets {
+			var (
+				namespace string
+				tags      []string
+			)
+			if tag != "" || opts.Facets {
+				namespace, tags = s.toolFacets(doc.id)
+			} else {
+				namespace, _, _ = toolmodel.ParseToolID(doc.id)
+			}
+			if opts.Namespace != "" && namespace != opts.Namespace {
+				continue
+			}
+			if tag != "" && !slices.Contains(tags, tag) {
+				continue
+			}
+			if opts.Facets {
+				results.Facets.add(namespace, tags)
+			}
+		}
+		hit.Terms = len(matched)
+		hits = append(hits, hit)
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Terms != hits[j].Terms {
+			return hits[i].Terms > hits[j].Terms
+		}
+		if hits[i].Hits != hits[j].Hits {
+			return hits[i].Hits > hits[j].Hits
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	results.Total = len(hits)
+	if opts.Limit > 0 && len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+	}
+	if hits != nil {
+		results.Hits = hits
+	}
+	return results
+}
+
+// isWordRune reports whether r belongs to a searchable word.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// DefaultStopWords is a small set of English stop-words suitable for
+// StoreOptions.SearchStopWords.
+var DefaultStopWords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "by", "for", "from", "how",
+	"i", "in", "is", "it", "me", "my", "of", "on", "or", "the", "this", "to",
+	"what", "with",
+}
+
+// searchConfig holds the normalized StoreOptions.SearchSynonyms and
+// SearchStopWords.
+type searchConfig struct {
+	synonyms  map[string][]string // word -> other words of its groups
+	stopWords map[string]bool
+}
+
+// searchTerm is a query word and the words that count as matching it.
+type searchTerm struct {
+	word string
+	alts []string // word first, then its synonyms
+}
+
+// splitWords splits s into lowercase words.
+func splitWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !isWordRune(r) })
+}
+
+// newSearchConfig lowercases the synonym groups and stop-words. Group
+// entries that are not a single word are ignored.
+func newSearchConfig(groups [][]string, stopWords []string) searchConfig {
+	cfg := searchConfig{
+		synonyms:  make(map[string][]string),
+		stopWords: make(map[string]bool, len(stopWords)),
+	}
+	for _, w := range stopWords {
+		cfg.stopWords[strings.ToLower(w)] = true
+	}
+	for _, group := range groups {
+		var words []string
+		for _, entry := range group {
+			if w := splitWords(entry); len(w) == 1 {
+				words = append(words, w[0])
+			}
+		}
+		for _, w := range words {
+			for _, other := range words {
+				if other != w && !slices.Contains(cfg.synonyms[w], other) {
+					cfg.synonyms[w] = append(cfg.synonyms[w], other)
+				}
+			}
+		}
+	}
+	return cfg
+}
+
+// terms splits query into distinct lowercase words, drops stop-words, and
+// expands each remaining word with its synonyms.
+func (c searchConfig) terms(query string) []searchTerm {
+	var terms []searchTerm
+	seen := make(map[string]bool)
+	for _, word := range splitWords(query) {
+		if seen[word] || c.stopWords[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, searchTerm{word: word, alts: append([]string{word}, c.synonyms[word]...)})
+	}
+	return terms
+}
+
+// findTerms returns the ranges of words in text that start with one of
+// the terms or their synonyms (ignoring case), in order, and marks the
+// matched query words.
+func findTerms(text string, terms []searchTerm, matched map[string]bool) []Match {
+	var matches []Match
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !isWordRune(r) {
+			i += size
+			continue
+		}
+		start := i
+		for i < len(text) {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			if !isWordRune(r) {
+				break
+			}
+			i += size
+		}
+		word := text[start:i]
+	match:
+		for _, term := range terms {
+			for _, alt := range term.alts {
+				if hasFoldPrefix(word, alt) {
+					matches = append(matches, Match{Start: start, End: start + len(alt)})
+					matched[term.word] = true
+					break match
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// hasFoldPrefix reports whether word starts with term, ignoring case.
+func hasFoldPrefix(word, term string) bool {
+	if len(word) < len(term) || (len(word) > len(term) && !utf8.RuneStart(word[len(term)])) {
+		return false
+	}
+	return strings.EqualFold(word[:len(term)], term)
+}
+
+// makeSnippet cuts a window of about n bytes from text around the first
+// match, trimmed to word boundaries, and rebases the matches inside it.
+func makeSnippet(text string, matches []Match, n int) Snippet {
+	start := matches[0].Start - n/4
+	if start <= 0 {
+		start = 0
+	} else if sp := strings.IndexAny(text[start:matches[0].Start], " \n\t"); sp >= 0 {
+		start += sp + 1
+	} else {
+		start = matches[0].Start
+	}
+	end := start + n
+	if end < matches[0].End {
+		end = matches[0].End
+	}
+	if end >= len(text) {
+		end = len(text)
+	} else if sp := strings.LastIndexAny(text[matches[0].End:end], " \n\t"); sp >= 0 {
+		end = matches[0].End + sp
+	} else {
+		for end < len(text) && !utf8.RuneStart(text[end]) {
+			end++
+		}
+	}
+
+	prefix := ""
+	if start > 0 {
+		prefix = snippetEllipsis
+	}
+	suffix := ""
+	if end < len(text) {
+		suffix = snippetEllipsis
+	}
+	sn := Snippet{
+		Text:    prefix + strings.ReplaceAll(text[start:end], "\n", " ") + suffix,
+		Matches: []Match{},
+	}
+	offset := len(prefix) - start
+	for _, m := range matches {
+		if m.Start >= start && m.End <= end {
+			sn.Matches = append(sn.Matches, Match{Start: m.Start + offset, End: m.End + offset})
+		}
+	}
+	return sn
+}
@@ -0,0 +1,111 @@
+package tooldocs
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSearchDocs(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "gh:create_issue", DocEntry{
+		Summary: "Create an issue in a repository",
+		Notes:   strings.Repeat("Filler text here. ", 10) + "Labels must already exist in the Repo.\nOtherwise the call fails. " + strings.Repeat("More filler. ", 10),
+		Examples: []ToolExample{
+			{Title: "Bug report", Description: "Open an issue with a bug label", Args: map[string]any{}},
+		},
+	})
+	mustRegisterDoc(t, store, "gh:list_repos", DocEntry{Summary: "List repositories for a user"})
+	mustRegisterDoc(t, store, "ops:deploy", DocEntry{
+		Summary:   "Deploy a service",
+		Notes:     "Rotates the repo deploy key.",
+		Sensitive: true,
+	})
+
+	hits := store.SearchDocs(context.Background(), "repo ISSUE", SearchOptions{SnippetLen: 60}).Hits
+	var ids []string
+	for _, h := range hits {
+		ids = append(ids, h.ID)
+	}
+	if got := strings.Join(ids, ","); got != "gh:create_issue,gh:list_repos" {
+		t.Fatalf("hit IDs = %s, want gh:create_issue,gh:list_repos (sensitive notes not searched)", got)
+	}
+
+	top := hits[0]
+	if top.Terms != 2 || top.Hits != 5 || len(top.Snippets) != 3 {
+		t.Fatalf("top hit = %+v, want 2 terms, 5 hits, 3 snippets", top)
+	}
+	if got := top.Snippets[0].Highlight("[", "]"); got != "Create an [issue] in a [repo]sitory" {
+		t.Errorf("summary snippet = %q", got)
+	}
+	notes := top.Snippets[1]
+	if notes.Field != SearchFieldNotes || !strings.HasPrefix(notes.Text, "…") || !strings.HasSuffix(notes.Text, "…") {
+		t.Errorf("notes snippet = %+v, want trimmed notes excerpt", notes)
+	}
+	if got := notes.Highlight("[", "]"); !strings.Contains(got, "in the [Repo]. Otherwise") {
+		t.Errorf("notes snippet = %q, want highlighted Repo with flattened newline", got)
+	}
+	if ex := top.Snippets[2]; ex.Field != SearchFieldExample || ex.Example != "Bug report" || ex.Highlight("[", "]") != "Bug [repo]rt: Open an [issue] with a bug label" {
+		t.Errorf("example snippet = %+v", ex)
+	}
+
+	authorized := WithGrants(context.Background(), GrantSensitiveDocs)
+	if hits := store.SearchDocs(authorized, "repo", SearchOptions{Limit: 1}).Hits; len(hits) != 1 || hits[0].ID != "gh:create_issue" {
+		t.Errorf("limited hits = %+v, want gh:create_issue only", hits)
+	}
+	if hits := store.SearchDocs(authorized, "deploy key", SearchOptions{}).Hits; len(hits) != 1 || hits[0].Snippets[1].Highlight("<", ">") != "Rotates the repo <deploy> <key>." {
+		t.Errorf("authorized hits = %+v, want ops:deploy notes", hits)
+	}
+	if hits := store.SearchDocs(context.Background(), " , ", SearchOptions{}).Hits; len(hits) != 0 {
+		t.Errorf("empty query hits = %+v, want none", hits)
+	}
+}
+
+func TestSearchDocs_UnavailableExamples(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{Environment: []string{"github"}})
+	mustRegisterDoc(t, store, "ci:run", DocEntry{
+		Summary: "Run a pipeline",
+		Examples: []ToolExample{
+			{Title: "GitHub trigger", Description: "Run on push", Requires: []string{"github"}, Args: map[string]any{}},
+			{Title: "GitLab trigger", Description: "Run on push", Requires: []string{"gitlab"}, Args: map[string]any{}},
+		},
+	})
+
+	if hits := store.SearchDocs(context.Background(), "gitlab", SearchOptions{}).Hits; len(hits) != 0 {
+		t.Errorf("gitlab hits = %+v, want none (example requires an unmet environment)", hits)
+	}
+	hits := store.SearchDocs(context.Background(), "push", SearchOptions{}).Hits
+	if len(hits) != 1 || len(hits[0].Snippets) != 1 || hits[0].Snippets[0].Example != "GitHub trigger" {
+		t.Errorf("push hits = %+v, want only the GitHub example", hits)
+	}
+}
+
+func TestSearchDocs_SynonymsAndStopWords(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{
+		SearchSynonyms:  [][]string{{"Ticket", "issue", "bug"}, {"repo", "repository"}, {"pull request"}},
+		SearchStopWords: DefaultStopWords,
+	})
+	mustRegisterDoc(t, store, "gh:create_issue", DocEntry{Summary: "Create an issue"})
+	mustRegisterDoc(t, store, "gh:create_repo", DocEntry{Summary: "Create a repository"})
+	mustRegisterDoc(t, store, "jira:file_bug", DocEntry{Summary: "File a bug in a project"})
+
+	hits := store.SearchDocs(context.Background(), "how to open a ticket", SearchOptions{}).Hits
+	var got []string
+	for _, h := range hits {
+		got = append(got, h.ID+"="+h.Snippets[0].Highlight("[", "]"))
+	}
+	want := "gh:create_issue=Create an [issue],jira:file_bug=File a [bug] in a project"
+	if strings.Join(got, ",") != want {
+		t.Errorf("hits = %v, want %s", got, want)
+	}
+	if hits[0].Terms != 1 {
+		t.Errorf("Terms = %d, want 1 (synonyms count as the query term)", hits[0].Terms)
+	}
+
+	if hits := store.SearchDocs(context.Background(), "the a of", SearchOptions{}).Hits; len(hits) != 0 {
+		t.Errorf("stop-word-only query hits = %+v, want none", hits)
+	}
+	if hits := store.SearchDocs(context.Background(), "REPOSITORY", SearchOptions{}).Hits; len(hits) != 1 || hits[0].ID != "gh:create_repo" {
+		t.Errorf("synonym hits = %+v, want gh:create_repo", hits)
+	}
+}
@@ -0,0 +1,116 @@
+package tooldocs
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// searchIndex is an inverted index from lowercase words of each tool's
+// searchable text (summary, notes, example titles and descriptions) to the
+// tools containing them. It narrows SearchDocs to candidate tools; matching
+// and snippets still run on the candidates' text.
+//
+// It is updated per tool on every write, so registrations cost time
+// proportional to the tool's own text rather than to the catalog. The
+// caller holds the store's write lock for updates and at least its read
+// lock for lookups.
+type searchIndex struct {
+	postings map[string]map[string]struct{} // word -> tool IDs
+	words    map[string][]string            // tool ID -> its distinct words
+	vocab    []string                       // sorted keys of postings
+}
+
+// update reindexes id from record.
+func (x *searchIndex) update(id string, record *docRecord) {
+	x.remove(id)
+	if x.postings == nil {
+		x.postings = make(map[string]map[string]struct{})
+		x.words = make(map[string][]string)
+	}
+
+	seen := make(map[string]bool)
+	var words []string
+	addText := func(text string) {
+		for _, w := range splitWords(text) {
+			if !seen[w] {
+				seen[w] = true
+				words = append(words, w)
+			}
+		}
+	}
+	addText(record.summary)
+	addText(record.notes)
+	for _, ex := range record.examples {
+		addText(ex.Title)
+		addText(ex.Description)
+	}
+	if len(words) == 0 {
+		return
+	}
+
+	for _, w := range words {
+		ids, ok := x.postings[w]
+		if !ok {
+			ids = make(map[string]struct{})
+			x.postings[w] = ids
+			i, _ := slices.BinarySearch(x.vocab, w)
+			x.vocab = slices.Insert(x.vocab, i, w)
+		}
+		ids[id] = struct{}{}
+	}
+	x.words[id] = words
+}
+
+// remove drops id from the index.
+func (x *searchIndex) remove(id string) {
+	for _, w := range x.words[id] {
+		ids := x.postings[w]
+		delete(ids, id)
+		if len(ids) == 0 {
+			delete(x.postings, w)
+			if i, found := slices.BinarySearch(x.vocab, w); found {
+				x.vocab = slices.Delete(x.vocab, i, i+1)
+			}
+		}
+	}
+	delete(x.words, id)
+}
+
+// reset empties the index.
+func (x *searchIndex) reset() {
+	*x = searchIndex{}
+}
+
+// candidates returns the IDs of tools containing a word that starts with
+// one of the terms or their synonyms.
+func (x *searchIndex) candidates(terms []searchTerm) map[string]bool {
+	ids := make(map[string]bool)
+	for _, term := range terms {
+		for _, alt := range term.alts {
+			for i := sort.SearchStrings(x.vocab, alt); i < len(x.vocab) && strings.HasPrefix(x.vocab[i], alt); i++ {
+				for id := range x.postings[x.vocab[i]] {
+					ids[id] = true
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// RebuildIndex discards the search index and rebuilds it from every
+// registered doc. The index is maintained incrementally on each write, so
+// this is only an escape hatch, e.g. to compact it after mass removals or
+// to rule it out while debugging search results. It is a no-op on a sealed
+// store, whose index can no longer change.
+func (s *InMemoryStore) RebuildIndex() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sealed.Load() {
+		return
+	}
+	s.searchIndex.reset()
+	for id, record := range s.docs {
+		s.searchIndex.update(id, record)
+	}
+}
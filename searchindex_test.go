@@ -0,0 +1,85 @@
+package tooldocs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// searchIDs returns the IDs SearchDocs finds for query, sorted.
+func searchIDs(s *InMemoryStore, query string) []string {
+	var ids []string
+	for _, h := range s.SearchDocs(context.Background(), query, SearchOptions{}).Hits {
+		ids = append(ids, h.ID)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestSearchIndexIncremental(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "gh:create_issue", DocEntry{Summary: "Create an issue", Notes: "Needs a label."})
+	mustRegisterDoc(t, store, "gh:list_repos", DocEntry{Summary: "List repositories"})
+
+	check := func(step, query, want string) {
+		t.Helper()
+		if got := strings.Join(searchIDs(store, query), ","); got != want {
+			t.Errorf("%s: SearchDocs(%q) = %q, want %q", step, query, got, want)
+		}
+	}
+	check("register", "label", "gh:create_issue")
+
+	// Re-registering replaces the old words.
+	mustRegisterDoc(t, store, "gh:create_issue", DocEntry{Summary: "Open a ticket"})
+	check("replace", "label", "")
+	check("replace", "ticket", "gh:create_issue")
+
+	if err := store.RegisterExamples("gh:list_repos", []ToolExample{{Title: "Paginate results", Args: map[string]any{}}}); err != nil {
+		t.Fatal(err)
+	}
+	check("examples", "paginate", "gh:list_repos")
+	if err := store.AppendExamples("gh:create_issue", []ToolExample{{Title: "Paginate comments", Args: map[string]any{}}}); err != nil {
+		t.Fatal(err)
+	}
+	check("append", "paginate", "gh:create_issue,gh:list_repos")
+
+	if _, err := store.Import(map[string]DocEntry{"ops:deploy": {Summary: "Deploy a ticket fix"}}, ImportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	check("import", "ticket", "gh:create_issue,ops:deploy")
+
+	if _, err := store.UnregisterWhere(UnregisterFilter{NamespacePrefix: "gh"}); err != nil {
+		t.Fatal(err)
+	}
+	check("unregister", "ticket", "ops:deploy")
+	check("unregister", "paginate", "")
+	if len(store.searchIndex.words) != 1 || len(store.searchIndex.vocab) != len(store.searchIndex.postings) {
+		t.Errorf("index after unregister: %d tools, %d vocab, %d postings",
+			len(store.searchIndex.words), len(store.searchIndex.vocab), len(store.searchIndex.postings))
+	}
+}
+
+func TestRebuildIndex(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	for i := range 20 {
+		mustRegisterDoc(t, store, fmt.Sprintf("ns:tool%02d", i), DocEntry{
+			Summary: fmt.Sprintf("Tool number %d handles widgets", i),
+			Notes:   strings.Repeat("gadget ", i%3),
+		})
+	}
+	before := store.searchIndex.vocab
+	wantWidgets, wantGadgets := searchIDs(store, "widget"), searchIDs(store, "gadgets")
+
+	store.RebuildIndex()
+	if !slices.Equal(store.searchIndex.vocab, before) {
+		t.Errorf("vocab after rebuild = %v, want %v", store.searchIndex.vocab, before)
+	}
+	if got := searchIDs(store, "widget"); len(got) != 20 || !slices.Equal(got, wantWidgets) {
+		t.Errorf("widget hits after rebuild = %v", got)
+	}
+	if got := searchIDs(store, "gadgets"); !slices.Equal(got, wantGadgets) {
+		t.Errorf("gadget hits after rebuild = %v, want %v", got, wantGadgets)
+	}
+}
@@ -0,0 +1,101 @@
+package tooldocs
+
+// Caps for DocEntry.Sections, enforced at registration time.
+const (
+	MaxSections        = 8    // Maximum number of sections per tool
+	MaxSectionTitleLen = 80   // Maximum length of DocSection.Title
+	MaxSectionBodyLen  = 1000 // Maximum length of DocSection.Body
+)
+
+// DocSection is a named block of guidance (e.g. "Auth", "Pagination",
+// "Limits", "Gotchas") that lets complex tools structure what would
+// otherwise be one long Notes blob.
+type DocSection struct {
+	// Title names the section. Maximum length: MaxSectionTitleLen.
+	Title string `json:"title"`
+
+	// Body is the section text. Maximum length: MaxSectionBodyLen.
+	Body string `json:"body"`
+
+	// Tier is the lowest detail level that returns the section: a
+	// DetailSummary section appears at every level, a DetailSchema section
+	// at schema and full. Empty or unknown values mean DetailFull.
+	Tier DetailLevel `json:"tier,omitempty"`
+}
+
+// tierRank orders detail levels; unknown levels rank as DetailFull.
+func tierRank(level DetailLevel) int {
+	switch level {
+	case DetailSummary:
+		return 0
+	case DetailSchema:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// sectionsAt returns the sections returned at level, in order, or nil.
+func sectionsAt(sections []DocSection, level DetailLevel) []DocSection {
+	var out []DocSection
+	for _, sec := range sections {
+		if tierRank(sec.Tier) <= tierRank(level) {
+			out = append(out, sec)
+		}
+	}
+	return out
+}
+
+// truncateSections returns a copy of sections capped to MaxSections, with
+// titles and bodies cut to their caps and unknown tiers set to DetailFull.
+func truncateSections(sections []DocSection) []DocSection {
+	if sections == nil {
+		return nil
+	}
+	if len(sections) > MaxSections {
+		sections = sections[:MaxSections]
+	}
+	result := make([]DocSection, len(sections))
+	for i, sec := range sections {
+		sec.Title = truncateString(sec.Title, MaxSectionTitleLen)
+		sec.Body = truncateString(sec.Body, MaxSectionBodyLen)
+		if sec.Tier != DetailSummary && sec.Tier != DetailSchema {
+			sec.Tier = DetailFull
+		}
+		result[i] = sec
+	}
+	return result
+}
+
+// internSections interns the text of sections in place and returns it.
+func internSections(sections []DocSection) []DocSection {
+	for i := range sections {
+		sections[i].Title = intern(sections[i].Title)
+		sections[i].Body = intern(sections[i].Body)
+		sections[i].Tier = DetailLevel(intern(string(sections[i].Tier)))
+	}
+	return sections
+}
+
+// mergeSections returns base with each overlay section replacing the base
+// section of the same title in place, or appended when the title is new.
+func mergeSections(base, overlay []DocSection) []DocSection {
+	result := append([]DocSection(nil), base...)
+	for _, sec := range overlay {
+		replaced := false
+		for i := range result {
+			if result[i].Title == sec.Title {
+				result[i] = sec
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result = append(result, sec)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
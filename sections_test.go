@@ -0,0 +1,81 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestSectionsByTier(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			tool := makeToolWithSchema("list", "gh", "List", map[string]any{"type": "object"})
+			return &tool, nil
+		},
+	})
+	mustRegisterDoc(t, store, "gh:list", DocEntry{
+		Summary: "List issues",
+		Sections: []DocSection{
+			{Title: "Auth", Body: "Needs repo scope.", Tier: DetailSummary},
+			{Title: "Pagination", Body: "Follow the cursor.", Tier: DetailSchema},
+			{Title: "Gotchas", Body: "Closed issues are hidden by default."},
+			{Title: "Limits", Body: "100 per page.", Tier: "bogus"},
+		},
+	})
+
+	for level, want := range map[DetailLevel][]string{
+		DetailSummary: {"Auth"},
+		DetailSchema:  {"Auth", "Pagination"},
+		DetailFull:    {"Auth", "Pagination", "Gotchas", "Limits"},
+	} {
+		doc, err := store.DescribeTool("gh:list", level)
+		if err != nil {
+			t.Fatalf("DescribeTool(%s) failed: %v", level, err)
+		}
+		var got []string
+		for _, sec := range doc.Sections {
+			got = append(got, sec.Title)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("DescribeTool(%s) sections = %v, want %v", level, got, want)
+		}
+	}
+}
+
+func TestTruncateSections(t *testing.T) {
+	sections := make([]DocSection, MaxSections+2)
+	sections[0] = DocSection{Title: strings.Repeat("t", 100), Body: strings.Repeat("b", 1200), Tier: "bogus"}
+	got := truncateSections(sections)
+	if len(got) != MaxSections {
+		t.Fatalf("len = %d, want %d", len(got), MaxSections)
+	}
+	if len(got[0].Title) != MaxSectionTitleLen || len(got[0].Body) != MaxSectionBodyLen || got[0].Tier != DetailFull {
+		t.Errorf("section = %d/%d chars, tier %q; want capped and full", len(got[0].Title), len(got[0].Body), got[0].Tier)
+	}
+	if sections[0].Tier != "bogus" {
+		t.Error("truncateSections modified its input")
+	}
+}
+
+func TestMergeSections(t *testing.T) {
+	base := DocEntry{Sections: []DocSection{{Title: "Auth", Body: "old"}, {Title: "Limits", Body: "10/s"}}}
+	overlay := DocEntry{Sections: []DocSection{{Title: "Gotchas", Body: "new"}, {Title: "Auth", Body: "OAuth only"}}}
+	got := MergeDocEntries(base, overlay, MergePolicy{}).Sections
+	want := []DocSection{{Title: "Auth", Body: "OAuth only"}, {Title: "Limits", Body: "10/s"}, {Title: "Gotchas", Body: "new"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("merged sections = %+v, want %+v", got, want)
+	}
+}
+
+func TestDocBuilder_AddSection(t *testing.T) {
+	_, err := NewDocBuilder().
+		WithSummary("List issues").
+		AddSection(DocSection{Title: "Auth", Body: strings.Repeat("x", MaxSectionBodyLen+1)}).
+		Build()
+	if !errors.Is(err, ErrFieldTooLong) {
+		t.Errorf("Build error = %v, want ErrFieldTooLong", err)
+	}
+}
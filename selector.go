@@ -0,0 +1,206 @@
+package tooldocs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SelectContext describes the request an ExampleSelector is choosing for.
+type SelectContext struct {
+	// Context is the caller context of the read.
+	Context context.Context
+
+	// ToolID is the tool whose examples are being selected.
+	ToolID string
+
+	// Max is the number of examples to return; 0 means no limit.
+	Max int
+
+	// Query is the caller's task description, if one was attached with
+	// WithExampleQuery. Empty otherwise.
+	Query string
+}
+
+// ExampleSelector chooses which examples a read returns. It is applied
+// wherever the MaxExamples cap is enforced (DescribeTool at DetailFull and
+// ListExamples), after examples with unmet Requires are dropped.
+//
+// Select receives a copy of the tool's examples in authored order and must
+// return at most ctx.Max of them (all of them when Max is 0). The store
+// re-applies the cap to whatever is returned.
+type ExampleSelector interface {
+	Select(examples []ToolExample, ctx SelectContext) []ToolExample
+}
+
+// ExampleSelectorFunc adapts a function to an ExampleSelector.
+type ExampleSelectorFunc func(examples []ToolExample, ctx SelectContext) []ToolExample
+
+// Select calls f.
+func (f ExampleSelectorFunc) Select(examples []ToolExample, ctx SelectContext) []ToolExample {
+	return f(examples, ctx)
+}
+
+// FirstNSelector keeps the first Max examples in authored order. It is the
+// default when StoreOptions.ExampleSelector is nil.
+type FirstNSelector struct{}
+
+// Select implements ExampleSelector.
+func (FirstNSelector) Select(examples []ToolExample, ctx SelectContext) []ToolExample {
+	return capExamples(examples, ctx.Max)
+}
+
+// PrioritySelector returns examples whose key (ID, else Title) appears in
+// Order first, in that order, followed by the rest in authored order.
+type PrioritySelector struct {
+	// Order lists example keys from most to least preferred.
+	Order []string
+}
+
+// Select implements ExampleSelector.
+func (p PrioritySelector) Select(examples []ToolExample, ctx SelectContext) []ToolExample {
+	rank := make(map[string]int, len(p.Order))
+	for i, key := range p.Order {
+		if _, dup := rank[key]; !dup {
+			rank[key] = i
+		}
+	}
+	return rankExamples(examples, ctx.Max, func(ex ToolExample) float64 {
+		if r, ok := rank[exampleName(ex)]; ok {
+			return float64(len(p.Order) - r)
+		}
+		return 0
+	})
+}
+
+// FeedbackSelector ranks examples by a score from an external feedback
+// source (e.g. the success rate of calls agents made after seeing the
+// example), highest first. Ties keep authored order.
+type FeedbackSelector struct {
+	// Score returns the feedback score of an example of tool id. Nil
+	// scores every example equally.
+	Score func(id string, ex ToolExample) float64
+}
+
+// Select implements ExampleSelector.
+func (f FeedbackSelector) Select(examples []ToolExample, ctx SelectContext) []ToolExample {
+	if f.Score == nil {
+		return capExamples(examples, ctx.Max)
+	}
+	return rankExamples(examples, ctx.Max, func(ex ToolExample) float64 {
+		return f.Score(ctx.ToolID, ex)
+	})
+}
+
+// QuerySelector ranks examples by word overlap between ctx.Query and the
+// example's title, description, result hint, and Args keys and string
+// values. Without a query it keeps authored order.
+type QuerySelector struct{}
+
+// Select implements ExampleSelector.
+func (QuerySelector) Select(examples []ToolExample, ctx SelectContext) []ToolExample {
+	query := words(ctx.Query)
+	if len(query) == 0 {
+		return capExamples(examples, ctx.Max)
+	}
+	return rankExamples(examples, ctx.Max, func(ex ToolExample) float64 {
+		text := words(ex.Title + " " + ex.Description + " " + ex.ResultHint + " " + argsText(ex.Args))
+		score := 0
+		for w := range query {
+			if text[w] {
+				score++
+			}
+		}
+		return float64(score)
+	})
+}
+
+type exampleQueryKey struct{}
+
+// WithExampleQuery returns a context carrying the caller's task description,
+// passed to the ExampleSelector as SelectContext.Query.
+func WithExampleQuery(ctx context.Context, query string) context.Context {
+	return context.WithValue(ctx, exampleQueryKey{}, query)
+}
+
+// selectExamples applies the store's ExampleSelector and the max cap.
+func (s *InMemoryStore) selectExamples(ctx context.Context, id string, examples []ToolExample, maxExamples int) []ToolExample {
+	if s.selector == nil {
+		return capExamples(examples, maxExamples)
+	}
+	query, _ := ctx.Value(exampleQueryKey{}).(string)
+	return capExamples(s.selector.Select(examples, SelectContext{
+		Context: ctx,
+		ToolID:  id,
+		Max:     maxExamples,
+		Query:   query,
+	}), maxExamples)
+}
+
+// capExamples returns at most n examples; n <= 0 means no limit.
+func capExamples(examples []ToolExample, n int) []ToolExample {
+	if n > 0 && len(examples) > n {
+		return examples[:n]
+	}
+	return examples
+}
+
+// rankExamples orders examples by score, highest first and stable, then
+// applies the cap.
+func rankExamples(examples []ToolExample, n int, score func(ToolExample) float64) []ToolExample {
+	scores := make([]float64, len(examples))
+	order := make([]int, len(examples))
+	for i, ex := range examples {
+		scores[i] = score(ex)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+	ranked := make([]ToolExample, len(examples))
+	for i, j := range order {
+		ranked[i] = examples[j]
+	}
+	return capExamples(ranked, n)
+}
+
+// words returns the set of lowercase alphanumeric words in s.
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		set[w] = true
+	}
+	return set
+}
+
+// argsText flattens Args keys and scalar values into text for matching.
+func argsText(args map[string]any) string {
+	var b strings.Builder
+	var walk func(v any)
+	walk = func(v any) {
+		switch val := v.(type) {
+		case map[string]any:
+			for k, item := range val {
+				b.WriteString(k)
+				b.WriteByte(' ')
+				walk(item)
+			}
+		case []any:
+			for _, item := range val {
+				walk(item)
+			}
+		case string:
+			b.WriteString(val)
+			b.WriteByte(' ')
+		case nil:
+		default:
+			fmt.Fprint(&b, val, " ")
+		}
+	}
+	walk(args)
+	return b.String()
+}
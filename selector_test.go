@@ -0,0 +1,80 @@
+package tooldocs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func selectorExamples() []ToolExample {
+	return []ToolExample{
+		{ID: "basic", Title: "Basic search", Args: map[string]any{"q": "repo"}},
+		{ID: "paged", Title: "Paginate results", Description: "Fetch the next page with a cursor.", Args: map[string]any{"cursor": "abc"}},
+		{ID: "labels", Title: "Filter by label", Args: map[string]any{"labels": []any{"bug"}}},
+	}
+}
+
+func TestSelectors(t *testing.T) {
+	scores := map[string]float64{"basic": 0.2, "paged": 0.2, "labels": 0.9}
+	tests := []struct {
+		name     string
+		selector ExampleSelector
+		ctx      SelectContext
+		want     []string
+	}{
+		{"first-n", FirstNSelector{}, SelectContext{Max: 2}, []string{"basic", "paged"}},
+		{"first-n unlimited", FirstNSelector{}, SelectContext{}, []string{"basic", "paged", "labels"}},
+		{"priority", PrioritySelector{Order: []string{"labels", "paged"}}, SelectContext{Max: 2}, []string{"labels", "paged"}},
+		{"priority partial", PrioritySelector{Order: []string{"labels"}}, SelectContext{}, []string{"labels", "basic", "paged"}},
+		{"feedback", FeedbackSelector{Score: func(_ string, ex ToolExample) float64 { return scores[ex.ID] }}, SelectContext{Max: 2}, []string{"labels", "basic"}},
+		{"feedback nil score", FeedbackSelector{}, SelectContext{Max: 1}, []string{"basic"}},
+		{"query", QuerySelector{}, SelectContext{Max: 1, Query: "get the next page"}, []string{"paged"}},
+		{"query args", QuerySelector{}, SelectContext{Max: 1, Query: "only bug issues"}, []string{"labels"}},
+		{"query empty", QuerySelector{}, SelectContext{Max: 2}, []string{"basic", "paged"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := exampleIDs(tt.selector.Select(selectorExamples(), tt.ctx))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Select = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_ExampleSelector(t *testing.T) {
+	tool := makeToolWithSchema("search", "gh", "Search", map[string]any{"type": "object"})
+	var seen SelectContext
+	s := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(string) (*toolmodel.Tool, error) { return &tool, nil },
+		MaxExamples:  2,
+		ExampleSelector: ExampleSelectorFunc(func(examples []ToolExample, ctx SelectContext) []ToolExample {
+			seen = ctx
+			// Misbehave by returning everything; the store still caps.
+			return QuerySelector{}.Select(examples, SelectContext{Query: ctx.Query})
+		}),
+	})
+	mustRegisterDoc(t, s, "gh:search", DocEntry{Summary: "Search", Examples: selectorExamples()})
+
+	ctx := WithExampleQuery(context.Background(), "bug labels")
+	doc, err := s.DescribeToolContext(ctx, "gh:search", DetailFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := exampleIDs(doc.Examples); !reflect.DeepEqual(got, []string{"labels", "basic"}) {
+		t.Errorf("DescribeTool examples = %v", got)
+	}
+	if seen.ToolID != "gh:search" || seen.Max != 2 || seen.Query != "bug labels" {
+		t.Errorf("SelectContext = %+v", seen)
+	}
+
+	examples, err := s.ListExamplesContext(ctx, "gh:search", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := exampleIDs(examples); !reflect.DeepEqual(got, []string{"labels"}) {
+		t.Errorf("ListExamples = %v", got)
+	}
+}
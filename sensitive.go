@@ -0,0 +1,50 @@
+package tooldocs
+
+import "context"
+
+// GrantSensitiveDocs is the grant that, by default, authorizes reading the
+// full tier of Sensitive tools.
+const GrantSensitiveDocs = "docs:sensitive"
+
+// grantsKey is the context key for caller grants.
+type grantsKey struct{}
+
+// WithGrants returns a context carrying the given authorization grants in
+// addition to any already present, typically added by auth middleware after
+// verifying a caller's token.
+func WithGrants(ctx context.Context, grants ...string) context.Context {
+	existing := GrantsFromContext(ctx)
+	merged := make(map[string]bool, len(existing)+len(grants))
+	for _, g := range existing {
+		merged[g] = true
+	}
+	for _, g := range grants {
+		merged[g] = true
+	}
+	return context.WithValue(ctx, grantsKey{}, merged)
+}
+
+// GrantsFromContext returns the grants carried by ctx, in no particular order.
+func GrantsFromContext(ctx context.Context) []string {
+	set, _ := ctx.Value(grantsKey{}).(map[string]bool)
+	grants := make([]string, 0, len(set))
+	for g := range set {
+		grants = append(grants, g)
+	}
+	return grants
+}
+
+// HasGrant reports whether ctx carries grant.
+func HasGrant(ctx context.Context, grant string) bool {
+	set, _ := ctx.Value(grantsKey{}).(map[string]bool)
+	return set[grant]
+}
+
+// authorizedForSensitive reports whether the caller may read the full tier
+// of the Sensitive tool id.
+func (s *InMemoryStore) authorizedForSensitive(ctx context.Context, id string) bool {
+	if s.authorize != nil {
+		return s.callAuthorize(ctx, id)
+	}
+	return HasGrant(ctx, GrantSensitiveDocs)
+}
@@ -0,0 +1,103 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func newSensitiveStore(t *testing.T, opts StoreOptions) *InMemoryStore {
+	t.Helper()
+	tool := makeToolWithSchema("rotate_keys", "sec", "Rotate keys", map[string]any{"type": "object"})
+	opts.ToolResolver = func(string) (*toolmodel.Tool, error) { return &tool, nil }
+	store := NewInMemoryStore(opts)
+	mustRegisterDoc(t, store, "sec:rotate_keys", DocEntry{
+		Summary:   "Rotate signing keys",
+		Notes:     "Run only during the maintenance window.",
+		Examples:  []ToolExample{{Title: "Rotate", Args: map[string]any{"kid": "k1"}}},
+		Sensitive: true,
+	})
+	return store
+}
+
+func TestSensitive_GatesFullTier(t *testing.T) {
+	store := newSensitiveStore(t, StoreOptions{})
+	ctx := context.Background()
+
+	for _, level := range []DetailLevel{DetailSummary, DetailSchema} {
+		if _, err := store.DescribeToolContext(ctx, "sec:rotate_keys", level); err != nil {
+			t.Errorf("%s tier should stay open: %v", level, err)
+		}
+	}
+	if _, err := store.DescribeTool("sec:rotate_keys", DetailFull); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("DescribeTool(full) err = %v, want ErrUnauthorized", err)
+	}
+	if _, err := store.ListExamples("sec:rotate_keys", 5); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("ListExamples err = %v, want ErrUnauthorized", err)
+	}
+
+	authed := WithGrants(ctx, "other", GrantSensitiveDocs)
+	doc, err := store.DescribeToolContext(authed, "sec:rotate_keys", DetailFull)
+	if err != nil {
+		t.Fatalf("authorized DescribeToolContext failed: %v", err)
+	}
+	if doc.Notes == "" || len(doc.Examples) != 1 {
+		t.Errorf("authorized full doc = %+v", doc)
+	}
+	if examples, err := store.ListExamplesContext(authed, "sec:rotate_keys", 5); err != nil || len(examples) != 1 {
+		t.Errorf("authorized ListExamplesContext = %v, %v", examples, err)
+	}
+
+	// Admin APIs are unaffected.
+	if stored, err := store.GetDocEntry("sec:rotate_keys"); err != nil || !stored.Entry.Sensitive {
+		t.Errorf("GetDocEntry = %+v, %v", stored, err)
+	}
+}
+
+func TestSensitive_CustomAuthorizer(t *testing.T) {
+	type tokenKey struct{}
+	store := newSensitiveStore(t, StoreOptions{
+		AuthorizeSensitive: func(ctx context.Context, id string) bool {
+			return ctx.Value(tokenKey{}) == "secret-"+id
+		},
+	})
+
+	if _, err := store.DescribeToolContext(WithGrants(context.Background(), GrantSensitiveDocs), "sec:rotate_keys", DetailFull); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("default grant should not apply with a custom authorizer: %v", err)
+	}
+	ctx := context.WithValue(context.Background(), tokenKey{}, "secret-sec:rotate_keys")
+	if _, err := store.DescribeToolContext(ctx, "sec:rotate_keys", DetailFull); err != nil {
+		t.Errorf("custom authorizer rejected valid token: %v", err)
+	}
+}
+
+func TestWithGrants(t *testing.T) {
+	ctx := WithGrants(context.Background(), "a")
+	ctx = WithGrants(ctx, "b", "a")
+	if !HasGrant(ctx, "a") || !HasGrant(ctx, "b") || HasGrant(ctx, "c") {
+		t.Errorf("grants = %v", GrantsFromContext(ctx))
+	}
+	if got := GrantsFromContext(context.Background()); len(got) != 0 {
+		t.Errorf("empty context grants = %v", got)
+	}
+}
+
+func TestSensitive_MergeAndImport(t *testing.T) {
+	merged := MergeDocEntries(DocEntry{Sensitive: true}, DocEntry{Summary: "x"}, MergePolicy{})
+	if !merged.Sensitive {
+		t.Error("Sensitive should be sticky across merges")
+	}
+
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "a"})
+	report, err := store.Import(map[string]DocEntry{"ns:a": {Summary: "a", Sensitive: true}}, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if got := report.Changes[0].Fields; !reflect.DeepEqual(got, []string{"sensitive"}) {
+		t.Errorf("Fields = %v, want [sensitive]", got)
+	}
+}
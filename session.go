@@ -0,0 +1,212 @@
+package tooldocs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// SessionTracker remembers which tool docs were delivered in one agent
+// session, and at which level, so repeated requests return only what the
+// agent has not seen yet instead of refilling its context with the same
+// doc every turn. Create one per session; it is safe for concurrent use.
+type SessionTracker struct {
+	store Store
+
+	mu        sync.Mutex
+	delivered map[string]*deliveredDoc
+}
+
+// deliveredDoc accumulates the content delivered for one tool.
+type deliveredDoc struct {
+	level DetailLevel
+	doc   ToolDoc
+}
+
+// Delivery is the result of SessionTracker.DescribeTool.
+type Delivery struct {
+	// ID is the tool ID.
+	ID string `json:"id"`
+
+	// Level is the requested detail level.
+	Level DetailLevel `json:"level"`
+
+	// Doc holds the content not delivered earlier in the session: the
+	// whole doc on first delivery, only new or changed fields, sections,
+	// examples, and other list items after that. Omitted, NextDetail,
+	// Stale, and Experiment always describe the current doc. Doc is zero
+	// when AlreadyProvided is set.
+	Doc ToolDoc `json:"doc,omitzero"`
+
+	// Delta reports that Doc leaves out content delivered earlier.
+	Delta bool `json:"delta,omitempty"`
+
+	// AlreadyProvided is set when the request adds nothing to what was
+	// delivered: it is the highest level the doc was delivered at.
+	AlreadyProvided DetailLevel `json:"alreadyProvided,omitempty"`
+
+	// Note is a marker to show the agent in place of the doc when
+	// AlreadyProvided is set, e.g. "github:search: already provided at
+	// schema level".
+	Note string `json:"note,omitempty"`
+}
+
+// NewSessionTracker returns a tracker for a new session over store.
+func NewSessionTracker(store Store) *SessionTracker {
+	return &SessionTracker{store: store, delivered: make(map[string]*deliveredDoc)}
+}
+
+// DescribeTool describes id at level from the store (passing ctx when the
+// store accepts one) and returns the part not yet delivered in the
+// session, recording it as delivered. A doc that changed since delivery
+// is re-sent as a delta of the changed content.
+//
+// Returns the store's errors; failed requests are not recorded.
+func (t *SessionTracker) DescribeTool(ctx context.Context, id string, level DetailLevel) (Delivery, error) {
+	doc, err := describeLayer(ctx, t.store, id, level)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prev := t.delivered[id]
+	if prev == nil {
+		t.delivered[id] = &deliveredDoc{level: level, doc: doc}
+		return Delivery{ID: id, Level: level, Doc: doc}, nil
+	}
+
+	delta, fresh := docDelta(prev.doc, doc)
+	if tierRank(level) > tierRank(prev.level) {
+		prev.level = level
+	}
+	if !fresh {
+		return Delivery{
+			ID:              id,
+			Level:           level,
+			AlreadyProvided: prev.level,
+			Note:            fmt.Sprintf("%s: already provided at %s level", id, prev.level),
+		}, nil
+	}
+	prev.doc = mergeDelivered(prev.doc, doc)
+	return Delivery{ID: id, Level: level, Doc: delta, Delta: true}, nil
+}
+
+// Delivered returns the highest level id was delivered at in the session,
+// or "" if it was not.
+func (t *SessionTracker) Delivered(id string) DetailLevel {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if d := t.delivered[id]; d != nil {
+		return d.level
+	}
+	return ""
+}
+
+// Forget marks the docs of ids as not delivered, e.g. after the agent's
+// context was compacted and they dropped out of it. With no ids, Forget
+// resets the session.
+func (t *SessionTracker) Forget(ids ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(ids) == 0 {
+		clear(t.delivered)
+		return
+	}
+	for _, id := range ids {
+		delete(t.delivered, id)
+	}
+}
+
+// docDelta returns the content of doc not in prev, and whether there is
+// any. Values equal to the delivered ones are cleared and list fields keep
+// only items not delivered; Omitted, NextDetail, Stale, and Experiment are
+// kept as is.
+func docDelta(prev, doc ToolDoc) (ToolDoc, bool) {
+	delta := ToolDoc{
+		Tool:               newValue(prev.Tool, doc.Tool),
+		Title:              newValue(prev.Title, doc.Title),
+		Summary:            newValue(prev.Summary, doc.Summary),
+		SchemaInfo:         newValue(prev.SchemaInfo, doc.SchemaInfo),
+		Capabilities:       newValue(prev.Capabilities, doc.Capabilities),
+		Effects:            newItems(prev.Effects, doc.Effects),
+		Idempotent:         doc.Idempotent && !prev.Idempotent,
+		RetryPolicy:        newValue(prev.RetryPolicy, doc.RetryPolicy),
+		Concurrency:        newValue(prev.Concurrency, doc.Concurrency),
+		ClientRequirements: newValue(prev.ClientRequirements, doc.ClientRequirements),
+		ShortNotes:         newValue(prev.ShortNotes, doc.ShortNotes),
+		Notes:              newValue(prev.Notes, doc.Notes),
+		Sections:           newItems(prev.Sections, doc.Sections),
+		Examples:           newItems(prev.Examples, doc.Examples),
+		Prerequisites:      newItems(prev.Prerequisites, doc.Prerequisites),
+		Edges:              newItems(prev.Edges, doc.Edges),
+		ExternalRefs:       newItems(prev.ExternalRefs, doc.ExternalRefs),
+	}
+	fresh := !reflect.ValueOf(delta).IsZero()
+	delta.Omitted = doc.Omitted
+	delta.NextDetail = doc.NextDetail
+	delta.Stale = doc.Stale
+	delta.Experiment = doc.Experiment
+	return delta, fresh
+}
+
+// mergeDelivered adds the content of doc to the delivered prev: set values
+// replace delivered ones and new list items are appended to copies, so
+// slices shared with the store are never written.
+func mergeDelivered(prev, doc ToolDoc) ToolDoc {
+	prev.Tool = setValue(prev.Tool, doc.Tool)
+	prev.Title = setValue(prev.Title, doc.Title)
+	prev.Summary = setValue(prev.Summary, doc.Summary)
+	prev.SchemaInfo = setValue(prev.SchemaInfo, doc.SchemaInfo)
+	prev.Capabilities = setValue(prev.Capabilities, doc.Capabilities)
+	prev.Effects = slices.Concat(prev.Effects, newItems(prev.Effects, doc.Effects))
+	prev.Idempotent = prev.Idempotent || doc.Idempotent
+	prev.RetryPolicy = setValue(prev.RetryPolicy, doc.RetryPolicy)
+	prev.Concurrency = setValue(prev.Concurrency, doc.Concurrency)
+	prev.ClientRequirements = setValue(prev.ClientRequirements, doc.ClientRequirements)
+	prev.ShortNotes = setValue(prev.ShortNotes, doc.ShortNotes)
+	prev.Notes = setValue(prev.Notes, doc.Notes)
+	prev.Sections = slices.Concat(prev.Sections, newItems(prev.Sections, doc.Sections))
+	prev.Examples = slices.Concat(prev.Examples, newItems(prev.Examples, doc.Examples))
+	prev.Prerequisites = slices.Concat(prev.Prerequisites, newItems(prev.Prerequisites, doc.Prerequisites))
+	prev.Edges = slices.Concat(prev.Edges, newItems(prev.Edges, doc.Edges))
+	prev.ExternalRefs = slices.Concat(prev.ExternalRefs, newItems(prev.ExternalRefs, doc.ExternalRefs))
+	return prev
+}
+
+// newValue returns v, or its zero value if it equals the delivered value.
+func newValue[T any](delivered, v T) T {
+	if reflect.DeepEqual(delivered, v) {
+		var zero T
+		return zero
+	}
+	return v
+}
+
+// setValue returns v, or delivered if v is unset.
+func setValue[T any](delivered, v T) T {
+	if reflect.ValueOf(&v).Elem().IsZero() {
+		return delivered
+	}
+	return v
+}
+
+// newItems returns the items of items not among the delivered ones.
+func newItems[T any](delivered, items []T) []T {
+	var out []T
+	for _, item := range items {
+		seen := false
+		for _, d := range delivered {
+			if reflect.DeepEqual(d, item) {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			out = append(out, item)
+		}
+	}
+	return out
+}
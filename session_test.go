@@ -0,0 +1,92 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSessionTracker_Deltas(t *testing.T) {
+	s := NewInMemoryStore(StoreOptions{Index: newCatalogIndex(t, "search")})
+	mustRegisterDoc(t, s, "ns:search", DocEntry{
+		Summary:    "Search issues",
+		ShortNotes: "Max 100 results.",
+		Notes:      "Use the cursor to page.",
+		Examples:   []ToolExample{{Title: "Open bugs", Args: map[string]any{"q": "is:open"}}},
+	})
+	ctx := context.Background()
+	tracker := NewSessionTracker(s)
+
+	d, err := tracker.DescribeTool(ctx, "ns:search", DetailSummary)
+	if err != nil || d.Delta || d.AlreadyProvided != "" || d.Doc.Summary != "Search issues" {
+		t.Fatalf("first delivery = %+v, %v; want the whole doc", d, err)
+	}
+
+	d = mustDeliver(t, tracker, ctx, "ns:search", DetailSummary)
+	if d.AlreadyProvided != DetailSummary || d.Note != "ns:search: already provided at summary level" {
+		t.Errorf("repeat = %+v, want the already-provided marker", d)
+	}
+
+	d = mustDeliver(t, tracker, ctx, "ns:search", DetailSchema)
+	if !d.Delta || d.Doc.Summary != "" || d.Doc.Tool == nil || d.Doc.ShortNotes == "" {
+		t.Errorf("schema delivery = %+v, want the schema-tier delta only", d.Doc)
+	}
+
+	d = mustDeliver(t, tracker, ctx, "ns:search", DetailFull)
+	if !d.Delta || d.Doc.Tool != nil || d.Doc.ShortNotes != "" || d.Doc.Notes == "" || len(d.Doc.Examples) != 1 {
+		t.Errorf("full delivery = %+v, want notes and examples only", d.Doc)
+	}
+	if got := tracker.Delivered("ns:search"); got != DetailFull {
+		t.Errorf("Delivered = %q, want full", got)
+	}
+
+	// Lower levels add nothing once full was delivered.
+	d = mustDeliver(t, tracker, ctx, "ns:search", DetailSchema)
+	if d.AlreadyProvided != DetailFull {
+		t.Errorf("schema after full = %+v, want already provided at full", d)
+	}
+
+	// Changed docs are re-sent as a delta.
+	mustRegisterDoc(t, s, "ns:search", DocEntry{
+		Summary:    "Search issues",
+		ShortNotes: "Max 100 results.",
+		Notes:      "Use the cursor to page; pages expire after 5 minutes.",
+		Examples: []ToolExample{
+			{Title: "Open bugs", Args: map[string]any{"q": "is:open"}},
+			{Title: "Mine", Args: map[string]any{"q": "author:@me"}},
+		},
+	})
+	d = mustDeliver(t, tracker, ctx, "ns:search", DetailFull)
+	if !d.Delta || d.Doc.Summary != "" || d.Doc.Notes == "" || len(d.Doc.Examples) != 1 || d.Doc.Examples[0].Title != "Mine" {
+		t.Errorf("changed doc = %+v, want the new notes and example", d.Doc)
+	}
+
+	tracker.Forget("ns:search")
+	d = mustDeliver(t, tracker, ctx, "ns:search", DetailSummary)
+	if d.Delta || d.Doc.Summary == "" {
+		t.Errorf("after Forget = %+v, want a full redelivery", d)
+	}
+	tracker.Forget()
+	if got := tracker.Delivered("ns:search"); got != "" {
+		t.Errorf("Delivered after reset = %q, want none", got)
+	}
+}
+
+func TestSessionTracker_ErrorsNotRecorded(t *testing.T) {
+	tracker := NewSessionTracker(NewInMemoryStore(StoreOptions{}))
+	if _, err := tracker.DescribeTool(context.Background(), "ns:missing", DetailSummary); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+	if got := tracker.Delivered("ns:missing"); got != "" {
+		t.Errorf("Delivered = %q, want failed requests unrecorded", got)
+	}
+}
+
+func mustDeliver(t *testing.T, tracker *SessionTracker, ctx context.Context, id string, level DetailLevel) Delivery {
+	t.Helper()
+	d, err := tracker.DescribeTool(ctx, id, level)
+	if err != nil {
+		t.Fatalf("DescribeTool(%s, %s): %v", id, level, err)
+	}
+	return d
+}
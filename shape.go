@@ -0,0 +1,128 @@
+package tooldocs
+
+import (
+	"math"
+	"sort"
+)
+
+// FindToolsByArgsShape returns the IDs of documented tools whose input
+// schema accepts the given argument payload, best match first.
+//
+// A tool is a candidate when every required parameter is present, every
+// argument with a declared type has a compatible JSON type, and no argument
+// is unknown to a schema that sets additionalProperties to false. Candidates
+// are ranked by the number of arguments matching declared properties minus
+// the number of undeclared arguments, then by ID.
+//
+// Only tools with registered documentation are considered; tools whose
+// schema cannot be resolved are skipped. This is a heuristic intended for
+// routing legacy payloads and debugging, not for validation.
+func (s *InMemoryStore) FindToolsByArgsShape(args map[string]any) []string {
+	type candidate struct {
+		id    string
+		score int
+	}
+
+	var candidates []candidate
+	for _, id := range s.DocIDs() {
+		tool, err := s.resolveTool(id)
+		if err != nil || tool == nil {
+			continue
+		}
+		if score, ok := matchArgsShape(schemaAsMap(tool.InputSchema), args); ok {
+			candidates = append(candidates, candidate{id: id, score: score})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.id
+	}
+	return ids
+}
+
+// matchArgsShape reports whether args fit schema and returns a match score.
+func matchArgsShape(schema map[string]any, args map[string]any) (int, bool) {
+	if schema == nil {
+		return 0, false
+	}
+
+	for _, name := range toStringSlice(schema["required"]) {
+		if _, ok := args[name]; !ok {
+			return 0, false
+		}
+	}
+
+	props, _ := schema["properties"].(map[string]any)
+	closed := schema["additionalProperties"] == false
+
+	score := 0
+	for name, value := range args {
+		prop, declared := props[name].(map[string]any)
+		if !declared {
+			if closed {
+				return 0, false
+			}
+			score--
+			continue
+		}
+		if !valueMatchesTypes(value, schemaTypes(prop["type"])) {
+			return 0, false
+		}
+		score++
+	}
+	return score, true
+}
+
+// schemaTypes returns the JSON Schema type names declared by a "type" keyword.
+func schemaTypes(t any) []string {
+	if tv, ok := t.(string); ok {
+		return []string{tv}
+	}
+	return toStringSlice(t)
+}
+
+// valueMatchesTypes reports whether v is an instance of any of the JSON
+// Schema types. An empty type list accepts any value.
+func valueMatchesTypes(v any, types []string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	actual := jsonType(v)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON Schema type name of a normalized Go value.
+// Integral numbers report "integer".
+func jsonType(v any) string {
+	switch val := deepCopyValue(v).(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		f, ok := normalizeNumeric(val).(float64)
+		if !ok {
+			return ""
+		}
+		if f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return "integer"
+		}
+		return "number"
+	}
+}
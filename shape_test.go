@@ -0,0 +1,122 @@
+package tooldocs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolindex"
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestFindToolsByArgsShape(t *testing.T) {
+	idx := toolindex.NewInMemoryIndex()
+	backend := toolmodel.ToolBackend{
+		Kind:  toolmodel.BackendKindLocal,
+		Local: &toolmodel.LocalBackend{Name: "handler"},
+	}
+	tools := []toolmodel.Tool{
+		makeToolWithSchema("create_ticket", "jira", "Create a ticket", map[string]any{
+			"type":     "object",
+			"required": []any{"title", "project"},
+			"properties": map[string]any{
+				"title":    map[string]any{"type": "string"},
+				"project":  map[string]any{"type": "string"},
+				"priority": map[string]any{"type": "integer"},
+			},
+		}),
+		makeToolWithSchema("search", "jira", "Search tickets", map[string]any{
+			"type":     "object",
+			"required": []any{"query"},
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string"},
+			},
+		}),
+		makeToolWithSchema("create_issue", "github", "Create an issue", map[string]any{
+			"type":                 "object",
+			"required":             []any{"title"},
+			"additionalProperties": false,
+			"properties": map[string]any{
+				"title": map[string]any{"type": "string"},
+				"body":  map[string]any{"type": "string"},
+			},
+		}),
+		makeToolWithSchema("note", "misc", "Take a note", map[string]any{
+			"type":     "object",
+			"required": []any{"title"},
+			"properties": map[string]any{
+				"title": map[string]any{"type": "string"},
+			},
+		}),
+	}
+
+	store := NewInMemoryStore(StoreOptions{Index: idx})
+	for _, tool := range tools {
+		if err := idx.RegisterTool(tool, backend); err != nil {
+			t.Fatalf("RegisterTool failed: %v", err)
+		}
+		mustRegisterDoc(t, store, tool.ToolID(), DocEntry{Summary: tool.Description})
+	}
+	// Docs without a resolvable tool are ignored
+	mustRegisterDoc(t, store, "docs:only", DocEntry{Summary: "no tool"})
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want []string
+	}{
+		{
+			name: "ticket payload",
+			args: map[string]any{"title": "Bug", "project": "OPS", "priority": 2},
+			want: []string{"jira:create_ticket", "misc:note"},
+		},
+		{
+			name: "wrong type excludes",
+			args: map[string]any{"title": "Bug", "project": "OPS", "priority": "high"},
+			want: []string{"misc:note"},
+		},
+		{
+			name: "closed schema excludes unknown keys",
+			args: map[string]any{"title": "Bug", "labels": []string{"x"}},
+			want: []string{"misc:note"},
+		},
+		{
+			name: "closed schema matches declared keys",
+			args: map[string]any{"title": "Bug", "body": "details"},
+			want: []string{"github:create_issue", "misc:note"},
+		},
+		{
+			name: "no match",
+			args: map[string]any{"unrelated": true},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := store.FindToolsByArgsShape(tt.args)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindToolsByArgsShape = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONType(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{nil, "null"},
+		{"s", "string"},
+		{true, "boolean"},
+		{3, "integer"},
+		{3.0, "integer"},
+		{3.5, "number"},
+		{[]string{"a"}, "array"},
+		{map[string]any{}, "object"},
+	}
+	for _, tt := range tests {
+		if got := jsonType(tt.v); got != tt.want {
+			t.Errorf("jsonType(%v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package tooldocs
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// lastKnownTools remembers the last tool each lookup resolved successfully,
+// for StoreOptions.ServeStale.
+type lastKnownTools struct {
+	mu    sync.Mutex
+	tools map[string]*toolmodel.Tool
+}
+
+func (c *lastKnownTools) get(id string) *toolmodel.Tool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tools[id]
+}
+
+func (c *lastKnownTools) put(id string, tool *toolmodel.Tool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tools == nil {
+		c.tools = make(map[string]*toolmodel.Tool)
+	}
+	c.tools[id] = tool
+}
+
+// withStaleFallback applies StoreOptions.ServeStale to a lookup result: a
+// successful lookup refreshes the last-known tool, and a failed one (other
+// than ErrNotFound, which reports the tool as gone) is replaced by the
+// last-known tool when there is one. stale reports the replacement.
+func (s *InMemoryStore) withStaleFallback(id string, tool *toolmodel.Tool, err error) (_ *toolmodel.Tool, stale bool, _ error) {
+	if !s.serveStale {
+		return tool, false, err
+	}
+	if err == nil {
+		if tool != nil {
+			s.lastKnown.put(id, tool)
+		}
+		return tool, false, nil
+	}
+	if errors.Is(err, ErrNotFound) {
+		return tool, false, err
+	}
+	if cached := s.lastKnown.get(id); cached != nil {
+		return cached, true, nil
+	}
+	return tool, false, err
+}
@@ -0,0 +1,91 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestServeStale(t *testing.T) {
+	var failWith error
+	var staleEvents int
+	opts := StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			if failWith != nil {
+				return nil, failWith
+			}
+			tool := makeToolWithSchema("search", "gh", "Search", map[string]any{"type": "object"})
+			return &tool, nil
+		},
+		OnRead: func(_ context.Context, e ReadEvent) {
+			if e.Stale {
+				staleEvents++
+			}
+		},
+		ServeStale: true,
+	}
+	store := NewInMemoryStore(opts)
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Summary: "Search issues"})
+
+	// Nothing cached yet: the outage is reported.
+	failWith = errors.New("upstream unavailable")
+	if _, err := store.DescribeTool("gh:search", DetailSchema); err == nil {
+		t.Fatal("DescribeTool with empty cache succeeded")
+	}
+
+	failWith = nil
+	fresh, err := store.DescribeTool("gh:search", DetailSchema)
+	if err != nil || fresh.Stale {
+		t.Fatalf("DescribeTool = %+v, %v; want fresh doc", fresh, err)
+	}
+
+	failWith = fmt.Errorf("%w: gh:search", ErrLookupTimeout)
+	for _, level := range []DetailLevel{DetailSummary, DetailSchema, DetailFull} {
+		doc, err := store.DescribeTool("gh:search", level)
+		if err != nil {
+			t.Fatalf("DescribeTool(%s) during outage failed: %v", level, err)
+		}
+		if !doc.Stale {
+			t.Errorf("DescribeTool(%s).Stale = false, want true", level)
+		}
+		if level != DetailSummary && (doc.Tool == nil || doc.Tool.Name != "search") {
+			t.Errorf("DescribeTool(%s).Tool = %v, want last-known tool", level, doc.Tool)
+		}
+	}
+	if _, err := store.ListExamples("gh:search", 1); err != nil {
+		t.Errorf("ListExamples during outage failed: %v", err)
+	}
+	if staleEvents != 3 {
+		t.Errorf("stale ReadEvents = %d, want 3", staleEvents)
+	}
+
+	// ErrNotFound means the tool is gone, not an outage.
+	failWith = fmt.Errorf("%w: gh:search", ErrNotFound)
+	if _, err := store.DescribeTool("gh:search", DetailSchema); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DescribeTool error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestServeStale_Disabled(t *testing.T) {
+	fail := false
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			if fail {
+				return nil, errors.New("upstream unavailable")
+			}
+			tool := makeToolWithSchema("search", "gh", "Search", map[string]any{"type": "object"})
+			return &tool, nil
+		},
+	})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Summary: "Search issues"})
+	if _, err := store.DescribeTool("gh:search", DetailSchema); err != nil {
+		t.Fatal(err)
+	}
+	fail = true
+	if _, err := store.DescribeTool("gh:search", DetailSchema); err == nil {
+		t.Error("DescribeTool succeeded during outage without ServeStale")
+	}
+}
@@ -0,0 +1,108 @@
+package tooldocs
+
+import "unique"
+
+// Stats summarizes the size of a store's registered documentation.
+type Stats struct {
+	// Docs is the number of tools with registered documentation.
+	Docs int `json:"docs"`
+
+	// Examples is the total number of stored examples.
+	Examples int `json:"examples"`
+
+	// TextBytes is the size of all interned text (titles, summaries, notes,
+	// external refs, sources, and example titles, descriptions, and result
+	// hints) as if every occurrence were stored separately.
+	TextBytes int `json:"textBytes"`
+
+	// UniqueTextBytes is the size of the distinct interned strings, i.e.
+	// what is actually retained.
+	UniqueTextBytes int `json:"uniqueTextBytes"`
+
+	// DedupSavedBytes is TextBytes minus UniqueTextBytes: memory saved by
+	// sharing identical boilerplate across tools.
+	DedupSavedBytes int `json:"dedupSavedBytes"`
+
+	// MemoryBytes estimates the memory retained by the registered docs:
+	// their fields, text, and example args. Interned text is counted for
+	// every doc holding it, so the estimate errs high.
+	// StoreOptions.MaxMemoryBytes is enforced against it.
+	MemoryBytes int `json:"memoryBytes"`
+
+	// Evictions counts docs evicted to stay within StoreOptions.MaxDocs and
+	// MaxMemoryBytes.
+	Evictions int `json:"evictions"`
+}
+
+// intern returns the canonical copy of s, so identical strings registered
+// for many tools (common in generated catalogs) share one allocation.
+// Canonical copies are released by the garbage collector once unused.
+func intern(s string) string {
+	if s == "" {
+		return ""
+	}
+	return unique.Make(s).Value()
+}
+
+// internStrings interns each element of ss in place.
+func internStrings(ss []string) []string {
+	for i, s := range ss {
+		ss[i] = intern(s)
+	}
+	return ss
+}
+
+// Stats reports document counts, text deduplication savings, and the
+// estimated memory footprint.
+func (s *InMemoryStore) Stats() Stats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st := Stats{Docs: len(s.docs)}
+	seen := make(map[string]bool)
+	add := func(text string) {
+		if text == "" {
+			return
+		}
+		st.TextBytes += len(text)
+		if !seen[text] {
+			seen[text] = true
+			st.UniqueTextBytes += len(text)
+		}
+	}
+	for _, record := range s.docs {
+		st.MemoryBytes += recordSize(record)
+		add(record.title)
+		add(record.summary)
+		add(record.notes)
+		add(record.source)
+		for _, p := range record.prerequisites {
+			add(p.Description)
+		}
+		for _, e := range record.effects {
+			add(e.Description)
+		}
+		for _, d := range record.dependencies {
+			add(d.Description)
+		}
+		if record.retryPolicy != nil {
+			add(record.retryPolicy.Backoff)
+		}
+		for _, ref := range record.externalRefs {
+			add(ref)
+		}
+		st.Examples += len(record.examples)
+		for _, ex := range record.examples {
+			add(ex.Title)
+			add(ex.Description)
+			add(ex.ResultHint)
+		}
+	}
+	st.DedupSavedBytes = st.TextBytes - st.UniqueTextBytes
+	if s.lru != nil {
+		s.lru.mu.Lock()
+		st.Evictions = s.lru.evictions
+		s.lru.mu.Unlock()
+	}
+	return st
+}
@@ -0,0 +1,53 @@
+package tooldocs
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestStats_DedupSavings(t *testing.T) {
+	s := NewInMemoryStore(StoreOptions{})
+	boilerplate := "Generated from the OpenAPI spec. Rate limited to 100 requests per minute."
+	for _, id := range []string{"api:a", "api:b", "api:c"} {
+		if err := s.RegisterDoc(id, DocEntry{
+			Summary:  "Call " + id,
+			Notes:    boilerplate,
+			Examples: []ToolExample{{Title: "Basic", Description: "Minimal call"}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	st := s.Stats()
+	if st.Docs != 3 || st.Examples != 3 {
+		t.Errorf("Docs=%d Examples=%d, want 3, 3", st.Docs, st.Examples)
+	}
+	shared := len(boilerplate) + len("Basic") + len("Minimal call")
+	if st.DedupSavedBytes != 2*shared {
+		t.Errorf("DedupSavedBytes = %d, want %d", st.DedupSavedBytes, 2*shared)
+	}
+	if st.TextBytes-st.UniqueTextBytes != st.DedupSavedBytes {
+		t.Errorf("inconsistent stats: %+v", st)
+	}
+}
+
+func TestRegisterDoc_InternsText(t *testing.T) {
+	s := NewInMemoryStore(StoreOptions{})
+	// Build the notes separately so the inputs do not share storage.
+	notes1 := strings.Repeat("shared ", 10)
+	notes2 := strings.Repeat("shared ", 10)
+	if err := s.RegisterDoc("x:a", DocEntry{Summary: "a", Notes: notes1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RegisterDoc("x:b", DocEntry{Summary: "b", Notes: notes2}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.mu.RLock()
+	a, b := s.docs["x:a"].notes, s.docs["x:b"].notes
+	s.mu.RUnlock()
+	if unsafe.StringData(a) != unsafe.StringData(b) {
+		t.Error("identical notes were not interned")
+	}
+}
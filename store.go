@@ -1,13 +1,21 @@
 package tooldocs
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/jonwraymond/toolindex"
 	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // Error values for consistent error handling.
@@ -28,6 +36,19 @@ var (
 	// ErrArgsTooLarge is returned when an example's Args exceeds depth or size caps.
 	// The error message includes which example and what limits were exceeded.
 	ErrArgsTooLarge = errors.New("args exceeds caps")
+
+	// ErrExampleLimit is returned when a registration would leave a tool with
+	// more examples than StoreOptions.MaxExamplesPerTool, or with examples
+	// larger in total than StoreOptions.MaxExampleBytes.
+	ErrExampleLimit = errors.New("example limit exceeded")
+
+	// ErrUnauthorized is returned when the full tier or examples of a
+	// Sensitive tool are requested without authorization.
+	ErrUnauthorized = errors.New("unauthorized for sensitive docs")
+
+	// ErrFrozen is returned when a write targets a tool whose documentation
+	// has been frozen with Freeze.
+	ErrFrozen = errors.New("tool docs are frozen")
 )
 
 // Store defines the interface for tool documentation storage.
@@ -66,38 +87,386 @@ type StoreOptions struct {
 	Index toolindex.Index
 
 	// ToolResolver is an optional injection path for resolving a tool by ID
-	// when Index is nil or does not contain the tool.
+	// when Index is nil or does not contain the tool. A panic is recovered
+	// and returned as a *HookPanicError.
 	ToolResolver func(id string) (*toolmodel.Tool, error)
 
+	// ContextToolResolver is ToolResolver with the caller's context, e.g. to
+	// forward TraceID(ctx) upstream. It takes precedence over ToolResolver.
+	// Reads without a caller context (DescribeTool, Reconcile, ...) pass
+	// context.Background(). Panics and LookupTimeout are handled as for
+	// ToolResolver.
+	ContextToolResolver func(ctx context.Context, id string) (*toolmodel.Tool, error)
+
+	// BatchToolResolver resolves many tools in one upstream round trip.
+	// DescribeTools uses it for every ID the Index does not know; IDs
+	// missing from the returned map are treated as unknown. When
+	// ToolResolver is nil, single lookups also go through it. Panics and
+	// LookupTimeout are handled as for ToolResolver.
+	BatchToolResolver func(ids []string) (map[string]*toolmodel.Tool, error)
+
+	// LookupTimeout bounds each Index.GetTool and ToolResolver call. A
+	// lookup that does not answer in time fails with ErrLookupTimeout.
+	// ContextToolResolver's context is cancelled at the deadline; other
+	// hooks cannot be cancelled, so the abandoned call keeps running in the
+	// background until it returns. While 64 abandoned calls are still
+	// running, further lookups fail with ErrLookupTimeout at once instead
+	// of starting more. Zero means no timeout.
+	LookupTimeout time.Duration
+
 	// MaxExamples is the default maximum number of examples to return.
 	// Zero means no limit (use ListExamples max parameter).
 	MaxExamples int
+
+	// MaxExamplesPerTool caps the number of examples a tool may hold.
+	// Registrations that would exceed it fail with ErrExampleLimit instead of
+	// being trimmed on read. Zero means no limit.
+	MaxExamplesPerTool int
+
+	// MaxExampleBytes caps the total JSON-encoded size of a tool's examples.
+	// Registrations that would exceed it fail with ErrExampleLimit.
+	// Zero means no limit.
+	MaxExampleBytes int
+
+	// WriteRateLimit optionally limits registration calls per source
+	// (DocEntry.Source or ImportOptions.Source; unattributed writes share
+	// one bucket). Throttled calls fail fast with ErrThrottled before taking
+	// the write lock, so a misbehaving sync job cannot starve readers.
+	// Nil disables limiting.
+	WriteRateLimit *RateLimit
+
+	// DuplicateExamples controls how examples with the same Title and
+	// normalized Args are handled at registration.
+	// Empty means DuplicatesAllow.
+	DuplicateExamples DuplicatePolicy
+
+	// RejectUnknownArgTypes makes registration fail with an *ArgTypeError
+	// (matching ErrUnknownArgType) when example Args contain values that are
+	// not JSON-compatible, such as structs or pointers. By default such
+	// values are stored by reference and only surface when a consumer
+	// marshals the example.
+	RejectUnknownArgTypes bool
+
+	// ArgsNormalization selects how example Args are normalized at
+	// registration. Empty means NormalizeCopy. RejectUnknownArgTypes
+	// applies to NormalizeCopy only.
+	ArgsNormalization ArgsNormalization
+
+	// Environment lists the requirements this deployment satisfies (see
+	// ToolExample.Requires). Examples with unmet requirements are omitted
+	// from DescribeTool and ListExamples and skipped by ReplayExamples.
+	Environment []string
+
+	// AuthorizeSensitive decides whether a caller may read the full tier of
+	// a Sensitive tool. Nil means the context must carry GrantSensitiveDocs
+	// (see WithGrants). A panic is recovered and treated as a denial.
+	AuthorizeSensitive func(ctx context.Context, id string) bool
+
+	// SummaryProfile, when set, is enforced at registration: RegisterDoc,
+	// Import, and SubmitForReview reject entries whose summary violates it
+	// with ErrSummaryStyle. Use LintSummaries to audit existing docs.
+	SummaryProfile *SummaryProfile
+
+	// SynthesizeSummaries makes DescribeTool fall back to a summary built
+	// from the tool name and its required parameters (e.g. "create_ticket:
+	// requires title, project") when neither the doc nor Tool.Description
+	// provides one.
+	SynthesizeSummaries bool
+
+	// Summarizer, when set, derives summaries for tools whose doc has none,
+	// ahead of the Tool.Description fallback. Its output is cached per tool
+	// and reused until the DocEntry or Tool it was derived from changes. An
+	// error or panic falls back to Tool.Description and is retried on the
+	// next read. The entry passed includes the notes of Sensitive tools,
+	// and the derived summary is served at every level.
+	Summarizer Summarizer
+
+	// OnRead is called after every DescribeTool, DescribeTools (once per
+	// ID), and ListExamples call with the outcome, for logging and metrics.
+	// A panic is recovered and ignored.
+	OnRead func(ctx context.Context, e ReadEvent)
+
+	// ServeStale keeps the last tool each lookup resolved and, when a later
+	// Index or resolver lookup fails (e.g. ErrLookupTimeout or a resolver
+	// outage), serves it instead of the error, with ToolDoc.Stale set.
+	// Lookup errors matching ErrNotFound are returned as usual. It likewise
+	// serves expired docs when DocResolver fails to refresh them.
+	ServeStale bool
+
+	// ExampleSelector chooses which examples DescribeTool (full level) and
+	// ListExamples return when capping them. Nil keeps the first N in
+	// authored order (FirstNSelector).
+	ExampleSelector ExampleSelector
+
+	// AssignVariant chooses the arm of a running experiment served to a
+	// caller. variants lists ControlVariant first, then the experiment's
+	// variant names. Returning "" or an unknown name leaves the caller
+	// unenrolled: it is served the registered doc and not counted. Nil
+	// hashes ExperimentSubject(ctx), enrolling only callers with a subject.
+	// A panic is recovered and treated as unenrolled.
+	AssignVariant func(ctx context.Context, experiment string, variants []string) string
+
+	// OnExposure is called after each successful DescribeTool served from
+	// an experiment arm, e.g. to log exposures for offline analysis. A
+	// panic is recovered and ignored.
+	OnExposure func(ctx context.Context, e Exposure)
+
+	// OnCallOutcome is called for each RecordCallOutcome with the outcome
+	// paired to the describe that preceded it, e.g. to export telemetry
+	// for doc ranking. A panic is recovered and ignored.
+	OnCallOutcome func(ctx context.Context, e CallOutcome)
+
+	// AnonymizeOutcomes replaces CallOutcome.TraceID with a stable hash
+	// before OnCallOutcome sees it, so exported events can be grouped by
+	// trace without revealing trace IDs.
+	AnonymizeOutcomes bool
+
+	// SearchSynonyms lists groups of interchangeable single words for
+	// SearchDocs, e.g. {"ticket", "issue"}: a query term matches text
+	// containing any word of its groups. Matching is case-insensitive.
+	SearchSynonyms [][]string
+
+	// SearchStopWords are dropped from SearchDocs queries, e.g.
+	// DefaultStopWords. Matching is case-insensitive.
+	SearchStopWords []string
+
+	// Catalogs translates the store's own messages and boilerplate, keyed
+	// by BCP 47 locale tag (e.g. "de", "pt-BR"). The caller's locale comes
+	// from WithLocale; a tag without a catalog falls back to its base
+	// language, then to Locale, then to English. See Catalog.
+	Catalogs map[string]Catalog
+
+	// Locale is the default locale for callers without one.
+	Locale string
+
+	// Persister, when set, mirrors every successful documentation write
+	// and removal to durable storage. See Persister.
+	Persister Persister
+
+	// ChangeLog, when set, receives an append-only log of documentation
+	// writes and removals as JSON lines (see Change), for auditing and
+	// for rebuilding a store with ReplayLog.
+	ChangeLog io.Writer
+
+	// Author is recorded as Change.Author for writes by this store.
+	Author string
+
+	// DefaultDocTTL expires documentation that long after it was last
+	// registered or imported, unless RegisterOptions.TTL says otherwise.
+	// Expired docs are treated as missing by reads. Zero means docs never
+	// expire.
+	DefaultDocTTL time.Duration
+
+	// DocResolver, when set, is asked for fresh documentation when a read
+	// finds a tool's doc expired; the entry it returns is registered in
+	// place of the expired one. A nil entry or an ErrNotFound error leaves
+	// the doc expired. When it fails, the read fails with its error, or
+	// serves the expired doc as Stale under ServeStale.
+	DocResolver func(ctx context.Context, id string) (*DocEntry, error)
+
+	// MaxDocs caps the number of registered docs. A write that exceeds it
+	// evicts the least recently used doc (by reads and writes); frozen docs
+	// are never evicted. Evictions are recorded like removals: logged to
+	// ChangeLog as ChangeEvict and passed to Persister.DeleteDoc, so a
+	// replayed log or persisted mirror holds what memory holds. Zero means
+	// unlimited.
+	MaxDocs int
+
+	// MaxMemoryBytes caps the estimated memory footprint of registered docs
+	// (see Stats.MemoryBytes), evicting like MaxDocs. Zero means unlimited.
+	MaxMemoryBytes int
+
+	// Encryptor, when set, encrypts the free-text fields of docs written by
+	// persistent backends built from these options (FileStore, boltstore)
+	// and by the change log, and decrypts them on load and replay (see
+	// SealEntry). InMemoryStore holds plaintext.
+	Encryptor Encryptor
+
+	// Answerer answers AskAboutTool questions from a tool's documentation,
+	// typically with an LLM. Without it, AskAboutTool returns
+	// ErrNoAnswerer.
+	Answerer Answerer
+
+	// ResourceLoader fetches the text of an external reference (a URL or
+	// resource ID from DocEntry.ExternalRefs) for AskAboutTool, so
+	// long-form docs can inform answers. Failed loads are cited by
+	// reference only.
+	ResourceLoader func(ctx context.Context, ref string) (string, error)
 }
 
 // docRecord holds registered documentation for a tool.
 type docRecord struct {
-	summary      string
-	notes        string
-	examples     []ToolExample
-	externalRefs []string
+	title         string
+	annotations   *mcp.ToolAnnotations
+	summary       string
+	shortNotes    string
+	notes         string
+	sections      []DocSection
+	examples      []ToolExample
+	prerequisites []Prerequisite
+	effects       []Effect
+	dependencies  []Dependency
+	idempotent    bool
+	retryPolicy   *RetryPolicy
+	concurrency   *Concurrency
+	clientReqs    *ClientRequirements
+	externalRefs  []string
+	source        string
+	sensitive     bool
+	render        *RenderHints
+	frozen        bool
+	expires       time.Time // zero: never
+}
+
+// entry returns a deep copy of the record as a DocEntry.
+func (r *docRecord) entry() DocEntry {
+	refs := make([]string, len(r.externalRefs))
+	copy(refs, r.externalRefs)
+	return DocEntry{
+		Title:              r.title,
+		Annotations:        copyAnnotations(r.annotations),
+		Summary:            r.summary,
+		ShortNotes:         r.shortNotes,
+		Notes:              r.notes,
+		Sections:           slices.Clone(r.sections),
+		Examples:           copyExamples(r.examples),
+		Prerequisites:      slices.Clone(r.prerequisites),
+		Effects:            slices.Clone(r.effects),
+		Dependencies:       slices.Clone(r.dependencies),
+		Idempotent:         r.idempotent,
+		RetryPolicy:        copyRetryPolicy(r.retryPolicy),
+		Concurrency:        copyConcurrency(r.concurrency),
+		ClientRequirements: copyClientRequirements(r.clientReqs),
+		ExternalRefs:       refs,
+		Source:             r.source,
+		Sensitive:          r.sensitive,
+		Render:             copyRenderHints(r.render),
+	}
+}
+
+// StoredDoc is a snapshot of the documentation registered for a tool,
+// together with store-managed metadata.
+type StoredDoc struct {
+	// ID is the tool ID the documentation is registered under.
+	ID string
+
+	// Entry is a caller-owned copy of the registered documentation.
+	Entry DocEntry
+
+	// Frozen reports whether the documentation is protected from writes.
+	Frozen bool
+
+	// Expires is when the documentation's TTL passes; zero means never
+	// (see RegisterOptions.TTL).
+	Expires time.Time
 }
 
 // InMemoryStore is an in-memory implementation of Store.
 type InMemoryStore struct {
-	mu           sync.RWMutex
-	index        toolindex.Index
-	toolResolver func(id string) (*toolmodel.Tool, error)
-	docs         map[string]*docRecord
-	maxExamples  int
+	mu            sync.RWMutex
+	sealed        atomic.Bool // see Seal
+	index         toolindex.Index
+	toolResolver  func(id string) (*toolmodel.Tool, error)
+	ctxResolver   func(ctx context.Context, id string) (*toolmodel.Tool, error)
+	batchResolver func(ids []string) (map[string]*toolmodel.Tool, error)
+	lookupTimeout time.Duration
+	abandoned     atomic.Int64 // timed-out lookups still running; see withTimeout
+	docs          map[string]*docRecord
+	maxExamples   int
+	duplicates    DuplicatePolicy
+	maxPerTool    int
+	maxBytes      int
+	strictArgs    bool
+	jsonArgs      bool
+	limiter       *writeLimiter
+	environment   map[string]bool
+	authorize     func(ctx context.Context, id string) bool
+	style         *SummaryProfile
+	synthesize    bool
+	summarizer    Summarizer
+	summaries     summaryCache
+	flights       flightGroup
+	serveStale    bool
+	lastKnown     lastKnownTools
+	selector      ExampleSelector
+	toolsets      map[string]Toolset
+	placeholders  map[string]string      // name -> description
+	experiments   map[string]*experiment // by tool ID
+	assignVariant func(ctx context.Context, experiment string, variants []string) string
+	onExposure    func(ctx context.Context, e Exposure)
+	onRead        func(ctx context.Context, e ReadEvent)
+	onOutcome     func(ctx context.Context, e CallOutcome)
+	anonymize     bool
+	outcomes      outcomeState
+	search        searchConfig
+	searchIndex   searchIndex
+	catalogs      map[string]Catalog // by lowercase locale tag
+	locale        string
+	persister     Persister
+	changeLog     io.Writer
+	author        string
+	defaultTTL    time.Duration
+	docResolver   func(ctx context.Context, id string) (*DocEntry, error)
+	now           func() time.Time
+	maxDocs       int
+	maxMemory     int
+	lru           *docLRU
+	evicted       []string // evictions not yet logged or persisted; see saved
+	usage         exampleUsage
+	answerer      Answerer
+	loadResource  func(ctx context.Context, ref string) (string, error)
+	health        healthState
+	maintenance   maintenanceState
+	review        reviewState
+	opts          StoreOptions // for Clone
 }
 
 // NewInMemoryStore creates a new in-memory documentation store.
 func NewInMemoryStore(opts StoreOptions) *InMemoryStore {
 	return &InMemoryStore{
-		index:        opts.Index,
-		toolResolver: opts.ToolResolver,
-		docs:         make(map[string]*docRecord),
-		maxExamples:  opts.MaxExamples,
+		index:         opts.Index,
+		toolResolver:  opts.ToolResolver,
+		ctxResolver:   opts.ContextToolResolver,
+		batchResolver: opts.BatchToolResolver,
+		lookupTimeout: opts.LookupTimeout,
+		docs:          make(map[string]*docRecord),
+		maxExamples:   opts.MaxExamples,
+		duplicates:    opts.DuplicateExamples,
+		maxPerTool:    opts.MaxExamplesPerTool,
+		maxBytes:      opts.MaxExampleBytes,
+		strictArgs:    opts.RejectUnknownArgTypes,
+		jsonArgs:      opts.ArgsNormalization == NormalizeJSON,
+		limiter:       newWriteLimiter(opts.WriteRateLimit),
+		environment:   environmentSet(opts.Environment),
+		authorize:     opts.AuthorizeSensitive,
+		style:         opts.SummaryProfile,
+		synthesize:    opts.SynthesizeSummaries,
+		summarizer:    opts.Summarizer,
+		selector:      opts.ExampleSelector,
+		toolsets:      make(map[string]Toolset),
+		placeholders:  make(map[string]string),
+		experiments:   make(map[string]*experiment),
+		assignVariant: opts.AssignVariant,
+		onExposure:    opts.OnExposure,
+		onRead:        opts.OnRead,
+		onOutcome:     opts.OnCallOutcome,
+		anonymize:     opts.AnonymizeOutcomes,
+		search:        newSearchConfig(opts.SearchSynonyms, opts.SearchStopWords),
+		serveStale:    opts.ServeStale,
+		catalogs:      normalizeCatalogs(opts.Catalogs),
+		locale:        opts.Locale,
+		persister:     opts.Persister,
+		changeLog:     opts.ChangeLog,
+		author:        opts.Author,
+		defaultTTL:    opts.DefaultDocTTL,
+		docResolver:   opts.DocResolver,
+		now:           time.Now,
+		maxDocs:       opts.MaxDocs,
+		maxMemory:     opts.MaxMemoryBytes,
+		lru:           newDocLRU(opts.MaxDocs, opts.MaxMemoryBytes),
+		answerer:      opts.Answerer,
+		loadResource:  opts.ResourceLoader,
+		opts:          opts,
 	}
 }
 
@@ -107,146 +476,358 @@ func NewInMemoryStore(opts StoreOptions) *InMemoryStore {
 // Args in examples are deep-copied to prevent external mutation.
 //
 // Returns ErrArgsTooLarge if any example's Args exceeds MaxArgsDepth or MaxArgsKeys.
+// Returns ErrExampleLimit if the examples exceed the per-tool caps.
+// Returns ErrFrozen if the tool's documentation has been frozen.
+// Returns ErrThrottled if WriteRateLimit is exceeded for entry.Source.
+// Returns ErrPersist if StoreOptions.Persister or ChangeLog fails; the
+// write is kept.
+// Returns ErrSummaryStyle if the summary violates StoreOptions.SummaryProfile.
+// Returns ErrUnknownArgType if StoreOptions.RejectUnknownArgTypes is set and
+// example Args hold a value that is not JSON-compatible.
 func (s *InMemoryStore) RegisterDoc(id string, entry DocEntry) error {
+	return s.RegisterDocWithOptions(id, entry, RegisterOptions{})
+}
+
+// publish stores a prepared record as id's documentation, creating the
+// record if needed, and records the write as op by author (see saved).
+// Returns ErrFrozen if the existing record is frozen.
+func (s *InMemoryStore) publish(op ChangeOp, author, id string, prepared *docRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return err
+	}
+
+	record, exists := s.docs[id]
+	if exists && record.frozen {
+		return fmt.Errorf("%w: %s", ErrFrozen, id)
+	}
+	if !exists {
+		record = &docRecord{}
+		s.docs[id] = record
+	}
+
+	changes := s.exampleChanges(record.examples, prepared.examples)
+	record.setContent(prepared)
+	s.stored(id, record)
+
+	return s.saved(op, author, id, record, changes)
+}
+
+// prepareRecord validates, truncates, and deep-copies a DocEntry into a
+// detached docRecord ready to be stored.
+//
+// Returns ErrArgsTooLarge if any example's Args exceeds MaxArgsDepth or MaxArgsKeys.
+func (s *InMemoryStore) prepareRecord(entry DocEntry) (*docRecord, error) {
 	entry = entry.ValidateAndTruncate()
 
-	// Deep copy examples with their Args and validate caps
-	examples := make([]ToolExample, len(entry.Examples))
-	for i, ex := range entry.Examples {
-		// Deep copy first (normalizes types to map[string]any)
-		argsCopy := deepCopyArgs(ex.Args)
+	examples, err := s.prepareExamples(entry.Examples)
+	if err != nil {
+		return nil, err
+	}
+
+	record := newRecord(entry, examples)
+	record.expires = s.expiry(0)
+	return record, nil
+}
+
+// newRecord deep-copies entry into a detached docRecord holding examples,
+// without validation. Text is interned so boilerplate shared across tools
+// is stored once.
+func newRecord(entry DocEntry, examples []ToolExample) *docRecord {
+	externalRefs := make([]string, len(entry.ExternalRefs))
+	copy(externalRefs, entry.ExternalRefs)
+	return &docRecord{
+		title:         intern(entry.Title),
+		annotations:   copyAnnotations(entry.Annotations),
+		summary:       intern(entry.Summary),
+		shortNotes:    intern(entry.ShortNotes),
+		notes:         intern(entry.Notes),
+		sections:      internSections(entry.Sections),
+		examples:      examples,
+		prerequisites: internPrerequisites(entry.Prerequisites),
+		effects:       internEffects(entry.Effects),
+		dependencies:  internDependencies(entry.Dependencies),
+		idempotent:    entry.Idempotent,
+		retryPolicy:   entry.RetryPolicy,
+		concurrency:   entry.Concurrency,
+		clientReqs:    entry.ClientRequirements,
+		externalRefs:  internStrings(externalRefs),
+		source:        intern(entry.Source),
+		sensitive:     entry.Sensitive,
+		render:        entry.Render,
+	}
+}
+
+// prepareExamples truncates, deep-copies, and validates examples, then
+// applies the store's duplicate policy.
+//
+// Returns ErrArgsTooLarge if any example's Args exceeds MaxArgsDepth or MaxArgsKeys,
+// ErrDuplicateExample under DuplicatesReject, or ErrExampleLimit if the
+// per-tool example caps are exceeded.
+func (s *InMemoryStore) prepareExamples(examples []ToolExample) ([]ToolExample, error) {
+	prepared := make([]ToolExample, len(examples))
+	for i, ex := range examples {
+		argsCopy, err := s.normalizeArgs(ex.Args)
+		if err != nil {
+			return nil, fmt.Errorf("example %d (%s): %w", i, ex.Title, err)
+		}
 
 		// Validate caps on normalized copy
 		stats, valid := ValidateArgs(argsCopy)
 		if !valid {
-			return fmt.Errorf("%w: example %d (%s) has depth=%d (max %d), keys=%d (max %d)",
+			return nil, fmt.Errorf("%w: example %d (%s) has depth=%d (max %d), keys=%d (max %d)",
 				ErrArgsTooLarge, i, ex.Title, stats.Depth, MaxArgsDepth, stats.Keys, MaxArgsKeys)
 		}
 
-		examples[i] = ToolExample{
+		prepared[i] = ToolExample{
 			ID:          ex.ID,
-			Title:       ex.Title,
-			Description: ex.Description,
+			Title:       intern(ex.Title),
+			Description: intern(truncateString(ex.Description, MaxDescriptionLen)),
 			Args:        argsCopy,
-			ResultHint:  ex.ResultHint,
+			ResultHint:  intern(truncateString(ex.ResultHint, MaxResultHintLen)),
+			Requires:    slices.Clone(ex.Requires),
+			Source:      intern(ex.Source),
 		}
 	}
 
-	// Copy external refs
-	externalRefs := make([]string, len(entry.ExternalRefs))
-	copy(externalRefs, entry.ExternalRefs)
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	record, exists := s.docs[id]
-	if !exists {
-		record = &docRecord{}
-		s.docs[id] = record
+	prepared, err := s.dedupExamples(nil, prepared)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkExampleLimits(prepared); err != nil {
+		return nil, err
 	}
+	return prepared, nil
+}
 
-	record.summary = entry.Summary
-	record.notes = entry.Notes
-	record.examples = examples
-	record.externalRefs = externalRefs
+// normalizeArgs copies example Args per StoreOptions.ArgsNormalization,
+// applying RejectUnknownArgTypes under NormalizeCopy.
+func (s *InMemoryStore) normalizeArgs(args map[string]any) (map[string]any, error) {
+	if s.jsonArgs {
+		return normalizeArgsJSON(args)
+	}
+	if s.strictArgs {
+		if err := checkArgTypes(args); err != nil {
+			return nil, err
+		}
+	}
+	// Deep copy (normalizes types to map[string]any)
+	return deepCopyArgs(args), nil
+}
 
+// checkExampleLimits enforces MaxExamplesPerTool and MaxExampleBytes on the
+// complete example set a tool would hold after a registration.
+//
+// Returns ErrExampleLimit if either cap is exceeded.
+func (s *InMemoryStore) checkExampleLimits(examples []ToolExample) error {
+	if s.maxPerTool > 0 && len(examples) > s.maxPerTool {
+		return fmt.Errorf("%w: %d examples (max %d)", ErrExampleLimit, len(examples), s.maxPerTool)
+	}
+	if s.maxBytes > 0 && len(examples) > 0 {
+		data, err := json.Marshal(examples)
+		if err != nil {
+			return fmt.Errorf("%w: examples are not JSON-encodable: %v", ErrExampleLimit, err)
+		}
+		if len(data) > s.maxBytes {
+			return fmt.Errorf("%w: %d bytes of examples (max %d)", ErrExampleLimit, len(data), s.maxBytes)
+		}
+	}
 	return nil
 }
 
+// setContent replaces the documentation content of r with that of src,
+// preserving store-managed metadata such as the frozen flag. The expiry is
+// taken from src, since the content is new.
+func (r *docRecord) setContent(src *docRecord) {
+	r.expires = src.expires
+	r.title = src.title
+	r.annotations = src.annotations
+	r.summary = src.summary
+	r.shortNotes = src.shortNotes
+	r.notes = src.notes
+	r.sections = src.sections
+	r.examples = src.examples
+	r.prerequisites = src.prerequisites
+	r.effects = src.effects
+	r.dependencies = src.dependencies
+	r.idempotent = src.idempotent
+	r.retryPolicy = src.retryPolicy
+	r.concurrency = src.concurrency
+	r.clientReqs = src.clientReqs
+	r.externalRefs = src.externalRefs
+	r.source = src.source
+	r.sensitive = src.sensitive
+	r.render = src.render
+}
+
 // RegisterExamples adds or replaces examples for a tool.
 // Examples are validated and truncated to fit within caps.
 // Args are deep-copied to prevent external mutation.
 //
 // Returns ErrArgsTooLarge if any example's Args exceeds MaxArgsDepth or MaxArgsKeys.
+// Returns ErrExampleLimit if the examples exceed the per-tool caps.
+// Returns ErrFrozen if the tool's documentation has been frozen.
+// Returns ErrThrottled if WriteRateLimit is exceeded.
+// Returns ErrPersist if StoreOptions.Persister or ChangeLog fails; the
+// write is kept.
 func (s *InMemoryStore) RegisterExamples(id string, examples []ToolExample) error {
+	if err := s.limiter.allow(""); err != nil {
+		return err
+	}
+
 	limit := len(examples)
 	if s.maxExamples > 0 && limit > s.maxExamples {
 		limit = s.maxExamples
 	}
 
-	truncated := make([]ToolExample, limit)
-	for i := 0; i < limit; i++ {
-		ex := examples[i]
-		// Deep copy first (normalizes types to map[string]any)
-		argsCopy := deepCopyArgs(ex.Args)
-
-		// Validate caps on normalized copy
-		stats, valid := ValidateArgs(argsCopy)
-		if !valid {
-			return fmt.Errorf("%w: example %d (%s) has depth=%d (max %d), keys=%d (max %d)",
-				ErrArgsTooLarge, i, ex.Title, stats.Depth, MaxArgsDepth, stats.Keys, MaxArgsKeys)
-		}
-
-		truncated[i] = ToolExample{
-			ID:          ex.ID,
-			Title:       ex.Title,
-			Description: truncateString(ex.Description, MaxDescriptionLen),
-			Args:        argsCopy,
-			ResultHint:  truncateString(ex.ResultHint, MaxResultHintLen),
-		}
+	truncated, err := s.prepareExamples(examples[:limit])
+	if err != nil {
+		return err
 	}
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return err
+	}
 
 	record, exists := s.docs[id]
+	if exists && record.frozen {
+		return fmt.Errorf("%w: %s", ErrFrozen, id)
+	}
 	if !exists {
 		record = &docRecord{}
 		s.docs[id] = record
 	}
 
+	changes := s.exampleChanges(record.examples, truncated)
 	record.examples = truncated
+	s.stored(id, record)
 
-	return nil
+	return s.saved(ChangeRegisterExamples, "", id, record, changes)
+}
+
+// GetDocEntry returns the documentation registered for a tool, exactly as
+// stored (no tool resolution or tier filtering is applied).
+// Returns ErrNotFound if no documentation is registered for the ID.
+func (s *InMemoryStore) GetDocEntry(id string) (StoredDoc, error) {
+	s.rlock()
+	defer s.runlock()
+
+	record := s.docs[id]
+	if record == nil {
+		return StoredDoc{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return StoredDoc{
+		ID:      id,
+		Entry:   record.entry(),
+		Frozen:  record.frozen,
+		Expires: record.expires,
+	}, nil
+}
+
+// DocIDs returns the IDs of all tools with registered documentation, sorted.
+func (s *InMemoryStore) DocIDs() []string {
+	s.rlock()
+	ids := make([]string, 0, len(s.docs))
+	for id := range s.docs {
+		ids = append(ids, id)
+	}
+	s.runlock()
+
+	sort.Strings(ids)
+	return ids
 }
 
 // DescribeTool returns documentation for a tool at the specified detail level.
 // For schema/full levels, Tool must be available from the index.
+// It is DescribeToolContext with a background context, so the full tier of
+// Sensitive tools is available only when StoreOptions.AuthorizeSensitive
+// allows it without caller context.
 func (s *InMemoryStore) DescribeTool(id string, level DetailLevel) (ToolDoc, error) {
-	// Validate detail level
+	return s.DescribeToolContext(context.Background(), id, level)
+}
+
+// DescribeToolContext is DescribeTool with a caller context used to
+// authorize the full tier of Sensitive tools. It returns ErrUnauthorized for
+// DetailFull on a Sensitive tool when the caller is not authorized.
+//
+// Identical concurrent calls (same id and level) are coalesced: one caller
+// does the lookup and rendering and the others receive copies of its
+// result. Reads that depend on the caller's context are never shared (see
+// describeCoalesced).
+func (s *InMemoryStore) DescribeToolContext(ctx context.Context, id string, level DetailLevel) (ToolDoc, error) {
+	if err := validateDetail(level); err != nil {
+		return ToolDoc{}, err
+	}
+	start := s.readStart()
+	doc, shared, err := s.describeCoalesced(ctx, id, level)
+	if err == nil {
+		s.outcomes.noteDescribe(TraceID(ctx), id, level)
+		s.usage.served(id, doc.Examples, s.now())
+	}
+	s.observe(ctx, ReadEvent{Op: OpDescribe, ToolID: id, Level: level, Coalesced: shared, Stale: doc.Stale, Err: err}, start)
+	return doc, err
+}
+
+// validateDetail returns ErrInvalidDetail for unknown detail levels.
+func validateDetail(level DetailLevel) error {
 	switch level {
 	case DetailSummary, DetailSchema, DetailFull:
-		// valid
+		return nil
 	default:
-		return ToolDoc{}, fmt.Errorf("%w: %s", ErrInvalidDetail, level)
+		return fmt.Errorf("%w: %s", ErrInvalidDetail, level)
+	}
+}
+
+// describe implements DescribeToolContext for a validated level, looking the
+// tool up with resolve.
+func (s *InMemoryStore) describe(ctx context.Context, id string, level DetailLevel, resolve func(id string) (*toolmodel.Tool, error)) (ToolDoc, error) {
+	serveExpired, err := s.refreshExpired(ctx, id)
+	if err != nil {
+		return ToolDoc{}, err
 	}
 
 	// Copy doc record fields under lock to prevent races
-	var summary, notes string
-	var examples []ToolExample
-	var externalRefs []string
+	var entry DocEntry
 	var hasDoc bool
 
-	s.mu.RLock()
-	if docRec := s.docs[id]; docRec != nil {
+	s.rlock()
+	if docRec := s.docs[id]; docRec != nil && (serveExpired || !s.expired(docRec)) {
 		hasDoc = true
-		summary = docRec.summary
-		notes = docRec.notes
-		// Deep copy examples for return
-		examples = copyExamples(docRec.examples)
-		// Copy external refs
-		externalRefs = make([]string, len(docRec.externalRefs))
-		copy(externalRefs, docRec.externalRefs)
+		s.lru.touch(id)
+		// Deep copy for return
+		entry = docRec.entry()
 	}
+	var edges []ToolEdge
+	if level == DetailFull {
+		edges = s.toolEdges(id)
+	}
+	exp := s.experiments[id]
 	maxExamples := s.maxExamples
-	s.mu.RUnlock()
+	s.runlock()
 
-	// Try to get tool from index - needed for summary fallback and schema/full levels
-	var tool *toolmodel.Tool
-	var resolverErr error
-	if s.index != nil {
-		t, _, err := s.index.GetTool(id)
-		if err == nil {
-			tool = &t
+	var variant string
+	if exp != nil {
+		if variant = s.assign(ctx, exp); variant != "" && variant != ControlVariant {
+			entry = exp.applyVariant(variant, entry)
+			hasDoc = true
 		}
 	}
-	if tool == nil && s.toolResolver != nil {
-		t, err := s.toolResolver(id)
-		if err != nil {
-			resolverErr = err
-		} else if t != nil {
-			tool = t
-		}
+
+	if level == DetailFull && entry.Sensitive && !s.authorizedForSensitive(ctx, id) {
+		return ToolDoc{}, fmt.Errorf("%w: %s", ErrUnauthorized, id)
+	}
+
+	summary := entry.Summary
+
+	// Try to get tool from index - needed for summary fallback and schema/full levels
+	tool, resolverErr := resolve(id)
+	tool, stale, resolverErr := s.withStaleFallback(id, tool, resolverErr)
+	stale = stale || serveExpired
+	if tool != nil {
+		tool = applyToolOverrides(tool, entry)
 	}
 
 	// For schema/full, Tool is REQUIRED per MCP contract
@@ -263,10 +844,17 @@ func (s *InMemoryStore) DescribeTool(id string, level DetailLevel) (ToolDoc, err
 		}
 	}
 
-	// Build the summary - prefer doc summary, fallback to tool description
+	// Build the summary - prefer doc summary, then the Summarizer, then
+	// the tool description
+	if summary == "" && s.summarizer != nil && (hasDoc || tool != nil) {
+		summary = s.summarize(ctx, id, tool, entry)
+	}
 	if summary == "" && tool != nil && tool.Description != "" {
 		summary = truncateString(tool.Description, MaxSummaryLen)
 	}
+	if summary == "" && tool != nil && s.synthesize {
+		summary = synthesizeSummary(tool, s.Catalog(ctx))
+	}
 
 	// For summary level, we're done
 	if level == DetailSummary {
@@ -277,7 +865,18 @@ func (s *InMemoryStore) DescribeTool(id string, level DetailLevel) (ToolDoc, err
 			}
 			return ToolDoc{}, fmt.Errorf("%w: %s", ErrNotFound, id)
 		}
-		return ToolDoc{Summary: summary}, nil
+		doc := ToolDoc{
+			Summary:  summary,
+			Title:    displayTitle(tool, entry),
+			Sections: sectionsAt(entry.Sections, level),
+			Omitted:  s.omitted(entry, tool, level, 0),
+			Stale:    stale,
+		}
+		doc.NextDetail = s.nextDetail(ctx, id, entry, tool, level, summary, maxExamples)
+		if variant != "" {
+			doc.Experiment = s.expose(ctx, exp, variant, level)
+		}
+		return doc, nil
 	}
 
 	// Build schema info from tool's InputSchema
@@ -288,19 +887,36 @@ func (s *InMemoryStore) DescribeTool(id string, level DetailLevel) (ToolDoc, err
 
 	// Build result based on level
 	result := ToolDoc{
-		Tool:       tool,
-		Summary:    summary,
-		SchemaInfo: schemaInfo,
+		Tool:               tool,
+		Title:              displayTitle(tool, entry),
+		Summary:            summary,
+		SchemaInfo:         schemaInfo,
+		Capabilities:       deriveCapabilities(tool),
+		Effects:            entry.Effects,
+		Idempotent:         isIdempotent(entry, tool),
+		RetryPolicy:        entry.RetryPolicy,
+		Concurrency:        entry.Concurrency,
+		ClientRequirements: entry.ClientRequirements,
+		ShortNotes:         entry.ShortNotes,
+		Sections:           sectionsAt(entry.Sections, level),
+		Stale:              stale,
+	}
+	if hasIrreversibleEffect(entry.Effects) {
+		result.Capabilities.IsDestructive = true
 	}
 
 	if level == DetailFull {
-		result.Notes = notes
-		result.ExternalRefs = externalRefs
-		// Apply MaxExamples cap
-		if maxExamples > 0 && len(examples) > maxExamples {
-			examples = examples[:maxExamples]
-		}
-		result.Examples = examples
+		result.Notes = entry.Notes
+		result.Prerequisites = entry.Prerequisites
+		result.Edges = edges
+		result.ExternalRefs = entry.ExternalRefs
+		// Apply environment filter, then the selector and MaxExamples cap
+		result.Examples = s.selectExamples(ctx, id, s.availableExamples(entry.Examples), maxExamples)
+	}
+	result.Omitted = s.omitted(entry, tool, level, len(result.Examples))
+	result.NextDetail = s.nextDetail(ctx, id, entry, tool, level, summary, maxExamples)
+	if variant != "" {
+		result.Experiment = s.expose(ctx, exp, variant, level)
 	}
 
 	return result, nil
@@ -308,33 +924,67 @@ func (s *InMemoryStore) DescribeTool(id string, level DetailLevel) (ToolDoc, err
 
 // ListExamples returns up to maxExamples for a tool.
 // The effective limit is min(maxExamples, MaxExamples) when both are set.
+// It is ListExamplesContext with a background context.
 func (s *InMemoryStore) ListExamples(id string, maxExamples int) ([]ToolExample, error) {
+	return s.ListExamplesContext(context.Background(), id, maxExamples)
+}
+
+// ListExamplesContext is ListExamples with a caller context used to
+// authorize access to the examples of Sensitive tools. It returns
+// ErrUnauthorized for a Sensitive tool when the caller is not authorized.
+func (s *InMemoryStore) ListExamplesContext(ctx context.Context, id string, maxExamples int) ([]ToolExample, error) {
+	start := s.readStart()
+	examples, err := s.listExamples(ctx, id, maxExamples)
+	s.usage.served(id, examples, s.now())
+	s.observe(ctx, ReadEvent{Op: OpExamples, ToolID: id, Err: err}, start)
+	return examples, err
+}
+
+// listExamples implements ListExamplesContext.
+func (s *InMemoryStore) listExamples(ctx context.Context, id string, maxExamples int) ([]ToolExample, error) {
 	// Copy examples under lock to prevent races
 	var examples []ToolExample
-	var hasDoc bool
+	var hasDoc, sensitive bool
 
-	s.mu.RLock()
-	if docRec := s.docs[id]; docRec != nil {
+	serveExpired, err := s.refreshExpired(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rlock()
+	if docRec := s.docs[id]; docRec != nil && (serveExpired || !s.expired(docRec)) {
 		hasDoc = true
-		examples = copyExamples(docRec.examples)
+		s.lru.touch(id)
+		sensitive = docRec.sensitive
+		examples = s.availableExamples(copyExamples(docRec.examples))
 	}
+	exp := s.experiments[id]
 	defaultMax := s.maxExamples
-	s.mu.RUnlock()
+	s.runlock()
+
+	// Serve the assigned variant's examples; exposures are counted by
+	// DescribeTool only.
+	if exp != nil {
+		if variant := s.assign(ctx, exp); variant != "" && variant != ControlVariant {
+			entry := exp.applyVariant(variant, DocEntry{Sensitive: sensitive})
+			hasDoc = true
+			sensitive = entry.Sensitive
+			examples = s.availableExamples(entry.Examples)
+		}
+	}
 
-	// Check if tool exists in index or via resolver
-	toolExists := false
-	if s.index != nil {
-		_, _, err := s.index.GetTool(id)
-		toolExists = err == nil
+	if sensitive && !s.authorizedForSensitive(ctx, id) {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthorized, id)
 	}
-	if !toolExists && s.toolResolver != nil {
-		t, err := s.toolResolver(id)
-		if err != nil {
-			// Propagate resolver errors (not ErrNotFound style)
-			return nil, err
-		}
-		toolExists = t != nil
+
+	// Check if tool exists in index or via resolver
+	tool, err := s.resolveToolContext(ctx, id)
+	tool, _, err = s.withStaleFallback(id, tool, err)
+	if err != nil {
+		// Propagate resolver errors (not ErrNotFound style)
+		return nil, err
 	}
+	toolExists := tool != nil
 
 	if !hasDoc && !toolExists {
 		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
@@ -352,12 +1002,102 @@ func (s *InMemoryStore) ListExamples(id string, maxExamples int) ([]ToolExample,
 		}
 	}
 
-	// Apply limit
-	if effectiveMax > 0 && len(examples) > effectiveMax {
-		examples = examples[:effectiveMax]
+	return s.selectExamples(ctx, id, examples, effectiveMax), nil
+}
+
+// resolveTool is resolveToolContext with a background context.
+func (s *InMemoryStore) resolveTool(id string) (*toolmodel.Tool, error) {
+	return s.resolveToolContext(context.Background(), id)
+}
+
+// resolveToolContext looks up a tool in the index, then via the resolver
+// hooks. It returns (nil, nil) when neither source knows the tool, and the
+// resolver's error when the resolver fails. An index timeout falls through
+// to the resolver and is returned only when no resolver is configured.
+func (s *InMemoryStore) resolveToolContext(ctx context.Context, id string) (*toolmodel.Tool, error) {
+	var indexErr error
+	if s.index != nil {
+		t, err := s.lookupIndex(id)
+		if err == nil {
+			return t, nil
+		}
+		if errors.Is(err, ErrLookupTimeout) {
+			indexErr = err
+		}
+	}
+	if s.hasResolver() {
+		return s.callResolver(ctx, id)
+	}
+	return nil, indexErr
+}
+
+// applyToolOverrides returns a copy of tool with the entry's Title and
+// Annotations overrides applied. The original tool is never modified, so
+// index- and resolver-owned values stay untouched.
+func applyToolOverrides(tool *toolmodel.Tool, entry DocEntry) *toolmodel.Tool {
+	if entry.Title == "" && entry.Annotations == nil {
+		return tool
+	}
+	t := *tool
+	if entry.Title != "" {
+		t.Title = entry.Title
+	}
+	if entry.Annotations != nil {
+		t.Annotations = copyAnnotations(entry.Annotations)
 	}
+	return &t
+}
 
-	return examples, nil
+// synthesizeSummary builds a minimal summary from the tool name and its
+// top-level required parameters, e.g. "create_ticket: requires title, project".
+func synthesizeSummary(tool *toolmodel.Tool, c Catalog) string {
+	if tool.Name == "" {
+		return ""
+	}
+	var required []string
+	if schema := schemaAsMap(tool.InputSchema); schema != nil {
+		required = toStringSlice(schema["required"])
+	}
+	if len(required) == 0 {
+		return tool.Name
+	}
+	return truncateString(c.Sprintf("%s: requires %s", tool.Name, strings.Join(required, ", ")), MaxSummaryLen)
+}
+
+// displayTitle returns the human-readable title for a tool, following the
+// MCP display precedence: doc override, Tool.Title, then Annotations.Title.
+// Returns "" when no title is available.
+func displayTitle(tool *toolmodel.Tool, entry DocEntry) string {
+	if entry.Title != "" {
+		return entry.Title
+	}
+	if tool == nil {
+		return ""
+	}
+	if tool.Title != "" {
+		return tool.Title
+	}
+	if tool.Annotations != nil {
+		return tool.Annotations.Title
+	}
+	return ""
+}
+
+// copyAnnotations returns a deep copy of MCP tool annotations.
+func copyAnnotations(a *mcp.ToolAnnotations) *mcp.ToolAnnotations {
+	if a == nil {
+		return nil
+	}
+	c := *a
+	if a.DestructiveHint != nil {
+		v := *a.DestructiveHint
+		c.DestructiveHint = &v
+	}
+	if a.OpenWorldHint != nil {
+		v := *a.OpenWorldHint
+		c.OpenWorldHint = &v
+	}
+	return &c
 }
 
 // deepCopyArgs performs a deep copy of Args map.
@@ -365,9 +1105,12 @@ func (s *InMemoryStore) ListExamples(id string, maxExamples int) ([]ToolExample,
 // races and mutation side effects.
 //
 // For JSON-compatible types (maps, slices, primitives), values are fully
-// deep-copied. For unknown types, values are shallow-copied (reference kept)
-// to avoid silently dropping data. MCP tool arguments should only contain
-// JSON-compatible types.
+// deep-copied. For unknown types (structs, pointers, other typed maps and
+// slices), values are shallow-copied (reference kept) to avoid silently
+// dropping data; the stored example then shares that value with the caller
+// and fails or changes shape only when marshaled. MCP tool arguments should
+// only contain JSON-compatible types, and StoreOptions.RejectUnknownArgTypes
+// turns such values into registration errors (see checkArgTypes).
 func deepCopyArgs(args map[string]any) map[string]any {
 	if args == nil {
 		return nil
@@ -512,6 +1255,8 @@ func copyExamples(examples []ToolExample) []ToolExample {
 			Description: ex.Description,
 			Args:        deepCopyArgs(ex.Args),
 			ResultHint:  ex.ResultHint,
+			Requires:    slices.Clone(ex.Requires),
+			Source:      ex.Source,
 		}
 	}
 	return result
@@ -548,18 +1293,15 @@ func normalizeNumeric(v any) any {
 	}
 }
 
-// deriveSchemaInfo extracts schema information from an InputSchema.
-// Returns nil if derivation is not possible.
-// Numeric default values are normalized to float64.
-func deriveSchemaInfo(schema any) *SchemaInfo {
-	if schema == nil {
-		return nil
-	}
-
-	// Try to get schema as map
+// schemaAsMap converts an InputSchema of any supported representation
+// (map, json.RawMessage, []byte, or any JSON-marshalable value) to a map.
+// Returns nil if conversion is not possible.
+func schemaAsMap(schema any) map[string]any {
 	var schemaMap map[string]any
 
 	switch s := schema.(type) {
+	case nil:
+		return nil
 	case map[string]any:
 		schemaMap = s
 	case json.RawMessage:
@@ -581,6 +1323,22 @@ func deriveSchemaInfo(schema any) *SchemaInfo {
 		}
 	}
 
+	return schemaMap
+}
+
+// deriveSchemaInfo extracts schema information from an InputSchema.
+// Returns nil if derivation is not possible.
+// Numeric default values are normalized to float64.
+func deriveSchemaInfo(schema any) *SchemaInfo {
+	if schema == nil {
+		return nil
+	}
+
+	schemaMap := schemaAsMap(schema)
+	if schemaMap == nil {
+		return nil
+	}
+
 	info := &SchemaInfo{}
 	hasData := false
 
@@ -616,6 +1374,14 @@ func deriveSchemaInfo(schema any) *SchemaInfo {
 						info.Defaults[name] = normalizeNumeric(def)
 						hasData = true
 					}
+
+					if constraints := schemaConstraints(propMap); len(constraints) > 0 {
+						if info.Constraints == nil {
+							info.Constraints = make(map[string][]string)
+						}
+						info.Constraints[name] = constraints
+						hasData = true
+					}
 				}
 			}
 
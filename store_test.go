@@ -101,8 +101,9 @@ func TestRegisterDoc_Truncation(t *testing.T) {
 	longHint := strings.Repeat("d", MaxResultHintLen+100)
 
 	entry := DocEntry{
-		Summary: longSummary,
-		Notes:   longNotes,
+		Summary:    longSummary,
+		ShortNotes: strings.Repeat("e", MaxShortNotesLen+100),
+		Notes:      longNotes,
 		Examples: []ToolExample{
 			{Title: "Ex", Description: longDesc, ResultHint: longHint},
 		},
@@ -117,6 +118,9 @@ func TestRegisterDoc_Truncation(t *testing.T) {
 	if len(record.summary) != MaxSummaryLen {
 		t.Errorf("summary len = %d, want %d", len(record.summary), MaxSummaryLen)
 	}
+	if len(record.shortNotes) != MaxShortNotesLen {
+		t.Errorf("shortNotes len = %d, want %d", len(record.shortNotes), MaxShortNotesLen)
+	}
 	if len(record.notes) != MaxNotesLen {
 		t.Errorf("notes len = %d, want %d", len(record.notes), MaxNotesLen)
 	}
@@ -291,8 +295,9 @@ func TestDescribeTool_Schema(t *testing.T) {
 
 	store := NewInMemoryStore(StoreOptions{Index: idx})
 	mustRegisterDoc(t, store, "api:search", DocEntry{
-		Summary: "Custom summary",
-		Notes:   "These notes should not appear at schema level",
+		Summary:    "Custom summary",
+		ShortNotes: "Max 100 results per query.",
+		Notes:      "These notes should not appear at schema level",
 	})
 
 	doc, err := store.DescribeTool("api:search", DetailSchema)
@@ -327,10 +332,21 @@ func TestDescribeTool_Schema(t *testing.T) {
 		t.Errorf("Defaults[query] = %v, want test", doc.SchemaInfo.Defaults["query"])
 	}
 
-	// Notes should be empty at schema level
+	// Short notes are returned at schema level; notes are not
+	if doc.ShortNotes != "Max 100 results per query." {
+		t.Errorf("ShortNotes = %q, want the registered short notes", doc.ShortNotes)
+	}
 	if doc.Notes != "" {
 		t.Errorf("Notes = %q, want empty", doc.Notes)
 	}
+
+	summary, err := store.DescribeTool("api:search", DetailSummary)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.ShortNotes != "" {
+		t.Errorf("summary ShortNotes = %q, want empty", summary.ShortNotes)
+	}
 }
 
 func TestDescribeTool_Full(t *testing.T) {
@@ -1244,3 +1260,50 @@ func TestRegisterExamples_ArgsCapsEnforced(t *testing.T) {
 		t.Errorf("unexpected error for valid args: %v", err)
 	}
 }
+
+func TestDescribeTool_SynthesizedSummary(t *testing.T) {
+	tool := makeToolWithSchema("create_ticket", "jira", "", map[string]any{
+		"type":       "object",
+		"required":   []any{"title", "project"},
+		"properties": map[string]any{"title": map[string]any{"type": "string"}},
+	})
+	bare := makeToolWithSchema("ping", "jira", "", nil)
+	resolver := func(id string) (*toolmodel.Tool, error) {
+		switch id {
+		case tool.ToolID():
+			return &tool, nil
+		case bare.ToolID():
+			return &bare, nil
+		}
+		return nil, nil
+	}
+
+	off := NewInMemoryStore(StoreOptions{ToolResolver: resolver})
+	if doc, err := off.DescribeTool("jira:create_ticket", DetailSummary); err != nil || doc.Summary != "" {
+		t.Errorf("without opt-in: summary = %q, err = %v", doc.Summary, err)
+	}
+
+	on := NewInMemoryStore(StoreOptions{ToolResolver: resolver, SynthesizeSummaries: true})
+	tests := []struct {
+		id, want string
+	}{
+		{"jira:create_ticket", "create_ticket: requires title, project"},
+		{"jira:ping", "ping"},
+	}
+	for _, tt := range tests {
+		for _, level := range []DetailLevel{DetailSummary, DetailSchema} {
+			doc, err := on.DescribeTool(tt.id, level)
+			if err != nil {
+				t.Fatalf("DescribeTool(%s, %s): %v", tt.id, level, err)
+			}
+			if doc.Summary != tt.want {
+				t.Errorf("DescribeTool(%s, %s).Summary = %q, want %q", tt.id, level, doc.Summary, tt.want)
+			}
+		}
+	}
+
+	mustRegisterDoc(t, on, "jira:create_ticket", DocEntry{Summary: "Open a Jira ticket"})
+	if doc, _ := on.DescribeTool("jira:create_ticket", DetailSummary); doc.Summary != "Open a Jira ticket" {
+		t.Errorf("doc summary should win, got %q", doc.Summary)
+	}
+}
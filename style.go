@@ -0,0 +1,208 @@
+package tooldocs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// ErrSummaryStyle is returned when a summary violates the configured
+// StoreOptions.SummaryProfile.
+var ErrSummaryStyle = errors.New("summary style violation")
+
+// Summary style rules reported in StyleIssue.Rule.
+const (
+	StyleMaxSentences   = "max-sentences"
+	StyleImperative     = "imperative"
+	StyleToolNamePrefix = "tool-name-prefix"
+)
+
+// SummaryProfile is a house style for summaries. Zero fields disable their
+// rule. Empty summaries are not checked.
+type SummaryProfile struct {
+	// MaxSentences caps the number of sentences.
+	MaxSentences int
+
+	// RequireImperative requires the summary to start with an imperative
+	// verb ("List issues", not "Lists issues" or "This tool lists issues").
+	// The check is a heuristic over the first word.
+	RequireImperative bool
+
+	// ForbidToolNamePrefix rejects summaries that start with the tool's
+	// name or ID ("get_repo: Fetch ...", "Get repo fetches ...").
+	ForbidToolNamePrefix bool
+}
+
+// StyleIssue is a single summary style violation.
+type StyleIssue struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// nonImperativeStarters are first words that indicate a descriptive rather
+// than imperative summary.
+var nonImperativeStarters = map[string]bool{
+	"this": true, "the": true, "a": true, "an": true, "it": true,
+	"tool": true, "used": true, "allows": true, "provides": true,
+}
+
+// Check returns the profile's violations for a tool's summary.
+func (p SummaryProfile) Check(id, summary string) []StyleIssue {
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return nil
+	}
+
+	var issues []StyleIssue
+	if p.MaxSentences > 0 {
+		if n := countSentences(summary); n > p.MaxSentences {
+			issues = append(issues, StyleIssue{
+				Rule:    StyleMaxSentences,
+				Message: fmt.Sprintf("has %d sentences (max %d)", n, p.MaxSentences),
+			})
+		}
+	}
+	if p.RequireImperative {
+		if first := firstWord(summary); !looksImperative(first) {
+			issues = append(issues, StyleIssue{
+				Rule:    StyleImperative,
+				Message: fmt.Sprintf("should start with an imperative verb, not %q", first),
+			})
+		}
+	}
+	if p.ForbidToolNamePrefix && startsWithToolName(id, summary) {
+		issues = append(issues, StyleIssue{
+			Rule:    StyleToolNamePrefix,
+			Message: "should not start with the tool name",
+		})
+	}
+	return issues
+}
+
+// LintSummaries checks every registered summary against profile and returns
+// the violations by tool ID. Tools without violations are omitted.
+func (s *InMemoryStore) LintSummaries(profile SummaryProfile) map[string][]StyleIssue {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	issues := make(map[string][]StyleIssue)
+	for id, record := range s.docs {
+		if found := profile.Check(id, record.summary); len(found) > 0 {
+			issues[id] = found
+		}
+	}
+	return issues
+}
+
+// checkSummaryStyle enforces StoreOptions.SummaryProfile for an entry being
+// registered.
+func (s *InMemoryStore) checkSummaryStyle(id string, entry DocEntry) error {
+	if s.style == nil {
+		return nil
+	}
+	issues := s.style.Check(id, truncateString(entry.Summary, MaxSummaryLen))
+	if len(issues) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(issues))
+	for i, issue := range issues {
+		msgs[i] = issue.Rule + ": " + issue.Message
+	}
+	return fmt.Errorf("%w: %s: %s", ErrSummaryStyle, id, strings.Join(msgs, "; "))
+}
+
+// countSentences counts sentences terminated by '.', '!', or '?' followed by
+// whitespace or the end of the text. Common abbreviations are ignored.
+func countSentences(s string) int {
+	for _, abbr := range []string{"e.g.", "i.e.", "etc.", "vs."} {
+		s = strings.ReplaceAll(s, abbr, "")
+	}
+	n := 0
+	runes := []rune(s)
+	for i, r := range runes {
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+		if i == len(runes)-1 || unicode.IsSpace(runes[i+1]) {
+			n++
+		}
+	}
+	if last := runes[len(runes)-1]; last != '.' && last != '!' && last != '?' {
+		n++ // trailing sentence without terminal punctuation
+	}
+	return n
+}
+
+// firstWord returns the first word of s, stripped of punctuation.
+func firstWord(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimFunc(fields[0], func(r rune) bool { return !unicode.IsLetter(r) })
+}
+
+// looksImperative reports whether word plausibly is an imperative verb:
+// not a descriptive starter, not a third-person form ("Returns"), and not a
+// gerund ("Returning").
+func looksImperative(word string) bool {
+	w := strings.ToLower(word)
+	if w == "" || nonImperativeStarters[w] {
+		return false
+	}
+	if strings.HasSuffix(w, "ing") && len(w) > 5 {
+		return false
+	}
+	if strings.HasSuffix(w, "s") && len(w) > 3 &&
+		!strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is") {
+		return false
+	}
+	return true
+}
+
+// startsWithToolName reports whether summary starts with the tool's ID, or
+// echoes its name as an identifier: a name containing "_", "-", or ".", or
+// any name followed by ":", "(", " -", or " tool". Plain-word names followed
+// by ordinary text ("Search code" for "search") are not flagged, since they
+// are usually the imperative verb. Case is ignored.
+func startsWithToolName(id, summary string) bool {
+	lower := strings.ToLower(summary)
+	if hasWordPrefix(lower, strings.ToLower(id)) {
+		return true
+	}
+	_, name, err := toolmodel.ParseToolID(id)
+	if err != nil || name == "" {
+		return false
+	}
+	name = strings.ToLower(name)
+	if !hasWordPrefix(lower, name) {
+		return false
+	}
+	if strings.ContainsAny(name, "_-.") {
+		return true
+	}
+	rest := lower[len(name):]
+	for _, sep := range []string{":", "(", " -", " tool"} {
+		if strings.HasPrefix(rest, sep) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasWordPrefix reports whether s starts with prefix followed by a word
+// boundary.
+func hasWordPrefix(s, prefix string) bool {
+	if !strings.HasPrefix(s, prefix) {
+		return false
+	}
+	rest := s[len(prefix):]
+	if rest == "" {
+		return true
+	}
+	r := rune(rest[0])
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+}
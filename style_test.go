@@ -0,0 +1,83 @@
+package tooldocs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSummaryProfile_Check(t *testing.T) {
+	profile := SummaryProfile{MaxSentences: 1, RequireImperative: true, ForbidToolNamePrefix: true}
+
+	tests := []struct {
+		summary string
+		want    []string
+	}{
+		{"List open issues in a repository.", nil},
+		{"Search code, e.g. by symbol name.", nil},
+		{"Process a refund", nil},
+		{"", nil},
+		{"Lists open issues.", []string{StyleImperative}},
+		{"This tool lists open issues.", []string{StyleImperative}},
+		{"Listing open issues.", []string{StyleImperative}},
+		{"List issues. Supports pagination.", []string{StyleMaxSentences}},
+		{"list_issues: List open issues.", []string{StyleImperative, StyleToolNamePrefix}},
+		{"github:list_issues lists issues. Paginated!", []string{StyleMaxSentences, StyleImperative, StyleToolNamePrefix}},
+		{"List issues (open only).", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.summary, func(t *testing.T) {
+			issues := profile.Check("github:list_issues", tt.summary)
+			var rules []string
+			for _, issue := range issues {
+				rules = append(rules, issue.Rule)
+			}
+			if len(rules) != len(tt.want) {
+				t.Fatalf("rules = %v, want %v", rules, tt.want)
+			}
+			for i := range rules {
+				if rules[i] != tt.want[i] {
+					t.Errorf("rules = %v, want %v", rules, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSummaryProfile_PlainToolName(t *testing.T) {
+	profile := SummaryProfile{ForbidToolNamePrefix: true}
+	if issues := profile.Check("code:search", "Search code by symbol."); len(issues) != 0 {
+		t.Errorf("plain verb matching the name should pass: %+v", issues)
+	}
+	for _, summary := range []string{"search: Find code.", "Search tool for code.", "search - find code"} {
+		if issues := profile.Check("code:search", summary); len(issues) != 1 {
+			t.Errorf("%q: issues = %+v, want tool-name-prefix", summary, issues)
+		}
+	}
+}
+
+func TestSummaryProfile_Enforcement(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{SummaryProfile: &SummaryProfile{RequireImperative: true}})
+
+	if err := store.RegisterDoc("ns:a", DocEntry{Summary: "Returns things."}); !errors.Is(err, ErrSummaryStyle) {
+		t.Errorf("RegisterDoc err = %v, want ErrSummaryStyle", err)
+	}
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "Return things."})
+
+	if _, err := store.Import(map[string]DocEntry{"ns:b": {Summary: "Gets b."}}, ImportOptions{}); !errors.Is(err, ErrSummaryStyle) {
+		t.Errorf("Import err = %v, want ErrSummaryStyle", err)
+	}
+	if err := store.SubmitForReview("ns:c", DocEntry{Summary: "The c tool."}, "gen"); !errors.Is(err, ErrSummaryStyle) {
+		t.Errorf("SubmitForReview err = %v, want ErrSummaryStyle", err)
+	}
+}
+
+func TestLintSummaries(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:good", DocEntry{Summary: "Fetch a record."})
+	mustRegisterDoc(t, store, "ns:bad", DocEntry{Summary: "Fetches a record. Fast."})
+
+	issues := store.LintSummaries(SummaryProfile{MaxSentences: 1, RequireImperative: true})
+	if len(issues) != 1 || len(issues["ns:bad"]) != 2 {
+		t.Errorf("issues = %+v", issues)
+	}
+}
@@ -0,0 +1,74 @@
+package tooldocs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"sync"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// Summarizer derives a summary for a tool whose doc has none, typically by
+// calling an LLM over the tool definition and notes. tool is nil when only
+// a doc is registered. See StoreOptions.Summarizer.
+type Summarizer func(ctx context.Context, id string, tool *toolmodel.Tool, entry DocEntry) (string, error)
+
+// summaryCache memoizes Summarizer output per tool, keyed by a hash of the
+// content it was derived from. A changed DocEntry or Tool hashes
+// differently, so a stale summary is never served: the next read calls the
+// Summarizer again and replaces the cached value.
+type summaryCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedSummary
+}
+
+type cachedSummary struct {
+	hash    [sha256.Size]byte
+	summary string
+}
+
+// summarize returns the Summarizer's summary for id, from the cache when
+// the content is unchanged. Errors and panics yield "" and are not cached,
+// so the read falls back to Tool.Description.
+func (s *InMemoryStore) summarize(ctx context.Context, id string, tool *toolmodel.Tool, entry DocEntry) string {
+	hash, err := contentHash(tool, entry)
+	if err != nil {
+		return ""
+	}
+
+	s.summaries.mu.Lock()
+	cached, ok := s.summaries.entries[id]
+	s.summaries.mu.Unlock()
+	if ok && cached.hash == hash {
+		return cached.summary
+	}
+
+	summary, err := callHook(HookSummarizer, func() (string, error) {
+		return s.summarizer(ctx, id, tool, entry)
+	})
+	if err != nil {
+		return ""
+	}
+	summary = truncateString(summary, MaxSummaryLen)
+
+	s.summaries.mu.Lock()
+	if s.summaries.entries == nil {
+		s.summaries.entries = make(map[string]cachedSummary)
+	}
+	s.summaries.entries[id] = cachedSummary{hash: hash, summary: summary}
+	s.summaries.mu.Unlock()
+	return summary
+}
+
+// contentHash hashes the inputs a Summarizer sees.
+func contentHash(tool *toolmodel.Tool, entry DocEntry) ([sha256.Size]byte, error) {
+	data, err := json.Marshal(struct {
+		Tool  *toolmodel.Tool `json:"tool"`
+		Entry DocEntry        `json:"entry"`
+	}{tool, entry})
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
@@ -0,0 +1,113 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestSummarizer_CachesUntilContentChanges(t *testing.T) {
+	desc := "Original description"
+	var calls atomic.Int32
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			tool := makeToolWithSchema("search", "gh", desc, map[string]any{"type": "object"})
+			return &tool, nil
+		},
+		Summarizer: func(_ context.Context, id string, tool *toolmodel.Tool, entry DocEntry) (string, error) {
+			calls.Add(1)
+			return "Summary of " + tool.Description + " / " + entry.Notes, nil
+		},
+	})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Notes: "v1"})
+
+	describe := func() string {
+		t.Helper()
+		doc, err := store.DescribeTool("gh:search", DetailSummary)
+		if err != nil {
+			t.Fatalf("DescribeTool failed: %v", err)
+		}
+		return doc.Summary
+	}
+
+	if got := describe(); got != "Summary of Original description / v1" {
+		t.Errorf("Summary = %q", got)
+	}
+	describe()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Summarizer calls = %d, want 1 (cached)", n)
+	}
+
+	// Doc change invalidates.
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Notes: "v2"})
+	if got := describe(); got != "Summary of Original description / v2" {
+		t.Errorf("Summary after doc change = %q", got)
+	}
+
+	// Tool change invalidates.
+	desc = "New description"
+	if got := describe(); got != "Summary of New description / v2" {
+		t.Errorf("Summary after tool change = %q", got)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("Summarizer calls = %d, want 3", n)
+	}
+
+	// An authored summary wins without consulting the Summarizer.
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Summary: "Authored"})
+	if got := describe(); got != "Authored" {
+		t.Errorf("Summary = %q, want authored", got)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("Summarizer calls = %d, want 3", n)
+	}
+}
+
+func TestSummarizer_FailuresFallBackAndRetry(t *testing.T) {
+	var calls atomic.Int32
+	fail := true
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			tool := makeToolWithSchema("search", "gh", "From the tool", map[string]any{"type": "object"})
+			return &tool, nil
+		},
+		Summarizer: func(context.Context, string, *toolmodel.Tool, DocEntry) (string, error) {
+			calls.Add(1)
+			if fail {
+				return "", errors.New("model unavailable")
+			}
+			return "Derived", nil
+		},
+	})
+
+	doc, err := store.DescribeTool("gh:search", DetailSummary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Summary != "From the tool" {
+		t.Errorf("Summary = %q, want Tool.Description fallback", doc.Summary)
+	}
+
+	fail = false
+	doc, _ = store.DescribeTool("gh:search", DetailSummary)
+	if doc.Summary != "Derived" || calls.Load() != 2 {
+		t.Errorf("Summary = %q after %d calls, want retried derivation", doc.Summary, calls.Load())
+	}
+}
+
+func TestSummarizer_Panic(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{
+		Summarizer: func(context.Context, string, *toolmodel.Tool, DocEntry) (string, error) {
+			panic("boom")
+		},
+	})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Notes: "Notes only"})
+
+	doc, err := store.DescribeTool("gh:search", DetailSummary)
+	if err != nil || doc.Summary != "" {
+		t.Errorf("DescribeTool = %+v, %v; want empty summary", doc, err)
+	}
+}
@@ -0,0 +1,76 @@
+package tooldocs
+
+import "context"
+
+// Summary returns the tool's summary-tier text, the same as
+// DescribeTool(id, DetailSummary).Summary, on a fast path for the common
+// case: when the tool has a registered summary and no running experiment,
+// the summary is read straight from the doc record, without building a
+// ToolDoc, copying examples, or calling the index or resolver, and without
+// allocating. Other tools, including expired ones, fall back to
+// DescribeTool.
+//
+// OnRead observes fast-path reads as OpDescribe at DetailSummary.
+//
+// Returns the errors of DescribeTool on the fallback path, e.g.
+// ErrNotFound for unknown tools.
+func (s *InMemoryStore) Summary(id string) (string, error) {
+	start := s.readStart()
+	s.rlock()
+	var summary string
+	if record := s.docs[id]; record != nil && s.experiments[id] == nil && !s.expired(record) {
+		summary = record.summary
+		s.lru.touch(id)
+	}
+	s.runlock()
+
+	if summary == "" {
+		doc, err := s.DescribeTool(id, DetailSummary)
+		return doc.Summary, err
+	}
+	s.observe(context.Background(), ReadEvent{Op: OpDescribe, ToolID: id, Level: DetailSummary}, start)
+	return summary, nil
+}
+
+// Summaries returns the summary-tier text of many tools, keyed by ID, for
+// prompt assembly and for gateways annotating tools/list responses.
+// Registered summaries are read under a single lock acquisition, as by
+// Summary; the remaining tools are described with DescribeTools. Tools that
+// cannot be described, such as unknown IDs, are left out.
+func (s *InMemoryStore) Summaries(ids []string) map[string]string {
+	return s.summaryMap(context.Background(), ids)
+}
+
+// summaryMap implements Summaries, describing the tools without a
+// fast-path summary with ctx.
+func (s *InMemoryStore) summaryMap(ctx context.Context, ids []string) map[string]string {
+	start := s.readStart()
+	out := make(map[string]string, len(ids))
+	var rest []string
+	s.rlock()
+	for _, id := range ids {
+		if record := s.docs[id]; record != nil && record.summary != "" && s.experiments[id] == nil && !s.expired(record) {
+			out[id] = record.summary
+			s.lru.touch(id)
+		} else {
+			rest = append(rest, id)
+		}
+	}
+	s.runlock()
+
+	if s.onRead != nil {
+		for id := range out {
+			s.observe(ctx, ReadEvent{Op: OpDescribe, ToolID: id, Level: DetailSummary}, start)
+		}
+	}
+	if len(rest) > 0 {
+		// DetailSummary is valid, so only per-tool errors are possible.
+		results, _ := s.DescribeToolsContext(ctx, rest, DetailSummary)
+		for _, r := range results {
+			if r.Err == nil {
+				out[r.ID] = r.Doc.Summary
+			}
+		}
+	}
+	return out
+}
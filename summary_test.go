@@ -0,0 +1,87 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestSummary(t *testing.T) {
+	resolved := 0
+	tool := makeToolWithSchema("get_repo", "gh", "Get a repository from GitHub", map[string]any{"type": "object"})
+	reads := 0
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			resolved++
+			if id == "gh:get_repo" {
+				return &tool, nil
+			}
+			return nil, nil
+		},
+		OnRead: func(ctx context.Context, e ReadEvent) { reads++ },
+	})
+	mustRegisterDoc(t, store, "gh:list_repos", DocEntry{Summary: "List repositories"})
+
+	got, err := store.Summary("gh:list_repos")
+	if err != nil || got != "List repositories" {
+		t.Errorf("Summary = %q, %v", got, err)
+	}
+	if resolved != 0 || reads != 1 {
+		t.Errorf("fast path resolved %d tools and observed %d reads, want 0 and 1", resolved, reads)
+	}
+
+	// Without a doc summary, the tool description is used as by DescribeTool.
+	if got, err := store.Summary("gh:get_repo"); err != nil || got != "Get a repository from GitHub" {
+		t.Errorf("fallback Summary = %q, %v", got, err)
+	}
+	if _, err := store.Summary("gh:missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSummary_Experiment(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{
+		AssignVariant: func(ctx context.Context, experiment string, variants []string) string { return "terse" },
+	})
+	mustRegisterDoc(t, store, "gh:list_repos", DocEntry{Summary: "List repositories"})
+	if err := store.RegisterExperiment(Experiment{
+		Name:     "terse-summaries",
+		ToolID:   "gh:list_repos",
+		Variants: map[string]DocEntry{"terse": {Summary: "Repos"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.Summary("gh:list_repos"); err != nil || got != "Repos" {
+		t.Errorf("Summary during experiment = %q, %v; want the variant", got, err)
+	}
+}
+
+func TestSummaries(t *testing.T) {
+	tool := makeToolWithSchema("get_repo", "gh", "Get a repository", map[string]any{"type": "object"})
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			if id == "gh:get_repo" {
+				return &tool, nil
+			}
+			return nil, nil
+		},
+	})
+	mustRegisterDoc(t, store, "gh:list_repos", DocEntry{Summary: "List repositories"})
+	mustRegisterDoc(t, store, "gh:empty", DocEntry{Notes: "No summary yet."})
+
+	got := store.Summaries([]string{"gh:list_repos", "gh:get_repo", "gh:empty", "gh:missing"})
+	want := map[string]string{
+		"gh:list_repos": "List repositories",
+		"gh:get_repo":   "Get a repository",
+		"gh:empty":      "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Summaries = %v, want %v", got, want)
+	}
+	if got := store.Summaries(nil); len(got) != 0 {
+		t.Errorf("Summaries(nil) = %v, want empty", got)
+	}
+}
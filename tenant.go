@@ -0,0 +1,175 @@
+package tooldocs
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+type tenantKey struct{}
+
+// WithTenant returns a context carrying the caller's tenant (customer,
+// workspace), selecting the doc set a TenantStore serves them from.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// Tenant returns the tenant carried by ctx, or "".
+func Tenant(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// TenantOptions configures NewTenantStore.
+type TenantOptions struct {
+	// StoreOptions configures each tenant's InMemoryStore.
+	StoreOptions StoreOptions
+
+	// ForTenant, when set, returns the options for a tenant's store in
+	// place of StoreOptions, e.g. to give each tenant its own Persister,
+	// ChangeLog, or MaxDocs budget.
+	ForTenant func(tenant string) StoreOptions
+}
+
+// TenantStore serves isolated doc sets per tenant from one process: each
+// tenant has its own InMemoryStore, created on first registration, so one
+// customer's docs, frozen flags, experiments, and stats never leak into
+// another's. Tool definitions typically come from an Index or resolver
+// shared through the options.
+//
+// Reads for a tenant with no store behave as an empty store (summaries
+// fall back to the tool definition). As a Store, TenantStore serves the
+// tenant carried by the context (see WithTenant) to context-aware reads,
+// and the "" tenant otherwise.
+type TenantStore struct {
+	opts TenantOptions
+
+	mu      sync.RWMutex
+	tenants map[string]*InMemoryStore
+	empty   *InMemoryStore // read-only stand-in for unknown tenants; see reader
+}
+
+var _ Store = (*TenantStore)(nil)
+
+// NewTenantStore returns a TenantStore with no tenants.
+func NewTenantStore(opts TenantOptions) *TenantStore {
+	return &TenantStore{
+		opts:    opts,
+		tenants: make(map[string]*InMemoryStore),
+	}
+}
+
+// Tenant returns the tenant's store, creating it if needed. Register and
+// manage the tenant's docs through it.
+func (t *TenantStore) Tenant(tenant string) *InMemoryStore {
+	t.mu.RLock()
+	s := t.tenants[tenant]
+	t.mu.RUnlock()
+	if s != nil {
+		return s
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s := t.tenants[tenant]; s != nil {
+		return s
+	}
+	s = NewInMemoryStore(t.options(tenant))
+	t.tenants[tenant] = s
+	return s
+}
+
+// reader returns the store serving the tenant's reads without creating a
+// tenant: its store, or an empty one with the tenant's options. Unknown
+// tenants share one empty store, so reads naming arbitrary tenants do not
+// grow memory; with ForTenant set, each read gets an uncached stand-in
+// built from that tenant's options.
+func (t *TenantStore) reader(tenant string) *InMemoryStore {
+	t.mu.RLock()
+	s := t.tenants[tenant]
+	if s == nil && t.opts.ForTenant == nil {
+		s = t.empty
+	}
+	t.mu.RUnlock()
+	if s != nil {
+		return s
+	}
+	if t.opts.ForTenant != nil {
+		return NewInMemoryStore(t.opts.ForTenant(tenant))
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s := t.tenants[tenant]; s != nil {
+		return s
+	}
+	if t.empty == nil {
+		t.empty = NewInMemoryStore(t.opts.StoreOptions)
+	}
+	return t.empty
+}
+
+// options returns the StoreOptions for a tenant's store.
+func (t *TenantStore) options(tenant string) StoreOptions {
+	if t.opts.ForTenant != nil {
+		return t.opts.ForTenant(tenant)
+	}
+	return t.opts.StoreOptions
+}
+
+// Tenants returns the tenants with a store, sorted.
+func (t *TenantStore) Tenants() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make([]string, 0, len(t.tenants))
+	for tenant := range t.tenants {
+		out = append(out, tenant)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// RemoveTenant drops the tenant's store and all its docs, reporting
+// whether it existed. Persisted copies (StoreOptions.Persister) are left
+// alone.
+func (t *TenantStore) RemoveTenant(tenant string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.tenants[tenant]
+	delete(t.tenants, tenant)
+	return ok
+}
+
+// DescribeToolForTenant is InMemoryStore.DescribeTool against the tenant's
+// docs.
+func (t *TenantStore) DescribeToolForTenant(tenant, id string, level DetailLevel) (ToolDoc, error) {
+	return t.reader(tenant).DescribeTool(id, level)
+}
+
+// ListExamplesForTenant is InMemoryStore.ListExamples against the tenant's
+// docs.
+func (t *TenantStore) ListExamplesForTenant(tenant, id string, maxExamples int) ([]ToolExample, error) {
+	return t.reader(tenant).ListExamples(id, maxExamples)
+}
+
+// DescribeTool implements Store for the "" tenant.
+func (t *TenantStore) DescribeTool(id string, level DetailLevel) (ToolDoc, error) {
+	return t.DescribeToolForTenant("", id, level)
+}
+
+// DescribeToolContext is DescribeToolForTenant for the tenant carried by
+// ctx.
+func (t *TenantStore) DescribeToolContext(ctx context.Context, id string, level DetailLevel) (ToolDoc, error) {
+	return t.reader(Tenant(ctx)).DescribeToolContext(ctx, id, level)
+}
+
+// ListExamples implements Store for the "" tenant.
+func (t *TenantStore) ListExamples(id string, maxExamples int) ([]ToolExample, error) {
+	return t.ListExamplesForTenant("", id, maxExamples)
+}
+
+// ListExamplesContext is ListExamplesForTenant for the tenant carried by
+// ctx.
+func (t *TenantStore) ListExamplesContext(ctx context.Context, id string, maxExamples int) ([]ToolExample, error) {
+	return t.reader(Tenant(ctx)).ListExamplesContext(ctx, id, maxExamples)
+}
@@ -0,0 +1,96 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestTenantStore_Isolation(t *testing.T) {
+	tool := makeToolWithSchema("search", "gh", "Search issues", map[string]any{"type": "object"})
+	ts := NewTenantStore(TenantOptions{StoreOptions: StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			if id != "gh:search" {
+				return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+			}
+			return &tool, nil
+		},
+	}})
+	mustRegisterDoc(t, ts.Tenant("acme"), "gh:search", DocEntry{Summary: "Search Acme's issues", Notes: "Acme uses the ACME- prefix."})
+	mustRegisterDoc(t, ts.Tenant("globex"), "gh:search", DocEntry{Summary: "Search Globex issues"})
+
+	doc, err := ts.DescribeToolForTenant("acme", "gh:search", DetailFull)
+	if err != nil || doc.Summary != "Search Acme's issues" || doc.Notes == "" {
+		t.Errorf("acme doc = %+v, %v", doc, err)
+	}
+	doc, err = ts.DescribeToolContext(WithTenant(context.Background(), "globex"), "gh:search", DetailFull)
+	if err != nil || doc.Summary != "Search Globex issues" || doc.Notes != "" {
+		t.Errorf("globex doc = %+v, %v", doc, err)
+	}
+
+	// Unknown tenants read an empty doc set without being created.
+	doc, err = ts.DescribeToolForTenant("initech", "gh:search", DetailSummary)
+	if err != nil || doc.Summary != "Search issues" {
+		t.Errorf("unknown tenant doc = %+v, %v; want the tool description", doc, err)
+	}
+	if _, err := ts.ListExamplesForTenant("initech", "ext:none", 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown tool err = %v, want ErrNotFound", err)
+	}
+	if want := []string{"acme", "globex"}; !reflect.DeepEqual(ts.Tenants(), want) {
+		t.Errorf("Tenants = %v, want %v", ts.Tenants(), want)
+	}
+
+	// The Store interface serves the "" tenant.
+	mustRegisterDoc(t, ts.Tenant(""), "gh:search", DocEntry{Summary: "Default docs"})
+	if doc, err := ts.DescribeTool("gh:search", DetailSummary); err != nil || doc.Summary != "Default docs" {
+		t.Errorf("default tenant doc = %+v, %v", doc, err)
+	}
+
+	if !ts.RemoveTenant("acme") || ts.RemoveTenant("acme") {
+		t.Error("RemoveTenant should report the tenant existed exactly once")
+	}
+	if doc, _ := ts.DescribeToolForTenant("acme", "gh:search", DetailSummary); doc.Summary != "Search issues" {
+		t.Errorf("removed tenant doc = %+v, want its docs gone", doc)
+	}
+}
+
+func TestTenantStore_ForTenant(t *testing.T) {
+	var asked []string
+	ts := NewTenantStore(TenantOptions{ForTenant: func(tenant string) StoreOptions {
+		asked = append(asked, tenant)
+		return StoreOptions{MaxDocs: 1}
+	}})
+	mustRegisterDoc(t, ts.Tenant("acme"), "ns:a", DocEntry{Summary: "A"})
+	mustRegisterDoc(t, ts.Tenant("acme"), "ns:b", DocEntry{Summary: "B"})
+	if ids := ts.Tenant("acme").DocIDs(); !reflect.DeepEqual(ids, []string{"ns:b"}) {
+		t.Errorf("DocIDs = %v, want the tenant's MaxDocs applied", ids)
+	}
+	if !reflect.DeepEqual(asked, []string{"acme"}) {
+		t.Errorf("ForTenant calls = %v, want one per tenant", asked)
+	}
+}
+
+func TestTenantStore_UnknownTenantsShareEmptyStore(t *testing.T) {
+	ts := NewTenantStore(TenantOptions{})
+	for i := range 100 {
+		if _, err := ts.DescribeToolForTenant(fmt.Sprintf("t%d", i), "ns:a", DetailSummary); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("err = %v, want ErrNotFound", err)
+		}
+	}
+	if ts.reader("a") != ts.reader("b") {
+		t.Error("unknown tenants should share one empty store")
+	}
+	if len(ts.Tenants()) != 0 {
+		t.Errorf("Tenants = %v, want none created by reads", ts.Tenants())
+	}
+
+	// Per-tenant options are honored without caching a stand-in per tenant.
+	ts = NewTenantStore(TenantOptions{ForTenant: func(string) StoreOptions { return StoreOptions{} }})
+	if ts.reader("a") == ts.reader("a") || ts.empty != nil {
+		t.Error("ForTenant stand-ins should not be cached")
+	}
+}
@@ -0,0 +1,245 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrToolsetNotFound is returned when a named toolset is not registered.
+var ErrToolsetNotFound = errors.New("toolset not found")
+
+// Toolset is a named, curated subset of the catalog, so different agents
+// can see different slices of one store (e.g. "triage" and "coding").
+type Toolset struct {
+	// Name identifies the toolset.
+	Name string `json:"name"`
+
+	// Tools lists the tool IDs in the toolset, in presentation order. IDs
+	// need not be documented yet.
+	Tools []string `json:"tools"`
+
+	// Note is optional guidance shown ahead of the tools in Preamble
+	// (e.g. "Prefer read-only tools; ask before closing issues").
+	// Maximum length: MaxNotesLen (2000 chars).
+	Note string `json:"note,omitempty"`
+
+	// Policy sets the default detail level, examples, and token budget for
+	// agents using the toolset.
+	Policy RenderPolicy `json:"policy,omitzero"`
+}
+
+// RegisterToolset adds or replaces the toolset named ts.Name. Duplicate
+// tool IDs are dropped, keeping the first occurrence, and Note is truncated
+// to MaxNotesLen.
+//
+// Returns ErrInvalidDetail if ts.Policy.Level is set but invalid.
+func (s *InMemoryStore) RegisterToolset(ts Toolset) error {
+	if ts.Name == "" {
+		return errors.New("register toolset: name is required")
+	}
+	if err := ts.Policy.validate(); err != nil {
+		return fmt.Errorf("register toolset %s: %w", ts.Name, err)
+	}
+	var tools []string
+	for _, id := range ts.Tools {
+		if id != "" && !slices.Contains(tools, id) {
+			tools = append(tools, id)
+		}
+	}
+	ts.Tools = tools
+	ts.Note = truncateString(ts.Note, MaxNotesLen)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return err
+	}
+	s.toolsets[ts.Name] = ts
+	return nil
+}
+
+// UnregisterToolset removes a toolset.
+//
+// Returns ErrToolsetNotFound if no toolset has that name.
+func (s *InMemoryStore) UnregisterToolset(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return err
+	}
+	if _, ok := s.toolsets[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrToolsetNotFound, name)
+	}
+	delete(s.toolsets, name)
+	return nil
+}
+
+// Toolset returns a copy of the named toolset.
+//
+// Returns ErrToolsetNotFound if no toolset has that name.
+func (s *InMemoryStore) Toolset(name string) (Toolset, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ts, ok := s.toolsets[name]
+	if !ok {
+		return Toolset{}, fmt.Errorf("%w: %s", ErrToolsetNotFound, name)
+	}
+	ts.Tools = slices.Clone(ts.Tools)
+	return ts, nil
+}
+
+// ToolsetNames returns the names of all registered toolsets, sorted.
+func (s *InMemoryStore) ToolsetNames() []string {
+	s.mu.RLock()
+	names := make([]string, 0, len(s.toolsets))
+	for name := range s.toolsets {
+		names = append(names, name)
+	}
+	s.mu.RUnlock()
+
+	sort.Strings(names)
+	return names
+}
+
+// View returns a read-only view of the store scoped to the named toolset.
+// The view reads the toolset definition on every call, so later
+// RegisterToolset calls apply to existing views.
+//
+// Returns ErrToolsetNotFound if no toolset has that name.
+func (s *InMemoryStore) View(name string) (*ToolsetView, error) {
+	if _, err := s.Toolset(name); err != nil {
+		return nil, err
+	}
+	return &ToolsetView{store: s, name: name}, nil
+}
+
+// ToolsetView is a Store scoped to one toolset: tools outside it are
+// reported as ErrNotFound. It implements Store and the context-aware read
+// methods, so it can back httpapi and graphqlapi handlers directly.
+type ToolsetView struct {
+	store *InMemoryStore
+	name  string
+}
+
+// Name returns the toolset name.
+func (v *ToolsetView) Name() string {
+	return v.name
+}
+
+// DocIDs returns the toolset's tool IDs in presentation order, or nil if
+// the toolset has been unregistered.
+func (v *ToolsetView) DocIDs() []string {
+	ts, err := v.store.Toolset(v.name)
+	if err != nil {
+		return nil
+	}
+	return ts.Tools
+}
+
+// DescribeTool is DescribeToolContext with a background context.
+func (v *ToolsetView) DescribeTool(id string, level DetailLevel) (ToolDoc, error) {
+	return v.DescribeToolContext(context.Background(), id, level)
+}
+
+// DescribeToolContext describes a tool of the toolset. An empty level means
+// the toolset's Policy.Level.
+//
+// Returns ErrNotFound if id is not in the toolset, ErrToolsetNotFound if the
+// toolset has been unregistered, and otherwise what the store's
+// DescribeToolContext returns.
+func (v *ToolsetView) DescribeToolContext(ctx context.Context, id string, level DetailLevel) (ToolDoc, error) {
+	ts, err := v.toolset(id)
+	if err != nil {
+		return ToolDoc{}, err
+	}
+	if level == "" {
+		level = ts.Policy.level()
+	}
+	return v.store.DescribeToolContext(ctx, id, level)
+}
+
+// DescribeAll describes every tool of the toolset at one level, in
+// presentation order (see InMemoryStore.DescribeToolsContext). An empty
+// level means the toolset's Policy.Level.
+func (v *ToolsetView) DescribeAll(ctx context.Context, level DetailLevel) ([]DescribeResult, error) {
+	ts, err := v.store.Toolset(v.name)
+	if err != nil {
+		return nil, err
+	}
+	if level == "" {
+		level = ts.Policy.level()
+	}
+	return v.store.DescribeToolsContext(ctx, ts.Tools, level)
+}
+
+// ListExamples is ListExamplesContext with a background context.
+func (v *ToolsetView) ListExamples(id string, maxExamples int) ([]ToolExample, error) {
+	return v.ListExamplesContext(context.Background(), id, maxExamples)
+}
+
+// ListExamplesContext lists examples of a tool of the toolset.
+//
+// Returns ErrNotFound if id is not in the toolset, ErrToolsetNotFound if the
+// toolset has been unregistered, and otherwise what the store's
+// ListExamplesContext returns.
+func (v *ToolsetView) ListExamplesContext(ctx context.Context, id string, maxExamples int) ([]ToolExample, error) {
+	if _, err := v.toolset(id); err != nil {
+		return nil, err
+	}
+	return v.store.ListExamplesContext(ctx, id, maxExamples)
+}
+
+// Preamble renders the toolset for a system prompt: the toolset note, then
+// one "- <id>: <summary>" line per tool in presentation order. Tools that
+// cannot be described are left out. With a Policy.TokenBudget, lines that
+// would exceed it are replaced by a final "- (N more omitted)" line.
+//
+// Returns ErrToolsetNotFound if the toolset has been unregistered.
+func (v *ToolsetView) Preamble(ctx context.Context) (string, error) {
+	ts, err := v.store.Toolset(v.name)
+	if err != nil {
+		return "", err
+	}
+	summaries := v.store.summaryMap(ctx, ts.Tools)
+	var b strings.Builder
+	if ts.Note != "" {
+		b.WriteString(ts.Note)
+		b.WriteString("\n\n")
+	}
+	budget := ts.Policy.TokenBudget
+	for i, id := range ts.Tools {
+		summary, ok := summaries[id]
+		if !ok {
+			continue
+		}
+		line := fmt.Sprintf("- %s: %s\n", id, summary)
+		if budget > 0 && estimateTextTokens(b.String()+line) > budget {
+			more := 0
+			for _, rest := range ts.Tools[i:] {
+				if _, ok := summaries[rest]; ok {
+					more++
+				}
+			}
+			fmt.Fprintf(&b, "- (%d more omitted)\n", more)
+			break
+		}
+		b.WriteString(line)
+	}
+	return b.String(), nil
+}
+
+// toolset returns the current definition, checking that id belongs to it.
+func (v *ToolsetView) toolset(id string) (Toolset, error) {
+	ts, err := v.store.Toolset(v.name)
+	if err != nil {
+		return Toolset{}, err
+	}
+	if !slices.Contains(ts.Tools, id) {
+		return Toolset{}, fmt.Errorf("%w: %s is not in toolset %s", ErrNotFound, id, v.name)
+	}
+	return ts, nil
+}
@@ -0,0 +1,120 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var _ Store = (*ToolsetView)(nil)
+
+func newToolsetStore(t *testing.T) *InMemoryStore {
+	t.Helper()
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "gh:get_issue", DocEntry{Summary: "Fetch an issue", Examples: []ToolExample{{Title: "One", Args: map[string]any{"n": 1.0}}}})
+	mustRegisterDoc(t, store, "gh:close_issue", DocEntry{Summary: "Close an issue"})
+	mustRegisterDoc(t, store, "gh:push", DocEntry{Summary: "Push commits"})
+	if err := store.RegisterToolset(Toolset{
+		Name:  "triage",
+		Tools: []string{"gh:get_issue", "gh:close_issue", "gh:get_issue", "gh:unknown"},
+		Note:  "Ask before closing issues.",
+	}); err != nil {
+		t.Fatalf("RegisterToolset failed: %v", err)
+	}
+	return store
+}
+
+func TestRegisterToolset(t *testing.T) {
+	store := newToolsetStore(t)
+
+	ts, err := store.Toolset("triage")
+	if err != nil {
+		t.Fatalf("Toolset failed: %v", err)
+	}
+	if want := []string{"gh:get_issue", "gh:close_issue", "gh:unknown"}; !reflect.DeepEqual(ts.Tools, want) {
+		t.Errorf("Tools = %v, want %v", ts.Tools, want)
+	}
+	if err := store.RegisterToolset(Toolset{}); err == nil {
+		t.Error("RegisterToolset without name succeeded")
+	}
+	if err := store.RegisterToolset(Toolset{Name: "coding"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.ToolsetNames(); !reflect.DeepEqual(got, []string{"coding", "triage"}) {
+		t.Errorf("ToolsetNames = %v", got)
+	}
+
+	if err := store.UnregisterToolset("coding"); err != nil {
+		t.Fatalf("UnregisterToolset failed: %v", err)
+	}
+	if _, err := store.Toolset("coding"); !errors.Is(err, ErrToolsetNotFound) {
+		t.Errorf("Toolset(removed) error = %v, want ErrToolsetNotFound", err)
+	}
+	if err := store.UnregisterToolset("coding"); !errors.Is(err, ErrToolsetNotFound) {
+		t.Errorf("UnregisterToolset(removed) error = %v, want ErrToolsetNotFound", err)
+	}
+}
+
+func TestToolsetView_Scoped(t *testing.T) {
+	store := newToolsetStore(t)
+	view, err := store.View("triage")
+	if err != nil {
+		t.Fatalf("View failed: %v", err)
+	}
+
+	doc, err := view.DescribeTool("gh:get_issue", DetailSummary)
+	if err != nil || doc.Summary != "Fetch an issue" {
+		t.Errorf("DescribeTool(in toolset) = %+v, %v", doc, err)
+	}
+	if _, err := view.DescribeTool("gh:push", DetailSummary); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DescribeTool(outside) error = %v, want ErrNotFound", err)
+	}
+	if examples, err := view.ListExamples("gh:get_issue", 5); err != nil || len(examples) != 1 {
+		t.Errorf("ListExamples = %v, %v", examples, err)
+	}
+	if _, err := view.ListExamples("gh:push", 5); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ListExamples(outside) error = %v, want ErrNotFound", err)
+	}
+
+	results, err := view.DescribeAll(context.Background(), DetailSummary)
+	if err != nil || len(results) != 3 {
+		t.Fatalf("DescribeAll = %+v, %v", results, err)
+	}
+	if !errors.Is(results[2].Err, ErrNotFound) {
+		t.Errorf("DescribeAll unknown tool error = %v, want ErrNotFound", results[2].Err)
+	}
+
+	// Views follow later definition changes.
+	if err := store.RegisterToolset(Toolset{Name: "triage", Tools: []string{"gh:push"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := view.DocIDs(); !reflect.DeepEqual(got, []string{"gh:push"}) {
+		t.Errorf("DocIDs after update = %v", got)
+	}
+	if err := store.UnregisterToolset("triage"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := view.DescribeTool("gh:push", DetailSummary); !errors.Is(err, ErrToolsetNotFound) {
+		t.Errorf("DescribeTool(removed toolset) error = %v, want ErrToolsetNotFound", err)
+	}
+	if _, err := store.View("triage"); !errors.Is(err, ErrToolsetNotFound) {
+		t.Errorf("View(removed) error = %v, want ErrToolsetNotFound", err)
+	}
+}
+
+func TestToolsetView_Preamble(t *testing.T) {
+	store := newToolsetStore(t)
+	view, err := store.View("triage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := view.Preamble(context.Background())
+	if err != nil {
+		t.Fatalf("Preamble failed: %v", err)
+	}
+	want := "Ask before closing issues.\n\n- gh:get_issue: Fetch an issue\n- gh:close_issue: Close an issue\n"
+	if got != want {
+		t.Errorf("Preamble =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package tooldocs
+
+import (
+	"context"
+	"time"
+)
+
+type traceIDKey struct{}
+
+// WithTraceID returns a context carrying a caller-provided request or trace
+// ID. The store passes the context to ContextToolResolver, OnRead,
+// OnExposure, and the other context-aware hooks, and copies the ID into
+// ReadEvent and Exposure, so one agent turn's doc fetches can be correlated
+// end to end.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, id)
+}
+
+// TraceID returns the trace ID carried by ctx, or "".
+func TraceID(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey{}).(string)
+	return id
+}
+
+// Read operations reported in ReadEvent.Op.
+const (
+	OpDescribe = "describe"
+	OpExamples = "examples"
+)
+
+// ReadEvent describes one completed read. It is passed to
+// StoreOptions.OnRead for logging and metrics.
+type ReadEvent struct {
+	// Op is OpDescribe or OpExamples.
+	Op string
+
+	// ToolID is the tool that was read.
+	ToolID string
+
+	// Level is the requested detail level (OpDescribe only).
+	Level DetailLevel
+
+	// TraceID is TraceID(ctx) of the read.
+	TraceID string
+
+	// Duration is the time the read took, including lookups.
+	Duration time.Duration
+
+	// Coalesced is set when the read shared the result of an identical
+	// concurrent DescribeTool call instead of doing its own work.
+	Coalesced bool
+
+	// Stale is set when the read served a last-known tool definition
+	// because the lookup failed (see StoreOptions.ServeStale).
+	Stale bool
+
+	// Err is the error the read returned, if any.
+	Err error
+}
+
+// observe reports a completed read to StoreOptions.OnRead, recovering
+// panics. It is a no-op when no hook is configured.
+func (s *InMemoryStore) observe(ctx context.Context, e ReadEvent, start time.Time) {
+	if s.onRead == nil {
+		return
+	}
+	e.TraceID = TraceID(ctx)
+	e.Duration = time.Since(start)
+	_, _ = callHook(HookOnRead, func() (struct{}, error) {
+		s.onRead(ctx, e)
+		return struct{}{}, nil
+	})
+}
+
+// readStart returns the start time of a read for observe, or the zero time
+// when no OnRead hook would use it.
+func (s *InMemoryStore) readStart() time.Time {
+	if s.onRead == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
@@ -0,0 +1,103 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestTraceID(t *testing.T) {
+	if got := TraceID(context.Background()); got != "" {
+		t.Errorf("TraceID(background) = %q, want empty", got)
+	}
+	if got := TraceID(WithTraceID(context.Background(), "turn-1")); got != "turn-1" {
+		t.Errorf("TraceID = %q, want turn-1", got)
+	}
+}
+
+func TestTraceID_PropagatesToHooks(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		resolved []string
+		events   []ReadEvent
+	)
+	store := NewInMemoryStore(StoreOptions{
+		ContextToolResolver: func(ctx context.Context, id string) (*toolmodel.Tool, error) {
+			mu.Lock()
+			resolved = append(resolved, TraceID(ctx))
+			mu.Unlock()
+			if id != "gh:search" {
+				return nil, nil
+			}
+			tool := makeToolWithSchema("search", "gh", "Search", map[string]any{"type": "object"})
+			return &tool, nil
+		},
+		ToolResolver: func(string) (*toolmodel.Tool, error) {
+			t.Error("ToolResolver called; ContextToolResolver takes precedence")
+			return nil, nil
+		},
+		OnRead: func(_ context.Context, e ReadEvent) {
+			e.Duration = 0
+			mu.Lock()
+			events = append(events, e)
+			mu.Unlock()
+		},
+	})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Summary: "Search issues"})
+	ctx := WithTraceID(context.Background(), "turn-1")
+
+	if _, err := store.DescribeToolContext(ctx, "gh:search", DetailSchema); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.ListExamplesContext(ctx, "gh:search", 1); err != nil {
+		t.Fatal(err)
+	}
+	_, missingErr := store.DescribeToolContext(ctx, "gh:missing", DetailSummary)
+	if _, err := store.DescribeToolsContext(ctx, []string{"gh:search"}, DetailSummary); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"turn-1", "turn-1", "turn-1", "turn-1"}; !reflect.DeepEqual(resolved, want) {
+		t.Errorf("resolver trace IDs = %v, want %v", resolved, want)
+	}
+	want := []ReadEvent{
+		{Op: OpDescribe, ToolID: "gh:search", Level: DetailSchema, TraceID: "turn-1"},
+		{Op: OpExamples, ToolID: "gh:search", TraceID: "turn-1"},
+		{Op: OpDescribe, ToolID: "gh:missing", Level: DetailSummary, TraceID: "turn-1", Err: missingErr},
+		{Op: OpDescribe, ToolID: "gh:search", Level: DetailSummary, TraceID: "turn-1"},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %+v\nwant %+v", events, want)
+	}
+	if !errors.Is(missingErr, ErrNotFound) {
+		t.Errorf("missing tool error = %v, want ErrNotFound", missingErr)
+	}
+}
+
+func TestOnRead_Panic(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{
+		OnRead: func(context.Context, ReadEvent) { panic("boom") },
+	})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{Summary: "Search issues"})
+	if _, err := store.DescribeTool("gh:search", DetailSummary); err != nil {
+		t.Errorf("DescribeTool with panicking OnRead failed: %v", err)
+	}
+}
+
+func TestExposure_TraceID(t *testing.T) {
+	var got Exposure
+	store := newExperimentStore(t, StoreOptions{
+		AssignVariant: assignTo("rewrite"),
+		OnExposure:    func(_ context.Context, e Exposure) { got = e },
+	})
+	if _, err := store.DescribeToolContext(WithTraceID(context.Background(), "turn-9"), "gh:search", DetailSummary); err != nil {
+		t.Fatal(err)
+	}
+	if got.TraceID != "turn-9" {
+		t.Errorf("Exposure.TraceID = %q, want turn-9", got.TraceID)
+	}
+}
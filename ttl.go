@@ -0,0 +1,134 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RegisterOptions configures RegisterDocWithOptions.
+type RegisterOptions struct {
+	// TTL is how long the documentation stays valid after registration.
+	// Zero means StoreOptions.DefaultDocTTL; negative means it never
+	// expires.
+	TTL time.Duration
+}
+
+// RegisterDocWithOptions is RegisterDoc with per-record options, such as a
+// TTL for documentation generated from upstream APIs that goes stale.
+//
+// Returns the errors of RegisterDoc.
+func (s *InMemoryStore) RegisterDocWithOptions(id string, entry DocEntry, opts RegisterOptions) error {
+	if err := s.limiter.allow(entry.Source); err != nil {
+		return err
+	}
+
+	if err := s.checkSummaryStyle(id, entry); err != nil {
+		return err
+	}
+	prepared, err := s.prepareRecord(entry)
+	if err != nil {
+		return err
+	}
+	prepared.expires = s.expiry(opts.TTL)
+	return s.publish(ChangeRegisterDoc, "", id, prepared)
+}
+
+// expiry returns the expiry time for a record written now with ttl, or the
+// zero time if it never expires.
+func (s *InMemoryStore) expiry(ttl time.Duration) time.Time {
+	if ttl == 0 {
+		ttl = s.defaultTTL
+	}
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return s.now().Add(ttl)
+}
+
+// expired reports whether the record's TTL has passed. Frozen records, and
+// all records of a sealed store, never expire. Callers hold s.mu.
+func (s *InMemoryStore) expired(record *docRecord) bool {
+	return record != nil && !record.frozen && !record.expires.IsZero() && !s.sealed.Load() && !s.now().Before(record.expires)
+}
+
+// refreshExpired refreshes id's documentation from StoreOptions.DocResolver
+// when it has expired. It reports whether the read may serve the expired
+// record anyway: when the refresh failed under StoreOptions.ServeStale.
+//
+// Returns the refresh error otherwise.
+func (s *InMemoryStore) refreshExpired(ctx context.Context, id string) (serveExpired bool, err error) {
+	if s.docResolver == nil {
+		return false, nil
+	}
+	s.mu.RLock()
+	expired := s.expired(s.docs[id])
+	s.mu.RUnlock()
+	if !expired {
+		return false, nil
+	}
+
+	err = s.refreshDoc(ctx, id)
+	if err != nil && s.serveStale {
+		return true, nil
+	}
+	return false, err
+}
+
+// refreshDoc replaces id's documentation with the entry DocResolver
+// returns. A nil entry or an ErrNotFound error leaves the record expired.
+func (s *InMemoryStore) refreshDoc(ctx context.Context, id string) error {
+	entry, err := callHook(HookDocResolver, func() (*DocEntry, error) {
+		return s.docResolver(ctx, id)
+	})
+	if errors.Is(err, ErrNotFound) || (err == nil && entry == nil) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("refresh %s: %w", id, err)
+	}
+	if err := s.checkSummaryStyle(id, *entry); err != nil {
+		return fmt.Errorf("refresh %s: %w", id, err)
+	}
+	prepared, err := s.prepareRecord(*entry)
+	if err != nil {
+		return fmt.Errorf("refresh %s: %w", id, err)
+	}
+	// A refreshed doc is served even when persisting it failed.
+	if err := s.publish(ChangeRefresh, "", id, prepared); err != nil && !errors.Is(err, ErrPersist) {
+		return fmt.Errorf("refresh %s: %w", id, err)
+	}
+	return nil
+}
+
+// PruneExpired removes expired documentation and returns the removed IDs.
+// Expired docs are already treated as missing by reads; pruning frees them
+// and passes the removals to the change log and Persister.
+//
+// Returns the removed IDs and ErrPersist if StoreOptions.Persister or
+// ChangeLog fails for any of them.
+func (s *InMemoryStore) PruneExpired() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return nil, err
+	}
+
+	var (
+		removed []string
+		errs    []error
+	)
+	for id, record := range s.docs {
+		if s.expired(record) {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+	for _, id := range removed {
+		s.dropped(id)
+		errs = append(errs, s.deleted(id))
+	}
+	return removed, errors.Join(errs...)
+}
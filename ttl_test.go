@@ -0,0 +1,147 @@
+package tooldocs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock is a controllable StoreOptions clock for TTL tests.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTTLStore(opts StoreOptions) (*InMemoryStore, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store := NewInMemoryStore(opts)
+	store.now = clock.now
+	return store, clock
+}
+
+func TestTTL_Expiry(t *testing.T) {
+	store, clock := newTTLStore(StoreOptions{DefaultDocTTL: time.Hour})
+	mustRegisterDoc(t, store, "ns:default", DocEntry{Summary: "Default TTL", Examples: []ToolExample{{Title: "one"}}})
+	if err := store.RegisterDocWithOptions("ns:short", DocEntry{Summary: "Short TTL"}, RegisterOptions{TTL: time.Minute}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RegisterDocWithOptions("ns:forever", DocEntry{Summary: "Never expires"}, RegisterOptions{TTL: -1}); err != nil {
+		t.Fatal(err)
+	}
+	mustRegisterDoc(t, store, "ns:frozen", DocEntry{Summary: "Frozen doc"})
+	if err := store.Freeze("ns:frozen"); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.advance(2 * time.Minute)
+	if _, err := store.DescribeTool("ns:short", DetailSummary); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DescribeTool(expired) err = %v, want ErrNotFound", err)
+	}
+	if got, err := store.Summary("ns:default"); err != nil || got != "Default TTL" {
+		t.Errorf("Summary(unexpired) = %q, %v", got, err)
+	}
+
+	clock.advance(time.Hour)
+	if _, err := store.ListExamples("ns:default", 5); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ListExamples(expired) err = %v, want ErrNotFound", err)
+	}
+	if _, err := store.Summary("ns:default"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Summary(expired) err = %v, want ErrNotFound", err)
+	}
+	if got := searchIDs(store, "expires"); !reflect.DeepEqual(got, []string{"ns:forever"}) {
+		t.Errorf("search = %v, want only the unexpired doc", got)
+	}
+	got := store.Summaries([]string{"ns:default", "ns:short", "ns:forever", "ns:frozen"})
+	if want := map[string]string{"ns:forever": "Never expires", "ns:frozen": "Frozen doc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Summaries = %v, want %v", got, want)
+	}
+
+	// Re-registering restarts the TTL.
+	mustRegisterDoc(t, store, "ns:short", DocEntry{Summary: "Short TTL again"})
+	if _, err := store.DescribeTool("ns:short", DetailSummary); err != nil {
+		t.Errorf("DescribeTool(re-registered) err = %v", err)
+	}
+
+	removed, err := store.PruneExpired()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"ns:default"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("PruneExpired = %v, want %v", removed, want)
+	}
+	if want := []string{"ns:forever", "ns:frozen", "ns:short"}; !reflect.DeepEqual(store.DocIDs(), want) {
+		t.Errorf("DocIDs after prune = %v, want %v", store.DocIDs(), want)
+	}
+}
+
+func TestTTL_DocResolver(t *testing.T) {
+	var calls int
+	var fail error
+	resolver := func(_ context.Context, id string) (*DocEntry, error) {
+		calls++
+		switch {
+		case fail != nil:
+			return nil, fail
+		case id == "ns:gone":
+			return nil, ErrNotFound
+		}
+		return &DocEntry{Summary: "Refreshed " + id}, nil
+	}
+	var log bytes.Buffer
+	store, clock := newTTLStore(StoreOptions{DefaultDocTTL: time.Hour, DocResolver: resolver, ChangeLog: &log})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "Original"})
+	mustRegisterDoc(t, store, "ns:gone", DocEntry{Summary: "Gone"})
+
+	if _, err := store.DescribeTool("ns:a", DetailSummary); err != nil || calls != 0 {
+		t.Fatalf("unexpired read: err = %v, resolver calls = %d", err, calls)
+	}
+
+	clock.advance(2 * time.Hour)
+	doc, err := store.DescribeTool("ns:a", DetailSummary)
+	if err != nil || doc.Summary != "Refreshed ns:a" || doc.Stale {
+		t.Fatalf("refresh = %+v, %v", doc, err)
+	}
+	if _, err := store.DescribeTool("ns:a", DetailSummary); err != nil || calls != 1 {
+		t.Errorf("refreshed doc not kept: err = %v, resolver calls = %d", err, calls)
+	}
+	if !strings.Contains(log.String(), `"op":"refresh","id":"ns:a"`) {
+		t.Errorf("change log has no refresh of ns:a:\n%s", log.String())
+	}
+
+	if _, err := store.DescribeTool("ns:gone", DetailSummary); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unresolvable doc err = %v, want ErrNotFound", err)
+	}
+
+	clock.advance(2 * time.Hour)
+	boom := errors.New("upstream down")
+	fail = boom
+	if _, err := store.ListExamples("ns:a", 1); !errors.Is(err, boom) {
+		t.Errorf("failed refresh err = %v, want %v", err, boom)
+	}
+
+	stale, clock := newTTLStore(StoreOptions{DefaultDocTTL: time.Hour, DocResolver: resolver, ServeStale: true})
+	mustRegisterDoc(t, stale, "ns:a", DocEntry{Summary: "Original"})
+	clock.advance(2 * time.Hour)
+	doc, err = stale.DescribeTool("ns:a", DetailSummary)
+	if err != nil || doc.Summary != "Original" || !doc.Stale {
+		t.Errorf("ServeStale refresh failure = %+v, %v; want the expired doc marked stale", doc, err)
+	}
+}
+
+func TestTTL_DocResolverPanic(t *testing.T) {
+	store, clock := newTTLStore(StoreOptions{
+		DefaultDocTTL: time.Hour,
+		DocResolver:   func(context.Context, string) (*DocEntry, error) { panic("boom") },
+	})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "A"})
+	clock.advance(2 * time.Hour)
+
+	var hp *HookPanicError
+	if _, err := store.DescribeTool("ns:a", DetailSummary); !errors.As(err, &hp) || hp.Hook != HookDocResolver {
+		t.Errorf("err = %v, want DocResolver panic", err)
+	}
+}
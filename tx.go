@@ -0,0 +1,150 @@
+package tooldocs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// errTxDone is returned by DocTx methods called after RegisterBatch's
+// function returned.
+var errTxDone = errors.New("transaction already finished")
+
+// DocTx stages writes for RegisterBatch. Each method validates its write
+// immediately, returning the error the corresponding InMemoryStore method
+// would, but nothing reaches the store until the batch commits. Later
+// writes for a tool build on earlier ones in the same batch.
+type DocTx interface {
+	// RegisterDoc stages InMemoryStore.RegisterDoc.
+	RegisterDoc(id string, entry DocEntry) error
+
+	// RegisterExamples stages InMemoryStore.RegisterExamples.
+	RegisterExamples(id string, examples []ToolExample) error
+}
+
+// txWrite is one staged write: a prepared record (RegisterDoc) or prepared
+// examples (RegisterExamples).
+type txWrite struct {
+	op       ChangeOp
+	id       string
+	source   string
+	record   *docRecord
+	examples []ToolExample
+}
+
+// docTx implements DocTx.
+type docTx struct {
+	s      *InMemoryStore
+	writes []txWrite
+	err    error // first validation error
+	done   bool
+}
+
+// fail records a validation error, failing the batch even if fn ignores
+// it.
+func (tx *docTx) fail(err error) error {
+	if tx.err == nil {
+		tx.err = err
+	}
+	return err
+}
+
+func (tx *docTx) RegisterDoc(id string, entry DocEntry) error {
+	if tx.done {
+		return errTxDone
+	}
+	if err := tx.s.checkSummaryStyle(id, entry); err != nil {
+		return tx.fail(err)
+	}
+	prepared, err := tx.s.prepareRecord(entry)
+	if err != nil {
+		return tx.fail(err)
+	}
+	tx.writes = append(tx.writes, txWrite{op: ChangeRegisterDoc, id: id, source: entry.Source, record: prepared})
+	return nil
+}
+
+func (tx *docTx) RegisterExamples(id string, examples []ToolExample) error {
+	if tx.done {
+		return errTxDone
+	}
+	limit := len(examples)
+	if tx.s.maxExamples > 0 && limit > tx.s.maxExamples {
+		limit = tx.s.maxExamples
+	}
+	prepared, err := tx.s.prepareExamples(examples[:limit])
+	if err != nil {
+		return tx.fail(err)
+	}
+	tx.writes = append(tx.writes, txWrite{op: ChangeRegisterExamples, id: id, examples: prepared})
+	return nil
+}
+
+// RegisterBatch applies several registrations atomically. fn stages writes
+// on tx; if it returns an error, any write fails validation (even if fn
+// ignores the error), or any staged tool is frozen, nothing is applied and
+// the error is returned. Otherwise all writes are applied together under
+// the store's write lock, so readers see the store before or after the
+// whole batch, never in between. Writes are recorded in the change log and
+// persisted one by one, as by RegisterDoc and RegisterExamples.
+//
+// Returns fn's error, the validation errors of RegisterDoc and
+// RegisterExamples, ErrFrozen, ErrThrottled if WriteRateLimit is exceeded
+// for any source in the batch (each distinct source is charged once), or
+// ErrPersist if StoreOptions.Persister or ChangeLog fails for any write
+// (the batch is kept).
+func (s *InMemoryStore) RegisterBatch(fn func(tx DocTx) error) error {
+	tx := &docTx{s: s}
+	err := fn(tx)
+	tx.done = true
+	if err != nil {
+		return err
+	}
+	if tx.err != nil {
+		return tx.err
+	}
+	if len(tx.writes) == 0 {
+		return nil
+	}
+
+	var sources []string
+	for _, w := range tx.writes {
+		if !slices.Contains(sources, w.source) {
+			sources = append(sources, w.source)
+		}
+	}
+	for _, source := range sources {
+		if err := s.limiter.allow(source); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return err
+	}
+
+	for _, w := range tx.writes {
+		if record := s.docs[w.id]; record != nil && record.frozen {
+			return fmt.Errorf("%w: %s", ErrFrozen, w.id)
+		}
+	}
+
+	var persistErrs []error
+	for _, w := range tx.writes {
+		record := s.docs[w.id]
+		if record == nil {
+			record = &docRecord{}
+			s.docs[w.id] = record
+		}
+		if w.record != nil {
+			record.setContent(w.record)
+		} else {
+			record.examples = w.examples
+		}
+		s.stored(w.id, record)
+		persistErrs = append(persistErrs, s.saved(w.op, "", w.id, record, nil))
+	}
+	return errors.Join(persistErrs...)
+}
@@ -0,0 +1,84 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRegisterBatch_Commit(t *testing.T) {
+	s := NewInMemoryStore(StoreOptions{})
+	err := s.RegisterBatch(func(tx DocTx) error {
+		if err := tx.RegisterDoc("ns:a", DocEntry{Summary: "A", Examples: []ToolExample{{Title: "old"}}}); err != nil {
+			return err
+		}
+		if err := tx.RegisterDoc("ns:b", DocEntry{Summary: "B"}); err != nil {
+			return err
+		}
+		// Later writes build on earlier ones.
+		return tx.RegisterExamples("ns:a", []ToolExample{{Title: "new"}})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := s.DocIDs(); !reflect.DeepEqual(ids, []string{"ns:a", "ns:b"}) {
+		t.Errorf("DocIDs = %v", ids)
+	}
+	doc, _ := s.GetDocEntry("ns:a")
+	if doc.Entry.Summary != "A" || len(doc.Entry.Examples) != 1 || doc.Entry.Examples[0].Title != "new" {
+		t.Errorf("ns:a = %+v, want the doc with the staged examples", doc.Entry)
+	}
+}
+
+func TestRegisterBatch_Rollback(t *testing.T) {
+	s := NewInMemoryStore(StoreOptions{MaxExamplesPerTool: 1})
+	mustRegisterDoc(t, s, "ns:frozen", DocEntry{Summary: "Frozen"})
+	if err := s.Freeze("ns:frozen"); err != nil {
+		t.Fatal(err)
+	}
+	errStop := errors.New("stop")
+
+	tests := []struct {
+		name string
+		fn   func(tx DocTx) error
+		want error
+	}{
+		{"fn error", func(tx DocTx) error {
+			_ = tx.RegisterDoc("ns:a", DocEntry{Summary: "A"})
+			return errStop
+		}, errStop},
+		{"ignored validation error", func(tx DocTx) error {
+			_ = tx.RegisterDoc("ns:a", DocEntry{Summary: "A"})
+			_ = tx.RegisterExamples("ns:a", []ToolExample{{Title: "1"}, {Title: "2"}})
+			return nil
+		}, ErrExampleLimit},
+		{"frozen", func(tx DocTx) error {
+			_ = tx.RegisterDoc("ns:a", DocEntry{Summary: "A"})
+			return tx.RegisterDoc("ns:frozen", DocEntry{Summary: "Changed"})
+		}, ErrFrozen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.RegisterBatch(tt.fn); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if ids := s.DocIDs(); !reflect.DeepEqual(ids, []string{"ns:frozen"}) {
+				t.Errorf("DocIDs = %v, want nothing applied", ids)
+			}
+		})
+	}
+}
+
+func TestRegisterBatch_TxDone(t *testing.T) {
+	s := NewInMemoryStore(StoreOptions{})
+	var leaked DocTx
+	if err := s.RegisterBatch(func(tx DocTx) error { leaked = tx; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := leaked.RegisterDoc("ns:a", DocEntry{Summary: "A"}); err == nil {
+		t.Error("RegisterDoc after the batch should fail")
+	}
+	if len(s.DocIDs()) != 0 {
+		t.Error("late write reached the store")
+	}
+}
@@ -1,6 +1,9 @@
 package tooldocs
 
-import "github.com/jonwraymond/toolmodel"
+import (
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
 
 // DetailLevel specifies the amount of detail to return for tool documentation.
 type DetailLevel string
@@ -11,7 +14,8 @@ const (
 	DetailSummary DetailLevel = "summary"
 
 	// DetailSchema returns the full toolmodel.Tool with InputSchema/OutputSchema.
-	// SchemaInfo is populated when derivable. Notes are empty at this level.
+	// SchemaInfo is populated when derivable. ShortNotes are included;
+	// Notes are empty at this level.
 	// Requires tool to be resolved via toolindex or ToolResolver
 	// (returns ErrNoTool otherwise).
 	DetailSchema DetailLevel = "schema"
@@ -29,6 +33,7 @@ const (
 	MaxResultHintLen  = 200  // Maximum length of ToolExample.ResultHint
 	MaxSummaryLen     = 200  // Maximum length of ToolDoc.Summary
 	MaxNotesLen       = 2000 // Maximum length of ToolDoc.Notes
+	MaxShortNotesLen  = 300  // Maximum length of ToolDoc.ShortNotes
 )
 
 // Args caps to prevent context pollution when examples are included in LLM context.
@@ -66,8 +71,34 @@ type ToolExample struct {
 	// ResultHint describes the expected shape/semantics of the result.
 	// Maximum length: MaxResultHintLen (200 chars).
 	ResultHint string `json:"resultHint,omitempty"`
+
+	// Requires lists environment requirements that must all appear in
+	// StoreOptions.Environment for the example to be shown or replayed
+	// (e.g. RequireSandbox, "needs-credential:github", "feature-flag:beta").
+	// Empty means the example is safe everywhere.
+	Requires []string `json:"requires,omitempty"`
+
+	// Source records where the example came from when it was not authored
+	// directly, e.g. ExampleSourceSchema for examples converted from the
+	// tool's input schema.
+	Source string `json:"source,omitempty"`
 }
 
+// Conventional ToolExample.Requires values.
+const (
+	// RequireSandbox marks examples that are only safe against sandboxed
+	// backends.
+	RequireSandbox = "sandbox-only"
+
+	// RequireCredentialPrefix prefixes a named credential the example needs
+	// (e.g. "needs-credential:github").
+	RequireCredentialPrefix = "needs-credential:"
+
+	// RequireFeatureFlagPrefix prefixes a feature flag the example needs
+	// (e.g. "feature-flag:beta").
+	RequireFeatureFlagPrefix = "feature-flag:"
+)
+
 // SchemaInfo contains derived information about a tool's input schema.
 // This is best-effort only; fields may be nil if derivation is not possible.
 type SchemaInfo struct {
@@ -80,6 +111,11 @@ type SchemaInfo struct {
 	// Types maps parameter names to their allowed types.
 	// For example: {"limit": ["integer"], "query": ["string"]}
 	Types map[string][]string `json:"types,omitempty"`
+
+	// Constraints maps parameter names to human-readable validation
+	// constraints derived from the schema, in a fixed keyword order.
+	// For example: {"limit": ["min: 1", "max: 100"], "state": ["enum: open, closed"]}
+	Constraints map[string][]string `json:"constraints,omitempty"`
 }
 
 // ToolDoc represents documentation for a tool at varying levels of detail.
@@ -88,6 +124,10 @@ type ToolDoc struct {
 	// Required for schema/full levels; nil for summary.
 	Tool *toolmodel.Tool `json:"tool,omitempty"`
 
+	// Title is the human-readable display name: the DocEntry.Title override,
+	// else Tool.Title, else Tool.Annotations.Title. Empty when none is set.
+	Title string `json:"title,omitempty"`
+
 	// Summary is a short description (1-2 lines).
 	// Maximum length: MaxSummaryLen (200 chars).
 	Summary string `json:"summary"`
@@ -96,35 +136,163 @@ type ToolDoc struct {
 	// Optional; populated at schema/full levels when derivable.
 	SchemaInfo *SchemaInfo `json:"schemaInfo,omitempty"`
 
+	// Capabilities contains flags derived from the schema and annotations.
+	// Populated at schema/full levels.
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+
+	// Effects declares the state changes the tool causes.
+	// Populated at schema/full levels.
+	Effects []Effect `json:"effects,omitempty"`
+
+	// Idempotent reports whether repeated calls with the same arguments
+	// have the same effect as one call: DocEntry.Idempotent, or the tool's
+	// idempotent or read-only annotation. Populated at schema/full levels.
+	Idempotent bool `json:"idempotent,omitempty"`
+
+	// RetryPolicy carries retry guidance for orchestration layers.
+	// Populated at schema/full levels when documented.
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Concurrency carries parallel-call limits for schedulers.
+	// Populated at schema/full levels when documented.
+	Concurrency *Concurrency `json:"concurrency,omitempty"`
+
+	// ClientRequirements lists the client-side MCP features the tool needs.
+	// Populated at schema/full levels when documented.
+	ClientRequirements *ClientRequirements `json:"clientRequirements,omitempty"`
+
+	// ShortNotes holds the most critical constraints in brief.
+	// Populated at schema/full levels. Maximum length: MaxShortNotesLen
+	// (300 chars).
+	ShortNotes string `json:"shortNotes,omitempty"`
+
 	// Notes contains human-authored usage guidance, constraints,
 	// pagination/auth hints, and error semantics.
 	// Full level only. Maximum length: MaxNotesLen (2000 chars).
 	Notes string `json:"notes,omitempty"`
 
+	// Sections holds the custom sections whose Tier the requested level
+	// reaches, in registration order.
+	Sections []DocSection `json:"sections,omitempty"`
+
 	// Examples contains a small set of usage examples (1-3).
 	// Optional; typically populated at full level.
 	Examples []ToolExample `json:"examples,omitempty"`
 
+	// Prerequisites lists setup steps, tools to call first, and required
+	// state. Full level only.
+	Prerequisites []Prerequisite `json:"prerequisites,omitempty"`
+
+	// Edges lists the dependency-graph edges touching this tool in either
+	// direction (see InMemoryStore.ToolGraph). Full level only.
+	Edges []ToolEdge `json:"edges,omitempty"`
+
 	// ExternalRefs contains URLs or resource IDs for additional documentation.
 	// Full level only.
 	ExternalRefs []string `json:"externalRefs,omitempty"`
+
+	// Omitted lists documentation that exists but was left out by the
+	// requested level or a cap, so agents know a higher level would yield
+	// more: "schema" (the tool definition and derived schema info), the
+	// JSON names of other omitted fields ("shortNotes", "effects",
+	// "retryPolicy", "concurrency", "clientRequirements", "notes",
+	// "prerequisites", "externalRefs"), "section <title>" per omitted
+	// section, and an example count ("3 examples", or "2 more examples"
+	// when some are shown). Tier content comes first, in tier order.
+	Omitted []string `json:"omitted,omitempty"`
+
+	// NextDetail suggests the level to request for more and estimates its
+	// token cost. Set at summary and schema levels when a higher level
+	// would return more and can be served to the caller.
+	NextDetail DetailHint `json:"nextDetail,omitzero"`
+
+	// Stale is set when the tool definition could not be looked up and the
+	// last successfully resolved one was used instead (see
+	// StoreOptions.ServeStale).
+	Stale bool `json:"stale,omitempty"`
+
+	// Experiment identifies the experiment arm the doc was served from,
+	// when the tool is in a running experiment and the caller is enrolled.
+	Experiment *Assignment `json:"experiment,omitempty"`
 }
 
 // DocEntry is the input structure for registering documentation for a tool.
 // It contains the custom documentation that augments the tool's metadata.
+//
+// Entries encode with lower camel case JSON names (summary, externalRefs).
+// JSON written before the fields were tagged used the Go field names
+// (Summary, ExternalRefs); it still decodes, since decoding matches names
+// case-insensitively.
 type DocEntry struct {
+	// Title overrides the tool's human-readable title in output
+	// (ToolDoc.Title and the returned Tool). The index is not modified.
+	Title string `json:"title,omitempty"`
+
+	// Annotations, when non-nil, replaces the tool's MCP annotations in the
+	// returned Tool. The index is not modified.
+	Annotations *mcp.ToolAnnotations `json:"annotations,omitempty"`
+
 	// Summary overrides or supplements the tool's Description.
 	// If empty, the tool's Description is used.
-	Summary string
+	Summary string `json:"summary,omitempty"`
+
+	// ShortNotes states the one or two most critical constraints, returned
+	// from the schema tier so they need not wait for the full tier.
+	ShortNotes string `json:"shortNotes,omitempty"`
 
 	// Notes contains usage guidance, constraints, etc.
-	Notes string
+	Notes string `json:"notes,omitempty"`
+
+	// Sections structures guidance into named blocks, each returned from
+	// its own Tier. Capped at MaxSections.
+	Sections []DocSection `json:"sections,omitempty"`
 
 	// Examples for this tool.
-	Examples []ToolExample
+	Examples []ToolExample `json:"examples,omitempty"`
+
+	// Prerequisites lists what must be done or true before the tool is
+	// called.
+	Prerequisites []Prerequisite `json:"prerequisites,omitempty"`
+
+	// Effects declares the state changes the tool causes (resources
+	// created, messages sent, irreversible deletions).
+	Effects []Effect `json:"effects,omitempty"`
+
+	// Dependencies declares tools this tool depends on (consumes the output
+	// of, must follow).
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+
+	// Idempotent declares that repeated calls with the same arguments have
+	// the same effect as one call.
+	Idempotent bool `json:"idempotent,omitempty"`
+
+	// RetryPolicy describes how a failed call may be retried.
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Concurrency declares backend limits on parallel calls.
+	Concurrency *Concurrency `json:"concurrency,omitempty"`
+
+	// ClientRequirements declares the client-side MCP features (sampling,
+	// elicitation, roots, resources) the tool needs to work.
+	ClientRequirements *ClientRequirements `json:"clientRequirements,omitempty"`
 
 	// ExternalRefs contains URLs or resource IDs.
-	ExternalRefs []string
+	ExternalRefs []string `json:"externalRefs,omitempty"`
+
+	// Source records where the entry came from, for tracing bad content back
+	// to the pipeline that produced it. Free-form; conventional values are
+	// "manual", "bundle:<path>", "importer:<name>", and "mirror:<url>".
+	Source string `json:"source,omitempty"`
+
+	// Sensitive restricts the full tier (notes and examples) to callers
+	// authorized via StoreOptions.AuthorizeSensitive or, by default, the
+	// GrantSensitiveDocs grant (see WithGrants). Summary and schema tiers
+	// stay open.
+	Sensitive bool `json:"sensitive,omitempty"`
+
+	// Render holds presentation preferences honored by renderers such as
+	// the exporter. Optional.
+	Render *RenderHints `json:"render,omitempty"`
 }
 
 // truncateString truncates s to maxLen characters.
@@ -196,9 +364,23 @@ func countValueMetrics(v any, currentDepth int) (int, int) {
 // It returns a new DocEntry with truncated values.
 func (e DocEntry) ValidateAndTruncate() DocEntry {
 	result := DocEntry{
-		Summary:      truncateString(e.Summary, MaxSummaryLen),
-		Notes:        truncateString(e.Notes, MaxNotesLen),
-		ExternalRefs: e.ExternalRefs,
+		Title:              e.Title,
+		Annotations:        e.Annotations,
+		Summary:            truncateString(e.Summary, MaxSummaryLen),
+		ShortNotes:         truncateString(e.ShortNotes, MaxShortNotesLen),
+		Notes:              truncateString(e.Notes, MaxNotesLen),
+		Sections:           truncateSections(e.Sections),
+		Prerequisites:      truncatePrerequisites(e.Prerequisites),
+		Effects:            truncateEffects(e.Effects),
+		Dependencies:       truncateDependencies(e.Dependencies),
+		Idempotent:         e.Idempotent,
+		RetryPolicy:        truncateRetryPolicy(e.RetryPolicy),
+		Concurrency:        copyConcurrency(e.Concurrency),
+		ClientRequirements: copyClientRequirements(e.ClientRequirements),
+		ExternalRefs:       e.ExternalRefs,
+		Source:             e.Source,
+		Sensitive:          e.Sensitive,
+		Render:             copyRenderHints(e.Render),
 	}
 
 	// Truncate examples
@@ -210,6 +392,8 @@ func (e DocEntry) ValidateAndTruncate() DocEntry {
 			Description: truncateString(ex.Description, MaxDescriptionLen),
 			Args:        ex.Args,
 			ResultHint:  truncateString(ex.ResultHint, MaxResultHintLen),
+			Requires:    ex.Requires,
+			Source:      ex.Source,
 		}
 	}
 
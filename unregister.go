@@ -0,0 +1,118 @@
+package tooldocs
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+// ErrEmptyFilter is returned by UnregisterWhere when the filter sets no
+// criteria, to prevent accidentally removing every record.
+var ErrEmptyFilter = errors.New("filter has no criteria")
+
+// UnregisterFilter selects doc records for bulk removal.
+// All non-empty criteria must match for a record to be selected.
+type UnregisterFilter struct {
+	// NamespacePrefix matches tools whose namespace starts with the prefix.
+	// Tools without a namespace never match a non-empty prefix.
+	NamespacePrefix string
+
+	// Tag matches tools whose resolved toolmodel.Tool carries the tag
+	// (compared after toolmodel.NormalizeTags). Tools that cannot be
+	// resolved never match.
+	Tag string
+
+	// Source matches records whose DocEntry.Source equals the value exactly.
+	Source string
+
+	// Match is an optional custom predicate on the tool ID.
+	Match func(id string) bool
+}
+
+// isEmpty reports whether the filter sets no criteria.
+func (f UnregisterFilter) isEmpty() bool {
+	return f.NamespacePrefix == "" && f.Tag == "" && f.Source == "" && f.Match == nil
+}
+
+// UnregisterWhere removes the documentation of every tool matching filter
+// and returns the removed IDs, sorted. Frozen tools are never removed.
+//
+// Matching is evaluated first (resolving tools outside the lock when
+// filtering by tag), then all matched records are removed under a single
+// write lock, so readers never observe a partially applied removal.
+//
+// Returns ErrEmptyFilter if the filter sets no criteria, ErrThrottled if
+// WriteRateLimit is exceeded, or the removed IDs and ErrPersist if
+// StoreOptions.Persister fails for any of them.
+func (s *InMemoryStore) UnregisterWhere(filter UnregisterFilter) ([]string, error) {
+	if filter.isEmpty() {
+		return nil, ErrEmptyFilter
+	}
+	if err := s.limiter.allow(filter.Source); err != nil {
+		return nil, err
+	}
+
+	var tag string
+	if filter.Tag != "" {
+		normalized := toolmodel.NormalizeTags([]string{filter.Tag})
+		if len(normalized) == 0 {
+			return []string{}, nil
+		}
+		tag = normalized[0]
+	}
+
+	var matched []string
+	for _, id := range s.DocIDs() {
+		if s.matchesFilter(id, filter, tag) {
+			matched = append(matched, id)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writable(); err != nil {
+		return nil, err
+	}
+
+	removed := make([]string, 0, len(matched))
+	var errs []error
+	for _, id := range matched {
+		record := s.docs[id]
+		if record == nil || record.frozen {
+			continue
+		}
+		if filter.Source != "" && record.source != filter.Source {
+			continue
+		}
+		s.dropped(id)
+		removed = append(removed, id)
+		errs = append(errs, s.deleted(id))
+	}
+	return removed, errors.Join(errs...)
+}
+
+// matchesFilter reports whether id satisfies every criterion in filter.
+// tag is the normalized filter.Tag.
+func (s *InMemoryStore) matchesFilter(id string, filter UnregisterFilter, tag string) bool {
+	if filter.NamespacePrefix != "" {
+		namespace, _, err := toolmodel.ParseToolID(id)
+		if err != nil || namespace == "" || !strings.HasPrefix(namespace, filter.NamespacePrefix) {
+			return false
+		}
+	}
+	if filter.Match != nil && !filter.Match(id) {
+		return false
+	}
+	if tag != "" {
+		tool, err := s.resolveTool(id)
+		if err != nil || tool == nil {
+			return false
+		}
+		if !slices.Contains(toolmodel.NormalizeTags(tool.Tags), tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package tooldocs
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+)
+
+func TestUnregisterWhere_Namespace(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	for _, id := range []string{"vendor:a", "vendor:b", "vendor-eu:c", "local:d", "bare"} {
+		mustRegisterDoc(t, store, id, DocEntry{Summary: id})
+	}
+	if err := store.Freeze("vendor:b"); err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+
+	removed, err := store.UnregisterWhere(UnregisterFilter{NamespacePrefix: "vendor"})
+	if err != nil {
+		t.Fatalf("UnregisterWhere failed: %v", err)
+	}
+	if want := []string{"vendor-eu:c", "vendor:a"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+
+	for _, id := range []string{"vendor:b", "local:d", "bare"} {
+		if _, err := store.GetDocEntry(id); err != nil {
+			t.Errorf("%s should remain: %v", id, err)
+		}
+	}
+}
+
+func TestUnregisterWhere_TagAndMatch(t *testing.T) {
+	tools := map[string]toolmodel.Tool{}
+	for _, spec := range []struct{ name, tag string }{{"a", "legacy"}, {"b", "Legacy"}, {"c", "current"}} {
+		tool := makeToolWithSchema(spec.name, "ns", "", map[string]any{"type": "object"})
+		tool.Tags = []string{spec.tag}
+		tools[tool.ToolID()] = tool
+	}
+
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			tool, ok := tools[id]
+			if !ok {
+				return nil, nil
+			}
+			return &tool, nil
+		},
+	})
+	for id := range tools {
+		mustRegisterDoc(t, store, id, DocEntry{Summary: id})
+	}
+	mustRegisterDoc(t, store, "ns:docs-only", DocEntry{Summary: "no tool"})
+
+	removed, err := store.UnregisterWhere(UnregisterFilter{
+		Tag:   "LEGACY",
+		Match: func(id string) bool { return !strings.HasSuffix(id, ":b") },
+	})
+	if err != nil {
+		t.Fatalf("UnregisterWhere failed: %v", err)
+	}
+	if want := []string{"ns:a"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+}
+
+func TestUnregisterWhere_EmptyFilter(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{})
+	mustRegisterDoc(t, store, "ns:a", DocEntry{Summary: "a"})
+
+	if _, err := store.UnregisterWhere(UnregisterFilter{}); !errors.Is(err, ErrEmptyFilter) {
+		t.Errorf("UnregisterWhere error = %v, want ErrEmptyFilter", err)
+	}
+	if _, err := store.GetDocEntry("ns:a"); err != nil {
+		t.Errorf("record removed by empty filter: %v", err)
+	}
+}
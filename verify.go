@@ -0,0 +1,181 @@
+package tooldocs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Severity ranks a VerifyFinding.
+type Severity string
+
+const (
+	// SeverityError marks a broken example (args that no longer fit the
+	// schema, a failed replay). CI should fail on it.
+	SeverityError Severity = "error"
+
+	// SeverityWarning marks an example that could not be fully verified
+	// (unresolvable tool, replay skipped for unmet requirements).
+	SeverityWarning Severity = "warning"
+)
+
+// atLeast reports whether s is as severe as threshold.
+func (s Severity) atLeast(threshold Severity) bool {
+	return s == SeverityError || threshold == SeverityWarning
+}
+
+// Verification checks reported in VerifyFinding.Check.
+const (
+	VerifyCheckTool   = "tool"
+	VerifyCheckSchema = "schema"
+	VerifyCheckReplay = "replay"
+)
+
+// VerifyOptions configures VerifyExamples.
+type VerifyOptions struct {
+	// IDs limits verification to these tools. Empty means every
+	// documented tool.
+	IDs []string
+
+	// Invoker, when set, also replays every example (see ReplayExamples)
+	// and reports failed calls.
+	Invoker ToolInvoker
+}
+
+// VerifyFinding is one problem found by VerifyExamples.
+type VerifyFinding struct {
+	ID       string   `json:"id"`
+	Example  string   `json:"example,omitempty"`
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// key identifies the finding across runs, for baseline comparison.
+func (f VerifyFinding) key() string {
+	return f.ID + "\x00" + f.Example + "\x00" + f.Check + "\x00" + f.Message
+}
+
+// VerifyReport is the machine-readable result of VerifyExamples.
+type VerifyReport struct {
+	Tools    int             `json:"tools"`
+	Examples int             `json:"examples"`
+	Errors   int             `json:"errors"`
+	Warnings int             `json:"warnings"`
+	Findings []VerifyFinding `json:"findings"`
+}
+
+// Failed reports whether any finding is at least as severe as threshold.
+func (r VerifyReport) Failed(threshold Severity) bool {
+	return len(r.AtLeast(threshold)) > 0
+}
+
+// AtLeast returns the findings at least as severe as threshold.
+func (r VerifyReport) AtLeast(threshold Severity) []VerifyFinding {
+	var out []VerifyFinding
+	for _, f := range r.Findings {
+		if f.Severity.atLeast(threshold) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Regressions returns the findings of r that baseline does not have, so CI
+// can fail only on newly broken examples.
+func (r VerifyReport) Regressions(baseline VerifyReport) []VerifyFinding {
+	known := make(map[string]bool, len(baseline.Findings))
+	for _, f := range baseline.Findings {
+		known[f.key()] = true
+	}
+	var out []VerifyFinding
+	for _, f := range r.Findings {
+		if !known[f.key()] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// add records a finding and updates the severity counts.
+func (r *VerifyReport) add(f VerifyFinding) {
+	r.Findings = append(r.Findings, f)
+	if f.Severity == SeverityError {
+		r.Errors++
+	} else {
+		r.Warnings++
+	}
+}
+
+// VerifyExamples checks every example of the selected tools against the
+// tool's current input schema (as CheckExamples does) and, when
+// opts.Invoker is set, replays it. Findings are sorted by tool ID.
+//
+// Returns ctx.Err() with the partial report if ctx is canceled.
+func (s *InMemoryStore) VerifyExamples(ctx context.Context, opts VerifyOptions) (VerifyReport, error) {
+	ids := opts.IDs
+	if len(ids) == 0 {
+		ids = s.DocIDs()
+	} else {
+		ids = append([]string(nil), ids...)
+		sort.Strings(ids)
+	}
+
+	report := VerifyReport{Findings: []VerifyFinding{}}
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		stored, err := s.GetDocEntry(id)
+		if err != nil {
+			report.add(VerifyFinding{ID: id, Check: VerifyCheckTool, Severity: SeverityError, Message: err.Error()})
+			continue
+		}
+		report.Tools++
+		report.Examples += len(stored.Entry.Examples)
+		if len(stored.Entry.Examples) == 0 {
+			continue
+		}
+
+		tool, err := s.resolveTool(id)
+		switch {
+		case err != nil:
+			report.add(VerifyFinding{ID: id, Check: VerifyCheckTool, Severity: SeverityWarning,
+				Message: fmt.Sprintf("examples not checked: %v", err)})
+		case tool == nil:
+			report.add(VerifyFinding{ID: id, Check: VerifyCheckTool, Severity: SeverityWarning,
+				Message: fmt.Sprintf("examples not checked: %v", ErrNoTool)})
+		default:
+			schema := schemaAsMap(tool.InputSchema)
+			for _, ex := range stored.Entry.Examples {
+				if err := checkArgsShape(schema, ex.Args); err != nil {
+					report.add(VerifyFinding{ID: id, Example: exampleName(ex), Check: VerifyCheckSchema,
+						Severity: SeverityError, Message: err.Error()})
+				}
+			}
+		}
+
+		if opts.Invoker == nil {
+			continue
+		}
+		replay, err := s.ReplayExamples(ctx, id, opts.Invoker)
+		if err != nil {
+			if ctx.Err() != nil {
+				return report, err
+			}
+			report.add(VerifyFinding{ID: id, Check: VerifyCheckReplay, Severity: SeverityError, Message: err.Error()})
+			continue
+		}
+		for _, r := range replay.Results {
+			switch {
+			case r.Skipped:
+				report.add(VerifyFinding{ID: id, Example: r.Example, Check: VerifyCheckReplay,
+					Severity: SeverityWarning, Message: r.Error})
+			case !r.Passed:
+				report.add(VerifyFinding{ID: id, Example: r.Example, Check: VerifyCheckReplay,
+					Severity: SeverityError, Message: r.Error})
+			}
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,86 @@
+package tooldocs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jonwraymond/toolmodel"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestVerifyExamples(t *testing.T) {
+	store := NewInMemoryStore(StoreOptions{
+		ToolResolver: func(id string) (*toolmodel.Tool, error) {
+			if id != "gh:search" {
+				return nil, nil
+			}
+			tool := makeToolWithSchema("search", "gh", "Search", map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"q": map[string]any{"type": "string"}},
+				"required":   []any{"q"},
+			})
+			return &tool, nil
+		},
+	})
+	mustRegisterDoc(t, store, "gh:search", DocEntry{
+		Summary: "Search issues",
+		Examples: []ToolExample{
+			{Title: "Open", Args: map[string]any{"q": "is:open"}},
+			{Title: "Missing query", Args: map[string]any{}},
+		},
+	})
+	mustRegisterDoc(t, store, "gh:orphan", DocEntry{
+		Summary:  "No tool definition",
+		Examples: []ToolExample{{Title: "Any", Args: map[string]any{}}},
+	})
+	mustRegisterDoc(t, store, "gh:plain", DocEntry{Summary: "No examples"})
+
+	report, err := store.VerifyExamples(context.Background(), VerifyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Tools != 3 || report.Examples != 3 || report.Errors != 1 || report.Warnings != 1 {
+		t.Fatalf("report = %+v, want 3 tools, 3 examples, 1 error, 1 warning", report)
+	}
+	if f := report.AtLeast(SeverityError); len(f) != 1 || f[0].ID != "gh:search" || f[0].Example != "Missing query" || f[0].Check != VerifyCheckSchema {
+		t.Errorf("errors = %+v, want schema error for gh:search Missing query", f)
+	}
+	if !report.Failed(SeverityError) || !report.Failed(SeverityWarning) {
+		t.Error("Failed = false, want true")
+	}
+
+	// A baseline with the same findings has no regressions.
+	if got := report.Regressions(report); len(got) != 0 {
+		t.Errorf("Regressions(self) = %+v, want none", got)
+	}
+	if got := report.Regressions(VerifyReport{}); len(got) != 2 {
+		t.Errorf("Regressions(empty) = %d findings, want 2", len(got))
+	}
+
+	// Replay adds failed calls as errors.
+	invoker := ToolInvokerFunc(func(_ context.Context, id string, _ *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+		if id == "gh:orphan" {
+			return nil, errors.New("no such tool")
+		}
+		return &mcp.CallToolResult{}, nil
+	})
+	report, err = store.VerifyExamples(context.Background(), VerifyOptions{IDs: []string{"gh:orphan"}, Invoker: invoker})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Tools != 1 || report.Errors != 1 || report.Findings[1].Check != VerifyCheckReplay {
+		t.Errorf("report = %+v, want one replay error for gh:orphan", report)
+	}
+
+	report, _ = store.VerifyExamples(context.Background(), VerifyOptions{IDs: []string{"gh:missing"}})
+	if report.Tools != 0 || report.Errors != 1 || report.Findings[0].Check != VerifyCheckTool {
+		t.Errorf("report = %+v, want tool error for undocumented ID", report)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := store.VerifyExamples(ctx, VerifyOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("VerifyExamples(canceled) error = %v, want context.Canceled", err)
+	}
+}